@@ -0,0 +1,98 @@
+package blindrsa
+
+import (
+	"crypto/rand"
+	"io"
+	"math/big"
+
+	"github.com/cloudflare/circl/blindsign/blindrsa/internal/common"
+	"github.com/cloudflare/circl/blindsign/blindrsa/internal/keys"
+)
+
+// BlindBatch blinds several prepared messages at once, amortizing the
+// inversion of the blinding factors. It fails if randomness was not
+// provided, or if any of the messages cannot be blinded.
+func (c Client) BlindBatch(random io.Reader, preparedMessages [][]byte) (blindedMsgs [][]byte, states []State, err error) {
+	if random == nil {
+		return nil, nil, common.ErrInvalidRandomness
+	}
+
+	n := len(preparedMessages)
+	salts := make([][]byte, n)
+	for i := range salts {
+		salts[i] = make([]byte, c.v.SaltLength)
+		if _, err = io.ReadFull(random, salts[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	r, rInv, err := common.GenerateBlindingFactors(random, c.v.pk.N, n)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	blindedMsgs = make([][]byte, n)
+	states = make([]State, n)
+	for i := range preparedMessages {
+		blindedMsgs[i], states[i], err = c.fixedBlind(preparedMessages[i], salts[i], r[i], rInv[i])
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return blindedMsgs, states, nil
+}
+
+// FinalizeBatch finalizes the blind signatures of the messages blinded with
+// BlindBatch, and fails if any of the signatures is invalid.
+func (c Client) FinalizeBatch(states []State, blindedSigs [][]byte) ([][]byte, error) {
+	if len(states) != len(blindedSigs) {
+		return nil, common.ErrInvalidMessage
+	}
+
+	sigs := make([][]byte, len(states))
+	for i := range states {
+		var err error
+		sigs[i], err = c.Finalize(states[i], blindedSigs[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return sigs, nil
+}
+
+// BlindSignBatch signs several blinded messages at once using a pool of
+// workers, and returns the same blind signatures as calling BlindSign on
+// each message. If workers is not positive, it uses one worker per CPU. It
+// fails if any of the messages is not valid.
+func (signer Signer) BlindSignBatch(data [][]byte, workers int) ([][]byte, error) {
+	kLen := (signer.sk.N.BitLen() + 7) / 8
+	msgs := make([]*big.Int, len(data))
+	for i := range data {
+		if len(data[i]) != kLen {
+			return nil, common.ErrUnexpectedSize
+		}
+		msgs[i] = new(big.Int).SetBytes(data[i])
+		if msgs[i].Cmp(signer.sk.N) > 0 {
+			return nil, common.ErrInvalidMessageLength
+		}
+	}
+
+	key := common.NewCRTKey(keys.NewBigPrivateKey(signer.sk))
+	blindSigs := make([][]byte, len(data))
+	err := common.Parallel(len(data), workers, func(i int) error {
+		s, err := key.DecryptAndCheck(rand.Reader, msgs[i])
+		if err != nil {
+			return err
+		}
+		blindSigs[i] = make([]byte, kLen)
+		s.FillBytes(blindSigs[i])
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return blindSigs, nil
+}
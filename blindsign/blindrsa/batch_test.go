@@ -0,0 +1,88 @@
+package blindrsa
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+func TestBatch(t *testing.T) {
+	const n = 17
+	key, err := loadPrivateKey()
+	test.CheckNoErr(t, err, "failed to load key")
+	signer := NewSigner(key)
+	client, err := NewClient(SHA384PSSRandomized, &key.PublicKey)
+	test.CheckNoErr(t, err, "failed to create client")
+
+	inputMsgs := make([][]byte, n)
+	for i := range inputMsgs {
+		inputMsgs[i], err = client.Prepare(rand.Reader, []byte(fmt.Sprintf("message %v", i)))
+		test.CheckNoErr(t, err, "prepare failed")
+	}
+	blindedMsgs, states, err := client.BlindBatch(rand.Reader, inputMsgs)
+	test.CheckNoErr(t, err, "blind failed")
+
+	for _, workers := range []int{0, 1, 4} {
+		blindedSigs, err := signer.BlindSignBatch(blindedMsgs, workers)
+		test.CheckNoErr(t, err, "batch sign failed")
+		for i := range blindedMsgs {
+			want, err := signer.BlindSign(blindedMsgs[i])
+			test.CheckNoErr(t, err, "sign failed")
+			if !bytes.Equal(blindedSigs[i], want) {
+				test.ReportError(t, blindedSigs[i], want, i, workers)
+			}
+		}
+
+		sigs, err := client.FinalizeBatch(states, blindedSigs)
+		test.CheckNoErr(t, err, "finalize failed")
+		for i := range sigs {
+			test.CheckNoErr(t, client.Verify(inputMsgs[i], sigs[i]), "invalid signature")
+		}
+	}
+
+	blindedSigs, err := signer.BlindSignBatch(blindedMsgs, 0)
+	test.CheckNoErr(t, err, "batch sign failed")
+	blindedSigs[0], blindedSigs[1] = blindedSigs[1], blindedSigs[0]
+	_, err = client.FinalizeBatch(states, blindedSigs)
+	test.CheckIsErr(t, err, "should fail with swapped signatures")
+	_, err = client.FinalizeBatch(states[1:], blindedSigs)
+	test.CheckIsErr(t, err, "should fail with mismatched lengths")
+
+	blindedMsgs[3] = blindedMsgs[3][1:]
+	_, err = signer.BlindSignBatch(blindedMsgs, 0)
+	test.CheckIsErr(t, err, "should fail with an invalid message")
+	_, _, err = client.BlindBatch(nil, inputMsgs)
+	test.CheckIsErr(t, err, "should fail without randomness")
+}
+
+func BenchmarkBatch(b *testing.B) {
+	const n = 64
+	key := loadStrongRSAKey()
+	signer := NewSigner(key)
+	client, err := NewClient(SHA384PSSRandomized, &key.PublicKey)
+	test.CheckNoErr(b, err, "failed to create client")
+
+	inputMsgs := make([][]byte, n)
+	for i := range inputMsgs {
+		inputMsgs[i], err = client.Prepare(rand.Reader, []byte(fmt.Sprintf("message %v", i)))
+		test.CheckNoErr(b, err, "prepare failed")
+	}
+	blindedMsgs, _, err := client.BlindBatch(rand.Reader, inputMsgs)
+	test.CheckNoErr(b, err, "blind failed")
+
+	b.Run("BlindSign", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := range blindedMsgs {
+				_, _ = signer.BlindSign(blindedMsgs[j])
+			}
+		}
+	})
+	b.Run("BlindSignBatch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = signer.BlindSignBatch(blindedMsgs, 0)
+		}
+	})
+}
@@ -32,6 +32,13 @@
 // variants (or otherwise ensure high-entropy encodings) and verify that the
 // signer's key is honestly generated.
 //
+// # Threshold signing
+//
+// The signing key can be split among several nodes using tss/rsa. Each
+// ThresholdSigner computes a signature share of the blinded message, and a
+// ThresholdCombiner combines them into the blind signature, which clients
+// finalize as usual.
+//
 // [RFC-9474]: https://www.rfc-editor.org/info/rfc9474
 package blindrsa
 
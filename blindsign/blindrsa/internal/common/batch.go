@@ -0,0 +1,149 @@
+package common
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"io"
+	"math/big"
+	"runtime"
+	"sync"
+
+	"github.com/cloudflare/circl/blindsign/blindrsa/internal/keys"
+)
+
+// CRTKey holds the values of a private key used for computing the private
+// key operation with the Chinese remainder theorem, which are computed once
+// and shared by all the operations with the same key.
+type CRTKey struct {
+	priv   *keys.BigPrivateKey
+	dP, dQ *big.Int // d mod (p-1), d mod (q-1)
+	qInv   *big.Int // q^-1 mod p
+}
+
+// NewCRTKey precomputes the CRT values of the private key.
+func NewCRTKey(priv *keys.BigPrivateKey) *CRTKey {
+	pm1 := new(big.Int).Sub(priv.P, bigOne)
+	qm1 := new(big.Int).Sub(priv.Q, bigOne)
+
+	return &CRTKey{
+		priv: priv,
+		dP:   new(big.Int).Mod(priv.D, pm1),
+		dQ:   new(big.Int).Mod(priv.D, qm1),
+		qInv: new(big.Int).ModInverse(priv.Q, priv.P),
+	}
+}
+
+// DecryptAndCheck computes the private key operation using RSA blinding,
+// and checks that the result is consistent (fault attack detection). The
+// output is the same as that of the package-level common.DecryptAndCheck.
+func (k *CRTKey) DecryptAndCheck(random io.Reader, c *big.Int) (*big.Int, error) {
+	N := k.priv.Pk.N
+	if c.Cmp(N) > 0 || N.Sign() == 0 || k.qInv == nil {
+		return nil, rsa.ErrDecryption
+	}
+
+	r, ir, err := GenerateBlindingFactor(random, N)
+	if err != nil {
+		return nil, err
+	}
+	blinded := new(big.Int).Exp(r, k.priv.Pk.E, N)
+	blinded.Mul(blinded, c)
+	blinded.Mod(blinded, N)
+
+	// m = m2 + q * (qInv * (m1 - m2) mod p), where m1 = c^dP mod p, and
+	// m2 = c^dQ mod q.
+	m1 := new(big.Int).Exp(blinded, k.dP, k.priv.P)
+	m2 := new(big.Int).Exp(blinded, k.dQ, k.priv.Q)
+	m := m1.Sub(m1, m2)
+	m.Mul(m, k.qInv)
+	m.Mod(m, k.priv.P)
+	m.Mul(m, k.priv.Q)
+	m.Add(m, m2)
+
+	// Unblind.
+	m.Mul(m, ir)
+	m.Mod(m, N)
+
+	check := encrypt(new(big.Int), N, k.priv.Pk.E, m)
+	if c.Cmp(check) != 0 {
+		return nil, errors.New("rsa: internal error")
+	}
+
+	return m, nil
+}
+
+// GenerateBlindingFactors generates n blinding factors and their
+// multiplicative inverses, using a single modular inversion.
+func GenerateBlindingFactors(random io.Reader, N *big.Int, n int) ([]*big.Int, []*big.Int, error) {
+	if random == nil {
+		random = rand.Reader
+	}
+
+	r := make([]*big.Int, n)
+	// prod[i] is the product of r[0], ..., r[i-1].
+	prod := make([]*big.Int, n+1)
+	prod[0] = big.NewInt(1)
+	for i := range r {
+		var err error
+		r[i], err = rand.Int(random, N)
+		if err != nil {
+			return nil, nil, err
+		}
+		if r[i].Sign() == 0 {
+			r[i].SetInt64(1)
+		}
+		prod[i+1] = new(big.Int).Mul(prod[i], r[i])
+		prod[i+1].Mod(prod[i+1], N)
+	}
+
+	inv := new(big.Int).ModInverse(prod[n], N)
+	if inv == nil {
+		return nil, nil, ErrInvalidBlind
+	}
+	rInv := make([]*big.Int, n)
+	for i := n - 1; i >= 0; i-- {
+		rInv[i] = new(big.Int).Mul(inv, prod[i])
+		rInv[i].Mod(rInv[i], N)
+		inv.Mul(inv, r[i])
+		inv.Mod(inv, N)
+	}
+
+	return r, rInv, nil
+}
+
+// Parallel calls f(i) for all 0 <= i < n using a pool of workers, and returns
+// the error of the call with the lowest index that failed. If workers is not
+// positive, it uses one worker per CPU.
+func Parallel(n, workers int, f func(i int) error) error {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, n)
+
+	errs := make([]error, n)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				errs[i] = f(i)
+			}
+		}()
+	}
+	for i := range n {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package partiallyblindrsa
+
+import (
+	"crypto/rand"
+	"io"
+	"math/big"
+
+	"github.com/cloudflare/circl/blindsign/blindrsa/internal/common"
+)
+
+// BlindBatch blinds several (message, metadata) pairs at once with the
+// Verifier, amortizing the inversion of the blinding factors. It fails if
+// randomness was not provided, or if any of the messages cannot be blinded.
+func BlindBatch(v Verifier, random io.Reader, messages, metadata [][]byte) (blindedMsgs [][]byte, states []VerifierState, err error) {
+	if random == nil {
+		return nil, nil, common.ErrInvalidRandomness
+	}
+	if len(messages) != len(metadata) {
+		return nil, nil, common.ErrInvalidMessage
+	}
+	rv, ok := v.(randomizedVerifier)
+	if !ok {
+		return nil, nil, common.ErrInvalidVariant
+	}
+	if err = validatePublicKey(rv.cryptoHash, rv.pk); err != nil {
+		return nil, nil, err
+	}
+
+	n := len(messages)
+	salts := make([][]byte, n)
+	for i := range salts {
+		salts[i] = make([]byte, rv.hash.Size())
+		if _, err = io.ReadFull(random, salts[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	r, rInv, err := common.GenerateBlindingFactors(random, rv.pk.N, n)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	blindedMsgs = make([][]byte, n)
+	states = make([]VerifierState, n)
+	for i := range messages {
+		blindedMsgs[i], states[i], err = rv.FixedBlind(messages[i], metadata[i], salts[i], r[i].Bytes(), rInv[i].Bytes())
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return blindedMsgs, states, nil
+}
+
+// FinalizeBatch finalizes the blind signatures of the messages blinded with
+// BlindBatch, and fails if any of the signatures is invalid.
+func FinalizeBatch(states []VerifierState, blindedSigs [][]byte) ([][]byte, error) {
+	if len(states) != len(blindedSigs) {
+		return nil, common.ErrInvalidMessage
+	}
+
+	sigs := make([][]byte, len(states))
+	for i := range states {
+		var err error
+		sigs[i], err = states[i].Finalize(blindedSigs[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return sigs, nil
+}
+
+// BlindSignBatch signs several blinded messages, each with its metadata, at
+// once using a pool of workers, and returns the same blind signatures as
+// calling BlindSign on each message. The private key for each distinct
+// metadata is derived only once. If workers is not positive, it uses one
+// worker per CPU. It fails if any of the messages is not valid.
+func (signer Signer) BlindSignBatch(data, metadata [][]byte, workers int) ([][]byte, error) {
+	if len(data) != len(metadata) {
+		return nil, common.ErrInvalidMessage
+	}
+
+	kLen := (signer.sk.Pk.N.BitLen() + 7) / 8
+	msgs := make([]*big.Int, len(data))
+	crtKeys := make([]*common.CRTKey, len(data))
+	derived := make(map[string]*common.CRTKey)
+	for i := range data {
+		if len(data[i]) != kLen {
+			return nil, common.ErrUnexpectedSize
+		}
+		msgs[i] = new(big.Int).SetBytes(data[i])
+		if msgs[i].Cmp(signer.sk.Pk.N) > 0 {
+			return nil, common.ErrInvalidMessageLength
+		}
+
+		key, ok := derived[string(metadata[i])]
+		if !ok {
+			skPrime, err := deriveKeyPair(signer.h, signer.sk, metadata[i])
+			if err != nil {
+				return nil, err
+			}
+			key = common.NewCRTKey(skPrime)
+			derived[string(metadata[i])] = key
+		}
+		crtKeys[i] = key
+	}
+
+	blindSigs := make([][]byte, len(data))
+	err := common.Parallel(len(data), workers, func(i int) error {
+		s, err := crtKeys[i].DecryptAndCheck(rand.Reader, msgs[i])
+		if err != nil {
+			return err
+		}
+		blindSigs[i] = make([]byte, kLen)
+		s.FillBytes(blindSigs[i])
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return blindSigs, nil
+}
@@ -0,0 +1,55 @@
+package partiallyblindrsa
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+func TestBatch(t *testing.T) {
+	const n = 9
+	key := loadStrongRSAKey()
+	verifier := NewVerifier(&key.PublicKey, crypto.SHA384)
+	signer, err := NewSigner(key, crypto.SHA384)
+	test.CheckNoErr(t, err, "failed to create signer")
+
+	messages := make([][]byte, n)
+	metadata := make([][]byte, n)
+	for i := range messages {
+		messages[i] = []byte(fmt.Sprintf("message %v", i))
+		metadata[i] = []byte(fmt.Sprintf("metadata %v", i%3))
+	}
+	blindedMsgs, states, err := BlindBatch(verifier, rand.Reader, messages, metadata)
+	test.CheckNoErr(t, err, "blind failed")
+
+	blindedSigs, err := signer.BlindSignBatch(blindedMsgs, metadata, 0)
+	test.CheckNoErr(t, err, "batch sign failed")
+	for i := range blindedMsgs {
+		want, err := signer.BlindSign(blindedMsgs[i], metadata[i])
+		test.CheckNoErr(t, err, "sign failed")
+		if !bytes.Equal(blindedSigs[i], want) {
+			test.ReportError(t, blindedSigs[i], want, i)
+		}
+	}
+
+	sigs, err := FinalizeBatch(states, blindedSigs)
+	test.CheckNoErr(t, err, "finalize failed")
+	for i := range sigs {
+		test.CheckNoErr(t, verifier.Verify(messages[i], metadata[i], sigs[i]), "invalid signature")
+	}
+
+	// Signing with other metadata is detected.
+	blindedSigs, err = signer.BlindSignBatch(blindedMsgs, append(metadata[1:], metadata[0]), 2)
+	test.CheckNoErr(t, err, "batch sign failed")
+	_, err = FinalizeBatch(states, blindedSigs)
+	test.CheckIsErr(t, err, "should fail with other metadata")
+
+	_, err = signer.BlindSignBatch(blindedMsgs, metadata[1:], 0)
+	test.CheckIsErr(t, err, "should fail with mismatched lengths")
+	_, _, err = BlindBatch(verifier, nil, messages, metadata)
+	test.CheckIsErr(t, err, "should fail without randomness")
+}
@@ -0,0 +1,95 @@
+package blindrsa
+
+import (
+	"crypto/rsa"
+	"io"
+	"math/big"
+
+	"github.com/cloudflare/circl/blindsign/blindrsa/internal/common"
+	tss "github.com/cloudflare/circl/tss/rsa"
+)
+
+// ThresholdSigner represents one of the nodes that share the signing key in
+// a threshold version of the blind RSA protocol. Each node produces a
+// signature share of the blinded message, and any threshold of them are
+// combined with a ThresholdCombiner into a blind signature, which is the same
+// as the one produced by Signer.BlindSign. Hence, clients use Client.Finalize
+// as usual, for any Variant.
+//
+// Key shares are generated with tss/rsa, either by a dealer with Deal, or
+// with the distributed key generation.
+type ThresholdSigner struct {
+	pk    *rsa.PublicKey
+	share tss.KeyShare
+	vk    *tss.VerifyKey
+}
+
+// NewThresholdSigner creates a node for the threshold blind RSA protocol,
+// using a share of the private key corresponding to pk. If vk is not nil,
+// the signature shares carry a proof of correctness that can be checked with
+// vk by the ThresholdCombiner. These proofs are only sound if the primes of
+// the key are safe primes, as generated by tss/rsa.GenerateKey.
+func NewThresholdSigner(pk *rsa.PublicKey, share tss.KeyShare, vk *tss.VerifyKey) ThresholdSigner {
+	return ThresholdSigner{pk, share, vk}
+}
+
+// BlindSignShare computes a signature share of the blinded message, if it's
+// of valid length, and returns an error should the function fail. The
+// random source is used for blinding the computation and, if the signer has
+// a verification key, for the proof of correctness.
+func (signer ThresholdSigner) BlindSignShare(random io.Reader, data []byte) (tss.SignShare, error) {
+	if random == nil {
+		return tss.SignShare{}, common.ErrInvalidRandomness
+	}
+	if err := checkBlindedMessage(signer.pk, data); err != nil {
+		return tss.SignShare{}, err
+	}
+
+	if signer.vk != nil {
+		return signer.share.SignAndProve(random, signer.pk, signer.vk, data, true)
+	}
+	return signer.share.Sign(random, signer.pk, data, true)
+}
+
+// ThresholdCombiner combines the signature shares produced by the
+// ThresholdSigner nodes into a blind signature.
+type ThresholdCombiner struct {
+	pk                 *rsa.PublicKey
+	players, threshold uint
+	vk                 *tss.VerifyKey
+}
+
+// NewThresholdCombiner creates a combiner for signature shares of the given
+// public key, generated by a (threshold, players) sharing of the private key.
+// If vk is not nil, signature shares are verified, and faulty ones are
+// excluded before combining.
+func NewThresholdCombiner(pk *rsa.PublicKey, players, threshold uint, vk *tss.VerifyKey) ThresholdCombiner {
+	return ThresholdCombiner{pk, players, threshold, vk}
+}
+
+// Combine returns the blind signature of the blinded message from the
+// signature shares, which is accepted by Client.Finalize.
+func (c ThresholdCombiner) Combine(data []byte, shares []tss.SignShare) ([]byte, error) {
+	if err := checkBlindedMessage(c.pk, data); err != nil {
+		return nil, err
+	}
+
+	if c.vk != nil {
+		return tss.CombineVerifiedSignShares(c.pk, c.vk, c.players, c.threshold, shares, data)
+	}
+	return tss.CombineSignShares(c.pk, c.players, c.threshold, shares, data)
+}
+
+func checkBlindedMessage(pk *rsa.PublicKey, data []byte) error {
+	kLen := (pk.N.BitLen() + 7) / 8
+	if len(data) != kLen {
+		return common.ErrUnexpectedSize
+	}
+
+	m := new(big.Int).SetBytes(data)
+	if m.Cmp(pk.N) >= 0 {
+		return common.ErrInvalidMessageLength
+	}
+
+	return nil
+}
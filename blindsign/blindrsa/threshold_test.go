@@ -0,0 +1,110 @@
+package blindrsa
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	tss "github.com/cloudflare/circl/tss/rsa"
+)
+
+func TestThresholdRoundTrip(t *testing.T) {
+	const players, threshold = 5, 3
+	key, err := loadPrivateKey()
+	test.CheckNoErr(t, err, "failed to load key")
+	pk := &key.PublicKey
+
+	keyShares, err := tss.Deal(rand.Reader, players, threshold, key, false)
+	test.CheckNoErr(t, err, "failed to deal")
+	vk, err := tss.NewVerifyKey(rand.Reader, pk, keyShares)
+	test.CheckNoErr(t, err, "failed to create verification key")
+
+	message := []byte("hello world")
+	for _, variant := range []Variant{
+		SHA384PSSRandomized,
+		SHA384PSSZeroRandomized,
+		SHA384PSSDeterministic,
+		SHA384PSSZeroDeterministic,
+	} {
+		t.Run(variant.String(), func(t *testing.T) {
+			client, err := NewClient(variant, pk)
+			test.CheckNoErr(t, err, "failed to create client")
+
+			for _, verifyKey := range []*tss.VerifyKey{nil, vk} {
+				inputMsg, err := client.Prepare(rand.Reader, message)
+				test.CheckNoErr(t, err, "prepare failed")
+				blindedMsg, state, err := client.Blind(rand.Reader, inputMsg)
+				test.CheckNoErr(t, err, "blind failed")
+
+				shares := make([]tss.SignShare, players)
+				for i := range keyShares {
+					signer := NewThresholdSigner(pk, keyShares[i], verifyKey)
+					shares[i], err = signer.BlindSignShare(rand.Reader, blindedMsg)
+					test.CheckNoErr(t, err, "blind sign share failed")
+				}
+
+				combiner := NewThresholdCombiner(pk, players, threshold, verifyKey)
+				blindedSig, err := combiner.Combine(blindedMsg, shares[players-threshold:])
+				test.CheckNoErr(t, err, "combine failed")
+
+				// Same blind signature as the single signer.
+				want, err := NewSigner(key).BlindSign(blindedMsg)
+				test.CheckNoErr(t, err, "blind sign failed")
+				test.CheckOk(new(big.Int).SetBytes(want).Cmp(new(big.Int).SetBytes(blindedSig)) == 0, "blind signatures differ", t)
+
+				sig, err := client.Finalize(state, blindedSig)
+				test.CheckNoErr(t, err, "finalize failed")
+				test.CheckNoErr(t, client.Verify(inputMsg, sig), "verification failed")
+			}
+		})
+	}
+}
+
+func TestThresholdFaultySigner(t *testing.T) {
+	const players, threshold = 4, 2
+	key, err := loadPrivateKey()
+	test.CheckNoErr(t, err, "failed to load key")
+	pk := &key.PublicKey
+
+	keyShares, err := tss.Deal(rand.Reader, players, threshold, key, false)
+	test.CheckNoErr(t, err, "failed to deal")
+	vk, err := tss.NewVerifyKey(rand.Reader, pk, keyShares)
+	test.CheckNoErr(t, err, "failed to create verification key")
+
+	client, err := NewClient(SHA384PSSRandomized, pk)
+	test.CheckNoErr(t, err, "failed to create client")
+	inputMsg, err := client.Prepare(rand.Reader, []byte("hello world"))
+	test.CheckNoErr(t, err, "prepare failed")
+	blindedMsg, state, err := client.Blind(rand.Reader, inputMsg)
+	test.CheckNoErr(t, err, "blind failed")
+
+	// One node signs a different message.
+	otherMsg, _, err := client.Blind(rand.Reader, inputMsg)
+	test.CheckNoErr(t, err, "blind failed")
+	shares := make([]tss.SignShare, players)
+	for i := range keyShares {
+		msg := blindedMsg
+		if i == 0 {
+			msg = otherMsg
+		}
+		signer := NewThresholdSigner(pk, keyShares[i], vk)
+		shares[i], err = signer.BlindSignShare(rand.Reader, msg)
+		test.CheckNoErr(t, err, "blind sign share failed")
+	}
+
+	_, err = NewThresholdCombiner(pk, players, threshold, nil).Combine(blindedMsg, shares[:threshold])
+	test.CheckIsErr(t, err, "combining a faulty share must fail")
+
+	blindedSig, err := NewThresholdCombiner(pk, players, threshold, vk).Combine(blindedMsg, shares)
+	test.CheckNoErr(t, err, "combine failed")
+	sig, err := client.Finalize(state, blindedSig)
+	test.CheckNoErr(t, err, "finalize failed")
+	test.CheckNoErr(t, client.Verify(inputMsg, sig), "verification failed")
+
+	signer := NewThresholdSigner(pk, keyShares[0], nil)
+	_, err = signer.BlindSignShare(rand.Reader, blindedMsg[1:])
+	test.CheckIsErr(t, err, "should fail with invalid length")
+	_, err = signer.BlindSignShare(nil, blindedMsg)
+	test.CheckIsErr(t, err, "should fail without randomness")
+}
@@ -0,0 +1,503 @@
+package group
+
+import (
+	"crypto"
+	_ "crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/big"
+
+	r255 "github.com/bwesterb/go-ristretto"
+	ed "github.com/bwesterb/go-ristretto/edwards25519"
+	"github.com/cloudflare/circl/expander"
+	"github.com/cloudflare/circl/internal/conv"
+	"golang.org/x/crypto/cryptobyte"
+)
+
+// Ed25519 is the prime-order subgroup of the edwards25519 curve.
+//
+// Elements are encoded as specified in RFC 8032, so the identity is encoded
+// as 0x01 followed by 31 zero bytes. Decoding rejects non-canonical encodings
+// and points outside the prime-order subgroup.
+var Ed25519 Group = edGroup{}
+
+type edGroup struct{}
+
+type edElement struct {
+	p ed.ExtendedPoint
+}
+
+type edScalar struct {
+	s r255.Scalar
+}
+
+var (
+	// edD is the constant d = -121665/121666 of the edwards25519 curve.
+	edD ed.FieldElement
+	// edSqrtM1 is sqrt(-1) = 2^((p-1)/4) mod p.
+	edSqrtM1 ed.FieldElement
+	// edSqrtM486664 is sqrt(-486664) with sgn0 equal to 0, used by the
+	// rational map from curve25519 to edwards25519 (RFC 9380, Section 6.8.2).
+	edSqrtM486664 ed.FieldElement
+	// edOrder is the order of the prime-order subgroup in little-endian.
+	edOrder [32]byte
+	// edBase is the generator of RFC 8032, and edBaseTable holds its
+	// precomputed multiples. Note that the basepoint of go-ristretto is only
+	// equivalent to it modulo the torsion subgroup, so it cannot be used here.
+	edBase      ed.ExtendedPoint
+	edBaseTable ed.ScalarMultTable
+)
+
+func init() {
+	p := new(big.Int).Lsh(big.NewInt(1), 255)
+	p.Sub(p, big.NewInt(19))
+	d := new(big.Int).ModInverse(big.NewInt(121666), p)
+	d.Mul(d, big.NewInt(-121665))
+	d.Mod(d, p)
+	edD.SetBigInt(d)
+
+	e := new(big.Int).Sub(p, big.NewInt(1))
+	e.Rsh(e, 2)
+	edSqrtM1.SetBigInt(new(big.Int).Exp(big.NewInt(2), e, p))
+
+	var m486664 ed.FieldElement
+	m486664.SetBigInt(big.NewInt(486664))
+	m486664.Neg(&m486664)
+	edSqrtM486664.Abs(edSqrt(&m486664))
+
+	l, _ := new(big.Int).SetString("1000000000000000000000000000000014def9dea2f79cd65812631a5cf5d3ed", 16)
+	conv.BigInt2BytesLe(edOrder[:], l)
+
+	var base edElement
+	b, _ := hex.DecodeString("5866666666666666666666666666666666666666666666666666666666666666")
+	if err := base.UnmarshalBinary(b); err != nil {
+		panic(err)
+	}
+	edBase.Set(&base.p)
+	edBaseTable.Compute(&edBase)
+}
+
+// edSqrt returns a square root of a. The result is only meaningful if a is a
+// square, which can be checked with edSqrtCheck.
+func edSqrt(a *ed.FieldElement) *ed.FieldElement {
+	r, _ := edSqrtCheck(a)
+	return r
+}
+
+// edSqrtCheck returns (r, 1) such that r^2 = a if a is a square; otherwise,
+// returns (r, 0) with r being an arbitrary element.
+func edSqrtCheck(a *ed.FieldElement) (*ed.FieldElement, int32) {
+	// r = a^((p+3)/8) = a * a^((p-5)/8).
+	var r, r2, minusA, rI ed.FieldElement
+	r.Exp22523(a)
+	r.Mul(&r, a)
+	r2.Square(&r)
+	minusA.Neg(a)
+	isRoot := r2.EqualsI(a)
+	isMinusRoot := r2.EqualsI(&minusA)
+	rI.Mul(&r, &edSqrtM1)
+	r.ConditionalSet(&rI, isMinusRoot)
+	return &r, isRoot | isMinusRoot
+}
+
+func (g edGroup) String() string { return "ed25519" }
+
+func (g edGroup) Params() *Params { return &Params{32, 32, 32} }
+
+func (g edGroup) NewElement() Element { return g.Identity() }
+
+func (g edGroup) NewScalar() Scalar { return &edScalar{} }
+
+func (g edGroup) Identity() Element {
+	e := &edElement{}
+	e.p.SetZero()
+	return e
+}
+
+func (g edGroup) Generator() Element {
+	e := &edElement{}
+	e.p.Set(&edBase)
+	return e
+}
+
+func (g edGroup) RandomElement(rd io.Reader) Element {
+	b := make([]byte, 32)
+	if n, err := io.ReadFull(rd, b); err != nil || n != len(b) {
+		panic(err)
+	}
+	return g.HashToElement(b, nil)
+}
+
+func (g edGroup) RandomScalar(rd io.Reader) Scalar {
+	var b [64]byte
+	if n, err := io.ReadFull(rd, b[:]); err != nil || n != len(b) {
+		panic(err)
+	}
+	s := &edScalar{}
+	s.s.SetReduced(&b)
+	return s
+}
+
+func (g edGroup) RandomNonZeroScalar(rd io.Reader) Scalar {
+	for {
+		s := g.RandomScalar(rd)
+		if !s.IsZero() {
+			return s
+		}
+	}
+}
+
+func (g edGroup) HashToElementNonUniform(msg, dst []byte) Element {
+	// Compliant with RFC 9380, Section 8.5.
+	// SuiteID: edwards25519_XMD:SHA-512_ELL2_NU_
+	var u [1]big.Int
+	xmd := expander.NewExpanderMD(crypto.SHA512, dst)
+	HashToField(u[:], msg, xmd, edFieldOrder(), 48)
+	e := &edElement{}
+	e.mapToCurve(&u[0])
+	return e.clearCofactor()
+}
+
+func (g edGroup) HashToElement(msg, dst []byte) Element {
+	// Compliant with RFC 9380, Section 8.5.
+	// SuiteID: edwards25519_XMD:SHA-512_ELL2_RO_
+	var u [2]big.Int
+	xmd := expander.NewExpanderMD(crypto.SHA512, dst)
+	HashToField(u[:], msg, xmd, edFieldOrder(), 48)
+	q0, q1 := &edElement{}, &edElement{}
+	q0.mapToCurve(&u[0])
+	q1.mapToCurve(&u[1])
+	q0.p.Add(&q0.p, &q1.p)
+	return q0.clearCofactor()
+}
+
+func (g edGroup) HashToScalar(msg, dst []byte) Scalar {
+	var uniformBytes [64]byte
+	xmd := expander.NewExpanderMD(crypto.SHA512, dst)
+	copy(uniformBytes[:], xmd.Expand(msg, 64))
+	s := &edScalar{}
+	s.s.SetReduced(&uniformBytes)
+	return s
+}
+
+func edFieldOrder() *big.Int {
+	p := new(big.Int).Lsh(big.NewInt(1), 255)
+	return p.Sub(p, big.NewInt(19))
+}
+
+// mapToCurve sets the receiver to the image of u under the Elligator 2 map
+// to curve25519 followed by the rational map to edwards25519, as specified
+// in RFC 9380, Sections 6.7.1 and 6.8.2.
+func (e *edElement) mapToCurve(u *big.Int) {
+	const montJ = 486662
+	var fu, tv, one, j, x1, x2, gx1, gx2, y1, y2, x, y ed.FieldElement
+	fu.SetBigInt(u)
+	one.SetOne()
+	j.SetBigInt(big.NewInt(montJ))
+
+	// x1 = -J / (1 + 2u^2), or x1 = -J if the denominator is zero.
+	tv.Square(&fu)
+	tv.Add(&tv, &tv)
+	tv.Add(&tv, &one)
+	tv.Inverse(&tv)
+	x1.Mul(&j, &tv)
+	x1.Neg(&x1)
+	minusJ := new(ed.FieldElement).Neg(&j)
+	x1.ConditionalSet(minusJ, 1-x1.IsNonZeroI())
+
+	// gx1 = x1^3 + J*x1^2 + x1, and x2 = -x1 - J.
+	montRHS(&gx1, &x1, &j)
+	x2.Add(&x1, &j)
+	x2.Neg(&x2)
+	montRHS(&gx2, &x2, &j)
+
+	r1, isSquare := edSqrtCheck(&gx1)
+	y1.Abs(r1)
+	y1.Neg(&y1) // sgn0(y1) == 1, unless y1 is zero.
+	y2.Abs(edSqrt(&gx2))
+
+	x.Set(&x2)
+	x.ConditionalSet(&x1, isSquare)
+	y.Set(&y2)
+	y.ConditionalSet(&y1, isSquare)
+
+	// Rational map: (v, w) = (sqrt(-486664) * s / t, (s - 1) / (s + 1)).
+	var num, den, v, w, sp1, sm1 ed.FieldElement
+	sp1.Add(&x, &one)
+	sm1.Sub(&x, &one)
+	den.Mul(&y, &sp1)
+	isExceptional := 1 - den.IsNonZeroI()
+	den.Inverse(&den)
+
+	num.Mul(&edSqrtM486664, &x)
+	v.Mul(&num, &sp1)
+	v.Mul(&v, &den)
+	w.Mul(&sm1, &y)
+	w.Mul(&w, &den)
+
+	var zero ed.FieldElement
+	v.ConditionalSet(&zero, isExceptional)
+	w.ConditionalSet(&one, isExceptional)
+	e.p.X.Set(&v)
+	e.p.Y.Set(&w)
+	e.p.Z.SetOne()
+	e.p.T.Mul(&v, &w)
+}
+
+// montRHS sets gx to x^3 + J*x^2 + x.
+func montRHS(gx, x, j *ed.FieldElement) {
+	var t ed.FieldElement
+	t.Add(x, j)
+	t.Mul(&t, x)
+	gx.SetOne()
+	t.Add(&t, gx)
+	gx.Mul(&t, x)
+}
+
+func (e *edElement) clearCofactor() *edElement {
+	e.p.Double(&e.p)
+	e.p.Double(&e.p)
+	e.p.Double(&e.p)
+	return e
+}
+
+func (e *edElement) Group() Group { return Ed25519 }
+
+func (e *edElement) String() string {
+	b, _ := e.MarshalBinary()
+	return fmt.Sprintf("%x", b)
+}
+
+func (e *edElement) IsIdentity() bool {
+	return e.p.X.IsNonZeroI() == 0 && e.p.Y.Equals(&e.p.Z)
+}
+
+func (e *edElement) IsEqual(x Element) bool {
+	xx := x.(*edElement)
+	var l, r ed.FieldElement
+	l.Mul(&e.p.X, &xx.p.Z)
+	r.Mul(&xx.p.X, &e.p.Z)
+	eqX := l.EqualsI(&r)
+	l.Mul(&e.p.Y, &xx.p.Z)
+	r.Mul(&xx.p.Y, &e.p.Z)
+	eqY := l.EqualsI(&r)
+	return eqX&eqY == 1
+}
+
+func (e *edElement) Set(x Element) Element {
+	e.p.Set(&x.(*edElement).p)
+	return e
+}
+
+func (e *edElement) Copy() Element {
+	c := &edElement{}
+	c.p.Set(&e.p)
+	return c
+}
+
+func (e *edElement) CMov(v int, x Element) Element {
+	if !(v == 0 || v == 1) {
+		panic(ErrSelector)
+	}
+	e.p.ConditionalSet(&x.(*edElement).p, int32(v))
+	return e
+}
+
+func (e *edElement) CSelect(v int, x Element, y Element) Element {
+	if !(v == 0 || v == 1) {
+		panic(ErrSelector)
+	}
+	e.p.ConditionalSet(&x.(*edElement).p, int32(v))
+	e.p.ConditionalSet(&y.(*edElement).p, int32(1-v))
+	return e
+}
+
+func (e *edElement) Add(x Element, y Element) Element {
+	e.p.Add(&x.(*edElement).p, &y.(*edElement).p)
+	return e
+}
+
+func (e *edElement) Dbl(x Element) Element {
+	e.p.Double(&x.(*edElement).p)
+	return e
+}
+
+func (e *edElement) Neg(x Element) Element {
+	e.p.Neg(&x.(*edElement).p)
+	return e
+}
+
+func (e *edElement) Mul(x Element, y Scalar) Element {
+	var buf [32]byte
+	y.(*edScalar).s.BytesInto(&buf)
+	e.p.ScalarMult(&x.(*edElement).p, &buf)
+	return e
+}
+
+func (e *edElement) MulGen(x Scalar) Element {
+	var buf [32]byte
+	x.(*edScalar).s.BytesInto(&buf)
+	edBaseTable.ScalarMult(&e.p, &buf)
+	return e
+}
+
+func (e *edElement) MarshalBinaryCompress() ([]byte, error) {
+	return e.MarshalBinary()
+}
+
+// MarshalBinary encodes the element as specified in RFC 8032, Section 5.1.2.
+func (e *edElement) MarshalBinary() ([]byte, error) {
+	var zInv, x, y ed.FieldElement
+	var buf [32]byte
+	zInv.Inverse(&e.p.Z)
+	x.Mul(&e.p.X, &zInv)
+	y.Mul(&e.p.Y, &zInv)
+	y.BytesInto(&buf)
+	buf[31] |= byte(x.IsNegativeI() << 7)
+	return buf[:], nil
+}
+
+// UnmarshalBinary decodes an element as specified in RFC 8032, Section 5.1.3.
+// Additionally, it returns an error if the encoding is not canonical or if
+// the point does not belong to the prime-order subgroup.
+func (e *edElement) UnmarshalBinary(data []byte) error {
+	if len(data) != 32 {
+		return ErrUnmarshal
+	}
+	var buf, check [32]byte
+	copy(buf[:], data)
+	sign := int32(buf[31] >> 7)
+	buf[31] &= 0x7F
+
+	var x, y, u, v, one ed.FieldElement
+	y.SetBytes(&buf)
+	y.BytesInto(&check)
+	if subtle.ConstantTimeCompare(buf[:], check[:]) != 1 {
+		return ErrUnmarshal
+	}
+
+	// x^2 = u/v, where u = y^2 - 1 and v = d*y^2 + 1.
+	one.SetOne()
+	u.Square(&y)
+	v.Mul(&u, &edD)
+	u.Sub(&u, &one)
+	v.Add(&v, &one)
+	vInv := new(ed.FieldElement).Inverse(&v)
+	ratio := new(ed.FieldElement).Mul(&u, vInv)
+	r, isSquare := edSqrtCheck(ratio)
+	if isSquare == 0 {
+		return ErrUnmarshal
+	}
+	x.Set(r)
+	if x.IsNonZeroI() == 0 && sign == 1 {
+		return ErrUnmarshal
+	}
+	minusX := new(ed.FieldElement).Neg(&x)
+	x.ConditionalSet(minusX, x.IsNegativeI()^sign)
+
+	var p, q ed.ExtendedPoint
+	p.X.Set(&x)
+	p.Y.Set(&y)
+	p.Z.SetOne()
+	p.T.Mul(&x, &y)
+
+	q.VarTimeScalarMult(&p, &edOrder)
+	if !(&edElement{q}).IsIdentity() {
+		return ErrUnmarshal
+	}
+
+	e.p.Set(&p)
+	return nil
+}
+
+func (s *edScalar) Group() Group                { return Ed25519 }
+func (s *edScalar) String() string              { return conv.BytesLe2Hex(s.s.Bytes()) }
+func (s *edScalar) SetUint64(n uint64) Scalar   { s.s.SetUint64(n); return s }
+func (s *edScalar) SetBigInt(x *big.Int) Scalar { s.s.SetBigInt(x); return s }
+func (s *edScalar) IsZero() bool                { return s.s.IsNonZeroI() == 0 }
+func (s *edScalar) IsEqual(x Scalar) bool {
+	return s.s.Equals(&x.(*edScalar).s)
+}
+
+func (s *edScalar) Set(x Scalar) Scalar {
+	s.s.Set(&x.(*edScalar).s)
+	return s
+}
+
+func (s *edScalar) Copy() Scalar {
+	return &edScalar{*new(r255.Scalar).Set(&s.s)}
+}
+
+func (s *edScalar) CMov(v int, x Scalar) Scalar {
+	if !(v == 0 || v == 1) {
+		panic(ErrSelector)
+	}
+	s.s.ConditionalSet(&x.(*edScalar).s, int32(v))
+	return s
+}
+
+func (s *edScalar) CSelect(v int, x Scalar, y Scalar) Scalar {
+	if !(v == 0 || v == 1) {
+		panic(ErrSelector)
+	}
+	s.s.ConditionalSet(&x.(*edScalar).s, int32(v))
+	s.s.ConditionalSet(&y.(*edScalar).s, int32(1-v))
+	return s
+}
+
+func (s *edScalar) Add(x Scalar, y Scalar) Scalar {
+	s.s.Add(&x.(*edScalar).s, &y.(*edScalar).s)
+	return s
+}
+
+func (s *edScalar) Sub(x Scalar, y Scalar) Scalar {
+	s.s.Sub(&x.(*edScalar).s, &y.(*edScalar).s)
+	return s
+}
+
+func (s *edScalar) Mul(x Scalar, y Scalar) Scalar {
+	s.s.Mul(&x.(*edScalar).s, &y.(*edScalar).s)
+	return s
+}
+
+func (s *edScalar) Neg(x Scalar) Scalar {
+	s.s.Neg(&x.(*edScalar).s)
+	return s
+}
+
+func (s *edScalar) Inv(x Scalar) Scalar {
+	s.s.Inverse(&x.(*edScalar).s)
+	return s
+}
+
+func (s *edScalar) MarshalBinary() ([]byte, error) {
+	return s.s.MarshalBinary()
+}
+
+// UnmarshalBinary errors if the scalar is not reduced modulo the group order.
+func (s *edScalar) UnmarshalBinary(data []byte) error {
+	if len(data) != 32 {
+		return ErrUnmarshal
+	}
+	var b [32]byte
+	copy(b[:], data)
+	if !s.s.SetBytesStrict(&b) {
+		return ErrUnmarshal
+	}
+	return nil
+}
+
+func (s *edScalar) Marshal(b *cryptobyte.Builder) error {
+	b.AddBytes(s.s.Bytes())
+	return nil
+}
+
+func (s *edScalar) Unmarshal(str *cryptobyte.String) bool {
+	var b [32]byte
+	if !str.CopyBytes(b[:]) {
+		return false
+	}
+	return s.s.SetBytesStrict(&b)
+}
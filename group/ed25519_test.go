@@ -0,0 +1,79 @@
+package group
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// The generator is the point B of RFC 8032, Section 5.1.
+func TestEd25519Generator(t *testing.T) {
+	g := Ed25519
+	enc, err := g.Generator().MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "5866666666666666666666666666666666666666666666666666666666666666"
+	if got := hex.EncodeToString(enc); got != want {
+		t.Fatalf("got: %v want: %v", got, want)
+	}
+
+	two := g.NewScalar().SetUint64(2)
+	P := g.NewElement().MulGen(two)
+	Q := g.NewElement().Dbl(g.Generator())
+	if !P.IsEqual(Q) {
+		t.Fatalf("MulGen(2) != 2*G")
+	}
+}
+
+// https://www.rfc-editor.org/rfc/rfc9380.html#appendix-J.5.1
+func TestEd25519HashToElement(t *testing.T) {
+	const dst = "QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_RO_"
+	vectors := []struct{ msg, enc string }{
+		// Encodings of the points (P.x, P.y) given in the RFC.
+		{"", "21dc15e10253796df23a7699c8a383ea624cce88c52431f6be220b1a56c8a609"},
+		{"abc", "31558a26887f23fb8218f143e69d5f0af2e7831130bd5b432ef23883b895839a"},
+	}
+
+	for i, v := range vectors {
+		P := Ed25519.HashToElement([]byte(v.msg), []byte(dst))
+		enc, err := P.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(enc); got != v.enc {
+			t.Fatalf("vector %v: got: %v want: %v", i, got, v.enc)
+		}
+	}
+}
+
+func TestEd25519InvalidEncodings(t *testing.T) {
+	encVec := []string{
+		// Non-canonical y-coordinate, y = p.
+		"edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		// Non-canonical y-coordinate, y = p + 1.
+		"eeffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		// Point of order 2, (0,-1).
+		"ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		// Point of order 4, (sqrt(-1),0).
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		// Point of order 8.
+		"26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc05",
+		// Negative zero x-coordinate.
+		"0100000000000000000000000000000000000000000000000000000000000080",
+		// y = 2 is not on the curve.
+		"0200000000000000000000000000000000000000000000000000000000000000",
+		// Wrong length.
+		"58666666666666666666666666666666666666666666666666666666666666",
+	}
+
+	for i, enc := range encVec {
+		raw, err := hex.DecodeString(enc)
+		if err != nil {
+			t.Fatal("DecodeString")
+		}
+		err = Ed25519.NewElement().UnmarshalBinary(raw)
+		if err == nil {
+			t.Fatalf("Decode succeeded for vector %d: %v", i, enc)
+		}
+	}
+}
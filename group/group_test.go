@@ -15,6 +15,7 @@ var allGroups = []group.Group{
 	group.P384,
 	group.P521,
 	group.Ristretto255,
+	group.Ed25519,
 }
 
 func TestGroup(t *testing.T) {
@@ -193,7 +194,8 @@ func testMarshal(t *testing.T, testTimes int, g group.Group) {
 	I := g.Identity()
 	got, err := I.MarshalBinary()
 	test.CheckNoErr(t, err, "error on MarshalBinary")
-	if !isZero(got) {
+	// Ed25519 follows RFC 8032, which encodes the identity as (0,1).
+	if g != group.Ed25519 && !isZero(got) {
 		test.ReportError(t, got, "Non-zero identity")
 	}
 	if l := uint(len(got)); !(l == 1 || l == params.ElementLength) {
@@ -201,7 +203,7 @@ func testMarshal(t *testing.T, testTimes int, g group.Group) {
 	}
 	got, err = I.MarshalBinaryCompress()
 	test.CheckNoErr(t, err, "error on MarshalBinaryCompress")
-	if !isZero(got) {
+	if g != group.Ed25519 && !isZero(got) {
 		test.ReportError(t, got, "Non-zero identity")
 	}
 	if l := uint(len(got)); !(l == 1 || l == params.CompressedElementLength) {
@@ -30,12 +30,19 @@ type (
 // bytes), and produces a secret derived from the internal exporter secret
 // using the corresponding KDF Expand function. It panics if length is
 // greater than 255*N bytes, where N is the size (in bytes) of the KDF's
-// output.
+// output, or greater than 65535 bytes for one-stage KDFs.
 func (c *encdecContext) Export(exporterContext []byte, length uint) []byte {
 	maxLength := uint(255 * c.suite.kdfID.ExtractSize())
+	if c.suite.kdfID.IsOneStage() {
+		maxLength = 0xFFFF
+	}
 	if length > maxLength {
 		panic(fmt.Errorf("output length must be lesser than %v bytes", maxLength))
 	}
+	if c.suite.kdfID.IsOneStage() {
+		return c.suite.labeledDerive(c.exporterSecret, []byte("sec"),
+			exporterContext, uint16(length))
+	}
 	return c.suite.labeledExpand(c.exporterSecret, []byte("sec"),
 		exporterContext, uint16(length))
 }
@@ -76,6 +83,9 @@ func (c *encdecContext) increment() error {
 }
 
 func (c *sealContext) Seal(pt, aad []byte) ([]byte, error) {
+	if c.AEAD == nil {
+		return nil, ErrExportOnly
+	}
 	ct := c.AEAD.Seal(nil, c.calcNonce(), pt, aad)
 	err := c.increment()
 	if err != nil {
@@ -88,6 +98,9 @@ func (c *sealContext) Seal(pt, aad []byte) ([]byte, error) {
 }
 
 func (c *openContext) Open(ct, aad []byte) ([]byte, error) {
+	if c.AEAD == nil {
+		return nil, ErrExportOnly
+	}
 	pt, err := c.AEAD.Open(nil, c.calcNonce(), ct, aad)
 	if err != nil {
 		return nil, err
@@ -3,6 +3,7 @@ package hpke
 import (
 	"bytes"
 	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"testing"
 
@@ -184,3 +185,95 @@ func TestAeadSeqOverflow(t *testing.T) {
 		test.ReportError(t, gotIncorrect, wantIncorrect)
 	}
 }
+
+func setupSuiteTest(t *testing.T, s Suite) (Sealer, Opener) {
+	pk, sk, err := s.kemID.Scheme().GenerateKeyPair()
+	test.CheckNoErr(t, err, "key generation failed")
+	sender, err := s.NewSender(pk, []byte("info"))
+	test.CheckNoErr(t, err, "sender creation failed")
+	receiver, err := s.NewReceiver(sk, []byte("info"))
+	test.CheckNoErr(t, err, "receiver creation failed")
+
+	enc, sealer, err := sender.SetupPSK(rand.Reader, []byte("psk"), []byte("psk id"))
+	test.CheckNoErr(t, err, "sender setup failed")
+	opener, err := receiver.SetupPSK(enc, []byte("psk"), []byte("psk id"))
+	test.CheckNoErr(t, err, "receiver setup failed")
+	return sealer, opener
+}
+
+func TestExportOnly(t *testing.T) {
+	s := NewSuite(KEM_X25519_HKDF_SHA256, KDF_HKDF_SHA256, AEAD_ExportOnly)
+	sealer, opener := setupSuiteTest(t, s)
+
+	_, err := sealer.Seal([]byte("pt"), nil)
+	test.CheckOk(err == ErrExportOnly, "seal must fail", t)
+	_, err = opener.Open([]byte("ct"), nil)
+	test.CheckOk(err == ErrExportOnly, "open must fail", t)
+
+	want := sealer.Export([]byte("exporter"), 32)
+	test.CheckOk(bytes.Equal(opener.Export([]byte("exporter"), 32), want),
+		"exported values differ", t)
+
+	raw, err := opener.MarshalBinary()
+	test.CheckNoErr(t, err, "marshal failed")
+	opener, err = UnmarshalOpener(raw)
+	test.CheckNoErr(t, err, "unmarshal failed")
+	test.CheckOk(bytes.Equal(opener.Export([]byte("exporter"), 32), want),
+		"exported values differ", t)
+	_, err = opener.Open([]byte("ct"), nil)
+	test.CheckOk(err == ErrExportOnly, "open must fail", t)
+}
+
+func TestOneStageKDF(t *testing.T) {
+	kdfs := []KDF{
+		KDF_SHAKE128,
+		KDF_SHAKE256,
+		KDF_TurboSHAKE128,
+		KDF_TurboSHAKE256,
+	}
+	for _, kdf := range kdfs {
+		s := NewSuite(KEM_XWING, kdf, AEAD_ChaCha20Poly1305)
+		sealer, opener := setupSuiteTest(t, s)
+
+		ct, err := sealer.Seal([]byte("pt"), []byte("aad"))
+		test.CheckNoErr(t, err, "seal failed")
+		pt, err := opener.Open(ct, []byte("aad"))
+		test.CheckNoErr(t, err, "open failed")
+		test.CheckOk(bytes.Equal(pt, []byte("pt")), "plaintexts differ", t)
+
+		// Unlike HKDF, the output of the exporter is not limited to
+		// 255 blocks.
+		want := sealer.Export([]byte("exporter"), 0xFFFF)
+		got := opener.Export([]byte("exporter"), 0xFFFF)
+		test.CheckOk(bytes.Equal(got, want), "exported values differ", t)
+		got = opener.Export([]byte("exporter"), 16)
+		test.CheckOk(!bytes.Equal(got, want[:16]), "export length is not bound", t)
+
+		err = test.CheckPanic(func() { s.kdfID.Extract(nil, nil) })
+		test.CheckNoErr(t, err, "one-stage KDF must not extract")
+	}
+
+	// Known-answer tests from the vectors of draft-ietf-hpke-pq, which pin
+	// LabeledDerive and the one-stage key schedule.
+	vectors := readFile(t, "testdata/vectors_hpke_pq_draft03.json.gz")
+	for _, kdf := range kdfs {
+		found := false
+		for i := range vectors {
+			v := &vectors[i]
+			if KDF(v.KdfID) != kdf {
+				continue
+			}
+			found = true
+			s := NewSuite(KEM(v.KemID), kdf, AEAD(v.AeadID))
+			sender, recv := v.getActors(t, s.kemID.Scheme(), s)
+			sealer, _ := v.setup(t, s.kemID.Scheme(), sender, recv, v.ModeID, s)
+			got := hex.EncodeToString(sealer.(*sealContext).key)
+			if got != v.Key {
+				test.ReportError(t, got, v.Key, s)
+			}
+			v.checkAead(t, sealer.(*sealContext).encdecContext, v.ModeID)
+			v.checkExports(t, sealer, v.ModeID)
+		}
+		test.CheckOk(found, fmt.Sprintf("missing test vector for KDF %v", kdf), t)
+	}
+}
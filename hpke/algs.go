@@ -15,7 +15,11 @@ import (
 	"github.com/cloudflare/circl/dh/x448"
 	"github.com/cloudflare/circl/kem"
 	"github.com/cloudflare/circl/kem/kyber/kyber768"
+	"github.com/cloudflare/circl/kem/mlkem/mlkem1024"
+	"github.com/cloudflare/circl/kem/mlkem/mlkem512"
+	"github.com/cloudflare/circl/kem/mlkem/mlkem768"
 	"github.com/cloudflare/circl/kem/xwing"
+	"github.com/cloudflare/circl/xof"
 	"golang.org/x/crypto/chacha20poly1305"
 	"golang.org/x/crypto/hkdf"
 )
@@ -41,6 +45,16 @@ const (
 	KEM_X25519_KYBER768_DRAFT00 KEM = 0x30
 	// KEM_XWING is a hybrid KEM using X25519 and ML-KEM-768.
 	KEM_XWING KEM = 0x647a
+	// KEM_ML_KEM_512 is a KEM using ML-KEM-512.
+	KEM_ML_KEM_512 KEM = 0x0040
+	// KEM_ML_KEM_768 is a KEM using ML-KEM-768.
+	KEM_ML_KEM_768 KEM = 0x0041
+	// KEM_ML_KEM_1024 is a KEM using ML-KEM-1024.
+	KEM_ML_KEM_1024 KEM = 0x0042
+	// KEM_MLKEM768_P256 is a hybrid KEM using ML-KEM-768 and P-256.
+	KEM_MLKEM768_P256 KEM = 0x0050
+	// KEM_MLKEM1024_P384 is a hybrid KEM using ML-KEM-1024 and P-384.
+	KEM_MLKEM1024_P384 KEM = 0x0051
 )
 
 // IsValid returns true if the KEM identifier is supported by the HPKE package.
@@ -52,7 +66,12 @@ func (k KEM) IsValid() bool {
 		KEM_X25519_HKDF_SHA256,
 		KEM_X448_HKDF_SHA512,
 		KEM_X25519_KYBER768_DRAFT00,
-		KEM_XWING:
+		KEM_XWING,
+		KEM_ML_KEM_512,
+		KEM_ML_KEM_768,
+		KEM_ML_KEM_1024,
+		KEM_MLKEM768_P256,
+		KEM_MLKEM1024_P384:
 		return true
 	default:
 		return false
@@ -77,6 +96,16 @@ func (k KEM) Scheme() kem.Scheme {
 		return hybridkemX25519Kyber768
 	case KEM_XWING:
 		return kemXwing
+	case KEM_ML_KEM_512:
+		return kemMlkem512
+	case KEM_ML_KEM_768:
+		return kemMlkem768
+	case KEM_ML_KEM_1024:
+		return kemMlkem1024
+	case KEM_MLKEM768_P256:
+		return kemMlkem768P256
+	case KEM_MLKEM1024_P384:
+		return kemMlkem1024P384
 	default:
 		panic(ErrInvalidKEM)
 	}
@@ -92,13 +121,39 @@ const (
 	KDF_HKDF_SHA384 KDF = 0x02
 	// KDF_HKDF_SHA512 is a KDF using HKDF with SHA-512.
 	KDF_HKDF_SHA512 KDF = 0x03
+	// KDF_SHAKE128 is a one-stage KDF using SHAKE128.
+	KDF_SHAKE128 KDF = 0x10
+	// KDF_SHAKE256 is a one-stage KDF using SHAKE256.
+	KDF_SHAKE256 KDF = 0x11
+	// KDF_TurboSHAKE128 is a one-stage KDF using TurboSHAKE128.
+	KDF_TurboSHAKE128 KDF = 0x12
+	// KDF_TurboSHAKE256 is a one-stage KDF using TurboSHAKE256.
+	KDF_TurboSHAKE256 KDF = 0x13
 )
 
 func (k KDF) IsValid() bool {
 	switch k {
 	case KDF_HKDF_SHA256,
 		KDF_HKDF_SHA384,
-		KDF_HKDF_SHA512:
+		KDF_HKDF_SHA512,
+		KDF_SHAKE128,
+		KDF_SHAKE256,
+		KDF_TurboSHAKE128,
+		KDF_TurboSHAKE256:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsOneStage returns true if the KDF is a one-stage KDF, that is, it
+// provides Derive instead of Extract and Expand.
+func (k KDF) IsOneStage() bool {
+	switch k {
+	case KDF_SHAKE128,
+		KDF_SHAKE256,
+		KDF_TurboSHAKE128,
+		KDF_TurboSHAKE256:
 		return true
 	default:
 		return false
@@ -106,7 +161,8 @@ func (k KDF) IsValid() bool {
 }
 
 // ExtractSize returns the size (in bytes) of the pseudorandom key produced
-// by KDF.Extract.
+// by KDF.Extract. For one-stage KDFs, it returns the size of the secrets
+// derived in the key schedule.
 func (k KDF) ExtractSize() int {
 	switch k {
 	case KDF_HKDF_SHA256:
@@ -115,13 +171,18 @@ func (k KDF) ExtractSize() int {
 		return crypto.SHA384.Size()
 	case KDF_HKDF_SHA512:
 		return crypto.SHA512.Size()
+	case KDF_SHAKE128, KDF_TurboSHAKE128:
+		return 32
+	case KDF_SHAKE256, KDF_TurboSHAKE256:
+		return 64
 	default:
 		panic(ErrInvalidKDF)
 	}
 }
 
 // Extract derives a pseudorandom key from a high-entropy, secret input and a
-// salt. The size of the output is determined by KDF.ExtractSize.
+// salt. The size of the output is determined by KDF.ExtractSize. Panics if
+// the KDF is a one-stage KDF.
 func (k KDF) Extract(secret, salt []byte) (pseudorandomKey []byte) {
 	return hkdf.Extract(k.hash(), secret, salt)
 }
@@ -129,7 +190,8 @@ func (k KDF) Extract(secret, salt []byte) (pseudorandomKey []byte) {
 // Expand derives a variable length pseudorandom string from a pseudorandom key
 // and an information string. Panics if the pseudorandom key is less
 // than N bytes, or if the output length is greater than 255*N bytes,
-// where N is the size returned by KDF.Extract function.
+// where N is the size returned by KDF.Extract function, or if the KDF is
+// a one-stage KDF.
 func (k KDF) Expand(pseudorandomKey, info []byte, outputLen uint) []byte {
 	extractSize := k.ExtractSize()
 	if len(pseudorandomKey) < extractSize {
@@ -148,6 +210,16 @@ func (k KDF) Expand(pseudorandomKey, info []byte, outputLen uint) []byte {
 	return output
 }
 
+// Derive derives a pseudorandom string of length outputLen from the input
+// ikm. Panics if the KDF is not a one-stage KDF.
+func (k KDF) Derive(ikm []byte, outputLen uint) []byte {
+	x := k.xof().New()
+	_, _ = x.Write(ikm)
+	output := make([]byte, outputLen)
+	_, _ = x.Read(output)
+	return output
+}
+
 func (k KDF) hash() func() hash.Hash {
 	switch k {
 	case KDF_HKDF_SHA256:
@@ -161,6 +233,21 @@ func (k KDF) hash() func() hash.Hash {
 	}
 }
 
+func (k KDF) xof() xof.ID {
+	switch k {
+	case KDF_SHAKE128:
+		return xof.SHAKE128
+	case KDF_SHAKE256:
+		return xof.SHAKE256
+	case KDF_TurboSHAKE128:
+		return xof.TURBOSHAKE128
+	case KDF_TurboSHAKE256:
+		return xof.TURBOSHAKE256
+	default:
+		panic(ErrInvalidKDF)
+	}
+}
+
 type AEAD uint16
 
 //nolint:golint,stylecheck
@@ -171,12 +258,17 @@ const (
 	AEAD_AES256GCM AEAD = 0x02
 	// AEAD_ChaCha20Poly1305 is ChaCha20 stream cipher and Poly1305 MAC.
 	AEAD_ChaCha20Poly1305 AEAD = 0x03
+	// AEAD_ExportOnly denotes that HPKE contexts are only used to export
+	// secrets, and not for encryption.
+	AEAD_ExportOnly AEAD = 0xFFFF
 )
 
 // New instantiates an AEAD cipher from the identifier, returns an error if the
-// identifier is not known.
+// identifier is not known, or is AEAD_ExportOnly.
 func (a AEAD) New(key []byte) (cipher.AEAD, error) {
 	switch a {
+	case AEAD_ExportOnly:
+		return nil, ErrExportOnly
 	case AEAD_AES128GCM, AEAD_AES256GCM:
 		block, err := aes.NewCipher(key)
 		if err != nil {
@@ -194,7 +286,8 @@ func (a AEAD) IsValid() bool {
 	switch a {
 	case AEAD_AES128GCM,
 		AEAD_AES256GCM,
-		AEAD_ChaCha20Poly1305:
+		AEAD_ChaCha20Poly1305,
+		AEAD_ExportOnly:
 		return true
 	default:
 		return false
@@ -210,6 +303,8 @@ func (a AEAD) KeySize() uint {
 		return 32
 	case AEAD_ChaCha20Poly1305:
 		return chacha20poly1305.KeySize
+	case AEAD_ExportOnly:
+		return 0
 	default:
 		panic(ErrInvalidAEAD)
 	}
@@ -222,13 +317,15 @@ func (a AEAD) NonceSize() uint {
 		AEAD_AES256GCM,
 		AEAD_ChaCha20Poly1305:
 		return 12
+	case AEAD_ExportOnly:
+		return 0
 	default:
 		panic(ErrInvalidAEAD)
 	}
 }
 
 // CipherLen returns the length of a ciphertext corresponding to a message of
-// length mLen.
+// length mLen. Panics for AEAD_ExportOnly.
 func (a AEAD) CipherLen(mLen uint) uint {
 	switch a {
 	case AEAD_AES128GCM, AEAD_AES256GCM, AEAD_ChaCha20Poly1305:
@@ -243,6 +340,8 @@ var (
 	dhkemx25519hkdfsha256, dhkemx448hkdfsha512                    xKEM
 	hybridkemX25519Kyber768                                       hybridKEM
 	kemXwing                                                      genericNoAuthKEM
+	kemMlkem512, kemMlkem768, kemMlkem1024                        genericNoAuthKEM
+	kemMlkem768P256, kemMlkem1024P384                             genericNoAuthKEM
 )
 
 func init() {
@@ -283,5 +382,30 @@ func init() {
 	hybridkemX25519Kyber768.kemB = kyber768.Scheme()
 
 	kemXwing.Scheme = xwing.Scheme()
+	kemXwing.id = KEM_XWING
 	kemXwing.name = "HPKE_KEM_XWING"
+
+	kemMlkem512 = genericNoAuthKEM{seedKEM{mlkem512.Scheme()}, KEM_ML_KEM_512, "HPKE_KEM_ML_KEM_512"}
+	kemMlkem768 = genericNoAuthKEM{seedKEM{mlkem768.Scheme()}, KEM_ML_KEM_768, "HPKE_KEM_ML_KEM_768"}
+	kemMlkem1024 = genericNoAuthKEM{seedKEM{mlkem1024.Scheme()}, KEM_ML_KEM_1024, "HPKE_KEM_ML_KEM_1024"}
+
+	// The ephemeral scalar of P-256 is sampled from three candidates, and
+	// the one of P-384 from a single one, as in the test vectors of
+	// draft-ietf-hpke-pq.
+	kemMlkem768P256 = genericNoAuthKEM{mlkemECDH{
+		pq:              mlkem768.Scheme(),
+		curve:           ecdh.P256(),
+		label:           "MLKEM768-P256",
+		scalarSize:      32,
+		pointSize:       65,
+		encapCandidates: 3,
+	}, KEM_MLKEM768_P256, "HPKE_KEM_MLKEM768_P256"}
+	kemMlkem1024P384 = genericNoAuthKEM{mlkemECDH{
+		pq:              mlkem1024.Scheme(),
+		curve:           ecdh.P384(),
+		label:           "MLKEM1024-P384",
+		scalarSize:      48,
+		pointSize:       97,
+		encapCandidates: 1,
+	}, KEM_MLKEM1024_P384, "HPKE_KEM_MLKEM1024_P384"}
 }
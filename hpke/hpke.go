@@ -8,9 +8,12 @@
 // Specification in
 // https://datatracker.ietf.org/doc/draft-irtf-cfrg-hpke
 //
-// BUG(cjpatton): This package does not implement the "Export-Only" mode of the
-// HPKE context. In particular, it does not recognize the AEAD codepoint
-// reserved for this purpose (0xFFFF).
+// With the AEAD_ExportOnly codepoint, contexts only support exporting
+// secrets, and Seal and Open return ErrExportOnly.
+//
+// The one-stage KDFs based on SHAKE and TurboSHAKE, and their key
+// schedule, are specified in
+// https://datatracker.ietf.org/doc/draft-ietf-hpke-pq
 package hpke
 
 import (
@@ -31,7 +34,7 @@ type Context interface {
 	// bytes), and produces a secret derived from the internal exporter secret
 	// using the corresponding KDF Expand function. It panics if length is
 	// greater than 255*N bytes, where N is the size (in bytes) of the KDF's
-	// output.
+	// output, or greater than 65535 bytes for one-stage KDFs.
 	Export(exporterContext []byte, length uint) []byte
 	// Suite returns the cipher suite corresponding to this context.
 	Suite() Suite
@@ -280,4 +283,5 @@ var (
 	ErrInvalidKEMSharedSecret = errors.New("hpke: invalid KEM shared secret")
 	ErrInvalidKEMDeriveKey    = errors.New("hpke: too many tries to derive KEM key")
 	ErrAEADSeqOverflows       = errors.New("hpke: AEAD sequence number overflows")
+	ErrExportOnly             = errors.New("hpke: context is export-only")
 )
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/cloudflare/circl/hpke"
+	"github.com/cloudflare/circl/internal/test"
 )
 
 func Example() {
@@ -97,6 +98,37 @@ func TestHybridKEMMalformedInputs(t *testing.T) {
 	}
 }
 
+func TestXWingRejectsAuthModes(t *testing.T) {
+	// X-Wing is not an authenticated KEM, so the Auth modes are rejected.
+	kemID := hpke.KEM_XWING
+	suite := hpke.NewSuite(kemID, hpke.KDF_HKDF_SHA256, hpke.AEAD_AES128GCM)
+	info := []byte("info")
+	psk, pskID := []byte("a pre-shared key of 32 bytes...."), []byte("psk id")
+
+	pkR, skR, err := kemID.Scheme().GenerateKeyPair()
+	test.CheckNoErr(t, err, "key generation failed")
+	pkS, skS, err := kemID.Scheme().GenerateKeyPair()
+	test.CheckNoErr(t, err, "key generation failed")
+
+	sender, err := suite.NewSender(pkR, info)
+	test.CheckNoErr(t, err, "sender creation failed")
+	_, _, err = sender.SetupAuth(rand.Reader, skS)
+	test.CheckOk(err == hpke.ErrInvalidAuthKEM, "SetupAuth must fail", t)
+	_, _, err = sender.SetupAuthPSK(rand.Reader, skS, psk, pskID)
+	test.CheckOk(err == hpke.ErrInvalidAuthKEM, "SetupAuthPSK must fail", t)
+
+	sender, err = suite.NewSender(pkR, info)
+	test.CheckNoErr(t, err, "sender creation failed")
+	enc, _, err := sender.Setup(rand.Reader)
+	test.CheckNoErr(t, err, "sender setup failed")
+	receiver, err := suite.NewReceiver(skR, info)
+	test.CheckNoErr(t, err, "receiver creation failed")
+	_, err = receiver.SetupAuth(enc, pkS)
+	test.CheckOk(err == hpke.ErrInvalidAuthKEM, "SetupAuth must fail", t)
+	_, err = receiver.SetupAuthPSK(enc, psk, pskID, pkS)
+	test.CheckOk(err == hpke.ErrInvalidAuthKEM, "SetupAuthPSK must fail", t)
+}
+
 func runHpkeBenchmark(b *testing.B, kem hpke.KEM, kdf hpke.KDF, aead hpke.AEAD) {
 	suite := hpke.NewSuite(kem, kdf, aead)
 
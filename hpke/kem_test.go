@@ -16,6 +16,12 @@ func TestKemKeysExactLength(t *testing.T) {
 		hpke.KEM_X25519_HKDF_SHA256,
 		hpke.KEM_X448_HKDF_SHA512,
 		hpke.KEM_X25519_KYBER768_DRAFT00,
+		hpke.KEM_XWING,
+		hpke.KEM_ML_KEM_512,
+		hpke.KEM_ML_KEM_768,
+		hpke.KEM_ML_KEM_1024,
+		hpke.KEM_MLKEM768_P256,
+		hpke.KEM_MLKEM1024_P384,
 	} {
 		checkExactLengthUnmarshal(t, kemID)
 	}
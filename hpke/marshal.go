@@ -1,6 +1,7 @@
 package hpke
 
 import (
+	"bytes"
 	"errors"
 
 	"golang.org/x/crypto/cryptobyte"
@@ -49,6 +50,12 @@ func unmarshalContext(raw []byte) (*encdecContext, error) {
 		!t.ReadBytes(&c.sequenceNumber, len(t)) {
 		return nil, errors.New("failed to parse context")
 	}
+	// The slices read alias raw, and the sequence number is updated in
+	// place, so they are copied.
+	c.exporterSecret = bytes.Clone(c.exporterSecret)
+	c.key = bytes.Clone(c.key)
+	c.baseNonce = bytes.Clone(c.baseNonce)
+	c.sequenceNumber = bytes.Clone(c.sequenceNumber)
 
 	if !c.suite.isValid() {
 		return nil, ErrInvalidHPKESuite
@@ -64,9 +71,11 @@ func unmarshalContext(raw []byte) (*encdecContext, error) {
 		return nil, errors.New("invalid key length")
 	}
 
-	c.AEAD, err = c.suite.aeadID.New(c.key)
-	if err != nil {
-		return nil, err
+	if c.suite.aeadID != AEAD_ExportOnly {
+		c.AEAD, err = c.suite.aeadID.New(c.key)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	Nn := int(c.suite.aeadID.NonceSize())
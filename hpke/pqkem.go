@@ -0,0 +1,282 @@
+package hpke
+
+// This file implements the post-quantum and hybrid KEMs of
+// draft-ietf-hpke-pq whose private keys are serialized as seeds: ML-KEM,
+// and the hybrids of ML-KEM with ECDH over P-256 and P-384 defined in
+// draft-irtf-cfrg-concrete-hybrid-kems.
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/subtle"
+	"io"
+
+	"github.com/cloudflare/circl/internal/sha3"
+	"github.com/cloudflare/circl/kem"
+)
+
+// seedKEM wraps a KEM so that private keys are serialized as the seed they
+// are derived from, as draft-ietf-hpke-pq does for ML-KEM.
+type seedKEM struct{ kem.Scheme }
+
+type seedKEMPrivKey struct {
+	kem.PrivateKey
+	scheme seedKEM
+	seed   []byte
+}
+
+func (s seedKEM) PrivateKeySize() int { return s.SeedSize() }
+
+func (s seedKEM) GenerateKeyPair() (kem.PublicKey, kem.PrivateKey, error) {
+	seed := make([]byte, s.SeedSize())
+	_, err := io.ReadFull(rand.Reader, seed)
+	if err != nil {
+		return nil, nil, err
+	}
+	pk, sk := s.DeriveKeyPair(seed)
+	return pk, sk, nil
+}
+
+func (s seedKEM) DeriveKeyPair(seed []byte) (kem.PublicKey, kem.PrivateKey) {
+	pk, sk := s.Scheme.DeriveKeyPair(seed)
+	return pk, &seedKEMPrivKey{sk, s, bytes.Clone(seed)}
+}
+
+func (s seedKEM) UnmarshalBinaryPrivateKey(data []byte) (kem.PrivateKey, error) {
+	if len(data) != s.PrivateKeySize() {
+		return nil, kem.ErrPrivKeySize
+	}
+	_, sk := s.DeriveKeyPair(data)
+	return sk, nil
+}
+
+func (s seedKEM) Decapsulate(sk kem.PrivateKey, ct []byte) ([]byte, error) {
+	priv, ok := sk.(*seedKEMPrivKey)
+	if !ok {
+		return nil, kem.ErrTypeMismatch
+	}
+	return s.Scheme.Decapsulate(priv.PrivateKey, ct)
+}
+
+func (k *seedKEMPrivKey) Scheme() kem.Scheme { return k.scheme }
+
+func (k *seedKEMPrivKey) MarshalBinary() ([]byte, error) {
+	return bytes.Clone(k.seed), nil
+}
+
+func (k *seedKEMPrivKey) Equal(other kem.PrivateKey) bool {
+	oth, ok := other.(*seedKEMPrivKey)
+	return ok && k.PrivateKey.Equal(oth.PrivateKey)
+}
+
+// mlkemECDH is a hybrid of ML-KEM and ECDH over a NIST curve. The private
+// key is a 32-byte seed, the public key and the ciphertext are those of
+// ML-KEM followed by an uncompressed point, and the shared secret is
+//
+//	SHA3-256(ss_PQ || ss_T || ct_T || ek_T || label).
+type mlkemECDH struct {
+	pq    kem.Scheme
+	curve ecdh.Curve
+	label string
+
+	// Size of the scalars, and of the uncompressed points.
+	scalarSize, pointSize int
+	// Number of candidate scalars read from the encapsulation seed.
+	encapCandidates int
+}
+
+type mlkemECDHPubKey struct {
+	scheme mlkemECDH
+	pq     kem.PublicKey
+	t      *ecdh.PublicKey
+}
+
+type mlkemECDHPrivKey struct {
+	scheme mlkemECDH
+	seed   []byte
+	pq     kem.PrivateKey
+	t      *ecdh.PrivateKey
+}
+
+const mlkemECDHSeedSize = 32
+
+func (h mlkemECDH) Name() string        { return h.label }
+func (h mlkemECDH) PrivateKeySize() int { return mlkemECDHSeedSize }
+func (h mlkemECDH) SeedSize() int       { return mlkemECDHSeedSize }
+func (h mlkemECDH) SharedKeySize() int  { return 32 }
+func (h mlkemECDH) PublicKeySize() int  { return h.pq.PublicKeySize() + h.pointSize }
+func (h mlkemECDH) CiphertextSize() int { return h.pq.CiphertextSize() + h.pointSize }
+func (h mlkemECDH) EncapsulationSeedSize() int {
+	return h.pq.EncapsulationSeedSize() + h.encapCandidates*h.scalarSize
+}
+
+func (h mlkemECDH) GenerateKeyPair() (kem.PublicKey, kem.PrivateKey, error) {
+	seed := make([]byte, h.SeedSize())
+	_, err := io.ReadFull(rand.Reader, seed)
+	if err != nil {
+		return nil, nil, err
+	}
+	pk, sk := h.DeriveKeyPair(seed)
+	return pk, sk, nil
+}
+
+// DeriveKeyPair expands the seed with SHAKE256 into the seed of ML-KEM,
+// followed by candidates for the ECDH scalar, the first valid one of which
+// is used. Panics if seed is not of length SeedSize().
+func (h mlkemECDH) DeriveKeyPair(seed []byte) (kem.PublicKey, kem.PrivateKey) {
+	if len(seed) != h.SeedSize() {
+		panic(kem.ErrSeedSize)
+	}
+	xof := sha3.NewShake256()
+	_, _ = xof.Write(seed)
+	seedPQ := make([]byte, h.pq.SeedSize())
+	_, _ = xof.Read(seedPQ)
+	pkPQ, skPQ := h.pq.DeriveKeyPair(seedPQ)
+
+	// The draft bounds the number of candidates, but reaching the bound
+	// happens with negligible probability.
+	seedT := make([]byte, h.scalarSize)
+	for {
+		_, _ = xof.Read(seedT)
+		skT, err := h.curve.NewPrivateKey(seedT)
+		if err == nil {
+			sk := &mlkemECDHPrivKey{h, bytes.Clone(seed), skPQ, skT}
+			return &mlkemECDHPubKey{h, pkPQ, skT.PublicKey()}, sk
+		}
+	}
+}
+
+func (h mlkemECDH) Encapsulate(pk kem.PublicKey) (ct, ss []byte, err error) {
+	seed := make([]byte, h.EncapsulationSeedSize())
+	_, err = io.ReadFull(rand.Reader, seed)
+	if err != nil {
+		return nil, nil, err
+	}
+	return h.EncapsulateDeterministically(pk, seed)
+}
+
+// EncapsulateDeterministically uses the beginning of the seed to
+// encapsulate with ML-KEM, and the rest as candidates for the ephemeral
+// ECDH scalar.
+func (h mlkemECDH) EncapsulateDeterministically(
+	pk kem.PublicKey, seed []byte,
+) (ct, ss []byte, err error) {
+	if len(seed) != h.EncapsulationSeedSize() {
+		return nil, nil, kem.ErrSeedSize
+	}
+	pub, ok := pk.(*mlkemECDHPubKey)
+	if !ok {
+		return nil, nil, kem.ErrTypeMismatch
+	}
+
+	seedPQ, seedT := seed[:h.pq.EncapsulationSeedSize()], seed[h.pq.EncapsulationSeedSize():]
+	var skE *ecdh.PrivateKey
+	for i := 0; skE == nil; i++ {
+		if i == h.encapCandidates {
+			return nil, nil, ErrInvalidKEMDeriveKey
+		}
+		skE, _ = h.curve.NewPrivateKey(seedT[i*h.scalarSize : (i+1)*h.scalarSize])
+	}
+	ssT, err := skE.ECDH(pub.t)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctT := skE.PublicKey().Bytes()
+
+	ctPQ, ssPQ, err := h.pq.EncapsulateDeterministically(pub.pq, seedPQ)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return append(ctPQ, ctT...), h.combine(ssPQ, ssT, ctT, pub.t.Bytes()), nil
+}
+
+func (h mlkemECDH) Decapsulate(sk kem.PrivateKey, ct []byte) ([]byte, error) {
+	if len(ct) != h.CiphertextSize() {
+		return nil, kem.ErrCiphertextSize
+	}
+	priv, ok := sk.(*mlkemECDHPrivKey)
+	if !ok {
+		return nil, kem.ErrTypeMismatch
+	}
+
+	ctPQ, ctT := ct[:h.pq.CiphertextSize()], ct[h.pq.CiphertextSize():]
+	ssPQ, err := h.pq.Decapsulate(priv.pq, ctPQ)
+	if err != nil {
+		return nil, err
+	}
+	pkE, err := h.curve.NewPublicKey(ctT)
+	if err != nil {
+		return nil, err
+	}
+	ssT, err := priv.t.ECDH(pkE)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.combine(ssPQ, ssT, ctT, priv.t.PublicKey().Bytes()), nil
+}
+
+func (h mlkemECDH) combine(ssPQ, ssT, ctT, pkT []byte) []byte {
+	hh := sha3.New256()
+	_, _ = hh.Write(ssPQ)
+	_, _ = hh.Write(ssT)
+	_, _ = hh.Write(ctT)
+	_, _ = hh.Write(pkT)
+	_, _ = hh.Write([]byte(h.label))
+	return hh.Sum(nil)
+}
+
+func (h mlkemECDH) UnmarshalBinaryPublicKey(data []byte) (kem.PublicKey, error) {
+	if len(data) != h.PublicKeySize() {
+		return nil, kem.ErrPubKeySize
+	}
+	pkPQ, err := h.pq.UnmarshalBinaryPublicKey(data[:h.pq.PublicKeySize()])
+	if err != nil {
+		return nil, err
+	}
+	pkT, err := h.curve.NewPublicKey(data[h.pq.PublicKeySize():])
+	if err != nil {
+		return nil, err
+	}
+	return &mlkemECDHPubKey{h, pkPQ, pkT}, nil
+}
+
+func (h mlkemECDH) UnmarshalBinaryPrivateKey(data []byte) (kem.PrivateKey, error) {
+	if len(data) != h.PrivateKeySize() {
+		return nil, kem.ErrPrivKeySize
+	}
+	_, sk := h.DeriveKeyPair(data)
+	return sk, nil
+}
+
+func (k *mlkemECDHPubKey) Scheme() kem.Scheme { return k.scheme }
+
+func (k *mlkemECDHPubKey) MarshalBinary() ([]byte, error) {
+	pq, err := k.pq.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return append(pq, k.t.Bytes()...), nil
+}
+
+func (k *mlkemECDHPubKey) Equal(other kem.PublicKey) bool {
+	oth, ok := other.(*mlkemECDHPubKey)
+	return ok && k.pq.Equal(oth.pq) && k.t.Equal(oth.t)
+}
+
+func (k *mlkemECDHPrivKey) Scheme() kem.Scheme { return k.scheme }
+
+func (k *mlkemECDHPrivKey) MarshalBinary() ([]byte, error) {
+	return bytes.Clone(k.seed), nil
+}
+
+func (k *mlkemECDHPrivKey) Equal(other kem.PrivateKey) bool {
+	oth, ok := other.(*mlkemECDHPrivKey)
+	return ok && subtle.ConstantTimeCompare(k.seed, oth.seed) == 1
+}
+
+func (k *mlkemECDHPrivKey) Public() kem.PublicKey {
+	return &mlkemECDHPubKey{k.scheme, k.pq.Public(), k.t.PublicKey()}
+}
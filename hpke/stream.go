@@ -0,0 +1,216 @@
+package hpke
+
+// This file implements streaming encryption on top of Sealer and Opener.
+//
+// The plaintext is split in chunks, each of which is sealed in turn, so
+// their nonces are bound to their position in the stream. The associated
+// data of a chunk indicates whether it is the last one, which prevents
+// truncation of the stream at a chunk boundary. On the wire, a chunk is
+// (in TLS syntax)
+//
+//	struct {
+//	    uint8 final;                // 1 for the last chunk, 0 otherwise
+//	    opaque ciphertext<0..2^32-1>;
+//	} Chunk;
+//
+// Note that this format is not defined by the HPKE standard.
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+var (
+	ErrStreamTruncated = errors.New("hpke: stream is truncated")
+	ErrStreamClosed    = errors.New("hpke: stream is closed")
+	ErrStreamChunkSize = errors.New("hpke: invalid chunk size")
+)
+
+const (
+	chunkHeaderSize = 5
+
+	chunkNotFinal = 0
+	chunkFinal    = 1
+)
+
+// Writer encrypts the plaintext written to it with a Sealer, and writes
+// the chunks of ciphertext to an underlying io.Writer.
+type Writer struct {
+	w         io.Writer
+	s         Sealer
+	buf       []byte
+	chunkSize int
+	closed    bool
+	err       error
+}
+
+// NewWriter returns a Writer that seals chunks of chunkSize bytes of
+// plaintext with s and writes them to w. The stream must be terminated
+// by calling Close.
+//
+// The Sealer must not be used by other means while the Writer is in use.
+// To resume the stream later on, call Flush and serialize s with
+// MarshalBinary. Then, pass the parsed Sealer to NewWriter.
+func NewWriter(w io.Writer, s Sealer, chunkSize int) (*Writer, error) {
+	if err := checkStreamParams(s.Suite(), chunkSize); err != nil {
+		return nil, err
+	}
+	return &Writer{w: w, s: s, buf: make([]byte, 0, chunkSize), chunkSize: chunkSize}, nil
+}
+
+func checkStreamParams(s Suite, chunkSize int) error {
+	if s.aeadID == AEAD_ExportOnly {
+		return ErrExportOnly
+	}
+	if chunkSize <= 0 || uint64(s.aeadID.CipherLen(uint(chunkSize))) > 1<<32-1 {
+		return ErrStreamChunkSize
+	}
+	return nil
+}
+
+// Write encrypts p. Plaintext is buffered until a whole chunk is available.
+func (w *Writer) Write(p []byte) (n int, err error) {
+	if w.closed {
+		return 0, ErrStreamClosed
+	}
+	for len(p) > 0 && w.err == nil {
+		k := copy(w.buf[len(w.buf):w.chunkSize], p)
+		w.buf = w.buf[:len(w.buf)+k]
+		p = p[k:]
+		n += k
+		if len(w.buf) == w.chunkSize {
+			w.writeChunk(chunkNotFinal)
+		}
+	}
+	return n, w.err
+}
+
+// Flush encrypts and writes the buffered plaintext as a possibly short
+// chunk. Afterwards, the Sealer reflects all the plaintext written so far.
+func (w *Writer) Flush() error {
+	if w.closed {
+		return ErrStreamClosed
+	}
+	if len(w.buf) > 0 && w.err == nil {
+		w.writeChunk(chunkNotFinal)
+	}
+	return w.err
+}
+
+// Close encrypts and writes the buffered plaintext as the final chunk.
+// It does not close the underlying io.Writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return w.err
+	}
+	if w.err == nil {
+		w.writeChunk(chunkFinal)
+	}
+	w.closed = true
+	return w.err
+}
+
+func (w *Writer) writeChunk(final byte) {
+	var header [chunkHeaderSize]byte
+	header[0] = final
+	ct, err := w.s.Seal(w.buf, header[:1])
+	if err != nil {
+		w.err = err
+		return
+	}
+	binary.BigEndian.PutUint32(header[1:], uint32(len(ct)))
+	if _, err = w.w.Write(header[:]); err == nil {
+		_, err = w.w.Write(ct)
+	}
+	w.err = err
+	w.buf = w.buf[:0]
+}
+
+// Reader decrypts the chunks of ciphertext read from an underlying
+// io.Reader with an Opener.
+type Reader struct {
+	r         io.Reader
+	o         Opener
+	buf       []byte
+	ct        []byte
+	chunkSize int
+	final     bool
+	err       error
+}
+
+// NewReader returns a Reader that opens chunks of at most chunkSize bytes
+// of plaintext read from r with o.
+//
+// The Opener must not be used by other means while the Reader is in use.
+// Chunks are read from r one at a time, so whenever Buffered returns zero,
+// o reflects all the plaintext read so far, and may be serialized to
+// resume the stream later on.
+func NewReader(r io.Reader, o Opener, chunkSize int) (*Reader, error) {
+	if err := checkStreamParams(o.Suite(), chunkSize); err != nil {
+		return nil, err
+	}
+	return &Reader{r: r, o: o, chunkSize: chunkSize}, nil
+}
+
+// Read decrypts plaintext into p. It returns io.EOF after the final chunk,
+// and ErrStreamTruncated if the stream ends before it. Data after the final
+// chunk is not read.
+func (r *Reader) Read(p []byte) (n int, err error) {
+	for len(r.buf) == 0 && r.err == nil {
+		if r.final {
+			r.err = io.EOF
+		} else {
+			r.readChunk()
+		}
+	}
+	if len(r.buf) > 0 {
+		n = copy(p, r.buf)
+		r.buf = r.buf[n:]
+		return n, nil
+	}
+	return 0, r.err
+}
+
+// Buffered returns the number of bytes of plaintext of the current chunk
+// that were not read yet.
+func (r *Reader) Buffered() int { return len(r.buf) }
+
+func (r *Reader) readChunk() {
+	var header [chunkHeaderSize]byte
+	if _, err := io.ReadFull(r.r, header[:]); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			err = ErrStreamTruncated
+		}
+		r.err = err
+		return
+	}
+
+	final := header[0]
+	size := binary.BigEndian.Uint32(header[1:])
+	maxSize := r.o.Suite().aeadID.CipherLen(uint(r.chunkSize))
+	if final > chunkFinal || uint64(size) > uint64(maxSize) {
+		r.err = ErrStreamChunkSize
+		return
+	}
+
+	if cap(r.ct) < int(size) {
+		r.ct = make([]byte, size)
+	}
+	r.ct = r.ct[:size]
+	if _, err := io.ReadFull(r.r, r.ct); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			err = ErrStreamTruncated
+		}
+		r.err = err
+		return
+	}
+
+	pt, err := r.o.Open(r.ct, header[:1])
+	if err != nil {
+		r.err = err
+		return
+	}
+	r.buf = pt
+	r.final = final == chunkFinal
+}
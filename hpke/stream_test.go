@@ -0,0 +1,156 @@
+package hpke
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+func TestStream(t *testing.T) {
+	s := NewSuite(KEM_X25519_HKDF_SHA256, KDF_HKDF_SHA256, AEAD_AES128GCM)
+	const chunkSize = 64
+
+	msg := make([]byte, 1000)
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+
+	for _, size := range []int{0, 1, chunkSize - 1, chunkSize, chunkSize + 1, 5 * chunkSize, len(msg)} {
+		t.Run(fmt.Sprint(size), func(t *testing.T) {
+			sealer, opener := setupSuiteTest(t, s)
+
+			var ct bytes.Buffer
+			w, err := NewWriter(&ct, sealer, chunkSize)
+			test.CheckNoErr(t, err, "writer creation failed")
+			// Writes of various sizes.
+			for p, k := msg[:size], 1; len(p) > 0; k++ {
+				k = min(k, len(p))
+				n, err := w.Write(p[:k])
+				test.CheckNoErr(t, err, "write failed")
+				test.CheckOk(n == k, "short write", t)
+				p = p[k:]
+			}
+			test.CheckNoErr(t, w.Close(), "close failed")
+			_, err = w.Write([]byte{0})
+			test.CheckOk(err == ErrStreamClosed, "write after close must fail", t)
+
+			r, err := NewReader(bytes.NewReader(ct.Bytes()), opener, chunkSize)
+			test.CheckNoErr(t, err, "reader creation failed")
+			pt, err := io.ReadAll(r)
+			test.CheckNoErr(t, err, "read failed")
+			test.CheckOk(bytes.Equal(pt, msg[:size]), "plaintexts differ", t)
+		})
+	}
+}
+
+func TestStreamTampering(t *testing.T) {
+	s := NewSuite(KEM_X25519_HKDF_SHA256, KDF_HKDF_SHA256, AEAD_ChaCha20Poly1305)
+	const chunkSize = 16
+	chunkLen := chunkHeaderSize + int(s.aeadID.CipherLen(chunkSize))
+
+	sealer, opener := setupSuiteTest(t, s)
+	raw, err := opener.MarshalBinary()
+	test.CheckNoErr(t, err, "marshal failed")
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, sealer, chunkSize)
+	test.CheckNoErr(t, err, "writer creation failed")
+	_, err = w.Write(make([]byte, 3*chunkSize+5))
+	test.CheckNoErr(t, err, "write failed")
+	test.CheckNoErr(t, w.Close(), "close failed")
+	ct := buf.Bytes()
+
+	read := func(ct []byte) error {
+		opener, err := UnmarshalOpener(raw)
+		test.CheckNoErr(t, err, "unmarshal failed")
+		r, err := NewReader(bytes.NewReader(ct), opener, chunkSize)
+		test.CheckNoErr(t, err, "reader creation failed")
+		_, err = io.ReadAll(r)
+		return err
+	}
+
+	test.CheckNoErr(t, read(ct), "read failed")
+
+	// Truncation at a chunk boundary, and within a chunk.
+	err = read(ct[:2*chunkLen])
+	test.CheckOk(err == ErrStreamTruncated, "truncation not detected", t)
+	err = read(ct[:2*chunkLen+3])
+	test.CheckOk(err == ErrStreamTruncated, "truncation not detected", t)
+
+	// Marking a chunk as final.
+	forged := bytes.Clone(ct)
+	forged[2*chunkLen] = chunkFinal
+	test.CheckIsErr(t, read(forged[:3*chunkLen]), "forged final chunk accepted")
+
+	// Reordering of chunks.
+	forged = bytes.Clone(ct)
+	copy(forged[chunkLen:], ct[2*chunkLen:3*chunkLen])
+	copy(forged[2*chunkLen:], ct[chunkLen:2*chunkLen])
+	test.CheckIsErr(t, read(forged), "reordered chunks accepted")
+
+	// Oversized chunks.
+	forged = bytes.Clone(ct)
+	forged[4]++
+	test.CheckOk(read(forged) == ErrStreamChunkSize, "oversized chunk accepted", t)
+}
+
+func TestStreamResume(t *testing.T) {
+	s := NewSuite(KEM_P256_HKDF_SHA256, KDF_HKDF_SHA256, AEAD_AES256GCM)
+	const chunkSize = 32
+	sealer, opener := setupSuiteTest(t, s)
+
+	// The stream is written in two sessions.
+	var ct bytes.Buffer
+	w, err := NewWriter(&ct, sealer, chunkSize)
+	test.CheckNoErr(t, err, "writer creation failed")
+	_, err = w.Write([]byte("first part of the stream, longer than a chunk; "))
+	test.CheckNoErr(t, err, "write failed")
+	test.CheckNoErr(t, w.Flush(), "flush failed")
+	raw, err := sealer.MarshalBinary()
+	test.CheckNoErr(t, err, "marshal failed")
+
+	sealer, err = UnmarshalSealer(raw)
+	test.CheckNoErr(t, err, "unmarshal failed")
+	w, err = NewWriter(&ct, sealer, chunkSize)
+	test.CheckNoErr(t, err, "writer creation failed")
+	_, err = w.Write([]byte("second part."))
+	test.CheckNoErr(t, err, "write failed")
+	test.CheckNoErr(t, w.Close(), "close failed")
+
+	// The stream is read in two sessions as well.
+	r, err := NewReader(&ct, opener, chunkSize)
+	test.CheckNoErr(t, err, "reader creation failed")
+	first := make([]byte, chunkSize)
+	_, err = io.ReadFull(r, first)
+	test.CheckNoErr(t, err, "read failed")
+	test.CheckOk(r.Buffered() == 0, "chunk not consumed", t)
+	raw, err = opener.MarshalBinary()
+	test.CheckNoErr(t, err, "marshal failed")
+
+	opener, err = UnmarshalOpener(raw)
+	test.CheckNoErr(t, err, "unmarshal failed")
+	r, err = NewReader(&ct, opener, chunkSize)
+	test.CheckNoErr(t, err, "reader creation failed")
+	rest, err := io.ReadAll(r)
+	test.CheckNoErr(t, err, "read failed")
+
+	want := "first part of the stream, longer than a chunk; second part."
+	test.CheckOk(string(first)+string(rest) == want, "plaintexts differ", t)
+}
+
+func TestStreamParams(t *testing.T) {
+	s := NewSuite(KEM_X25519_HKDF_SHA256, KDF_HKDF_SHA256, AEAD_ExportOnly)
+	sealer, opener := setupSuiteTest(t, s)
+	_, err := NewWriter(io.Discard, sealer, 16)
+	test.CheckOk(err == ErrExportOnly, "export-only context accepted", t)
+	_, err = NewReader(bytes.NewReader(nil), opener, 16)
+	test.CheckOk(err == ErrExportOnly, "export-only context accepted", t)
+
+	s = NewSuite(KEM_X25519_HKDF_SHA256, KDF_HKDF_SHA256, AEAD_AES128GCM)
+	sealer, _ = setupSuiteTest(t, s)
+	_, err = NewWriter(io.Discard, sealer, 0)
+	test.CheckOk(err == ErrStreamChunkSize, "empty chunks accepted", t)
+}
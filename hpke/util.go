@@ -1,6 +1,7 @@
 package hpke
 
 import (
+	"crypto/cipher"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -11,31 +12,44 @@ func (st state) keySchedule(ss, info, psk, pskID []byte) (*encdecContext, error)
 		return nil, err
 	}
 
-	pskIDHash := st.labeledExtract(nil, []byte("psk_id_hash"), pskID)
-	infoHash := st.labeledExtract(nil, []byte("info_hash"), info)
-	keySchCtx := append(append(
-		[]byte{st.modeID},
-		pskIDHash...),
-		infoHash...)
+	Nk, Nn, Nh := st.aeadID.KeySize(), st.aeadID.NonceSize(), uint(st.kdfID.ExtractSize())
+	var keySchCtx, secret, key, baseNonce, exporterSecret []byte
+	if st.kdfID.IsOneStage() {
+		keySchCtx = append(append(
+			[]byte{st.modeID},
+			lengthPrefixed(pskID)...),
+			lengthPrefixed(info)...)
+		secrets := append(lengthPrefixed(psk), lengthPrefixed(ss)...)
+		// The key, the base nonce, and the exporter secret are derived at
+		// once, and split.
+		secret = st.labeledDerive(secrets, []byte("secret"), keySchCtx,
+			uint16(Nk+Nn+Nh))
+		key, baseNonce, exporterSecret = secret[:Nk], secret[Nk:Nk+Nn], secret[Nk+Nn:]
+	} else {
+		pskIDHash := st.labeledExtract(nil, []byte("psk_id_hash"), pskID)
+		infoHash := st.labeledExtract(nil, []byte("info_hash"), info)
+		keySchCtx = append(append(
+			[]byte{st.modeID},
+			pskIDHash...),
+			infoHash...)
 
-	secret := st.labeledExtract(ss, []byte("secret"), psk)
-
-	Nk := uint16(st.aeadID.KeySize())
-	key := st.labeledExpand(secret, []byte("key"), keySchCtx, Nk)
-
-	aead, err := st.aeadID.New(key)
-	if err != nil {
-		return nil, err
+		secret = st.labeledExtract(ss, []byte("secret"), psk)
+		if st.aeadID != AEAD_ExportOnly {
+			key = st.labeledExpand(secret, []byte("key"), keySchCtx, uint16(Nk))
+			baseNonce = st.labeledExpand(secret, []byte("base_nonce"), keySchCtx, uint16(Nn))
+		}
+		exporterSecret = st.labeledExpand(secret, []byte("exp"), keySchCtx, uint16(Nh))
 	}
 
-	Nn := uint16(aead.NonceSize())
-	baseNonce := st.labeledExpand(secret, []byte("base_nonce"), keySchCtx, Nn)
-	exporterSecret := st.labeledExpand(
-		secret,
-		[]byte("exp"),
-		keySchCtx,
-		uint16(st.kdfID.ExtractSize()),
-	)
+	// Export-only contexts have neither key nor nonce.
+	var aead cipher.AEAD
+	if st.aeadID != AEAD_ExportOnly {
+		var err error
+		aead, err = st.aeadID.New(key)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	return &encdecContext{
 		st.Suite,
@@ -119,3 +133,28 @@ func (suite Suite) labeledExpand(prk, label, info []byte, l uint16) []byte {
 		info...)
 	return suite.kdfID.Expand(prk, labeledInfo, uint(l))
 }
+
+// labeledDerive is the LabeledDerive function of one-stage KDFs.
+func (suite Suite) labeledDerive(ikm, label, context []byte, l uint16) []byte {
+	suiteID := suite.getSuiteID()
+	return labeledDerive(suite.kdfID, suiteID[:], ikm, label, context, l)
+}
+
+func labeledDerive(kdf KDF, suiteID, ikm, label, context []byte, l uint16) []byte {
+	labeledIKM := make([]byte, 0,
+		len(ikm)+len(versionLabel)+len(suiteID)+2+len(label)+2+len(context))
+	labeledIKM = append(append(append(append(labeledIKM,
+		ikm...),
+		versionLabel...),
+		suiteID...),
+		lengthPrefixed(label)...)
+	labeledIKM = binary.BigEndian.AppendUint16(labeledIKM, l)
+	labeledIKM = append(labeledIKM, context...)
+	return kdf.Derive(labeledIKM, uint(l))
+}
+
+// lengthPrefixed returns x prefixed with its length as a 16-bit big-endian
+// integer.
+func lengthPrefixed(x []byte) []byte {
+	return append(binary.BigEndian.AppendUint16(nil, uint16(len(x))), x...)
+}
@@ -1,11 +1,27 @@
+//go:generate go run gen.go
+
 // Package frodo provides the key encapsulation mechanism FrodoKEM.
 //
 // Compatible with the implementation submitted to round 3 of the
 // NIST PQC competition [1]. This implementation draws heavily from the PQClean
 // implementation [2].
 //
+// The parameter sets FrodoKEM-640, FrodoKEM-976, and FrodoKEM-1344 are
+// provided, each one generating the public matrix with either SHAKE128 or
+// AES-128, in the packages frodo640shake, frodo640aes, frodo976shake,
+// frodo976aes, frodo1344shake, and frodo1344aes.
+//
+// The schemes are those of round 3, and keep their names. The ISO draft [3]
+// reuses these names for a salted FrodoKEM, whose ciphertexts differ, and
+// which is not implemented. Neither is the ephemeral eFrodoKEM of the ISO
+// draft: it cannot be checked against the known-answer tests of the
+// reference implementation yet, so the round 3 schemes must not be used
+// in its place.
+//
 // References:
 //
 //	[1] https://frodokem.org/files/FrodoKEM-specification-20210604.pdf
 //	[2] https://github.com/PQClean/PQClean/tree/master/crypto_kem/frodokem640shake/clean
+//	[3] FrodoKEM: Learning With Errors Key Encapsulation. Preliminary
+//	    Standardization Proposal submitted to ISO (2023). https://frodokem.org/
 package frodo
@@ -0,0 +1,121 @@
+//go:build ignore
+// +build ignore
+
+// Autogenerates the parameter sets from templates to prevent too much
+// duplicated code between the code for different modes.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+type Instance struct {
+	N        int
+	LogQ     int
+	B        int   // Number of bits extracted per entry, 'B' in the spec.
+	Sec      int   // Length in bytes of the shared key, seeds, and hashes.
+	AES      bool  // Whether the matrix A is generated with AES-128.
+	CDFTable []int // Table for sampling the noise distribution.
+}
+
+func (m Instance) Matrix() string {
+	if m.AES {
+		return "AES"
+	}
+	return "SHAKE"
+}
+
+func (m Instance) Name() string {
+	return fmt.Sprintf("FrodoKEM-%d-%s", m.N, m.Matrix())
+}
+
+func (m Instance) Pkg() string {
+	return strings.ToLower(fmt.Sprintf("frodo%d%s", m.N, m.Matrix()))
+}
+
+// Shake returns the SHAKE function used for hashing and expanding seeds,
+// which is different to the one used to generate A.
+func (m Instance) Shake() string {
+	if m.N == 640 {
+		return "Shake128"
+	}
+	return "Shake256"
+}
+
+var (
+	cdf640  = []int{4643, 13363, 20579, 25843, 29227, 31145, 32103, 32525, 32689, 32745, 32762, 32766, 32767}
+	cdf976  = []int{5638, 15915, 23689, 28571, 31116, 32217, 32613, 32731, 32760, 32766, 32767}
+	cdf1344 = []int{9142, 23462, 30338, 32361, 32725, 32765, 32767}
+
+	Instances = []Instance{
+		{N: 640, LogQ: 15, B: 2, Sec: 16, AES: false, CDFTable: cdf640},
+		{N: 640, LogQ: 15, B: 2, Sec: 16, AES: true, CDFTable: cdf640},
+		{N: 976, LogQ: 16, B: 3, Sec: 24, AES: false, CDFTable: cdf976},
+		{N: 976, LogQ: 16, B: 3, Sec: 24, AES: true, CDFTable: cdf976},
+		{N: 1344, LogQ: 16, B: 4, Sec: 32, AES: false, CDFTable: cdf1344},
+		{N: 1344, LogQ: 16, B: 4, Sec: 32, AES: true, CDFTable: cdf1344},
+	}
+	TemplateWarning = "// Code generated from"
+)
+
+func main() {
+	generatePackageFiles()
+}
+
+// Generates instance/*.go from templates/*.templ.go
+func generatePackageFiles() {
+	files, err := filepath.Glob("templates/*.templ.go")
+	if err != nil {
+		panic(err)
+	}
+
+	for _, file := range files {
+		tl, err := template.ParseFiles(file)
+		if err != nil {
+			panic(err)
+		}
+
+		for _, mode := range Instances {
+			buf := new(bytes.Buffer)
+			err := tl.Execute(buf, mode)
+			if err != nil {
+				panic(err)
+			}
+
+			// Formating output code
+			code, err := format.Source(buf.Bytes())
+			if err != nil {
+				panic(fmt.Sprintf("error formating code: %v", err))
+			}
+
+			res := string(code)
+			offset := strings.Index(res, TemplateWarning)
+			if offset == -1 {
+				panic("Missing template warning in " + file)
+			}
+			out := filepath.Join(mode.Pkg(), strings.TrimSuffix(filepath.Base(file), ".templ.go")+".go")
+			err = os.WriteFile(out, []byte(res[offset:]), 0o644)
+			if err != nil {
+				panic(err)
+			}
+		}
+	}
+}
+
+func (m Instance) PublicKeySize() int {
+	return 16 + m.LogQ*m.N
+}
+
+func (m Instance) CiphertextSize() int {
+	return m.LogQ*m.N + m.LogQ*8
+}
+
+func (m Instance) PrivateKeySize() int {
+	return m.Sec + m.PublicKeySize() + 2*m.N*8 + m.Sec
+}
@@ -0,0 +1,15 @@
+//go:generate go run gen.go
+
+// Package hqc implements the code-based key encapsulation mechanism HQC
+// (Hamming Quasi-Cyclic) as submitted to round 4 of the NIST PQC
+// competition [1], and selected by NIST for standardization.
+//
+// The parameter sets HQC-128, HQC-192, and HQC-256 are provided in the
+// packages hqc128, hqc192, and hqc256. Seeds are split and consumed in
+// the same order as in the reference implementation [2].
+//
+// References:
+//
+//	[1] https://pqc-hqc.org/doc/hqc-specification_2023-04-30.pdf
+//	[2] https://pqc-hqc.org/implementation.html
+package hqc
@@ -0,0 +1,78 @@
+//go:build ignore
+// +build ignore
+
+// Autogenerates wrappers from templates to prevent too much duplicated code
+// between the code for different modes.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+type Instance struct {
+	Level int
+	N     int // Length in bytes of the vectors.
+	N1N2  int // Length in bytes of the codewords.
+	K     int // Length in bytes of the messages.
+}
+
+func (m Instance) Name() string   { return fmt.Sprintf("HQC-%d", m.Level) }
+func (m Instance) Pkg() string    { return fmt.Sprintf("hqc%d", m.Level) }
+func (m Instance) Params() string { return fmt.Sprintf("HQC%d", m.Level) }
+
+func (m Instance) PublicKeySize() int         { return 40 + m.N }
+func (m Instance) PrivateKeySize() int        { return 40 + m.K + m.PublicKeySize() }
+func (m Instance) CiphertextSize() int        { return m.N + m.N1N2 + 16 }
+func (m Instance) KeySeedSize() int           { return 80 + m.K }
+func (m Instance) EncapsulationSeedSize() int { return m.K + 16 }
+
+var (
+	Instances = []Instance{
+		{Level: 128, N: (17669 + 7) / 8, N1N2: 46 * 384 / 8, K: 16},
+		{Level: 192, N: (35851 + 7) / 8, N1N2: 56 * 640 / 8, K: 24},
+		{Level: 256, N: (57637 + 7) / 8, N1N2: 90 * 640 / 8, K: 32},
+	}
+	TemplateWarning = "// Code generated from"
+)
+
+func main() {
+	generatePackageFiles()
+}
+
+// Generates instance/hqc.go from templates/pkg.templ.go
+func generatePackageFiles() {
+	tl, err := template.ParseFiles("templates/pkg.templ.go")
+	if err != nil {
+		panic(err)
+	}
+
+	for _, mode := range Instances {
+		buf := new(bytes.Buffer)
+		err := tl.Execute(buf, mode)
+		if err != nil {
+			panic(err)
+		}
+
+		// Formating output code
+		code, err := format.Source(buf.Bytes())
+		if err != nil {
+			panic(fmt.Sprintf("error formating code: %v", err))
+		}
+
+		res := string(code)
+		offset := strings.Index(res, TemplateWarning)
+		if offset == -1 {
+			panic("Missing template warning in pkg.templ.go")
+		}
+		err = os.WriteFile(filepath.Join(mode.Pkg(), "hqc.go"), []byte(res[offset:]), 0o644)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
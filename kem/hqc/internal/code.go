@@ -0,0 +1,33 @@
+package internal
+
+// The public code of HQC is the concatenation of a Reed-Solomon code
+// RS[N1, K] over GF(2^8) as the external code, and a duplicated
+// Reed-Muller code RM(1,7) as the internal code, which encodes every byte
+// of a Reed-Solomon codeword into N2 bits.
+
+// encode sets em to the codeword of the K-byte message m. Only the first
+// N1*N2 bits of em are written.
+func (p *Params) encode(em vector, m []byte) {
+	cdw := make([]byte, p.N1)
+	p.rsEncode(cdw, m)
+
+	words := p.N2 / 64
+	for i, b := range cdw {
+		lo, hi := rmEncode(b)
+		for j := 0; j < words; j += 2 {
+			em[i*words+j] = lo
+			em[i*words+j+1] = hi
+		}
+	}
+}
+
+// decode sets m to the K-byte message whose codeword is the closest to the
+// first N1*N2 bits of em.
+func (p *Params) decode(m []byte, em vector) {
+	cdw := make([]byte, p.N1)
+	words := p.N2 / 64
+	for i := range cdw {
+		cdw[i] = rmDecode(em[i*words : (i+1)*words])
+	}
+	p.rsDecode(m, cdw)
+}
@@ -0,0 +1,53 @@
+package internal
+
+// Arithmetic in GF(2^8) = GF(2)[x]/(x^8 + x^4 + x^3 + x^2 + 1), where the
+// element x, denoted by alpha, is a generator of the multiplicative group.
+
+const gfPoly = 0x11D
+
+// gfExp[i] = alpha^i for 0 <= i < 255.
+var gfExp [255]byte
+
+func init() {
+	a := uint16(1)
+	for i := range gfExp {
+		gfExp[i] = byte(a)
+		a <<= 1
+		if a&0x100 != 0 {
+			a ^= gfPoly
+		}
+	}
+}
+
+// gfPow returns alpha^i for any integer i.
+func gfPow(i int) byte {
+	i %= 255
+	if i < 0 {
+		i += 255
+	}
+	return gfExp[i]
+}
+
+// gfMul returns a*b in constant time.
+func gfMul(a, b byte) byte {
+	var r uint16
+	for i := 0; i < 8; i++ {
+		r ^= (-uint16((b >> i) & 1)) & (uint16(a) << i)
+	}
+	for i := 14; i >= 8; i-- {
+		r ^= (-((r >> i) & 1)) & (gfPoly << (i - 8))
+	}
+	return byte(r)
+}
+
+// gfInv returns the inverse of a, or zero if a is zero, in constant time.
+func gfInv(a byte) byte {
+	// a^-1 = a^254 = a^(2+4+8+16+32+64+128)
+	r := byte(1)
+	a = gfMul(a, a)
+	for i := 0; i < 7; i++ {
+		r = gfMul(r, a)
+		a = gfMul(a, a)
+	}
+	return r
+}
@@ -0,0 +1,153 @@
+package internal
+
+import "crypto/subtle"
+
+// DeriveKey writes the packed public and private keys derived from seed,
+// which is the concatenation of the seed of the private key, the value
+// sigma used for implicit rejection, and the seed of the public key.
+//
+// Panics if the lengths of pk, sk, or seed are not PublicKeySize,
+// PrivateKeySize, and KeySeedSize respectively.
+func (p *Params) DeriveKey(pk, sk, seed []byte) {
+	if len(pk) != p.PublicKeySize() || len(sk) != p.PrivateKeySize() {
+		panic("wrong key size")
+	}
+	if len(seed) != p.KeySeedSize() {
+		panic("seed must be of length KeySeedSize")
+	}
+
+	skSeed := seed[:SeedSize]
+	sigma := seed[SeedSize : SeedSize+p.K]
+	pkSeed := seed[SeedSize+p.K:]
+
+	skXof := newSeedExpander(skSeed)
+	x := p.newVector()
+	p.setSupport(x, p.sampleFixedWeight(skXof, p.Omega))
+	y := p.sampleFixedWeight(skXof, p.Omega)
+
+	h := p.newVector()
+	p.sampleRandom(h, newSeedExpander(pkSeed))
+
+	// s = x + h*y
+	s := p.newVector()
+	p.mulSparse(s, h, y)
+	add(s, s, x)
+
+	copy(pk, pkSeed)
+	pack(pk[SeedSize:], s)
+
+	copy(sk, skSeed)
+	copy(sk[SeedSize:], sigma)
+	copy(sk[SeedSize+p.K:], pk)
+}
+
+// Encapsulate writes the ciphertext and shared key from the public key
+// and seed, which is the concatenation of the message and the salt.
+//
+// Panics if the lengths of ct, ss, pk, or seed are not CiphertextSize,
+// SharedKeySize, PublicKeySize, and EncapsulationSeedSize respectively.
+func (p *Params) Encapsulate(ct, ss, pk, seed []byte) {
+	if len(ct) != p.CiphertextSize() || len(ss) != SharedKeySize {
+		panic("wrong ciphertext or shared key size")
+	}
+	if len(pk) != p.PublicKeySize() {
+		panic("wrong public key size")
+	}
+	if len(seed) != p.EncapsulationSeedSize() {
+		panic("seed must be of length EncapsulationSeedSize")
+	}
+
+	m := seed[:p.K]
+	salt := seed[p.K:]
+
+	u, v := p.encrypt(pk, m, salt)
+	p.packCiphertext(ct, u, v, salt)
+
+	k := hash(kDomain, m, ct[:p.vecNSize()+p.vecN1N2Size()])
+	copy(ss, k[:])
+}
+
+// Decapsulate writes the shared key encapsulated in ct to ss.
+//
+// Panics if the lengths of ss, sk, or ct are not SharedKeySize,
+// PrivateKeySize, and CiphertextSize respectively.
+func (p *Params) Decapsulate(ss, sk, ct []byte) {
+	if len(ct) != p.CiphertextSize() || len(ss) != SharedKeySize {
+		panic("wrong ciphertext or shared key size")
+	}
+	if len(sk) != p.PrivateKeySize() {
+		panic("wrong private key size")
+	}
+
+	skSeed := sk[:SeedSize]
+	sigma := sk[SeedSize : SeedSize+p.K]
+	pk := sk[SeedSize+p.K:]
+	uv := ct[:p.vecNSize()+p.vecN1N2Size()]
+	salt := ct[len(uv):]
+
+	u := p.newVector()
+	v := p.newVector()
+	p.unpack(u, ct[:p.vecNSize()])
+	p.unpack(v, ct[p.vecNSize():len(uv)])
+
+	// m' = decode(v - u*y)
+	skXof := newSeedExpander(skSeed)
+	_ = p.sampleFixedWeight(skXof, p.Omega)
+	y := p.sampleFixedWeight(skXof, p.Omega)
+	t := p.newVector()
+	p.mulSparse(t, u, y)
+	add(t, t, v)
+	m := make([]byte, p.K)
+	p.decode(m, t)
+
+	// Re-encrypts m', and uses sigma instead of m' if the ciphertexts
+	// differ.
+	u2, v2 := p.encrypt(pk, m, salt)
+	ct2 := make([]byte, len(ct))
+	p.packCiphertext(ct2, u2, v2, salt)
+	eq := subtle.ConstantTimeCompare(uv, ct2[:len(uv)])
+	subtle.ConstantTimeCopy(1-eq, m, sigma)
+
+	k := hash(kDomain, m, uv)
+	copy(ss, k[:])
+}
+
+// encrypt returns the ciphertext (u, v) of the message m, with the
+// randomness derived from m, the public key, and the salt.
+func (p *Params) encrypt(pk, m, salt []byte) (u, v vector) {
+	pkSeed := pk[:SeedSize]
+	theta := hash(gDomain, m, pkSeed, salt)
+	xof := newSeedExpander(theta[:SeedSize])
+
+	h := p.newVector()
+	s := p.newVector()
+	p.sampleRandom(h, newSeedExpander(pkSeed))
+	p.unpack(s, pk[SeedSize:])
+
+	r1 := p.newVector()
+	e := p.newVector()
+	p.setSupport(r1, p.sampleFixedWeight(xof, p.OmegaR))
+	r2 := p.sampleFixedWeight(xof, p.OmegaR)
+	p.setSupport(e, p.sampleFixedWeight(xof, p.OmegaE))
+
+	// u = r1 + h*r2
+	u = p.newVector()
+	p.mulSparse(u, h, r2)
+	add(u, u, r1)
+
+	// v = truncate(encode(m) + s*r2 + e)
+	v = p.newVector()
+	p.mulSparse(v, s, r2)
+	add(v, v, e)
+	em := p.newVector()
+	p.encode(em, m)
+	add(v, v, em)
+
+	return u, v
+}
+
+func (p *Params) packCiphertext(ct []byte, u, v vector, salt []byte) {
+	pack(ct[:p.vecNSize()], u)
+	pack(ct[p.vecNSize():p.vecNSize()+p.vecN1N2Size()], v)
+	copy(ct[p.vecNSize()+p.vecN1N2Size():], salt)
+}
@@ -0,0 +1,148 @@
+package internal
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/bits"
+	mrand "math/rand"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+var allParams = []*Params{HQC128, HQC192, HQC256}
+
+func TestGeneratorPoly(t *testing.T) {
+	for _, p := range allParams {
+		// g = (x - alpha)(x - alpha^2)...(x - alpha^(2*Delta))
+		g := []byte{1}
+		for i := 1; i <= 2*p.Delta; i++ {
+			next := make([]byte, len(g)+1)
+			for j := range g {
+				next[j+1] ^= g[j]
+				next[j] ^= gfMul(g[j], gfPow(i))
+			}
+			g = next
+		}
+		if !bytes.Equal(g, p.rsPoly) {
+			test.ReportError(t, p.rsPoly, g, p.Name)
+		}
+		test.CheckOk(len(p.rsPoly) == p.N1-p.K+1, "wrong degree", t)
+	}
+}
+
+func TestGF(t *testing.T) {
+	for a := 1; a < 256; a++ {
+		test.CheckOk(gfMul(byte(a), gfInv(byte(a))) == 1, "wrong inverse", t)
+	}
+	test.CheckOk(gfInv(0) == 0, "wrong inverse of zero", t)
+}
+
+func TestReedSolomon(t *testing.T) {
+	for _, p := range allParams {
+		msg := make([]byte, p.K)
+		got := make([]byte, p.K)
+		cdw := make([]byte, p.N1)
+		for nerr := 0; nerr <= p.Delta; nerr++ {
+			_, _ = rand.Read(msg)
+			p.rsEncode(cdw, msg)
+			for _, j := range mrand.Perm(p.N1)[:nerr] {
+				cdw[j] ^= byte(1 + mrand.Intn(255))
+			}
+			p.rsDecode(got, cdw)
+			if !bytes.Equal(got, msg) {
+				test.ReportError(t, got, msg, p.Name, nerr)
+			}
+		}
+	}
+}
+
+func TestReedMuller(t *testing.T) {
+	for m := 0; m < 256; m++ {
+		lo, hi := rmEncode(byte(m))
+		// Any two codewords are at distance 64, so it corrects 31 errors
+		// in a single copy.
+		cdw := []uint64{lo, hi}
+		for _, k := range mrand.Perm(128)[:31] {
+			cdw[k/64] ^= 1 << (k % 64)
+		}
+		test.CheckOk(rmDecode(cdw) == byte(m), "wrong decoding", t)
+
+		for n := 0; n < m; n++ {
+			lo2, hi2 := rmEncode(byte(n))
+			d := bits.OnesCount64(lo^lo2) + bits.OnesCount64(hi^hi2)
+			test.CheckOk(d == 64 || (d == 128 && m^n == 0x80), "wrong distance", t)
+		}
+	}
+}
+
+func TestFixedWeight(t *testing.T) {
+	for _, p := range allParams {
+		xof := newSeedExpander([]byte("fixed weight"))
+		for i := 0; i < 10; i++ {
+			support := p.sampleFixedWeight(xof, p.OmegaR)
+			v := p.newVector()
+			p.setSupport(v, support)
+			w := 0
+			for _, x := range v {
+				w += bits.OnesCount64(x)
+			}
+			test.CheckOk(w == p.OmegaR, "wrong weight", t)
+		}
+	}
+}
+
+func TestMulSparse(t *testing.T) {
+	for _, p := range allParams {
+		xof := newSeedExpander([]byte("mul"))
+		a := p.newVector()
+		p.sampleRandom(a, xof)
+		support := p.sampleFixedWeight(xof, 5)
+
+		// Schoolbook multiplication, bit by bit.
+		want := p.newVector()
+		for _, s := range support {
+			for i := 0; i < p.N; i++ {
+				if (a[i/64]>>(i%64))&1 == 1 {
+					j := (i + int(s)) % p.N
+					want[j/64] ^= 1 << (j % 64)
+				}
+			}
+		}
+
+		got := p.newVector()
+		p.mulSparse(got, a, support)
+		for i := range got {
+			if got[i] != want[i] {
+				test.ReportError(t, got[i], want[i], p.Name, i)
+			}
+		}
+	}
+}
+
+func TestKEM(t *testing.T) {
+	for _, p := range allParams {
+		seed := make([]byte, p.KeySeedSize())
+		eseed := make([]byte, p.EncapsulationSeedSize())
+		_, _ = rand.Read(seed)
+		_, _ = rand.Read(eseed)
+
+		pk := make([]byte, p.PublicKeySize())
+		sk := make([]byte, p.PrivateKeySize())
+		ct := make([]byte, p.CiphertextSize())
+		ss := make([]byte, SharedKeySize)
+		ss2 := make([]byte, SharedKeySize)
+
+		p.DeriveKey(pk, sk, seed)
+		p.Encapsulate(ct, ss, pk, eseed)
+		p.Decapsulate(ss2, sk, ct)
+		test.CheckOk(bytes.Equal(ss, ss2), "shared keys differ", t)
+
+		// Implicit rejection, the shared key is derived from sigma.
+		ct[0] ^= 1
+		p.Decapsulate(ss2, sk, ct)
+		sigma := sk[SeedSize : SeedSize+p.K]
+		want := hash(kDomain, sigma, ct[:p.vecNSize()+p.vecN1N2Size()])
+		test.CheckOk(bytes.Equal(ss2, want[:]), "wrong implicit rejection", t)
+	}
+}
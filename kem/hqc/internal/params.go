@@ -0,0 +1,120 @@
+// Package internal implements HQC for all parameter sets.
+package internal
+
+const (
+	// Size of the seeds used to expand the secret key, the public key,
+	// and the randomness of the encryption.
+	SeedSize = 40
+
+	// Size of the salt included in the ciphertext.
+	SaltSize = 16
+
+	// Size of the established shared key.
+	SharedKeySize = 64
+)
+
+// Params holds the parameters of an HQC parameter set.
+type Params struct {
+	Name string
+
+	// Length in bits of the vectors, which is a prime number.
+	N int
+
+	// Length in bytes of the Reed-Solomon code, and length in bits of the
+	// duplicated Reed-Muller code.
+	N1, N2 int
+
+	// Dimension of the Reed-Solomon code, which is the size in bytes of the
+	// encrypted messages.
+	K int
+
+	// Number of errors that the Reed-Solomon code corrects.
+	Delta int
+
+	// Hamming weights of the secret key, the ephemeral vectors r1 and r2,
+	// and the error vector e, respectively.
+	Omega, OmegaR, OmegaE int
+
+	// Coefficients of the generator polynomial of the Reed-Solomon code,
+	// from the lowest to the highest degree.
+	rsPoly []byte
+}
+
+var (
+	// HQC128 is the HQC-128 parameter set.
+	HQC128 = &Params{
+		Name:   "HQC-128",
+		N:      17669,
+		N1:     46,
+		N2:     384,
+		K:      16,
+		Delta:  15,
+		Omega:  66,
+		OmegaR: 75,
+		OmegaE: 75,
+		rsPoly: []byte{
+			89, 69, 153, 116, 176, 117, 111, 75, 73, 233, 242, 233, 65, 210,
+			21, 139, 103, 173, 67, 118, 105, 210, 174, 110, 74, 69, 228, 82,
+			255, 181, 1,
+		},
+	}
+
+	// HQC192 is the HQC-192 parameter set.
+	HQC192 = &Params{
+		Name:   "HQC-192",
+		N:      35851,
+		N1:     56,
+		N2:     640,
+		K:      24,
+		Delta:  16,
+		Omega:  100,
+		OmegaR: 114,
+		OmegaE: 114,
+		rsPoly: []byte{
+			45, 216, 239, 24, 253, 104, 27, 40, 107, 50, 163, 210, 227, 134,
+			224, 158, 119, 13, 158, 1, 238, 164, 82, 43, 15, 232, 246, 142,
+			50, 189, 29, 232, 1,
+		},
+	}
+
+	// HQC256 is the HQC-256 parameter set.
+	HQC256 = &Params{
+		Name:   "HQC-256",
+		N:      57637,
+		N1:     90,
+		N2:     640,
+		K:      32,
+		Delta:  29,
+		Omega:  131,
+		OmegaR: 149,
+		OmegaE: 149,
+		rsPoly: []byte{
+			49, 167, 49, 39, 200, 121, 124, 91, 240, 63, 148, 71, 150, 123,
+			87, 101, 32, 215, 159, 71, 201, 115, 97, 210, 186, 183, 141, 217,
+			123, 12, 31, 243, 180, 219, 152, 239, 99, 141, 4, 246, 191, 144,
+			8, 232, 47, 27, 141, 178, 130, 64, 124, 47, 39, 188, 216, 48, 199,
+			187, 1,
+		},
+	}
+)
+
+// Size in bytes of a vector of length N.
+func (p *Params) vecNSize() int { return (p.N + 7) / 8 }
+
+// Size in bytes of a codeword of the concatenated code.
+func (p *Params) vecN1N2Size() int { return p.N1 * p.N2 / 8 }
+
+// PublicKeySize is the size of a packed public key.
+func (p *Params) PublicKeySize() int { return SeedSize + p.vecNSize() }
+
+// PrivateKeySize is the size of a packed private key.
+func (p *Params) PrivateKeySize() int { return SeedSize + p.K + p.PublicKeySize() }
+
+// CiphertextSize is the size of a ciphertext.
+func (p *Params) CiphertextSize() int { return p.vecNSize() + p.vecN1N2Size() + SaltSize }
+
+// KeySeedSize is the size of the seed used to derive a key pair.
+func (p *Params) KeySeedSize() int { return 2*SeedSize + p.K }
+
+// EncapsulationSeedSize is the size of the seed used to encapsulate.
+func (p *Params) EncapsulationSeedSize() int { return p.K + SaltSize }
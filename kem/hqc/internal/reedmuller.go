@@ -0,0 +1,67 @@
+package internal
+
+import "crypto/subtle"
+
+// The inner code is the Reed-Muller code RM(1,7), which encodes a byte into
+// a 128-bit codeword, and every codeword is repeated N2/128 times.
+
+// rmEncode returns the RM(1,7) codeword of m as two 64-bit words. The bit k
+// of the codeword is m_7 + sum_{i<7} m_i*k_i, where m_i and k_i are the bits
+// of m and k respectively.
+func rmEncode(m byte) (lo, hi uint64) {
+	bit := func(i uint) uint32 { return -uint32((m >> i) & 1) }
+
+	w := bit(7)
+	w ^= bit(0) & 0xaaaaaaaa
+	w ^= bit(1) & 0xcccccccc
+	w ^= bit(2) & 0xf0f0f0f0
+	w ^= bit(3) & 0xff00ff00
+	w ^= bit(4) & 0xffff0000
+
+	// The bits 5 and 6 of k select the 32-bit word.
+	w0, w1 := w, w^bit(5)
+	w2, w3 := w^bit(6), w^bit(5)^bit(6)
+
+	lo = uint64(w0) | uint64(w1)<<32
+	hi = uint64(w2) | uint64(w3)<<32
+	return
+}
+
+// rmDecode returns the byte whose codeword, repeated, is the closest to the
+// words of cdw. It runs in constant time with respect to cdw.
+func rmDecode(cdw []uint64) byte {
+	// Maps the bits of all copies to +1 or -1, and adds them up.
+	var f [128]int32
+	for c := 0; c < len(cdw); c += 2 {
+		for k := 0; k < 128; k++ {
+			b := int32((cdw[c+k/64] >> (k % 64)) & 1)
+			f[k] += 1 - 2*b
+		}
+	}
+
+	// Fast Walsh-Hadamard transform, so f[i] is the correlation of the
+	// received word with the codeword of i.
+	for h := 1; h < 128; h <<= 1 {
+		for i := 0; i < 128; i += 2 * h {
+			for j := i; j < i+h; j++ {
+				a, b := f[j], f[j+h]
+				f[j], f[j+h] = a+b, a-b
+			}
+		}
+	}
+
+	// Finds the first peak in absolute value, whose sign is the bit 7.
+	var peakAbs, peakValue int32
+	var peakPos int
+	for i := range f {
+		v := f[i]
+		s := v >> 31
+		abs := (v ^ s) - s
+		greater := subtle.ConstantTimeLessOrEq(int(peakAbs)+1, int(abs))
+		peakAbs = int32(subtle.ConstantTimeSelect(greater, int(abs), int(peakAbs)))
+		peakValue = int32(subtle.ConstantTimeSelect(greater, int(v), int(peakValue)))
+		peakPos = subtle.ConstantTimeSelect(greater, i, peakPos)
+	}
+
+	return byte(peakPos) | byte((uint32(peakValue)>>31)<<7)
+}
@@ -0,0 +1,115 @@
+package internal
+
+import "crypto/subtle"
+
+// rsEncode computes the systematic Reed-Solomon codeword of msg, which has
+// the redundancy in the first N1-K bytes followed by msg.
+func (p *Params) rsEncode(cdw, msg []byte) {
+	nk := p.N1 - p.K
+	for i := range cdw[:nk] {
+		cdw[i] = 0
+	}
+	for i := 0; i < p.K; i++ {
+		gate := msg[p.K-1-i] ^ cdw[nk-1]
+		for j := nk - 1; j > 0; j-- {
+			cdw[j] = cdw[j-1] ^ gfMul(gate, p.rsPoly[j])
+		}
+		cdw[0] = gfMul(gate, p.rsPoly[0])
+	}
+	copy(cdw[nk:], msg)
+}
+
+// rsDecode corrects up to Delta errors in the codeword cdw, and writes the
+// message to msg. It runs in constant time with respect to cdw.
+func (p *Params) rsDecode(msg, cdw []byte) {
+	n := 2 * p.Delta
+
+	// Syndromes S_i = cdw(alpha^i) for 1 <= i <= 2*Delta.
+	syn := make([]byte, n)
+	for i := range syn {
+		for j := range cdw[:p.N1] {
+			syn[i] ^= gfMul(cdw[j], gfPow((i+1)*j))
+		}
+	}
+
+	sigma := berlekampMassey(syn)
+
+	// Error evaluator polynomial omega = S*sigma mod x^(2*Delta), where
+	// S = S_1 + S_2*x + ... + S_{2*Delta}*x^(2*Delta-1).
+	omega := make([]byte, n)
+	for k := range omega {
+		for i := 0; i <= k; i++ {
+			omega[k] ^= gfMul(sigma[i], syn[k-i])
+		}
+	}
+
+	// The error positions are the indices j such that sigma(alpha^-j) = 0,
+	// and the values are given by Forney's formula, which is
+	// omega(alpha^-j)/sigma'(alpha^-j) as the syndromes start at alpha^1.
+	for j := 0; j < p.N1; j++ {
+		x := gfPow(-j)
+		var ev, num, den byte
+		xi := byte(1)
+		for i := range sigma {
+			t := gfMul(sigma[i], xi)
+			ev ^= t
+			if i < n {
+				num ^= gfMul(omega[i], xi)
+			}
+			if i&1 == 1 {
+				// The formal derivative of sigma only keeps odd terms,
+				// and t/x = sigma_i*x^(i-1).
+				den ^= gfMul(t, gfInv(x))
+			}
+			xi = gfMul(xi, x)
+		}
+		mask := byte(subtle.ConstantTimeByteEq(ev, 0))
+		cdw[j] ^= -mask & gfMul(num, gfInv(den))
+	}
+
+	copy(msg, cdw[p.N1-p.K:p.N1])
+}
+
+// berlekampMassey returns the error locator polynomial given the 2*Delta
+// syndromes. It runs in constant time with respect to syn.
+func berlekampMassey(syn []byte) []byte {
+	n := len(syn)
+	sigma := make([]byte, n+1)
+	prev := make([]byte, n+1)
+	tmp := make([]byte, n+1)
+	sigma[0] = 1
+	prev[0] = 1
+	deg := 0
+	b := byte(1)
+
+	for k := 0; k < n; k++ {
+		// Discrepancy
+		d := syn[k]
+		for i := 1; i <= k; i++ {
+			d ^= gfMul(sigma[i], syn[k-i])
+		}
+
+		// prev = x*prev
+		copy(prev[1:], prev[:n])
+		prev[0] = 0
+
+		// tmp = sigma - (d/b)*prev
+		c := gfMul(d, gfInv(b))
+		for i := range tmp {
+			tmp[i] = sigma[i] ^ gfMul(c, prev[i])
+		}
+
+		// If d != 0 and 2*deg <= k, then prev = sigma, deg = k+1-deg, and
+		// b = d. In any case, if d != 0, sigma = tmp.
+		dNonZero := 1 ^ subtle.ConstantTimeByteEq(d, 0)
+		update := dNonZero & subtle.ConstantTimeLessOrEq(2*deg, k)
+		for i := range prev {
+			prev[i] = byte(subtle.ConstantTimeSelect(update, int(sigma[i]), int(prev[i])))
+			sigma[i] = byte(subtle.ConstantTimeSelect(dNonZero, int(tmp[i]), int(sigma[i])))
+		}
+		deg = subtle.ConstantTimeSelect(update, k+1-deg, deg)
+		b = byte(subtle.ConstantTimeSelect(update, int(d), int(b)))
+	}
+
+	return sigma
+}
@@ -0,0 +1,202 @@
+package internal
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+
+	"github.com/cloudflare/circl/internal/sha3"
+)
+
+const (
+	seedExpanderDomain = 2
+	gDomain            = 3
+	kDomain            = 4
+)
+
+// A vector of N bits, which also represents a polynomial in
+// GF(2)[X]/(X^N - 1), where the bit i is the coefficient of X^i.
+type vector []uint64
+
+func (p *Params) newVector() vector { return make(vector, (p.N+63)/64) }
+
+// newSeedExpander returns the XOF used to expand seed into vectors.
+func newSeedExpander(seed []byte) *sha3.State {
+	xof := sha3.NewShake256()
+	_, _ = xof.Write(seed)
+	_, _ = xof.Write([]byte{seedExpanderDomain})
+	return &xof
+}
+
+// expand reads len(out) bytes from the seed expander, which always reads
+// a multiple of eight bytes and discards the excess.
+func expand(xof *sha3.State, out []byte) {
+	r := len(out) % 8
+	_, _ = xof.Read(out[:len(out)-r])
+	if r != 0 {
+		var tmp [8]byte
+		_, _ = xof.Read(tmp[:])
+		copy(out[len(out)-r:], tmp[:r])
+	}
+}
+
+// hash returns SHAKE256(data || domain) with 64 bytes of output.
+func hash(domain byte, data ...[]byte) (out [64]byte) {
+	h := sha3.NewShake256()
+	for _, d := range data {
+		_, _ = h.Write(d)
+	}
+	_, _ = h.Write([]byte{domain})
+	_, _ = h.Read(out[:])
+	return
+}
+
+// sampleRandom sets v to a uniformly random vector read from xof.
+func (p *Params) sampleRandom(v vector, xof *sha3.State) {
+	buf := make([]byte, p.vecNSize())
+	expand(xof, buf)
+	p.unpack(v, buf)
+}
+
+// sampleFixedWeight returns the positions of the w nonzero bits of a
+// random vector read from xof. It runs in constant time with respect to
+// the output of xof.
+func (p *Params) sampleFixedWeight(xof *sha3.State, w int) []uint32 {
+	buf := make([]byte, 4*w)
+	expand(xof, buf)
+
+	support := make([]uint32, w)
+	for i := range support {
+		r := binary.LittleEndian.Uint32(buf[4*i:])
+		support[i] = uint32(i) + reduce(r, uint32(p.N-i))
+	}
+
+	// Replaces the repeated positions by the index, which ensures that all
+	// positions are distinct.
+	for i := w - 2; i >= 0; i-- {
+		found := 0
+		for j := i + 1; j < w; j++ {
+			found |= subtle.ConstantTimeEq(int32(support[j]), int32(support[i]))
+		}
+		support[i] = uint32(subtle.ConstantTimeSelect(found, i, int(support[i])))
+	}
+
+	return support
+}
+
+// reduce returns a mod n in constant time, for 0 < n < 2^16.
+func reduce(a, n uint32) uint32 {
+	m := uint64((1 << 32) / uint64(n))
+	q := uint32((uint64(a) * m) >> 32)
+	r := a - q*n
+	// At this point, 0 <= r < 2n.
+	r -= n
+	r += n & -(r >> 31)
+	return r
+}
+
+// setSupport sets v to the vector whose nonzero bits are the positions in
+// support. It runs in constant time with respect to support.
+func (p *Params) setSupport(v vector, support []uint32) {
+	for i := range v {
+		v[i] = 0
+	}
+	for _, s := range support {
+		idx := s / 64
+		bit := uint64(1) << (s % 64)
+		for i := range v {
+			eq := uint64(subtle.ConstantTimeEq(int32(idx), int32(i)))
+			v[i] |= bit & -eq
+		}
+	}
+}
+
+// mulSparse sets out = a*b, where b is the vector with the given support.
+// It runs in constant time with respect to support.
+func (p *Params) mulSparse(out, a vector, support []uint32) {
+	acc := p.newVector()
+	tmp := p.newVector()
+	for i := range out {
+		out[i] = 0
+	}
+
+	for _, s := range support {
+		// acc = a*X^s, computed as a product of X^(2^j) for the bits j of s.
+		copy(acc, a)
+		for j := 0; 1<<j < p.N; j++ {
+			p.rotate(tmp, acc, 1<<j)
+			mask := -uint64((s >> j) & 1)
+			for i := range acc {
+				acc[i] ^= (acc[i] ^ tmp[i]) & mask
+			}
+		}
+		for i := range out {
+			out[i] ^= acc[i]
+		}
+	}
+}
+
+// rotate sets out = in*X^k for 0 < k < N, where k is public.
+func (p *Params) rotate(out, in vector, k int) {
+	// out = (in << k) mod 2^N  xor  in >> (N - k)
+	q, r := k/64, uint(k%64)
+	for i := len(out) - 1; i >= 0; i-- {
+		var w uint64
+		if i-q >= 0 {
+			w = in[i-q] << r
+		}
+		if i-q-1 >= 0 {
+			w |= in[i-q-1] >> (64 - r)
+		}
+		out[i] = w
+	}
+	p.mask(out)
+
+	k = p.N - k
+	q, r = k/64, uint(k%64)
+	for i := range out {
+		var w uint64
+		if i+q < len(in) {
+			w = in[i+q] >> r
+		}
+		if i+q+1 < len(in) {
+			w |= in[i+q+1] << (64 - r)
+		}
+		out[i] |= w
+	}
+}
+
+// mask clears the bits of v beyond N.
+func (p *Params) mask(v vector) {
+	if r := p.N % 64; r != 0 {
+		v[len(v)-1] &= (1 << r) - 1
+	}
+}
+
+func add(out, a, b vector) {
+	for i := range out {
+		out[i] = a[i] ^ b[i]
+	}
+}
+
+// pack writes the first len(buf) bytes of v to buf in little-endian order.
+func pack(buf []byte, v vector) {
+	var tmp [8]byte
+	for i := 0; i < len(buf); i += 8 {
+		binary.LittleEndian.PutUint64(tmp[:], v[i/8])
+		copy(buf[i:], tmp[:])
+	}
+}
+
+// unpack sets v from the little-endian bytes in buf, and clears the bits
+// beyond N.
+func (p *Params) unpack(v vector, buf []byte) {
+	var tmp [8]byte
+	for i := range v {
+		tmp = [8]byte{}
+		if 8*i < len(buf) {
+			copy(tmp[:], buf[8*i:])
+		}
+		v[i] = binary.LittleEndian.Uint64(tmp[:])
+	}
+	p.mask(v)
+}
@@ -0,0 +1,99 @@
+package hqc
+
+// Code to generate the NIST "PQCkemKAT" test vectors.
+// See PQCgenKAT_kem.c and rng.c in the reference implementation.
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/nist"
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/kem/hqc/internal"
+	"github.com/cloudflare/circl/kem/schemes"
+)
+
+func TestPQCgenKATKem(t *testing.T) {
+	kats := []struct {
+		p    *internal.Params
+		want string
+	}{
+		// TODO crossreference with the KAT files of the reference
+		// 		implementation, which are not vendored.
+		{internal.HQC128, "db203f76bf478ccb87690a85298e5ba76fe7f1f9bf33c8526e98ef3871240822"},
+		{internal.HQC192, "8e93dd78e638e3449ba3e9396b83b2c78926c79ee899c71c37a5e08bbd4738ee"},
+		{internal.HQC256, "6248de38ad919d8859b062155549a6ca7388f6960e228f401db5f79e6c1705dd"},
+	}
+	for _, kat := range kats {
+		t.Run(kat.p.Name, func(t *testing.T) {
+			testPQCgenKATKem(t, kat.p, kat.want)
+		})
+	}
+}
+
+func testPQCgenKATKem(t *testing.T, p *internal.Params, expected string) {
+	scheme := schemes.ByName(p.Name)
+	if scheme == nil {
+		t.Fatal()
+	}
+	test.CheckOk(scheme.PublicKeySize() == p.PublicKeySize() &&
+		scheme.PrivateKeySize() == p.PrivateKeySize() &&
+		scheme.CiphertextSize() == p.CiphertextSize() &&
+		scheme.SeedSize() == p.KeySeedSize() &&
+		scheme.EncapsulationSeedSize() == p.EncapsulationSeedSize(),
+		"wrong sizes", t)
+
+	var seed [48]byte
+	kseed := make([]byte, scheme.SeedSize())
+	eseed := make([]byte, scheme.EncapsulationSeedSize())
+	for i := 0; i < 48; i++ {
+		seed[i] = byte(i)
+	}
+	f := sha256.New()
+	g := nist.NewDRBG(&seed)
+	mustWrite(t, f, "# %s\n\n", p.Name)
+	for i := 0; i < 100; i++ {
+		g.Fill(seed[:])
+		mustWrite(t, f, "count = %d\n", i)
+		mustWrite(t, f, "seed = %X\n", seed)
+
+		g2 := nist.NewDRBG(&seed)
+
+		// The seed of the private key, sigma, and the seed of the public
+		// key are sampled in separate calls.
+		g2.Fill(kseed[:internal.SeedSize])
+		g2.Fill(kseed[internal.SeedSize : internal.SeedSize+p.K])
+		g2.Fill(kseed[internal.SeedSize+p.K:])
+
+		pk, sk := scheme.DeriveKeyPair(kseed)
+		ppk, _ := pk.MarshalBinary()
+		psk, _ := sk.MarshalBinary()
+
+		// The message and the salt are sampled in separate calls.
+		g2.Fill(eseed[:p.K])
+		g2.Fill(eseed[p.K:])
+		ct, ss, err := scheme.EncapsulateDeterministically(pk, eseed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ss2, _ := scheme.Decapsulate(sk, ct)
+		if !bytes.Equal(ss, ss2) {
+			t.Fatal()
+		}
+		mustWrite(t, f, "pk = %X\n", ppk)
+		mustWrite(t, f, "sk = %X\n", psk)
+		mustWrite(t, f, "ct = %X\n", ct)
+		mustWrite(t, f, "ss = %X\n\n", ss)
+	}
+	if fmt.Sprintf("%x", f.Sum(nil)) != expected {
+		t.Fatalf("%s %x %s", p.Name, f.Sum(nil), expected)
+	}
+}
+
+func mustWrite(t *testing.T, f io.Writer, format string, data any) {
+	_, err := fmt.Fprintf(f, format, data)
+	test.CheckNoErr(t, err, "fprintf failed")
+}
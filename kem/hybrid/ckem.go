@@ -20,7 +20,11 @@ type cScheme struct {
 	curve ecdh.Curve
 }
 
-var p256Kem = &cScheme{ecdh.P256()}
+var (
+	p256Kem = &cScheme{ecdh.P256()}
+	p384Kem = &cScheme{ecdh.P384()}
+	p521Kem = &cScheme{ecdh.P521()}
+)
 
 func (sch cScheme) Name() string {
 	switch sch.curve {
@@ -129,11 +133,22 @@ func (sch cScheme) DeriveKeyPair(seed []byte) (kem.PublicKey, kem.PrivateKey) {
 	if len(seed) != sch.SeedSize() {
 		panic(kem.ErrSeedSize)
 	}
+	// ecdh.Curve.GenerateKey may ignore its source of randomness, so
+	// the scalar is sampled by rejection from the output of SHAKE256.
 	h := xof.SHAKE256.New()
 	_, _ = h.Write(seed)
-	privKey, err := sch.curve.GenerateKey(h)
-	if err != nil {
-		panic(err)
+	buf := make([]byte, sch.PrivateKeySize())
+	var privKey *ecdh.PrivateKey
+	for {
+		_, _ = h.Read(buf)
+		if sch.curve == ecdh.P521() {
+			buf[0] &= 0x01
+		}
+		var err error
+		privKey, err = sch.curve.NewPrivateKey(buf)
+		if err == nil {
+			break
+		}
 	}
 	pubKey := privKey.PublicKey()
 
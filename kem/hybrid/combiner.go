@@ -0,0 +1,155 @@
+package hybrid
+
+import (
+	"encoding/binary"
+	"hash"
+	"io"
+
+	"github.com/cloudflare/circl/internal/sha3"
+	"github.com/cloudflare/circl/kem"
+	"golang.org/x/crypto/hkdf"
+)
+
+// Combiner derives the shared key of a hybrid KEM from the shared keys,
+// ciphertexts, and public keys of its components.
+type Combiner interface {
+	// SharedKeySize returns the size of the shared key derived for
+	// the given components.
+	SharedKeySize(components []kem.Scheme) int
+
+	// UsesPublicKeys returns whether Combine needs the packed public keys
+	// of the components.
+	UsesPublicKeys() bool
+
+	// Combine returns the shared key derived from the shared keys ss,
+	// ciphertexts ct, and packed public keys pk of the components, in
+	// order. If UsesPublicKeys returns false, pk is nil.
+	Combine(ss, ct, pk [][]byte) []byte
+}
+
+// Concatenation is the combiner used by the hybrid KEMs in TLS, whose
+// shared key is the concatenation of the shared keys of the components.
+var Concatenation Combiner = concatenation{}
+
+type concatenation struct{}
+
+func (concatenation) SharedKeySize(components []kem.Scheme) int {
+	size := 0
+	for _, c := range components {
+		size += c.SharedKeySize()
+	}
+	return size
+}
+
+func (concatenation) UsesPublicKeys() bool { return false }
+
+func (concatenation) Combine(ss, _, _ [][]byte) []byte {
+	var ret []byte
+	for _, s := range ss {
+		ret = append(ret, s...)
+	}
+	return ret
+}
+
+// XWingCombiner returns the combiner of X-Wing generalized to any number
+// of components, whose shared key is
+//
+//	SHA3-256(ss_1 || ... || ss_n || ct_2 || pk_2 || ... || ct_n || pk_n || label)
+//
+// The first component must be a post-quantum KEM that binds its
+// ciphertext and public key to the shared key, such as ML-KEM; the others
+// are usually Diffie–Hellman KEMs. With ML-KEM-768, X25519, and the label
+// `\.//^\`, this is the combiner of X-Wing, see
+//
+//	https://datatracker.ietf.org/doc/draft-connolly-cfrg-xwing-kem/
+func XWingCombiner(label []byte) Combiner {
+	return &xwingCombiner{append([]byte{}, label...)}
+}
+
+type xwingCombiner struct{ label []byte }
+
+func (*xwingCombiner) SharedKeySize([]kem.Scheme) int { return 32 }
+func (*xwingCombiner) UsesPublicKeys() bool           { return true }
+
+func (c *xwingCombiner) Combine(ss, ct, pk [][]byte) []byte {
+	h := sha3.New256()
+	for _, s := range ss {
+		_, _ = h.Write(s)
+	}
+	for i := 1; i < len(ss); i++ {
+		_, _ = h.Write(ct[i])
+		_, _ = h.Write(pk[i])
+	}
+	_, _ = h.Write(c.label)
+	return h.Sum(nil)
+}
+
+// KEMCombiner returns the combiner of draft-ounsworth-cfrg-kem-combiners-05
+// instantiated with SHA3-256 as both the hash and the KDF, whose shared
+// key is
+//
+//	SHA3-256(counter || k_1 || ... || k_n || fixedInfo)
+//
+// where k_i = SHA3-256(ss_i || ct_i), and counter is the 32-bit big-endian
+// integer 1. See
+//
+//	https://datatracker.ietf.org/doc/draft-ounsworth-cfrg-kem-combiners/
+func KEMCombiner(fixedInfo []byte) Combiner {
+	return &kemCombiner{append([]byte{}, fixedInfo...)}
+}
+
+type kemCombiner struct{ fixedInfo []byte }
+
+func (*kemCombiner) SharedKeySize([]kem.Scheme) int { return 32 }
+func (*kemCombiner) UsesPublicKeys() bool           { return false }
+
+func (c *kemCombiner) Combine(ss, ct, _ [][]byte) []byte {
+	var counter [4]byte
+	binary.BigEndian.PutUint32(counter[:], 1)
+
+	kdf := sha3.New256()
+	_, _ = kdf.Write(counter[:])
+	for i := range ss {
+		h := sha3.New256()
+		_, _ = h.Write(ss[i])
+		_, _ = h.Write(ct[i])
+		_, _ = kdf.Write(h.Sum(nil))
+	}
+	_, _ = kdf.Write(c.fixedInfo)
+	return kdf.Sum(nil)
+}
+
+// HKDFCombiner returns a combiner based on HKDF with the hash function h,
+// whose shared key, of the size of the output of h, is
+//
+//	HKDF-Expand(HKDF-Extract(nil, ss_1 || ... || ss_n),
+//	            label || ct_1 || ... || ct_n, Nh)
+//
+// so that the label separates the uses of the same components.
+func HKDFCombiner(h func() hash.Hash, label []byte) Combiner {
+	return &hkdfCombiner{h, append([]byte{}, label...)}
+}
+
+type hkdfCombiner struct {
+	h     func() hash.Hash
+	label []byte
+}
+
+func (c *hkdfCombiner) SharedKeySize([]kem.Scheme) int { return c.h().Size() }
+func (*hkdfCombiner) UsesPublicKeys() bool             { return false }
+
+func (c *hkdfCombiner) Combine(ss, ct, _ [][]byte) []byte {
+	var ikm, info []byte
+	for _, s := range ss {
+		ikm = append(ikm, s...)
+	}
+	info = append(info, c.label...)
+	for _, s := range ct {
+		info = append(info, s...)
+	}
+
+	prk := hkdf.Extract(c.h, ikm, nil)
+	ret := make([]byte, c.h().Size())
+	_, _ = io.ReadFull(hkdf.Expand(c.h, prk, info), ret)
+	return ret
+}
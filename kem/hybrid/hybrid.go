@@ -9,6 +9,13 @@
 //
 // Note that this approach is not proven secure in broader context.
 //
+// Other hybrid KEMs can be built with New from any two or more KEMs and
+// a Combiner, which derives the shared key from those of the components.
+// Besides Concatenation, the package provides the combiners of X-Wing and
+// draft-ounsworth-cfrg-kem-combiners, and one based on HKDF. For the
+// classical components, X25519, X448, P256, P384, and P521 return KEMs
+// whose shared keys are the raw Diffie–Hellman shared secrets.
+//
 // For deriving a KEM keypair deterministically and encapsulating
 // deterministically, we expand a single seed to both using SHAKE256,
 // so that a non-uniform seed (such as a shared secret generated by a hybrid
@@ -38,11 +45,24 @@ import (
 	"github.com/cloudflare/circl/kem/kyber/kyber1024"
 	"github.com/cloudflare/circl/kem/kyber/kyber512"
 	"github.com/cloudflare/circl/kem/kyber/kyber768"
+	"github.com/cloudflare/circl/kem/mlkem/mlkem1024"
 	"github.com/cloudflare/circl/kem/mlkem/mlkem768"
 )
 
 var ErrUninitialized = errors.New("public or private key not initialized")
 
+// New returns a hybrid KEM with the given name that combines the shared keys
+// of the components with combiner. Its public keys, private keys, and
+// ciphertexts are the concatenations of those of the components, in order.
+//
+// Panics if fewer than two components are given.
+func New(name string, combiner Combiner, components ...kem.Scheme) kem.Scheme {
+	if len(components) < 2 {
+		panic("hybrid: at least two components are required")
+	}
+	return &scheme{name, append([]kem.Scheme{}, components...), combiner, 0}
+}
+
 // Returns the hybrid KEM of Kyber512Draft00 and X25519.
 func Kyber512X25519() kem.Scheme { return kyber512X }
 
@@ -62,96 +82,161 @@ func P256Kyber768Draft00() kem.Scheme { return p256Kyber768Draft00 }
 // https://www.ietf.org/archive/id/draft-kwiatkowski-tls-ecdhe-mlkem-01.html
 func X25519MLKEM768() kem.Scheme { return xmlkem768 }
 
+// Returns the hybrid KEM of P-256 and ML-KEM-768, in that order.
+// https://datatracker.ietf.org/doc/draft-kwiatkowski-tls-ecdhe-mlkem/
+func SecP256r1MLKEM768() kem.Scheme { return p256mlkem768 }
+
+// Returns the hybrid KEM of P-384 and ML-KEM-1024, in that order.
+// https://datatracker.ietf.org/doc/draft-kwiatkowski-tls-ecdhe-mlkem/
+func SecP384r1MLKEM1024() kem.Scheme { return p384mlkem1024 }
+
+// Returns the KEM based on Diffie–Hellman over X25519, whose ciphertexts
+// are ephemeral public keys and shared keys the raw shared secrets.
+func X25519() kem.Scheme { return x25519Kem }
+
+// Returns the KEM based on Diffie–Hellman over X448, whose ciphertexts
+// are ephemeral public keys and shared keys the raw shared secrets.
+func X448() kem.Scheme { return x448Kem }
+
+// Returns the KEM based on ECDH over P-256, whose ciphertexts are
+// uncompressed ephemeral public keys and shared keys the raw x-coordinates.
+func P256() kem.Scheme { return p256Kem }
+
+// Returns the KEM based on ECDH over P-384, whose ciphertexts are
+// uncompressed ephemeral public keys and shared keys the raw x-coordinates.
+func P384() kem.Scheme { return p384Kem }
+
+// Returns the KEM based on ECDH over P-521, whose ciphertexts are
+// uncompressed ephemeral public keys and shared keys the raw x-coordinates.
+func P521() kem.Scheme { return p521Kem }
+
 var p256Kyber768Draft00 kem.Scheme = &scheme{
 	"P256Kyber768Draft00",
-	p256Kem,
-	kyber768.Scheme(),
+	[]kem.Scheme{p256Kem, kyber768.Scheme()},
+	Concatenation,
+	0,
 }
 
 var kyber512X kem.Scheme = &scheme{
 	"Kyber512-X25519",
-	x25519Kem,
-	kyber512.Scheme(),
+	[]kem.Scheme{x25519Kem, kyber512.Scheme()},
+	Concatenation,
+	0,
 }
 
 var kyber768X kem.Scheme = &scheme{
 	"Kyber768-X25519",
-	x25519Kem,
-	kyber768.Scheme(),
+	[]kem.Scheme{x25519Kem, kyber768.Scheme()},
+	Concatenation,
+	0,
 }
 
 var kyber768X4 kem.Scheme = &scheme{
 	"Kyber768-X448",
-	x448Kem,
-	kyber768.Scheme(),
+	[]kem.Scheme{x448Kem, kyber768.Scheme()},
+	Concatenation,
+	0,
 }
 
 var kyber1024X kem.Scheme = &scheme{
 	"Kyber1024-X448",
-	x448Kem,
-	kyber1024.Scheme(),
+	[]kem.Scheme{x448Kem, kyber1024.Scheme()},
+	Concatenation,
+	0,
 }
 
 var xmlkem768 kem.Scheme = &scheme{
 	"X25519MLKEM768",
-	mlkem768.Scheme(),
-	x25519Kem,
+	[]kem.Scheme{mlkem768.Scheme(), x25519Kem},
+	Concatenation,
+	0x11EC,
+}
+
+var p256mlkem768 kem.Scheme = &scheme{
+	"SecP256r1MLKEM768",
+	[]kem.Scheme{p256Kem, mlkem768.Scheme()},
+	Concatenation,
+	0x11EB,
+}
+
+var p384mlkem1024 kem.Scheme = &scheme{
+	"SecP384r1MLKEM1024",
+	[]kem.Scheme{p384Kem, mlkem1024.Scheme()},
+	Concatenation,
+	0x11ED,
 }
 
 // Public key of a hybrid KEM.
 type publicKey struct {
 	scheme *scheme
-	first  kem.PublicKey
-	second kem.PublicKey
+	keys   []kem.PublicKey
 }
 
 // Private key of a hybrid KEM.
 type privateKey struct {
 	scheme *scheme
-	first  kem.PrivateKey
-	second kem.PrivateKey
+	keys   []kem.PrivateKey
 }
 
 // Scheme for a hybrid KEM.
 type scheme struct {
-	name   string
-	first  kem.Scheme
-	second kem.Scheme
+	name       string
+	components []kem.Scheme
+	combiner   Combiner
+
+	// Code point of the TLS named group, or zero if there is none.
+	tlsID uint
 }
 
 func (sch *scheme) Name() string { return sch.name }
+
+// TLSIdentifier returns the code point of the TLS named group, or zero if
+// the KEM is not a TLS named group.
+func (sch *scheme) TLSIdentifier() uint { return sch.tlsID }
 func (sch *scheme) PublicKeySize() int {
-	return sch.first.PublicKeySize() + sch.second.PublicKeySize()
+	size := 0
+	for _, c := range sch.components {
+		size += c.PublicKeySize()
+	}
+	return size
 }
 
 func (sch *scheme) PrivateKeySize() int {
-	return sch.first.PrivateKeySize() + sch.second.PrivateKeySize()
+	size := 0
+	for _, c := range sch.components {
+		size += c.PrivateKeySize()
+	}
+	return size
 }
 
 func (sch *scheme) SeedSize() int {
-	first := sch.first.SeedSize()
-	second := sch.second.SeedSize()
-	ret := second
-	if first > second {
-		ret = first
+	ret := 0
+	for _, c := range sch.components {
+		if c.SeedSize() > ret {
+			ret = c.SeedSize()
+		}
 	}
 	return ret
 }
 
 func (sch *scheme) SharedKeySize() int {
-	return sch.first.SharedKeySize() + sch.second.SharedKeySize()
+	return sch.combiner.SharedKeySize(sch.components)
 }
 
 func (sch *scheme) CiphertextSize() int {
-	return sch.first.CiphertextSize() + sch.second.CiphertextSize()
+	size := 0
+	for _, c := range sch.components {
+		size += c.CiphertextSize()
+	}
+	return size
 }
 
 func (sch *scheme) EncapsulationSeedSize() int {
-	first := sch.first.EncapsulationSeedSize()
-	second := sch.second.EncapsulationSeedSize()
-	ret := second
-	if first > second {
-		ret = first
+	ret := 0
+	for _, c := range sch.components {
+		if c.EncapsulationSeedSize() > ret {
+			ret = c.EncapsulationSeedSize()
+		}
 	}
 	return ret
 }
@@ -159,19 +244,43 @@ func (sch *scheme) EncapsulationSeedSize() int {
 func (sk *privateKey) Scheme() kem.Scheme { return sk.scheme }
 func (pk *publicKey) Scheme() kem.Scheme  { return pk.scheme }
 
+func (sk *privateKey) initialized() bool {
+	if len(sk.keys) == 0 {
+		return false
+	}
+	for _, k := range sk.keys {
+		if k == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (pk *publicKey) initialized() bool {
+	if len(pk.keys) == 0 {
+		return false
+	}
+	for _, k := range pk.keys {
+		if k == nil {
+			return false
+		}
+	}
+	return true
+}
+
 func (sk *privateKey) MarshalBinary() ([]byte, error) {
-	if sk.first == nil || sk.second == nil {
+	if !sk.initialized() {
 		return nil, ErrUninitialized
 	}
-	first, err := sk.first.MarshalBinary()
-	if err != nil {
-		return nil, err
+	var ret []byte
+	for _, k := range sk.keys {
+		packed, err := k.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, packed...)
 	}
-	second, err := sk.second.MarshalBinary()
-	if err != nil {
-		return nil, err
-	}
-	return append(first, second...), nil
+	return ret, nil
 }
 
 func (sk *privateKey) Equal(other kem.PrivateKey) bool {
@@ -179,17 +288,26 @@ func (sk *privateKey) Equal(other kem.PrivateKey) bool {
 	if !ok {
 		return false
 	}
-	if sk.first == nil && sk.second == nil && oth.first == nil && oth.second == nil {
+	if len(sk.keys) == 0 && len(oth.keys) == 0 {
 		return true
 	}
-	if sk.first == nil || sk.second == nil || oth.first == nil || oth.second == nil {
+	if !sk.initialized() || !oth.initialized() || len(sk.keys) != len(oth.keys) {
 		return false
 	}
-	return sk.first.Equal(oth.first) && sk.second.Equal(oth.second)
+	for i := range sk.keys {
+		if !sk.keys[i].Equal(oth.keys[i]) {
+			return false
+		}
+	}
+	return true
 }
 
 func (sk *privateKey) Public() kem.PublicKey {
-	return &publicKey{sk.scheme, sk.first.Public(), sk.second.Public()}
+	pk := &publicKey{sk.scheme, make([]kem.PublicKey, len(sk.keys))}
+	for i, k := range sk.keys {
+		pk.keys[i] = k.Public()
+	}
+	return pk
 }
 
 func (pk *publicKey) Equal(other kem.PublicKey) bool {
@@ -197,41 +315,46 @@ func (pk *publicKey) Equal(other kem.PublicKey) bool {
 	if !ok {
 		return false
 	}
-	if pk.first == nil && pk.second == nil && oth.first == nil && oth.second == nil {
+	if len(pk.keys) == 0 && len(oth.keys) == 0 {
 		return true
 	}
-	if pk.first == nil || pk.second == nil || oth.first == nil || oth.second == nil {
+	if !pk.initialized() || !oth.initialized() || len(pk.keys) != len(oth.keys) {
 		return false
 	}
-	return pk.first.Equal(oth.first) && pk.second.Equal(oth.second)
+	for i := range pk.keys {
+		if !pk.keys[i].Equal(oth.keys[i]) {
+			return false
+		}
+	}
+	return true
 }
 
 func (pk *publicKey) MarshalBinary() ([]byte, error) {
-	if pk.first == nil || pk.second == nil {
+	if !pk.initialized() {
 		return nil, ErrUninitialized
 	}
-	first, err := pk.first.MarshalBinary()
-	if err != nil {
-		return nil, err
-	}
-	second, err := pk.second.MarshalBinary()
-	if err != nil {
-		return nil, err
+	var ret []byte
+	for _, k := range pk.keys {
+		packed, err := k.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, packed...)
 	}
-	return append(first, second...), nil
+	return ret, nil
 }
 
 func (sch *scheme) GenerateKeyPair() (kem.PublicKey, kem.PrivateKey, error) {
-	pk1, sk1, err := sch.first.GenerateKeyPair()
-	if err != nil {
-		return nil, nil, err
-	}
-	pk2, sk2, err := sch.second.GenerateKeyPair()
-	if err != nil {
-		return nil, nil, err
-	}
-
-	return &publicKey{sch, pk1, pk2}, &privateKey{sch, sk1, sk2}, nil
+	pk := &publicKey{sch, make([]kem.PublicKey, len(sch.components))}
+	sk := &privateKey{sch, make([]kem.PrivateKey, len(sch.components))}
+	for i, c := range sch.components {
+		var err error
+		pk.keys[i], sk.keys[i], err = c.GenerateKeyPair()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return pk, sk, nil
 }
 
 func (sch *scheme) DeriveKeyPair(seed []byte) (kem.PublicKey, kem.PrivateKey) {
@@ -240,34 +363,58 @@ func (sch *scheme) DeriveKeyPair(seed []byte) (kem.PublicKey, kem.PrivateKey) {
 	}
 	h := sha3.NewShake256()
 	_, _ = h.Write(seed)
-	first := make([]byte, sch.first.SeedSize())
-	second := make([]byte, sch.second.SeedSize())
-	_, _ = h.Read(first)
-	_, _ = h.Read(second)
 
-	pk1, sk1 := sch.first.DeriveKeyPair(first)
-	pk2, sk2 := sch.second.DeriveKeyPair(second)
+	pk := &publicKey{sch, make([]kem.PublicKey, len(sch.components))}
+	sk := &privateKey{sch, make([]kem.PrivateKey, len(sch.components))}
+	seeds := make([][]byte, len(sch.components))
+	for i, c := range sch.components {
+		seeds[i] = make([]byte, c.SeedSize())
+		_, _ = h.Read(seeds[i])
+	}
+	for i, c := range sch.components {
+		pk.keys[i], sk.keys[i] = c.DeriveKeyPair(seeds[i])
+	}
+	return pk, sk
+}
 
-	return &publicKey{sch, pk1, pk2}, &privateKey{sch, sk1, sk2}
+// Returns the shared key from the shared keys and ciphertexts of the
+// components, using the public keys given by pk if the combiner needs them.
+func (sch *scheme) combine(ss, ct [][]byte, pk func(i int) kem.PublicKey) ([]byte, error) {
+	var pks [][]byte
+	if sch.combiner.UsesPublicKeys() {
+		pks = make([][]byte, len(ss))
+		for i := range pks {
+			var err error
+			pks[i], err = pk(i).MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return sch.combiner.Combine(ss, ct, pks), nil
 }
 
 func (sch *scheme) Encapsulate(pk kem.PublicKey) (ct, ss []byte, err error) {
 	pub, ok := pk.(*publicKey)
-	if !ok {
+	if !ok || len(pub.keys) != len(sch.components) {
 		return nil, nil, kem.ErrTypeMismatch
 	}
 
-	ct1, ss1, err := sch.first.Encapsulate(pub.first)
-	if err != nil {
-		return nil, nil, err
+	cts := make([][]byte, len(sch.components))
+	sss := make([][]byte, len(sch.components))
+	for i, c := range sch.components {
+		cts[i], sss[i], err = c.Encapsulate(pub.keys[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		ct = append(ct, cts[i]...)
 	}
 
-	ct2, ss2, err := sch.second.Encapsulate(pub.second)
+	ss, err = sch.combine(sss, cts, func(i int) kem.PublicKey { return pub.keys[i] })
 	if err != nil {
 		return nil, nil, err
 	}
-
-	return append(ct1, ct2...), append(ss1, ss2...), nil
+	return ct, ss, nil
 }
 
 func (sch *scheme) EncapsulateDeterministically(
@@ -279,25 +426,32 @@ func (sch *scheme) EncapsulateDeterministically(
 
 	h := sha3.NewShake256()
 	_, _ = h.Write(seed)
-	first := make([]byte, sch.first.EncapsulationSeedSize())
-	second := make([]byte, sch.second.EncapsulationSeedSize())
-	_, _ = h.Read(first)
-	_, _ = h.Read(second)
+	seeds := make([][]byte, len(sch.components))
+	for i, c := range sch.components {
+		seeds[i] = make([]byte, c.EncapsulationSeedSize())
+		_, _ = h.Read(seeds[i])
+	}
 
 	pub, ok := pk.(*publicKey)
-	if !ok {
+	if !ok || len(pub.keys) != len(sch.components) {
 		return nil, nil, kem.ErrTypeMismatch
 	}
 
-	ct1, ss1, err := sch.first.EncapsulateDeterministically(pub.first, first)
-	if err != nil {
-		return nil, nil, err
+	cts := make([][]byte, len(sch.components))
+	sss := make([][]byte, len(sch.components))
+	for i, c := range sch.components {
+		cts[i], sss[i], err = c.EncapsulateDeterministically(pub.keys[i], seeds[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		ct = append(ct, cts[i]...)
 	}
-	ct2, ss2, err := sch.second.EncapsulateDeterministically(pub.second, second)
+
+	ss, err = sch.combine(sss, cts, func(i int) kem.PublicKey { return pub.keys[i] })
 	if err != nil {
 		return nil, nil, err
 	}
-	return append(ct1, ct2...), append(ss1, ss2...), nil
+	return ct, ss, nil
 }
 
 func (sch *scheme) Decapsulate(sk kem.PrivateKey, ct []byte) ([]byte, error) {
@@ -306,50 +460,56 @@ func (sch *scheme) Decapsulate(sk kem.PrivateKey, ct []byte) ([]byte, error) {
 	}
 
 	priv, ok := sk.(*privateKey)
-	if !ok {
+	if !ok || len(priv.keys) != len(sch.components) {
 		return nil, kem.ErrTypeMismatch
 	}
 
-	firstSize := sch.first.CiphertextSize()
-	ss1, err := sch.first.Decapsulate(priv.first, ct[:firstSize])
-	if err != nil {
-		return nil, err
-	}
-	ss2, err := sch.second.Decapsulate(priv.second, ct[firstSize:])
-	if err != nil {
-		return nil, err
+	cts := make([][]byte, len(sch.components))
+	sss := make([][]byte, len(sch.components))
+	for i, c := range sch.components {
+		size := c.CiphertextSize()
+		cts[i], ct = ct[:size], ct[size:]
+
+		var err error
+		sss[i], err = c.Decapsulate(priv.keys[i], cts[i])
+		if err != nil {
+			return nil, err
+		}
 	}
-	return append(ss1, ss2...), nil
+
+	return sch.combine(sss, cts, func(i int) kem.PublicKey { return priv.keys[i].Public() })
 }
 
 func (sch *scheme) UnmarshalBinaryPublicKey(buf []byte) (kem.PublicKey, error) {
 	if len(buf) != sch.PublicKeySize() {
 		return nil, kem.ErrPubKeySize
 	}
-	firstSize := sch.first.PublicKeySize()
-	pk1, err := sch.first.UnmarshalBinaryPublicKey(buf[:firstSize])
-	if err != nil {
-		return nil, err
-	}
-	pk2, err := sch.second.UnmarshalBinaryPublicKey(buf[firstSize:])
-	if err != nil {
-		return nil, err
-	}
-	return &publicKey{sch, pk1, pk2}, nil
+	pk := &publicKey{sch, make([]kem.PublicKey, len(sch.components))}
+	for i, c := range sch.components {
+		size := c.PublicKeySize()
+		var err error
+		pk.keys[i], err = c.UnmarshalBinaryPublicKey(buf[:size])
+		if err != nil {
+			return nil, err
+		}
+		buf = buf[size:]
+	}
+	return pk, nil
 }
 
 func (sch *scheme) UnmarshalBinaryPrivateKey(buf []byte) (kem.PrivateKey, error) {
 	if len(buf) != sch.PrivateKeySize() {
 		return nil, kem.ErrPrivKeySize
 	}
-	firstSize := sch.first.PrivateKeySize()
-	sk1, err := sch.first.UnmarshalBinaryPrivateKey(buf[:firstSize])
-	if err != nil {
-		return nil, err
-	}
-	sk2, err := sch.second.UnmarshalBinaryPrivateKey(buf[firstSize:])
-	if err != nil {
-		return nil, err
-	}
-	return &privateKey{sch, sk1, sk2}, nil
+	sk := &privateKey{sch, make([]kem.PrivateKey, len(sch.components))}
+	for i, c := range sch.components {
+		size := c.PrivateKeySize()
+		var err error
+		sk.keys[i], err = c.UnmarshalBinaryPrivateKey(buf[:size])
+		if err != nil {
+			return nil, err
+		}
+		buf = buf[size:]
+	}
+	return sk, nil
 }
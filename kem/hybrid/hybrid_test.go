@@ -0,0 +1,128 @@
+package hybrid
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/kem"
+	"github.com/cloudflare/circl/kem/mlkem/mlkem1024"
+	"github.com/cloudflare/circl/kem/mlkem/mlkem768"
+	"github.com/cloudflare/circl/kem/xwing"
+)
+
+func TestXWingCombiner(t *testing.T) {
+	// X-Wing keys and ciphertexts are the concatenations of those of
+	// ML-KEM-768 and X25519.
+	sch := New("X-Wing", XWingCombiner([]byte(`\.//^\`)), mlkem768.Scheme(), X25519())
+	test.CheckOk(sch.PublicKeySize() == xwing.PublicKeySize &&
+		sch.CiphertextSize() == xwing.CiphertextSize &&
+		sch.SharedKeySize() == xwing.SharedKeySize, "wrong sizes", t)
+
+	sk, ppk, err := xwing.GenerateKeyPairPacked(nil)
+	test.CheckNoErr(t, err, "key generation failed")
+	pk, err := sch.UnmarshalBinaryPublicKey(ppk)
+	test.CheckNoErr(t, err, "unmarshal failed")
+
+	ct, ss, err := sch.Encapsulate(pk)
+	test.CheckNoErr(t, err, "encapsulation failed")
+	test.CheckOk(bytes.Equal(ss, xwing.Decapsulate(ct, sk)), "shared keys differ", t)
+}
+
+func TestNew(t *testing.T) {
+	for _, c := range []struct {
+		name     string
+		combiner Combiner
+	}{
+		{"concatenation", Concatenation},
+		{"xwing", XWingCombiner([]byte("label"))},
+		{"kem-combiner", KEMCombiner([]byte("fixed info"))},
+		{"hkdf", HKDFCombiner(sha256.New, []byte("label"))},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			sch := New(c.name, c.combiner, mlkem1024.Scheme(), P384(), X25519())
+			testScheme(t, sch)
+		})
+	}
+
+	err := test.CheckPanic(func() { New("single", Concatenation, X25519()) })
+	test.CheckNoErr(t, err, "New must panic with a single component")
+}
+
+func testScheme(t *testing.T, sch kem.Scheme) {
+	pk, sk, err := sch.GenerateKeyPair()
+	test.CheckNoErr(t, err, "key generation failed")
+
+	ppk, err := pk.MarshalBinary()
+	test.CheckNoErr(t, err, "marshal failed")
+	psk, err := sk.MarshalBinary()
+	test.CheckNoErr(t, err, "marshal failed")
+	test.CheckOk(len(ppk) == sch.PublicKeySize() &&
+		len(psk) == sch.PrivateKeySize(), "wrong key sizes", t)
+
+	pk2, err := sch.UnmarshalBinaryPublicKey(ppk)
+	test.CheckNoErr(t, err, "unmarshal failed")
+	sk2, err := sch.UnmarshalBinaryPrivateKey(psk)
+	test.CheckNoErr(t, err, "unmarshal failed")
+	test.CheckOk(pk.Equal(pk2) && sk.Equal(sk2), "keys differ", t)
+	test.CheckOk(pk.Equal(sk.Public()), "wrong public key", t)
+
+	ct, ss, err := sch.Encapsulate(pk2)
+	test.CheckNoErr(t, err, "encapsulation failed")
+	test.CheckOk(len(ct) == sch.CiphertextSize() &&
+		len(ss) == sch.SharedKeySize(), "wrong sizes", t)
+	ss2, err := sch.Decapsulate(sk2, ct)
+	test.CheckNoErr(t, err, "decapsulation failed")
+	test.CheckOk(bytes.Equal(ss, ss2), "shared keys differ", t)
+
+	seed := make([]byte, sch.EncapsulationSeedSize())
+	ct, ss, err = sch.EncapsulateDeterministically(pk, seed)
+	test.CheckNoErr(t, err, "encapsulation failed")
+	ct2, ss2, err := sch.EncapsulateDeterministically(pk, seed)
+	test.CheckNoErr(t, err, "encapsulation failed")
+	test.CheckOk(bytes.Equal(ct, ct2) && bytes.Equal(ss, ss2), "not deterministic", t)
+
+	kseed := make([]byte, sch.SeedSize())
+	pk3, sk3 := sch.DeriveKeyPair(kseed)
+	pk4, sk4 := sch.DeriveKeyPair(kseed)
+	test.CheckOk(pk3.Equal(pk4) && sk3.Equal(sk4), "not deterministic", t)
+}
+
+func TestNISTCurveMLKEM(t *testing.T) {
+	for _, tc := range []struct {
+		scheme         kem.Scheme
+		ecdh, mlkem    kem.Scheme
+		pkSize, ctSize int
+	}{
+		{SecP256r1MLKEM768(), P256(), mlkem768.Scheme(), 65 + 1184, 65 + 1088},
+		{SecP384r1MLKEM1024(), P384(), mlkem1024.Scheme(), 97 + 1568, 97 + 1568},
+	} {
+		t.Run(tc.scheme.Name(), func(t *testing.T) {
+			sch := tc.scheme
+			test.CheckOk(sch.PublicKeySize() == tc.pkSize &&
+				sch.CiphertextSize() == tc.ctSize &&
+				sch.SharedKeySize() == tc.ecdh.SharedKeySize()+32, "wrong sizes", t)
+			testScheme(t, sch)
+
+			// The ECDH share comes first on the wire, and so does its
+			// shared secret.
+			pk, sk, err := sch.GenerateKeyPair()
+			test.CheckNoErr(t, err, "key generation failed")
+			ppk, _ := pk.MarshalBinary()
+			test.CheckOk(ppk[0] == 4, "expected an uncompressed point", t)
+
+			ct, ss, err := sch.Encapsulate(pk)
+			test.CheckNoErr(t, err, "encapsulation failed")
+			test.CheckOk(ct[0] == 4, "expected an uncompressed point", t)
+
+			keys := sk.(*privateKey).keys
+			n := tc.ecdh.CiphertextSize()
+			ss1, err := tc.ecdh.Decapsulate(keys[0], ct[:n])
+			test.CheckNoErr(t, err, "decapsulation failed")
+			ss2, err := tc.mlkem.Decapsulate(keys[1], ct[n:])
+			test.CheckNoErr(t, err, "decapsulation failed")
+			test.CheckOk(bytes.Equal(ss, append(ss1, ss2...)), "wrong shared key", t)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+//go:generate go run gen.go
+
+// Package mceliece implements the code-based key encapsulation mechanism
+// Classic McEliece as submitted to round 4 of the NIST PQC competition [1].
+//
+// The parameter sets mceliece348864, mceliece460896, mceliece6688128,
+// mceliece6960119, and mceliece8192128 are provided in packages of the
+// same name, and so are their "f" variants, which compute the public key
+// in semi-systematic form for faster key generation. Both variants have
+// the same key and ciphertext formats.
+//
+// The public keys are between 255 KiB and 1.3 MiB long, so each package
+// also provides ReadPublicKey and PublicKey.WriteTo to read and write
+// public keys to streams without holding a second copy of them.
+//
+// Seeds are expanded and randomness is consumed in the same order as in
+// the reference implementation [2].
+//
+// References:
+//
+//	[1] https://classic.mceliece.org/mceliece-spec-20221023.pdf
+//	[2] https://classic.mceliece.org/software.html
+package mceliece
@@ -0,0 +1,99 @@
+//go:build ignore
+// +build ignore
+
+// Autogenerates wrappers from templates to prevent too much duplicated code
+// between the code for different modes.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+type Instance struct {
+	Set  string
+	Semi bool // Whether this is an "f" parameter set.
+	M    int
+	N    int
+	T    int
+}
+
+func (m Instance) Name() string {
+	if m.Semi {
+		return "mceliece" + m.Set + "f"
+	}
+	return "mceliece" + m.Set
+}
+
+func (m Instance) Pkg() string { return m.Name() }
+
+func (m Instance) Params() string {
+	return "McEliece" + strings.TrimPrefix(m.Name(), "mceliece")
+}
+
+func (m Instance) PublicKeySize() int { return m.M * m.T * ((m.N - m.M*m.T + 7) / 8) }
+func (m Instance) PrivateKeySize() int {
+	return 40 + 2*m.T + (1<<(m.M-4))*(2*m.M-1) + m.N/8
+}
+func (m Instance) CiphertextSize() int { return (m.M*m.T + 7) / 8 }
+
+var (
+	Instances = []Instance{
+		{Set: "348864", M: 12, N: 3488, T: 64},
+		{Set: "348864", Semi: true, M: 12, N: 3488, T: 64},
+		{Set: "460896", M: 13, N: 4608, T: 96},
+		{Set: "460896", Semi: true, M: 13, N: 4608, T: 96},
+		{Set: "6688128", M: 13, N: 6688, T: 128},
+		{Set: "6688128", Semi: true, M: 13, N: 6688, T: 128},
+		{Set: "6960119", M: 13, N: 6960, T: 119},
+		{Set: "6960119", Semi: true, M: 13, N: 6960, T: 119},
+		{Set: "8192128", M: 13, N: 8192, T: 128},
+		{Set: "8192128", Semi: true, M: 13, N: 8192, T: 128},
+	}
+	TemplateWarning = "// Code generated from"
+)
+
+func main() {
+	generatePackageFiles()
+}
+
+// Generates instance/mceliece.go from templates/pkg.templ.go
+func generatePackageFiles() {
+	tl, err := template.ParseFiles("templates/pkg.templ.go")
+	if err != nil {
+		panic(err)
+	}
+
+	for _, mode := range Instances {
+		buf := new(bytes.Buffer)
+		err := tl.Execute(buf, mode)
+		if err != nil {
+			panic(err)
+		}
+
+		// Formating output code
+		code, err := format.Source(buf.Bytes())
+		if err != nil {
+			panic(fmt.Sprintf("error formating code: %v", err))
+		}
+
+		res := string(code)
+		offset := strings.Index(res, TemplateWarning)
+		if offset == -1 {
+			panic("Missing template warning in pkg.templ.go")
+		}
+		err = os.MkdirAll(mode.Pkg(), 0o755)
+		if err != nil {
+			panic(err)
+		}
+		err = os.WriteFile(filepath.Join(mode.Pkg(), "mceliece.go"), []byte(res[offset:]), 0o644)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package internal
+
+// Computes the control bits of a Beneš network for a permutation, following
+// "Verified fast formulas for control bits for permutation networks" by
+// D. J. Bernstein, https://eprint.iacr.org/2020/1493.
+
+// Applies a layer of the Beneš network with swaps between positions at
+// distance 2^s, controlled by the bits in cb.
+func layer(p []int16, cb []byte, s int) {
+	stride := 1 << s
+	index := 0
+	for i := 0; i < len(p); i += 2 * stride {
+		for j := 0; j < stride; j++ {
+			d := p[i+j] ^ p[i+j+stride]
+			m := -int16(cb[index>>3] >> (index & 7) & 1)
+			d &= m
+			p[i+j] ^= d
+			p[i+j+stride] ^= d
+			index++
+		}
+	}
+}
+
+// Applies the 2w-1 layers of the Beneš network controlled by cb to p,
+// which has length 2^w with w >= 4, so that the layers are byte aligned.
+func applyBenes(p []int16, cb []byte, w int) {
+	n := len(p)
+	for i := 0; i < w; i++ {
+		layer(p, cb, i)
+		cb = cb[n>>4:]
+	}
+	for i := w - 2; i >= 0; i-- {
+		layer(p, cb, i)
+		cb = cb[n>>4:]
+	}
+}
+
+// Writes the control bits of the permutation pi, of length n = 2^w, to
+// out, which must be zeroed.
+func cbRecursion(out []byte, pos, step int, pi []int16, w, n int, temp []int32) {
+	if w == 1 {
+		out[pos>>3] ^= byte(pi[0]) << (pos & 7)
+		return
+	}
+
+	A := temp[:n]
+	B := temp[n : 2*n]
+
+	for x := 0; x < n; x++ {
+		A[x] = int32(pi[x]^1)<<16 | int32(pi[x^1])
+	}
+	int32Sort(A) // A = (id<<16)+pibar
+
+	for x := 0; x < n; x++ {
+		px := A[x] & 0xffff
+		cx := px
+		if int32(x) < cx {
+			cx = int32(x)
+		}
+		B[x] = px<<16 | cx
+	}
+	// B = (p<<16)+c
+
+	for x := 0; x < n; x++ {
+		A[x] = A[x]<<16 | int32(x) // A = (pibar<<16)+id
+	}
+	int32Sort(A) // A = (id<<16)+pibar^-1
+
+	for x := 0; x < n; x++ {
+		A[x] = A[x]<<16 + B[x]>>16 // A = (pibar^-1<<16)+pibar
+	}
+	int32Sort(A) // A = (id<<16)+pibar^2
+
+	for x := 0; x < n; x++ {
+		B[x] = A[x]<<16 | B[x]&0xffff
+	}
+	// B = (pibar^2<<16)+c
+
+	for i := 1; i < w-1; i++ {
+		// B = (p<<16)+c
+
+		for x := 0; x < n; x++ {
+			A[x] = B[x]&^0xffff | int32(x)
+		}
+		int32Sort(A) // A = (id<<16)+p^-1
+
+		for x := 0; x < n; x++ {
+			A[x] = A[x]<<16 | B[x]&0xffff
+		}
+		// A = (p^-1<<16)+c
+
+		if i < w-2 {
+			for x := 0; x < n; x++ {
+				B[x] = A[x]&^0xffff | B[x]>>16
+			}
+			// B = (p^-1<<16)+p
+			int32Sort(B) // B = (id<<16)+p^2
+			for x := 0; x < n; x++ {
+				B[x] = B[x]<<16 | A[x]&0xffff
+			}
+			// B = (p^2<<16)+c
+		}
+
+		int32Sort(A) // A = (id<<16)+cp
+		for x := 0; x < n; x++ {
+			cpx := B[x]&^0xffff | A[x]&0xffff
+			int32MinMax(&B[x], &cpx)
+		}
+	}
+
+	for x := 0; x < n; x++ {
+		B[x] &= 0xffff
+	}
+
+	for x := 0; x < n; x++ {
+		A[x] = int32(pi[x])<<16 + int32(x)
+	}
+	int32Sort(A) // A = (id<<16)+pi^-1
+
+	for j := 0; j < n/2; j++ {
+		x := 2 * j
+		fj := B[x] & 1      // f[j]
+		Fx := int32(x) + fj // F[x]
+		Fx1 := Fx ^ 1       // F[x+1]
+
+		out[pos>>3] ^= byte(fj) << (pos & 7)
+		pos += step
+
+		B[x] = A[x]<<16 | Fx
+		B[x+1] = A[x+1]<<16 | Fx1
+	}
+	// B = (pi^-1<<16)+F
+
+	int32Sort(B) // B = (id<<16)+F(pi)
+
+	pos += (2*w - 3) * step * (n / 2)
+
+	for k := 0; k < n/2; k++ {
+		y := 2 * k
+		lk := B[y] & 1      // l[k]
+		Ly := int32(y) + lk // L[y]
+		Ly1 := Ly ^ 1       // L[y+1]
+
+		out[pos>>3] ^= byte(lk) << (pos & 7)
+		pos += step
+
+		A[y] = Ly<<16 | B[y]&0xffff
+		A[y+1] = Ly1<<16 | B[y+1]&0xffff
+	}
+	// A = (L<<16)+F(pi)
+
+	int32Sort(A) // A = (id<<16)+F(pi(L)) = (id<<16)+M
+
+	pos -= (2*w - 2) * step * (n / 2)
+
+	q := make([]int16, n)
+	for j := 0; j < n/2; j++ {
+		q[j] = int16((A[2*j] & 0xffff) >> 1)
+		q[j+n/2] = int16((A[2*j+1] & 0xffff) >> 1)
+	}
+
+	cbRecursion(out, pos, step*2, q[:n/2], w-1, n/2, temp)
+	cbRecursion(out, pos+step, step*2, q[n/2:], w-1, n/2, temp)
+}
+
+// Writes the control bits of the permutation pi of length 2^w, with w >= 4,
+// to out.
+func controlBits(out []byte, pi []int16, w int) {
+	n := len(pi)
+	for i := range out {
+		out[i] = 0
+	}
+	cbRecursion(out, 0, 1, pi, w, n, make([]int32, 2*n))
+
+	// The formulas are proven correct, so this only catches bugs.
+	test := make([]int16, n)
+	for i := range test {
+		test[i] = int16(i)
+	}
+	applyBenes(test, out, w)
+	diff := int16(0)
+	for i := range test {
+		diff |= test[i] ^ pi[i]
+	}
+	if diff != 0 {
+		panic("mceliece: wrong control bits")
+	}
+}
@@ -0,0 +1,163 @@
+package internal
+
+// Arithmetic in GF(2^m) and in the extension GF(2^m)[y]/F(y).
+
+type gf = uint16
+
+func (p *Params) gfMask() gf { return gf(1)<<p.M - 1 }
+
+// Returns a*b in constant time.
+func (p *Params) gfMul(a, b gf) gf {
+	if p.M == 12 {
+		return gfMul12(a, b)
+	}
+	return gfMul13(a, b)
+}
+
+// Multiplication in GF(2^12) defined by z^12 + z^3 + 1.
+func gfMul12(a, b gf) gf {
+	x, y := uint32(a), uint32(b)
+	r := x * (y & 1)
+	for i := 1; i < 12; i++ {
+		r ^= x * (y & (1 << i))
+	}
+
+	t := r & 0x7FC000
+	r ^= t >> 9
+	r ^= t >> 12
+
+	t = r & 0x3000
+	r ^= t >> 9
+	r ^= t >> 12
+
+	return gf(r & 0xFFF)
+}
+
+// Multiplication in GF(2^13) defined by z^13 + z^4 + z^3 + z + 1.
+func gfMul13(a, b gf) gf {
+	x, y := uint64(a), uint64(b)
+	r := x * (y & 1)
+	for i := 1; i < 13; i++ {
+		r ^= x * (y & (1 << i))
+	}
+
+	t := r & 0x1FF0000
+	r ^= (t >> 9) ^ (t >> 10) ^ (t >> 12) ^ (t >> 13)
+
+	t = r & 0x000E000
+	r ^= (t >> 9) ^ (t >> 10) ^ (t >> 12) ^ (t >> 13)
+
+	return gf(r & 0x1FFF)
+}
+
+func (p *Params) gfSq(a gf) gf { return p.gfMul(a, a) }
+
+// Returns 1/a in constant time, and 0 if a is 0.
+func (p *Params) gfInv(a gf) gf {
+	// a^(2^m - 2) = (a^(2^(m-1) - 1))^2
+	r := a
+	for i := 1; i < p.M-1; i++ {
+		r = p.gfMul(p.gfSq(r), a)
+	}
+	return p.gfSq(r)
+}
+
+// Returns num/den.
+func (p *Params) gfFrac(den, num gf) gf { return p.gfMul(p.gfInv(den), num) }
+
+// Returns 0xFFFF if a is zero and 0 otherwise.
+func gfIsZero(a gf) gf {
+	t := uint32(a)
+	t--
+	t >>= 31
+	return gf(-t)
+}
+
+// Reverses the m bits of a.
+func (p *Params) bitrev(a gf) gf {
+	var r gf
+	for i := 0; i < p.M; i++ {
+		r |= (a >> i & 1) << (p.M - 1 - i)
+	}
+	return r
+}
+
+// Evaluates the polynomial f of degree t, given by its t+1 coefficients,
+// at a.
+func (p *Params) eval(f []gf, a gf) gf {
+	r := f[len(f)-1]
+	for i := len(f) - 2; i >= 0; i-- {
+		r = p.gfMul(r, a) ^ f[i]
+	}
+	return r
+}
+
+// Sets r = a*b in GF(2^m)[y]/F(y), where all elements have T coefficients.
+func (p *Params) polyMul(r, a, b []gf) {
+	prod := make([]gf, 2*p.T-1)
+	for i := 0; i < p.T; i++ {
+		for j := 0; j < p.T; j++ {
+			prod[i+j] ^= p.gfMul(a[i], b[j])
+		}
+	}
+	for i := 2*p.T - 2; i >= p.T; i-- {
+		for _, tm := range p.extPoly {
+			c := prod[i]
+			if tm.coef != 1 {
+				c = p.gfMul(c, tm.coef)
+			}
+			prod[i-p.T+tm.deg] ^= c
+		}
+	}
+	copy(r, prod[:p.T])
+}
+
+// Computes the minimal polynomial g of f, an element of GF(2^m)[y]/F(y).
+// On success, writes the T low coefficients of the monic g to out and
+// returns true. Returns false if f is not a generator of the extension.
+func (p *Params) genPoly(out, f []gf) bool {
+	t := p.T
+
+	// mat[j] = f^j for j = 0, ..., t.
+	mat := make([][]gf, t+1)
+	for j := range mat {
+		mat[j] = make([]gf, t)
+	}
+	mat[0][0] = 1
+	copy(mat[1], f)
+	for j := 2; j <= t; j++ {
+		p.polyMul(mat[j], mat[j-1], f)
+	}
+
+	// Gaussian elimination on the columns, which finds the linear
+	// dependency f^t = sum_j g_j f^j.
+	for j := 0; j < t; j++ {
+		for k := j + 1; k < t; k++ {
+			mask := gfIsZero(mat[j][j])
+			for c := j; c < t+1; c++ {
+				mat[c][j] ^= mat[c][k] & mask
+			}
+		}
+
+		if mat[j][j] == 0 {
+			return false
+		}
+
+		inv := p.gfInv(mat[j][j])
+		for c := j; c < t+1; c++ {
+			mat[c][j] = p.gfMul(mat[c][j], inv)
+		}
+
+		for k := 0; k < t; k++ {
+			if k != j {
+				tt := mat[j][k]
+				for c := j; c < t+1; c++ {
+					mat[c][k] ^= p.gfMul(mat[c][j], tt)
+				}
+			}
+		}
+	}
+
+	copy(out, mat[t])
+	return true
+}
@@ -0,0 +1,265 @@
+package internal
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/cloudflare/circl/internal/sha3"
+)
+
+// DeriveKey writes the packed public and private keys derived from the
+// seed delta.
+//
+// Panics if the lengths of pk, sk, or seed are not PublicKeySize,
+// PrivateKeySize, and SeedSize respectively.
+func (p *Params) DeriveKey(pk, sk, seed []byte) {
+	if len(pk) != p.PublicKeySize() || len(sk) != p.PrivateKeySize() {
+		panic("wrong key size")
+	}
+	if len(seed) != SeedSize {
+		panic("seed must be of length SeedSize")
+	}
+
+	q := 1 << p.M
+	sBytes := p.N / 8
+	r := make([]byte, sBytes+4*q+2*p.T+SeedSize)
+	f := make([]gf, p.T)
+	irr := make([]gf, p.T)
+	perm := make([]uint32, q)
+
+	delta := make([]byte, SeedSize)
+	copy(delta, seed)
+	for {
+		// r = SHAKE256(64 || delta), which is split in the string s, the
+		// randomness for the field ordering and for the irreducible
+		// polynomial, and the seed for the next attempt.
+		h := sha3.NewShake256()
+		_, _ = h.Write([]byte{64})
+		_, _ = h.Write(delta)
+		_, _ = h.Read(r)
+
+		copy(sk[:SeedSize], delta)
+		copy(delta, r[len(r)-SeedSize:])
+
+		rp := len(r) - SeedSize - 2*p.T
+		for i := range f {
+			f[i] = binary.LittleEndian.Uint16(r[rp+2*i:]) & p.gfMask()
+		}
+		if !p.genPoly(irr, f) {
+			continue
+		}
+
+		rp -= 4 * q
+		for i := range perm {
+			perm[i] = binary.LittleEndian.Uint32(r[rp+4*i:])
+		}
+		pi, pivots, ok := p.pkGen(pk, irr, perm)
+		if !ok {
+			continue
+		}
+
+		off := SeedSize
+		binary.LittleEndian.PutUint64(sk[off:], pivots)
+		off += 8
+		for i := range irr {
+			binary.LittleEndian.PutUint16(sk[off+2*i:], irr[i])
+		}
+		off += p.irrSize()
+		controlBits(sk[off:off+p.condSize()], pi, p.M)
+		off += p.condSize()
+		copy(sk[off:], r[:sBytes])
+		return
+	}
+}
+
+// Computes the field ordering from perm. Returns false if perm has
+// repeated values.
+func (p *Params) fieldOrdering(perm []uint32) ([]int16, bool) {
+	buf := make([]uint64, len(perm))
+	for i := range buf {
+		buf[i] = uint64(perm[i])<<31 | uint64(i)
+	}
+	uint64Sort(buf)
+	for i := 1; i < len(buf); i++ {
+		if buf[i-1]>>31 == buf[i]>>31 {
+			return nil, false
+		}
+	}
+
+	pi := make([]int16, len(perm))
+	for i := range pi {
+		pi[i] = int16(buf[i] & uint64(p.gfMask()))
+	}
+	return pi, true
+}
+
+// A binary matrix, where each row is stored as 64-bit words with one
+// extra word of padding.
+type matrix [][]uint64
+
+// Returns the 64 bits of row starting at the column c.
+func extract64(row []uint64, c int) uint64 {
+	w, b := c/64, uint(c%64)
+	if b == 0 {
+		return row[w]
+	}
+	return row[w]>>b | row[w+1]<<(64-b)
+}
+
+// Sets the 64 bits of row starting at the column c to t.
+func insert64(row []uint64, c int, t uint64) {
+	w, b := c/64, uint(c%64)
+	if b == 0 {
+		row[w] = t
+		return
+	}
+	mask := uint64(1)<<b - 1
+	row[w] = row[w]&mask | t<<b
+	row[w+1] = row[w+1]&^mask | t>>(64-b)
+}
+
+// Computes the public key from the Goppa polynomial g, given by its T low
+// coefficients, and perm. Returns the field ordering and the pivots of the
+// semi-systematic form, and false if the key is not in the required form.
+func (p *Params) pkGen(pk []byte, g []gf, perm []uint32) ([]int16, uint64, bool) {
+	pi, ok := p.fieldOrdering(perm)
+	if !ok {
+		return nil, 0, false
+	}
+
+	// Parity check matrix, where the row i*m+k holds the bit k of
+	// L_j^i/g(L_j) in the column j.
+	gFull := make([]gf, p.T+1)
+	copy(gFull, g)
+	gFull[p.T] = 1
+
+	L := make([]gf, p.N)
+	inv := make([]gf, p.N)
+	for j := range L {
+		L[j] = p.bitrev(gf(pi[j]))
+		inv[j] = p.gfInv(p.eval(gFull, L[j]))
+	}
+
+	rows := p.rows()
+	words := (p.N+63)/64 + 1
+	mat := make(matrix, rows)
+	for i := range mat {
+		mat[i] = make([]uint64, words)
+	}
+	for i := 0; i < p.T; i++ {
+		for j := 0; j < p.N; j++ {
+			for k := 0; k < p.M; k++ {
+				mat[i*p.M+k][j/64] |= uint64(inv[j]>>k&1) << (j % 64)
+			}
+			inv[j] = p.gfMul(inv[j], L[j])
+		}
+	}
+
+	// Gaussian elimination to reduce the matrix to the form [I | T].
+	pivots := uint64(0xFFFFFFFF)
+	for row := 0; row < rows; row++ {
+		if p.Semi && row == rows-mu {
+			if pivots, ok = movColumns(mat, pi, rows-mu); !ok {
+				return nil, 0, false
+			}
+		}
+
+		w, b := row/64, uint(row%64)
+		pr := mat[row][w:]
+		for k := row + 1; k < rows; k++ {
+			mask := -(^(pr[0] >> b) & 1)
+			rk := mat[k][w:]
+			rk = rk[:len(pr)]
+			for c := range pr {
+				pr[c] ^= rk[c] & mask
+			}
+		}
+
+		if (pr[0]>>b)&1 == 0 {
+			return nil, 0, false
+		}
+
+		for k := 0; k < rows; k++ {
+			if k != row {
+				rk := mat[k][w:]
+				rk = rk[:len(pr)]
+				mask := -((rk[0] >> b) & 1)
+				for c := range pr {
+					rk[c] ^= pr[c] & mask
+				}
+			}
+		}
+	}
+
+	rowSize := p.PublicKeyRowSize()
+	var buf [8]byte
+	for i := range mat {
+		out := pk[i*rowSize : (i+1)*rowSize]
+		for j := 0; j < rowSize; j += 8 {
+			binary.LittleEndian.PutUint64(buf[:], extract64(mat[i], rows+8*j))
+			copy(out[j:], buf[:])
+		}
+	}
+	return pi, pivots, true
+}
+
+// Computes the pivots of the mu x nu submatrix starting at (row, row) and
+// moves the corresponding columns of mat, and the entries of pi, so that
+// the pivots are on the diagonal. Returns false if the submatrix does not
+// have full rank.
+func movColumns(mat matrix, pi []int16, row int) (uint64, bool) {
+	var buf [mu]uint64
+	var ctz [mu]int
+	for i := range buf {
+		buf[i] = extract64(mat[row+i], row)
+	}
+
+	pivots := uint64(0)
+	for i := 0; i < mu; i++ {
+		t := buf[i]
+		for j := i + 1; j < mu; j++ {
+			t |= buf[j]
+		}
+		if t == 0 {
+			return 0, false
+		}
+		s := bits.TrailingZeros64(t)
+		ctz[i] = s
+		pivots |= 1 << s
+
+		for j := i + 1; j < mu; j++ {
+			mask := (buf[i]>>s)&1 - 1
+			buf[i] ^= buf[j] & mask
+		}
+		for j := i + 1; j < mu; j++ {
+			mask := -((buf[j] >> s) & 1)
+			buf[j] ^= buf[i] & mask
+		}
+	}
+
+	for j := 0; j < mu; j++ {
+		for k := j + 1; k < nu; k++ {
+			d := pi[row+j] ^ pi[row+k]
+			d &= -int16(subtleEq(k, ctz[j]))
+			pi[row+j] ^= d
+			pi[row+k] ^= d
+		}
+	}
+
+	for i := range mat {
+		t := extract64(mat[i], row)
+		for j := 0; j < mu; j++ {
+			d := t>>j ^ t>>ctz[j]
+			d &= 1
+			t ^= d << ctz[j]
+			t ^= d << j
+		}
+		insert64(mat[i], row, t)
+	}
+	return pivots, true
+}
+
+// Returns 1 if a == b and 0 otherwise.
+func subtleEq(a, b int) int {
+	return int((uint64(a^b) - 1) >> 63)
+}
@@ -0,0 +1,293 @@
+package internal
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"io"
+
+	"github.com/cloudflare/circl/internal/sha3"
+)
+
+// CheckPublicKey returns whether the padding bits at the end of each row of
+// the packed public key pk are zero.
+func (p *Params) CheckPublicKey(pk []byte) bool {
+	rowSize := p.PublicKeyRowSize()
+	for i := rowSize - 1; i < len(pk); i += rowSize {
+		if !p.checkRowPadding(pk[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns whether the padding bits of the last byte of a row of the public
+// key are zero.
+func (p *Params) checkRowPadding(b byte) bool {
+	tail := (p.N - p.rows()) % 8
+	return tail == 0 || b>>tail == 0
+}
+
+// Returns whether the padding bits of the ciphertext are zero.
+func (p *Params) checkCiphertext(ct []byte) bool {
+	tail := p.rows() % 8
+	return tail == 0 || ct[len(ct)-1]>>tail == 0
+}
+
+// Encapsulate writes the ciphertext and shared key from the public key,
+// reading the error vector from rand.
+//
+// Panics if the lengths of ct, ss, or pk are not CiphertextSize,
+// SharedKeySize, and PublicKeySize respectively.
+func (p *Params) Encapsulate(ct, ss, pk []byte, rand io.Reader) error {
+	if len(ct) != p.CiphertextSize() || len(ss) != SharedKeySize {
+		panic("wrong ciphertext or shared key size")
+	}
+	if len(pk) != p.PublicKeySize() {
+		panic("wrong public key size")
+	}
+
+	e, err := p.genE(rand)
+	if err != nil {
+		return err
+	}
+	p.syndrome(ct, pk, e)
+
+	h := sha3.NewShake256()
+	_, _ = h.Write([]byte{1})
+	_, _ = h.Write(e)
+	_, _ = h.Write(ct)
+	_, _ = h.Read(ss)
+	return nil
+}
+
+// Writes the syndrome H*e of e to s, where H = [I | T] and T is given by
+// the public key.
+func (p *Params) syndrome(s, pk, e []byte) {
+	rows := p.rows()
+	rowSize := p.PublicKeyRowSize()
+
+	// The bits of e in the columns of T.
+	words := (p.N+63)/64 + 1
+	ev := make([]uint64, words)
+	for i := 0; i < p.N/8; i++ {
+		ev[i/8] |= uint64(e[i]) << (8 * (i % 8))
+	}
+	et := make([]byte, rowSize)
+	var buf [8]byte
+	for j := 0; j < rowSize; j += 8 {
+		binary.LittleEndian.PutUint64(buf[:], extract64(ev, rows+8*j))
+		copy(et[j:], buf[:])
+	}
+
+	for i := range s {
+		s[i] = 0
+	}
+	for i := 0; i < rows; i++ {
+		row := pk[i*rowSize : (i+1)*rowSize]
+		b := e[i/8] >> (i % 8) & 1
+		for j := range row {
+			b ^= row[j] & et[j]
+		}
+		b ^= b >> 4
+		b ^= b >> 2
+		b ^= b >> 1
+		s[i/8] |= (b & 1) << (i % 8)
+	}
+}
+
+// Returns a random error vector of weight T read from rand.
+func (p *Params) genE(rand io.Reader) ([]byte, error) {
+	ind := make([]gf, p.T)
+	var buf []byte
+	if p.N == 1<<p.M {
+		buf = make([]byte, 2*p.T)
+	} else {
+		buf = make([]byte, 4*p.T)
+	}
+
+	for {
+		if _, err := io.ReadFull(rand, buf); err != nil {
+			return nil, err
+		}
+
+		// Keeps the first T indices in range.
+		count := 0
+		for i := 0; i < len(buf)/2 && count < p.T; i++ {
+			v := binary.LittleEndian.Uint16(buf[2*i:]) & p.gfMask()
+			if int(v) < p.N {
+				ind[count] = v
+				count++
+			}
+		}
+		if count < p.T {
+			continue
+		}
+
+		eq := 0
+		for i := 1; i < p.T; i++ {
+			for j := 0; j < i; j++ {
+				eq |= subtleEq(int(ind[i]), int(ind[j]))
+			}
+		}
+		if eq == 0 {
+			break
+		}
+	}
+
+	e := make([]byte, p.N/8)
+	for j := range ind {
+		val := byte(1) << (ind[j] & 7)
+		for i := range e {
+			e[i] |= val & -byte(subtleEq(i, int(ind[j]>>3)))
+		}
+	}
+	return e, nil
+}
+
+// Decapsulate writes the shared key encapsulated in ct to ss. Returns false,
+// and sets ss to all ones, if the padding bits of ct are not zero.
+//
+// Panics if the lengths of ss, sk, or ct are not SharedKeySize,
+// PrivateKeySize, and CiphertextSize respectively.
+func (p *Params) Decapsulate(ss, sk, ct []byte) bool {
+	if len(ct) != p.CiphertextSize() || len(ss) != SharedKeySize {
+		panic("wrong ciphertext or shared key size")
+	}
+	if len(sk) != p.PrivateKeySize() {
+		panic("wrong private key size")
+	}
+
+	if !p.checkCiphertext(ct) {
+		for i := range ss {
+			ss[i] = 0xFF
+		}
+		return false
+	}
+
+	e, ok := p.decrypt(sk[SeedSize+8:], ct)
+	s := sk[len(sk)-p.N/8:]
+
+	// Uses s instead of e if decoding failed.
+	subtle.ConstantTimeCopy(1-ok, e, s)
+
+	h := sha3.NewShake256()
+	_, _ = h.Write([]byte{byte(ok)})
+	_, _ = h.Write(e)
+	_, _ = h.Write(ct)
+	_, _ = h.Read(ss)
+	return true
+}
+
+// Decodes the error vector e from the syndrome ct with the Goppa polynomial
+// and the control bits in sk. Returns 1 on success and 0 otherwise.
+func (p *Params) decrypt(sk, ct []byte) (e []byte, ok int) {
+	r := make([]byte, p.N/8)
+	copy(r, ct)
+
+	g := make([]gf, p.T+1)
+	for i := 0; i < p.T; i++ {
+		g[i] = binary.LittleEndian.Uint16(sk[2*i:]) & p.gfMask()
+	}
+	g[p.T] = 1
+	L := p.supportGen(sk[p.irrSize() : p.irrSize()+p.condSize()])
+
+	s := p.synd(g, L, r)
+	locator := p.bm(s)
+
+	e = make([]byte, p.N/8)
+	w := 0
+	for i := range L {
+		t := gfIsZero(p.eval(locator, L[i])) & 1
+		e[i/8] |= byte(t) << (i % 8)
+		w += int(t)
+	}
+
+	sCmp := p.synd(g, L, e)
+	check := uint32(w ^ p.T)
+	for i := range s {
+		check |= uint32(s[i] ^ sCmp[i])
+	}
+	return e, subtle.ConstantTimeEq(int32(check), 0)
+}
+
+// Returns the support L from the control bits, such that L[i] is the
+// bit reversal of pi[i].
+func (p *Params) supportGen(cb []byte) []gf {
+	v := make([]int16, 1<<p.M)
+	for i := range v {
+		v[i] = int16(p.bitrev(gf(i)))
+	}
+	applyBenes(v, cb, p.M)
+
+	L := make([]gf, p.N)
+	for i := range L {
+		L[i] = gf(v[i])
+	}
+	return L
+}
+
+// Returns the 2T syndromes of r with respect to the Goppa polynomial g^2
+// and the support L.
+func (p *Params) synd(g, L []gf, r []byte) []gf {
+	out := make([]gf, 2*p.T)
+	for i := range L {
+		c := -gf(r[i/8] >> (i % 8) & 1)
+		e := p.eval(g, L[i])
+		eInv := p.gfInv(p.gfSq(e)) & c
+		for j := range out {
+			out[j] ^= eInv
+			eInv = p.gfMul(eInv, L[i])
+		}
+	}
+	return out
+}
+
+// Returns the error locator polynomial of the syndromes s, computed with
+// the Berlekamp-Massey algorithm in constant time, with its coefficients
+// in reverse order so that its roots are the error positions.
+func (p *Params) bm(s []gf) []gf {
+	t := p.T
+	T := make([]gf, t+1)
+	C := make([]gf, t+1)
+	B := make([]gf, t+1)
+	b := gf(1)
+	L := 0
+
+	B[1] = 1
+	C[0] = 1
+
+	for N := 0; N < 2*t; N++ {
+		d := gf(0)
+		for i := 0; i <= min(N, t); i++ {
+			d ^= p.gfMul(C[i], s[N-i])
+		}
+
+		mne := ^gfIsZero(d)
+		mle := gf(-((uint32(N-2*L) >> 31) ^ 1))
+		mle &= mne
+
+		copy(T, C)
+
+		f := p.gfFrac(b, d)
+		for i := range C {
+			C[i] ^= p.gfMul(f, B[i]) & mne
+		}
+
+		ml := -int(mle & 1)
+		L = L&^ml | (N+1-L)&ml
+
+		for i := range B {
+			B[i] = B[i]&^mle | T[i]&mle
+		}
+		b = b&^mle | d&mle
+
+		copy(B[1:], B[:t])
+		B[0] = 0
+	}
+
+	out := make([]gf, t+1)
+	for i := range out {
+		out[i] = C[t-i]
+	}
+	return out
+}
@@ -0,0 +1,119 @@
+package internal
+
+import (
+	"bytes"
+	"crypto/rand"
+	mrand "math/rand"
+	"sort"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/sha3"
+	"github.com/cloudflare/circl/internal/test"
+)
+
+var allParams = []*Params{
+	McEliece348864, McEliece348864f,
+	McEliece460896, McEliece460896f,
+	McEliece6688128, McEliece6688128f,
+	McEliece6960119, McEliece6960119f,
+	McEliece8192128, McEliece8192128f,
+}
+
+func TestSizes(t *testing.T) {
+	for _, tc := range []struct {
+		p          *Params
+		pk, sk, ct int
+	}{
+		{McEliece348864, 261120, 6492, 96},
+		{McEliece460896, 524160, 13608, 156},
+		{McEliece6688128, 1044992, 13932, 208},
+		{McEliece6960119, 1047319, 13948, 194},
+		{McEliece8192128, 1357824, 14120, 208},
+	} {
+		test.CheckOk(tc.p.PublicKeySize() == tc.pk &&
+			tc.p.PrivateKeySize() == tc.sk &&
+			tc.p.CiphertextSize() == tc.ct, "wrong sizes "+tc.p.Name, t)
+	}
+}
+
+func TestGF(t *testing.T) {
+	for _, p := range []*Params{McEliece348864, McEliece460896} {
+		for a := gf(1); a < 1<<p.M; a++ {
+			test.CheckOk(p.gfMul(a, p.gfInv(a)) == 1, "wrong inverse", t)
+		}
+		test.CheckOk(p.gfInv(0) == 0, "wrong inverse of zero", t)
+	}
+}
+
+func TestSort(t *testing.T) {
+	for _, n := range []int{1, 2, 3, 17, 100, 1024} {
+		x := make([]int32, n)
+		y := make([]uint64, n)
+		for i := range x {
+			x[i] = mrand.Int31n(1 << 30)
+			y[i] = mrand.Uint64()
+		}
+		int32Sort(x)
+		uint64Sort(y)
+		test.CheckOk(sort.SliceIsSorted(x, func(i, j int) bool { return x[i] < x[j] }), "int32 not sorted", t)
+		test.CheckOk(sort.SliceIsSorted(y, func(i, j int) bool { return y[i] < y[j] }), "uint64 not sorted", t)
+	}
+}
+
+func TestControlBits(t *testing.T) {
+	for w := 4; w <= 13; w++ {
+		n := 1 << w
+		pi := make([]int16, n)
+		for i, v := range mrand.Perm(n) {
+			pi[i] = int16(v)
+		}
+		// controlBits panics if the control bits are wrong.
+		out := make([]byte, ((2*w-1)*n/2+7)/8)
+		controlBits(out, pi, w)
+	}
+}
+
+func TestKEM(t *testing.T) {
+	params := allParams
+	if testing.Short() {
+		params = []*Params{McEliece348864, McEliece348864f}
+	}
+	for _, p := range params {
+		t.Run(p.Name, func(t *testing.T) {
+			seed := make([]byte, SeedSize)
+			_, _ = rand.Read(seed)
+
+			pk := make([]byte, p.PublicKeySize())
+			sk := make([]byte, p.PrivateKeySize())
+			ct := make([]byte, p.CiphertextSize())
+			ss := make([]byte, SharedKeySize)
+			ss2 := make([]byte, SharedKeySize)
+
+			p.DeriveKey(pk, sk, seed)
+			test.CheckOk(p.CheckPublicKey(pk), "invalid public key", t)
+
+			xof := sha3.NewShake256()
+			_, _ = xof.Write(seed)
+			test.CheckNoErr(t, p.Encapsulate(ct, ss, pk, &xof), "encapsulation failed")
+			test.CheckOk(p.Decapsulate(ss2, sk, ct), "decapsulation failed", t)
+			test.CheckOk(bytes.Equal(ss, ss2), "shared keys differ", t)
+
+			// Implicit rejection, the shared key is derived from s.
+			ct[0] ^= 1
+			test.CheckOk(p.Decapsulate(ss2, sk, ct), "decapsulation failed", t)
+			h := sha3.NewShake256()
+			_, _ = h.Write([]byte{0})
+			_, _ = h.Write(sk[len(sk)-p.N/8:])
+			_, _ = h.Write(ct)
+			want := make([]byte, SharedKeySize)
+			_, _ = h.Read(want)
+			test.CheckOk(bytes.Equal(ss2, want), "wrong implicit rejection", t)
+
+			// Ciphertexts with nonzero padding bits are rejected.
+			if p.rows()%8 != 0 {
+				ct[len(ct)-1] |= 0x80
+				test.CheckOk(!p.Decapsulate(ss2, sk, ct), "padding not checked", t)
+			}
+		})
+	}
+}
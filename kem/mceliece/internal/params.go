@@ -0,0 +1,88 @@
+// Package internal implements Classic McEliece for all parameter sets.
+package internal
+
+const (
+	// Size of the seed used to derive a key pair.
+	SeedSize = 32
+
+	// Size of the established shared key.
+	SharedKeySize = 32
+)
+
+// Params holds the parameters of a Classic McEliece parameter set.
+type Params struct {
+	Name string
+
+	// The field GF(2^M) is defined by the polynomial fieldPoly, given as
+	// a bitmask including the term z^M.
+	M         int
+	fieldPoly uint32
+
+	// Length of the code and number of errors it corrects.
+	N, T int
+
+	// The polynomial F(y) = y^T + sum_i extPoly[i].coef y^extPoly[i].deg
+	// defines the extension GF(2^M)[y]/F(y) of degree T.
+	extPoly []term
+
+	// Whether the public key is computed in semi-systematic form, as in
+	// the "f" parameter sets.
+	Semi bool
+}
+
+type term struct {
+	deg  int
+	coef gf
+}
+
+const (
+	// Parameters of the semi-systematic form.
+	mu, nu = 32, 64
+)
+
+var (
+	f12 = uint32(1<<12 | 1<<3 | 1)
+	f13 = uint32(1<<13 | 1<<4 | 1<<3 | 1<<1 | 1)
+
+	ext348864  = []term{{3, 1}, {1, 1}, {0, 2}}
+	ext460896  = []term{{10, 1}, {9, 1}, {6, 1}, {0, 1}}
+	ext6688128 = []term{{7, 1}, {2, 1}, {1, 1}, {0, 1}}
+	ext6960119 = []term{{8, 1}, {0, 1}}
+	ext8192128 = ext6688128
+)
+
+var (
+	McEliece348864   = &Params{"mceliece348864", 12, f12, 3488, 64, ext348864, false}
+	McEliece348864f  = &Params{"mceliece348864f", 12, f12, 3488, 64, ext348864, true}
+	McEliece460896   = &Params{"mceliece460896", 13, f13, 4608, 96, ext460896, false}
+	McEliece460896f  = &Params{"mceliece460896f", 13, f13, 4608, 96, ext460896, true}
+	McEliece6688128  = &Params{"mceliece6688128", 13, f13, 6688, 128, ext6688128, false}
+	McEliece6688128f = &Params{"mceliece6688128f", 13, f13, 6688, 128, ext6688128, true}
+	McEliece6960119  = &Params{"mceliece6960119", 13, f13, 6960, 119, ext6960119, false}
+	McEliece6960119f = &Params{"mceliece6960119f", 13, f13, 6960, 119, ext6960119, true}
+	McEliece8192128  = &Params{"mceliece8192128", 13, f13, 8192, 128, ext8192128, false}
+	McEliece8192128f = &Params{"mceliece8192128f", 13, f13, 8192, 128, ext8192128, true}
+)
+
+// Number of rows of the public key, which is the codimension of the code.
+func (p *Params) rows() int { return p.M * p.T }
+
+// PublicKeyRowSize is the size in bytes of a row of the public key.
+func (p *Params) PublicKeyRowSize() int { return (p.N - p.rows() + 7) / 8 }
+
+// PublicKeySize is the size of a packed public key.
+func (p *Params) PublicKeySize() int { return p.rows() * p.PublicKeyRowSize() }
+
+// Size in bytes of the Goppa polynomial in the private key.
+func (p *Params) irrSize() int { return 2 * p.T }
+
+// Size in bytes of the control bits of the Beneš network in the private key.
+func (p *Params) condSize() int { return (1 << (p.M - 4)) * (2*p.M - 1) }
+
+// PrivateKeySize is the size of a packed private key.
+func (p *Params) PrivateKeySize() int {
+	return SeedSize + 8 + p.irrSize() + p.condSize() + p.N/8
+}
+
+// CiphertextSize is the size of a ciphertext.
+func (p *Params) CiphertextSize() int { return (p.rows() + 7) / 8 }
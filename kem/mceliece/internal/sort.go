@@ -0,0 +1,85 @@
+package internal
+
+// Constant-time sorting networks, following djbsort.
+
+import "math/bits"
+
+func int32MinMax(a, b *int32) {
+	ab := *b ^ *a
+	c := *b - *a
+	c ^= ab & (c ^ *b)
+	c >>= 31
+	c &= ab
+	*a ^= c
+	*b ^= c
+}
+
+func uint64MinMax(a, b *uint64) {
+	// The borrow is set if b < a.
+	_, borrow := bits.Sub64(*b, *a, 0)
+	c := -borrow & (*a ^ *b)
+	*a ^= c
+	*b ^= c
+}
+
+// Sorts x in ascending order in time independent of its contents.
+func int32Sort(x []int32) {
+	n := len(x)
+	if n < 2 {
+		return
+	}
+	top := 1
+	for top < n-top {
+		top += top
+	}
+	for p := top; p > 0; p >>= 1 {
+		for i := 0; i < n-p; i++ {
+			if i&p == 0 {
+				int32MinMax(&x[i], &x[i+p])
+			}
+		}
+		i := 0
+		for q := top; q > p; q >>= 1 {
+			for ; i < n-q; i++ {
+				if i&p == 0 {
+					a := x[i+p]
+					for r := q; r > p; r >>= 1 {
+						int32MinMax(&a, &x[i+r])
+					}
+					x[i+p] = a
+				}
+			}
+		}
+	}
+}
+
+// Sorts x in ascending order in time independent of its contents.
+func uint64Sort(x []uint64) {
+	n := len(x)
+	if n < 2 {
+		return
+	}
+	top := 1
+	for top < n-top {
+		top += top
+	}
+	for p := top; p > 0; p >>= 1 {
+		for i := 0; i < n-p; i++ {
+			if i&p == 0 {
+				uint64MinMax(&x[i], &x[i+p])
+			}
+		}
+		i := 0
+		for q := top; q > p; q >>= 1 {
+			for ; i < n-q; i++ {
+				if i&p == 0 {
+					a := x[i+p]
+					for r := q; r > p; r >>= 1 {
+						uint64MinMax(&a, &x[i+r])
+					}
+					x[i+p] = a
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package mceliece
+
+// Code to generate the NIST "PQCkemKAT" test vectors.
+// See PQCgenKAT_kem.c and rng.c in the reference implementation.
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/nist"
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/kem/mceliece/internal"
+	"github.com/cloudflare/circl/kem/schemes"
+)
+
+// The reference implementation calls randombytes once per attempt at
+// sampling the error vector.
+type drbgReader struct{ g *nist.DRBG }
+
+func (r drbgReader) Read(p []byte) (int, error) {
+	r.g.Fill(p)
+	return len(p), nil
+}
+
+func TestPQCgenKATKem(t *testing.T) {
+	kats := []struct {
+		p    *internal.Params
+		want string
+	}{
+		// TODO crossreference with the KAT files of the reference
+		// 		implementation, which are not vendored.
+		{internal.McEliece348864, "a2e439625d96e4ba072d8bc0ee6664686c30c750d0c2ace7be43c8551d456ed2"},
+		{internal.McEliece348864f, "280c422cea6650a6f4ae2c80f9ed794747f55f53c7a417b18b267284b41b8e7f"},
+		{internal.McEliece460896, "77ce8b9af9b1ddcaccd3e3dedc265f6b5b765f45e078bd3fa325d2a4c324ecc3"},
+		{internal.McEliece460896f, "6e658e8d12441fcdaa8d1492319307b7da1c1fe064db1f789e74993be6a9a070"},
+		{internal.McEliece6688128, "0b76676d2e98b15085f561a77ecdca07cdbc4b607fa474063d4edb4dffe7cb19"},
+		{internal.McEliece6688128f, "bca203fefff6483ce1e51cdb0c07b634cc63bd43b22560313257b0eb6871efa2"},
+		{internal.McEliece6960119, "b09c4c9869115ff043c9e251a631428222e68eda46cefc29ffd0b6e617bb9a6b"},
+		{internal.McEliece6960119f, "99bd2f27a03da2d60e6c80c60362662a9f6f7be653b6d993382510d7aa449a96"},
+		{internal.McEliece8192128, "8310b90d208ca1a9b521fecd307afeb1072b8713732c0c761b7d5d3481bd71a9"},
+		{internal.McEliece8192128f, "a2f0c2a6f6f29282cbdedc4257a58a0aca694d0dddb94fba8ffe18f223ba9ee2"},
+	}
+	for _, kat := range kats {
+		t.Run(kat.p.Name, func(t *testing.T) {
+			testPQCgenKATKem(t, kat.p, kat.want)
+		})
+	}
+}
+
+func testPQCgenKATKem(t *testing.T, p *internal.Params, expected string) {
+	scheme := schemes.ByName(p.Name)
+	if scheme == nil {
+		t.Fatal()
+	}
+	test.CheckOk(scheme.PublicKeySize() == p.PublicKeySize() &&
+		scheme.PrivateKeySize() == p.PrivateKeySize() &&
+		scheme.CiphertextSize() == p.CiphertextSize() &&
+		scheme.SeedSize() == internal.SeedSize,
+		"wrong sizes", t)
+
+	// Key generation is slow, so only the first vectors are computed.
+	const count = 3
+	var seed [48]byte
+	for i := 0; i < 48; i++ {
+		seed[i] = byte(i)
+	}
+	f := sha256.New()
+	g := nist.NewDRBG(&seed)
+	mustWrite(t, f, "# %s\n\n", p.Name)
+	for i := 0; i < count; i++ {
+		g.Fill(seed[:])
+		mustWrite(t, f, "count = %d\n", i)
+		mustWrite(t, f, "seed = %X\n", seed)
+
+		g2 := nist.NewDRBG(&seed)
+
+		kseed := make([]byte, internal.SeedSize)
+		g2.Fill(kseed)
+		pk := make([]byte, p.PublicKeySize())
+		sk := make([]byte, p.PrivateKeySize())
+		p.DeriveKey(pk, sk, kseed)
+
+		ct := make([]byte, p.CiphertextSize())
+		ss := make([]byte, internal.SharedKeySize)
+		err := p.Encapsulate(ct, ss, pk, drbgReader{&g2})
+		test.CheckNoErr(t, err, "encapsulation failed")
+
+		ss2 := make([]byte, internal.SharedKeySize)
+		test.CheckOk(p.Decapsulate(ss2, sk, ct), "decapsulation failed", t)
+		test.CheckOk(bytes.Equal(ss, ss2), "shared keys differ", t)
+
+		mustWrite(t, f, "pk = %X\n", pk)
+		mustWrite(t, f, "sk = %X\n", sk)
+		mustWrite(t, f, "ct = %X\n", ct)
+		mustWrite(t, f, "ss = %X\n\n", ss)
+	}
+	if fmt.Sprintf("%x", f.Sum(nil)) != expected {
+		t.Fatalf("%s %x %s", p.Name, f.Sum(nil), expected)
+	}
+}
+
+func mustWrite(t *testing.T, f io.Writer, format string, data any) {
+	_, err := fmt.Fprintf(f, format, data)
+	test.CheckNoErr(t, err, "fprintf failed")
+}
@@ -0,0 +1,62 @@
+package mceliece
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/kem"
+	"github.com/cloudflare/circl/kem/mceliece/mceliece348864"
+	"github.com/cloudflare/circl/kem/mceliece/mceliece6960119"
+)
+
+func TestReadPublicKey(t *testing.T) {
+	pk, sk, err := mceliece348864.GenerateKeyPair(nil)
+	test.CheckNoErr(t, err, "key generation failed")
+
+	var buf bytes.Buffer
+	n, err := pk.WriteTo(&buf)
+	test.CheckNoErr(t, err, "write failed")
+	test.CheckOk(n == mceliece348864.PublicKeySize, "wrong length", t)
+
+	// Trailing data is left in the reader.
+	buf.WriteString("trailer")
+	pk2, err := mceliece348864.ReadPublicKey(&buf)
+	test.CheckNoErr(t, err, "read failed")
+	test.CheckOk(pk.Equal(pk2), "public keys differ", t)
+	test.CheckOk(buf.String() == "trailer", "read too much", t)
+
+	ct, ss, err := mceliece348864.Scheme().Encapsulate(pk2)
+	test.CheckNoErr(t, err, "encapsulation failed")
+	ss2, err := mceliece348864.Scheme().Decapsulate(sk, ct)
+	test.CheckNoErr(t, err, "decapsulation failed")
+	test.CheckOk(bytes.Equal(ss, ss2), "shared keys differ", t)
+
+	packed, _ := pk.MarshalBinary()
+	_, err = mceliece348864.ReadPublicKey(bytes.NewReader(packed[:1000]))
+	test.CheckIsErr(t, err, "expected error for truncated public key")
+	test.CheckOk(err == io.ErrUnexpectedEOF, "wrong error", t)
+}
+
+func TestPadding(t *testing.T) {
+	// The rows of the public key and the ciphertext of mceliece6960119
+	// end with padding bits, which must be zero.
+	packed := make([]byte, mceliece6960119.PublicKeySize)
+	_, err := mceliece6960119.ReadPublicKey(bytes.NewReader(packed))
+	test.CheckNoErr(t, err, "read failed")
+
+	rowSize := len(packed) / (13 * 119)
+	packed[5*rowSize-1] |= 0x80
+	_, err = mceliece6960119.ReadPublicKey(bytes.NewReader(packed))
+	test.CheckOk(err == kem.ErrPubKey, "padding of public key not checked", t)
+	_, err = mceliece6960119.Scheme().UnmarshalBinaryPublicKey(packed)
+	test.CheckOk(err == kem.ErrPubKey, "padding of public key not checked", t)
+
+	var sk mceliece6960119.PrivateKey
+	sk.Unpack(make([]byte, mceliece6960119.PrivateKeySize))
+	ct := make([]byte, mceliece6960119.CiphertextSize)
+	ct[len(ct)-1] = 0x80
+	_, err = mceliece6960119.Scheme().Decapsulate(&sk, ct)
+	test.CheckOk(err == kem.ErrCipherText, "padding of ciphertext not checked", t)
+}
@@ -12,8 +12,16 @@
 //
 // Post-quantum kems:
 //
-//	FrodoKEM-640-SHAKE
+//	FrodoKEM-640-SHAKE, FrodoKEM-640-AES, FrodoKEM-976-SHAKE, FrodoKEM-976-AES,
+//	FrodoKEM-1344-SHAKE, FrodoKEM-1344-AES
+//	HQC-128, HQC-192, HQC-256
+//	mceliece348864, mceliece460896, mceliece6688128, mceliece6960119,
+//	mceliece8192128, and their "f" variants
 //	Kyber512, Kyber768, Kyber1024
+//
+// Hybrid kems used as TLS named groups, see ByTLSID:
+//
+//	X25519MLKEM768, SecP256r1MLKEM768, SecP384r1MLKEM1024
 package schemes
 
 import (
@@ -21,11 +29,29 @@ import (
 
 	"github.com/cloudflare/circl/hpke"
 	"github.com/cloudflare/circl/kem"
+	"github.com/cloudflare/circl/kem/frodo/frodo1344aes"
+	"github.com/cloudflare/circl/kem/frodo/frodo1344shake"
+	"github.com/cloudflare/circl/kem/frodo/frodo640aes"
 	"github.com/cloudflare/circl/kem/frodo/frodo640shake"
+	"github.com/cloudflare/circl/kem/frodo/frodo976aes"
+	"github.com/cloudflare/circl/kem/frodo/frodo976shake"
+	"github.com/cloudflare/circl/kem/hqc/hqc128"
+	"github.com/cloudflare/circl/kem/hqc/hqc192"
+	"github.com/cloudflare/circl/kem/hqc/hqc256"
 	"github.com/cloudflare/circl/kem/hybrid"
 	"github.com/cloudflare/circl/kem/kyber/kyber1024"
 	"github.com/cloudflare/circl/kem/kyber/kyber512"
 	"github.com/cloudflare/circl/kem/kyber/kyber768"
+	"github.com/cloudflare/circl/kem/mceliece/mceliece348864"
+	"github.com/cloudflare/circl/kem/mceliece/mceliece348864f"
+	"github.com/cloudflare/circl/kem/mceliece/mceliece460896"
+	"github.com/cloudflare/circl/kem/mceliece/mceliece460896f"
+	"github.com/cloudflare/circl/kem/mceliece/mceliece6688128"
+	"github.com/cloudflare/circl/kem/mceliece/mceliece6688128f"
+	"github.com/cloudflare/circl/kem/mceliece/mceliece6960119"
+	"github.com/cloudflare/circl/kem/mceliece/mceliece6960119f"
+	"github.com/cloudflare/circl/kem/mceliece/mceliece8192128"
+	"github.com/cloudflare/circl/kem/mceliece/mceliece8192128f"
 	"github.com/cloudflare/circl/kem/mlkem/mlkem1024"
 	"github.com/cloudflare/circl/kem/mlkem/mlkem512"
 	"github.com/cloudflare/circl/kem/mlkem/mlkem768"
@@ -39,6 +65,24 @@ var allSchemes = [...]kem.Scheme{
 	hpke.KEM_X25519_HKDF_SHA256.Scheme(),
 	hpke.KEM_X448_HKDF_SHA512.Scheme(),
 	frodo640shake.Scheme(),
+	frodo640aes.Scheme(),
+	frodo976shake.Scheme(),
+	frodo976aes.Scheme(),
+	frodo1344shake.Scheme(),
+	frodo1344aes.Scheme(),
+	hqc128.Scheme(),
+	hqc192.Scheme(),
+	hqc256.Scheme(),
+	mceliece348864.Scheme(),
+	mceliece348864f.Scheme(),
+	mceliece460896.Scheme(),
+	mceliece460896f.Scheme(),
+	mceliece6688128.Scheme(),
+	mceliece6688128f.Scheme(),
+	mceliece6960119.Scheme(),
+	mceliece6960119f.Scheme(),
+	mceliece8192128.Scheme(),
+	mceliece8192128f.Scheme(),
 	kyber512.Scheme(),
 	kyber768.Scheme(),
 	kyber1024.Scheme(),
@@ -51,18 +95,38 @@ var allSchemes = [...]kem.Scheme{
 	hybrid.Kyber1024X448(),
 	hybrid.P256Kyber768Draft00(),
 	hybrid.X25519MLKEM768(),
+	hybrid.SecP256r1MLKEM768(),
+	hybrid.SecP384r1MLKEM1024(),
 	xwing.Scheme(),
 }
 
-var allSchemeNames map[string]kem.Scheme
+var (
+	allSchemeNames  map[string]kem.Scheme
+	allSchemesByTLS map[uint]kem.Scheme
+)
 
 func init() {
 	allSchemeNames = make(map[string]kem.Scheme)
+	allSchemesByTLS = make(map[uint]kem.Scheme)
 	for _, scheme := range allSchemes {
 		allSchemeNames[strings.ToLower(scheme.Name())] = scheme
+		if tlsScheme, ok := scheme.(TLSScheme); ok && tlsScheme.TLSIdentifier() != 0 {
+			allSchemesByTLS[tlsScheme.TLSIdentifier()] = scheme
+		}
 	}
 }
 
+// Additional methods when the KEM is a TLS named group.
+type TLSScheme interface {
+	// Returns the code point of the named group, or zero if there is
+	// none.
+	TLSIdentifier() uint
+}
+
+// ByTLSID returns the scheme of the TLS named group with the given code
+// point and nil if it is not supported.
+func ByTLSID(id uint) kem.Scheme { return allSchemesByTLS[id] }
+
 // ByName returns the scheme with the given name and nil if it is not
 // supported.
 //
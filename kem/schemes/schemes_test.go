@@ -14,6 +14,25 @@ func TestCaseSensitivity(t *testing.T) {
 	}
 }
 
+func TestByTLSID(t *testing.T) {
+	for _, tc := range []struct {
+		id   uint
+		name string
+	}{
+		{0x11EB, "SecP256r1MLKEM768"},
+		{0x11EC, "X25519MLKEM768"},
+		{0x11ED, "SecP384r1MLKEM1024"},
+	} {
+		scheme := schemes.ByTLSID(tc.id)
+		if scheme == nil || scheme.Name() != tc.name {
+			t.Fatalf("wrong scheme for %#x", tc.id)
+		}
+	}
+	if schemes.ByTLSID(0) != nil {
+		t.Fatal()
+	}
+}
+
 func BenchmarkGenerateKeyPair(b *testing.B) {
 	allSchemes := schemes.All()
 	for _, scheme := range allSchemes {
@@ -179,6 +198,24 @@ func Example_schemes() {
 	// HPKE_KEM_X25519_HKDF_SHA256
 	// HPKE_KEM_X448_HKDF_SHA512
 	// FrodoKEM-640-SHAKE
+	// FrodoKEM-640-AES
+	// FrodoKEM-976-SHAKE
+	// FrodoKEM-976-AES
+	// FrodoKEM-1344-SHAKE
+	// FrodoKEM-1344-AES
+	// HQC-128
+	// HQC-192
+	// HQC-256
+	// mceliece348864
+	// mceliece348864f
+	// mceliece460896
+	// mceliece460896f
+	// mceliece6688128
+	// mceliece6688128f
+	// mceliece6960119
+	// mceliece6960119f
+	// mceliece8192128
+	// mceliece8192128f
 	// Kyber512
 	// Kyber768
 	// Kyber1024
@@ -191,5 +228,7 @@ func Example_schemes() {
 	// Kyber1024-X448
 	// P256Kyber768Draft00
 	// X25519MLKEM768
+	// SecP256r1MLKEM768
+	// SecP384r1MLKEM1024
 	// X-Wing
 }
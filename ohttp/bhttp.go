@@ -0,0 +1,567 @@
+package ohttp
+
+// Binary HTTP messages, see RFC 9292.
+//
+// Requests and responses are encoded in the known-length format, where the
+// header section, the content, and the trailer section are prefixed with
+// their length, or in the indeterminate-length format, where the content
+// is split in chunks and the field sections are terminated by a zero, so
+// they can be streamed.
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Framing indicators of Binary HTTP messages.
+const (
+	knownLengthRequest          = 0
+	knownLengthResponse         = 1
+	indeterminateLengthRequest  = 2
+	indeterminateLengthResponse = 3
+)
+
+// maxFieldSectionSize is the maximum size of the header and trailer
+// sections, and of the control data, accepted when decoding.
+const maxFieldSectionSize = 1 << 18
+
+// Size of the buffer used to read the content of streamed messages.
+const contentBufferSize = 16 << 10
+
+// hopByHopFields are connection-specific fields, which are not forwarded
+// in Binary HTTP messages.
+var hopByHopFields = map[string]bool{
+	"Connection":        true,
+	"Keep-Alive":        true,
+	"Proxy-Connection":  true,
+	"Te":                true,
+	"Transfer-Encoding": true,
+	"Upgrade":           true,
+}
+
+// MarshalRequest returns the known-length encoding of req. The body of
+// req is read to the end, but not closed.
+func MarshalRequest(req *http.Request) ([]byte, error) {
+	b := appendVarint(nil, knownLengthRequest)
+	b, err := appendRequestControlData(b, req)
+	if err != nil {
+		return nil, err
+	}
+	content, err := readBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	b = appendFieldSection(b, req.Header, true)
+	b = appendVarint(b, uint64(len(content)))
+	b = append(b, content...)
+	return appendFieldSection(b, req.Trailer, true), nil
+}
+
+// WriteRequest writes the indeterminate-length encoding of req to w. The
+// body of req is read to the end, but not closed, and each read is written
+// in its own call to w.Write.
+func WriteRequest(w io.Writer, req *http.Request) error {
+	b := appendVarint(nil, indeterminateLengthRequest)
+	b, err := appendRequestControlData(b, req)
+	if err != nil {
+		return err
+	}
+	b = appendFieldSection(b, req.Header, false)
+	if _, err = w.Write(b); err != nil {
+		return err
+	}
+	return writeContent(w, req.Body, func() http.Header { return req.Trailer })
+}
+
+// MarshalResponse returns the known-length encoding of resp. The body of
+// resp is read to the end, but not closed.
+func MarshalResponse(resp *http.Response) ([]byte, error) {
+	if !isFinalStatus(resp.StatusCode) {
+		return nil, ErrEncoding
+	}
+	content, err := readBody(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return appendKnownLengthResponse(nil, resp.StatusCode, resp.Header,
+		content, resp.Trailer), nil
+}
+
+// WriteResponse writes the indeterminate-length encoding of resp to w. The
+// body of resp is read to the end, but not closed, and each read is written
+// in its own call to w.Write.
+func WriteResponse(w io.Writer, resp *http.Response) error {
+	if !isFinalStatus(resp.StatusCode) {
+		return ErrEncoding
+	}
+	b := appendResponseHead(nil, resp.StatusCode, resp.Header)
+	if _, err := w.Write(b); err != nil {
+		return err
+	}
+	return writeContent(w, resp.Body, func() http.Header { return resp.Trailer })
+}
+
+func isFinalStatus(code int) bool { return 200 <= code && code <= 599 }
+
+func readBody(body io.Reader) ([]byte, error) {
+	if body == nil {
+		return nil, nil
+	}
+	return io.ReadAll(body)
+}
+
+func appendKnownLengthResponse(
+	b []byte, code int, header http.Header, content []byte, trailer http.Header,
+) []byte {
+	b = appendVarint(b, knownLengthResponse)
+	b = appendVarint(b, uint64(code))
+	b = appendFieldSection(b, header, true)
+	b = appendVarint(b, uint64(len(content)))
+	b = append(b, content...)
+	return appendFieldSection(b, trailer, true)
+}
+
+// appendResponseHead appends the framing indicator, the status code and
+// the header section of an indeterminate-length response.
+func appendResponseHead(b []byte, code int, header http.Header) []byte {
+	b = appendVarint(b, indeterminateLengthResponse)
+	b = appendVarint(b, uint64(code))
+	return appendFieldSection(b, header, false)
+}
+
+func appendRequestControlData(b []byte, req *http.Request) ([]byte, error) {
+	if req.URL == nil {
+		return nil, ErrEncoding
+	}
+	method := req.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	scheme := req.URL.Scheme
+	if scheme == "" {
+		scheme = "https"
+	}
+	authority := req.Host
+	if authority == "" {
+		authority = req.URL.Host
+	}
+	b = appendString(b, method)
+	b = appendString(b, scheme)
+	b = appendString(b, authority)
+	return appendString(b, req.URL.RequestURI()), nil
+}
+
+func appendString(b []byte, s string) []byte {
+	return append(appendVarint(b, uint64(len(s))), s...)
+}
+
+// appendFieldSection appends the fields of h, with lowercase names sorted
+// in lexicographic order.
+func appendFieldSection(b []byte, h http.Header, knownLength bool) []byte {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		if name != "" && !hopByHopFields[http.CanonicalHeaderKey(name)] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var lines []byte
+	for _, name := range names {
+		lower := strings.ToLower(name)
+		for _, value := range h[name] {
+			lines = appendString(lines, lower)
+			lines = appendString(lines, value)
+		}
+	}
+
+	if knownLength {
+		b = appendVarint(b, uint64(len(lines)))
+		return append(b, lines...)
+	}
+	return appendVarint(append(b, lines...), 0)
+}
+
+// writeContent writes the content read from body in indeterminate-length
+// chunks, followed by the trailer section, which is obtained once body is
+// read to the end.
+func writeContent(w io.Writer, body io.Reader, trailer func() http.Header) error {
+	if body != nil {
+		buf := make([]byte, contentBufferSize)
+		chunk := make([]byte, 0, 8+len(buf))
+		for {
+			n, err := body.Read(buf)
+			if n > 0 {
+				chunk = appendVarint(chunk[:0], uint64(n))
+				chunk = append(chunk, buf[:n]...)
+				if _, werr := w.Write(chunk); werr != nil {
+					return werr
+				}
+			}
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return err
+			}
+		}
+	}
+	b := appendVarint(nil, 0)
+	b = appendFieldSection(b, trailer(), false)
+	_, err := w.Write(b)
+	return err
+}
+
+// ReadRequest reads a request in the known-length or indeterminate-length
+// format from r. The body of the request is read from r on demand, and its
+// trailers are set once the body is read to the end.
+//
+// The request is suitable to be handled by an http.Handler: its URL is the
+// path of the request, its Host is the authority, and the scheme is
+// discarded.
+func ReadRequest(r io.Reader) (*http.Request, error) {
+	br := bufio.NewReader(r)
+	framing, err := readVarint(br)
+	if err != nil {
+		return nil, encodingError(err)
+	}
+	if framing != knownLengthRequest && framing != indeterminateLengthRequest {
+		return nil, ErrEncoding
+	}
+	known := framing == knownLengthRequest
+
+	var control [4]string
+	budget := maxFieldSectionSize
+	for i := range control {
+		if control[i], err = readLengthPrefixed(br, &budget); err != nil {
+			return nil, encodingError(err)
+		}
+	}
+	method, authority, path := control[0], control[2], control[3]
+	if !isToken(method) {
+		return nil, ErrEncoding
+	}
+	u, err := url.ParseRequestURI(path)
+	if err != nil {
+		return nil, ErrEncoding
+	}
+
+	header, err := readFieldSection(br, known)
+	truncated := err == io.EOF
+	if truncated {
+		header = http.Header{}
+	} else if err != nil {
+		return nil, encodingError(err)
+	}
+	if authority == "" {
+		authority = header.Get("Host")
+	}
+	header.Del("Host")
+
+	body, contentLength, err := newBodyReader(br, known, truncated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Request{
+		Method:        method,
+		URL:           u,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          body,
+		ContentLength: contentLength,
+		Host:          authority,
+		RequestURI:    path,
+		Trailer:       body.trailer,
+	}, nil
+}
+
+// ReadResponse reads a response in the known-length or indeterminate-length
+// format from r. Informational responses are skipped. The body of the
+// response is read from r on demand, and its trailers are set once the body
+// is read to the end. The Body of the response does not need to be closed.
+func ReadResponse(r io.Reader, req *http.Request) (*http.Response, error) {
+	br := bufio.NewReader(r)
+	framing, err := readVarint(br)
+	if err != nil {
+		return nil, encodingError(err)
+	}
+	if framing != knownLengthResponse && framing != indeterminateLengthResponse {
+		return nil, ErrEncoding
+	}
+	known := framing == knownLengthResponse
+
+	var (
+		code      uint64
+		header    http.Header
+		truncated bool
+	)
+	for code < 200 {
+		if code, err = readVarint(br); err != nil {
+			return nil, encodingError(err)
+		}
+		if code < 100 || code > 599 {
+			return nil, ErrEncoding
+		}
+		header, err = readFieldSection(br, known)
+		truncated = err == io.EOF
+		if truncated && code >= 200 {
+			header = http.Header{}
+		} else if err != nil {
+			return nil, encodingError(err)
+		}
+	}
+
+	body, contentLength, err := newBodyReader(br, known, truncated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:        strconv.Itoa(int(code)) + " " + http.StatusText(int(code)),
+		StatusCode:    int(code),
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          body,
+		ContentLength: contentLength,
+		Trailer:       body.trailer,
+		Request:       req,
+	}, nil
+}
+
+// encodingError converts the end of the input within a message into
+// ErrEncoding. Other errors, such as decryption errors of chunked
+// messages, are returned as is.
+func encodingError(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return ErrEncoding
+	}
+	return err
+}
+
+// isToken returns whether s is a non-empty token of RFC 9110.
+func isToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c <= ' ' || c >= 0x7f || strings.IndexByte(`"(),/:;<=>?@[\]{}`, c) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// byteStreamReader is implemented by *bufio.Reader and *bytes.Reader.
+type byteStreamReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// readLengthPrefixed reads a string prefixed with its length, which is
+// deducted from the budget.
+func readLengthPrefixed(r byteStreamReader, budget *int) (string, error) {
+	n, err := readVarint(r)
+	if err != nil {
+		return "", err
+	}
+	return readString(r, n, budget)
+}
+
+func readString(r byteStreamReader, n uint64, budget *int) (string, error) {
+	if n > uint64(*budget) {
+		return "", ErrEncoding
+	}
+	*budget -= int(n)
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// readFieldSection reads a header or trailer section. It returns io.EOF if
+// the message ends before the section.
+func readFieldSection(r *bufio.Reader, knownLength bool) (http.Header, error) {
+	n, err := readVarint(r)
+	if err != nil {
+		return nil, err
+	}
+
+	h := http.Header{}
+	budget := maxFieldSectionSize
+	if knownLength {
+		if n > maxFieldSectionSize {
+			return nil, ErrEncoding
+		}
+		lines := make([]byte, n)
+		if _, err = io.ReadFull(r, lines); err != nil {
+			return nil, encodingError(err)
+		}
+		lr := bytes.NewReader(lines)
+		for lr.Len() > 0 {
+			if err = readFieldLine(lr, h, &budget); err != nil {
+				return nil, encodingError(err)
+			}
+		}
+		return h, nil
+	}
+
+	for ; n != 0; n, err = readVarint(r) {
+		name, err := readString(r, n, &budget)
+		if err != nil {
+			return nil, encodingError(err)
+		}
+		value, err := readLengthPrefixed(r, &budget)
+		if err != nil {
+			return nil, encodingError(err)
+		}
+		h.Add(name, value)
+	}
+	if err != nil {
+		return nil, encodingError(err)
+	}
+	return h, nil
+}
+
+func readFieldLine(r byteStreamReader, h http.Header, budget *int) error {
+	name, err := readLengthPrefixed(r, budget)
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		return ErrEncoding
+	}
+	value, err := readLengthPrefixed(r, budget)
+	if err != nil {
+		return err
+	}
+	h.Add(name, value)
+	return nil
+}
+
+// bodyReader reads the content of a message, followed by its trailer
+// section and padding.
+type bodyReader struct {
+	r         *bufio.Reader
+	known     bool
+	remaining uint64
+	// lastChunk is set when the remaining bytes are the last ones of the
+	// content.
+	lastChunk bool
+	started   bool
+	trailer   http.Header
+	err       error
+}
+
+// newBodyReader returns the reader of the content of a message, and its
+// length, or -1 if unknown. If the message is truncated before the
+// content, the body is empty.
+func newBodyReader(r *bufio.Reader, known, truncated bool) (*bodyReader, int64, error) {
+	b := &bodyReader{r: r, known: known, trailer: http.Header{}}
+	if truncated {
+		b.err = io.EOF
+		return b, 0, nil
+	}
+	if !known {
+		return b, -1, nil
+	}
+
+	n, err := readVarint(r)
+	if err == io.EOF {
+		b.err = io.EOF
+		return b, 0, nil
+	} else if err != nil {
+		return nil, 0, encodingError(err)
+	}
+	b.remaining, b.lastChunk = n, true
+	return b, int64(n), nil
+}
+
+// Read reads the content of the message. It returns io.EOF once the
+// trailer section and the padding have been read as well.
+func (b *bodyReader) Read(p []byte) (int, error) {
+	for b.remaining == 0 && b.err == nil {
+		b.next()
+	}
+	if b.remaining == 0 {
+		return 0, b.err
+	}
+
+	if uint64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.r.Read(p)
+	b.remaining -= uint64(n)
+	if err != nil {
+		b.err = encodingError(err)
+		b.remaining = 0
+	}
+	if n == 0 {
+		return 0, b.err
+	}
+	return n, nil
+}
+
+// Close does nothing, the message is not read further.
+func (b *bodyReader) Close() error { return nil }
+
+// next reads the next chunk of the content, or the trailer section once
+// the content ends.
+func (b *bodyReader) next() {
+	if !b.lastChunk {
+		n, err := readVarint(b.r)
+		if err == io.EOF && !b.started {
+			// The message is truncated before the content.
+			b.err = io.EOF
+			return
+		} else if err != nil {
+			b.err = encodingError(err)
+			return
+		}
+		b.started = true
+		if n > 0 {
+			b.remaining = n
+			return
+		}
+		b.lastChunk = true
+	}
+
+	trailer, err := readFieldSection(b.r, b.known)
+	if err == io.EOF {
+		// The message is truncated before the trailer section.
+		b.err = io.EOF
+		return
+	} else if err != nil {
+		b.err = err
+		return
+	}
+	for name, values := range trailer {
+		b.trailer[name] = values
+	}
+	b.err = readPadding(b.r)
+}
+
+// readPadding reads the padding at the end of a message, which must be
+// zeros. It returns io.EOF on success.
+func readPadding(r io.ByteReader) error {
+	for {
+		c, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+		if c != 0 {
+			return ErrEncoding
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package ohttp
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+func TestBinaryRequest(t *testing.T) {
+	for _, known := range []bool{true, false} {
+		req, err := http.NewRequest(http.MethodPost,
+			"https://example.com/path?q=1", strings.NewReader("content"))
+		test.CheckNoErr(t, err, "request creation failed")
+		req.Header.Set("Content-Type", "text/plain")
+		req.Header.Add("X-Multi", "a")
+		req.Header.Add("X-Multi", "b")
+		req.Header.Set("Connection", "close")
+		req.Trailer = http.Header{"Checksum": {"1234"}}
+
+		var enc bytes.Buffer
+		if known {
+			b, err := MarshalRequest(req)
+			test.CheckNoErr(t, err, "marshal failed")
+			enc.Write(b)
+		} else {
+			test.CheckNoErr(t, WriteRequest(&enc, req), "write failed")
+		}
+
+		got, err := ReadRequest(&enc)
+		test.CheckNoErr(t, err, "read failed")
+		test.CheckOk(got.Method == http.MethodPost, "methods differ", t)
+		test.CheckOk(got.Host == "example.com", "authorities differ", t)
+		test.CheckOk(got.RequestURI == "/path?q=1", "paths differ", t)
+		test.CheckOk(got.URL.Query().Get("q") == "1", "queries differ", t)
+		test.CheckOk(got.Header.Get("Content-Type") == "text/plain", "headers differ", t)
+		test.CheckOk(len(got.Header.Values("X-Multi")) == 2, "headers differ", t)
+		test.CheckOk(got.Header.Get("Connection") == "", "hop-by-hop header forwarded", t)
+		test.CheckOk(known == (got.ContentLength == 7), "content lengths differ", t)
+
+		content, err := io.ReadAll(got.Body)
+		test.CheckNoErr(t, err, "read failed")
+		test.CheckOk(string(content) == "content", "contents differ", t)
+		test.CheckOk(got.Trailer.Get("Checksum") == "1234", "trailers differ", t)
+	}
+}
+
+func TestBinaryResponse(t *testing.T) {
+	for _, known := range []bool{true, false} {
+		resp := &http.Response{
+			StatusCode: http.StatusNotFound,
+			Header:     http.Header{"Content-Type": {"text/plain"}},
+			Body:       io.NopCloser(strings.NewReader("not found")),
+		}
+
+		var enc bytes.Buffer
+		if known {
+			b, err := MarshalResponse(resp)
+			test.CheckNoErr(t, err, "marshal failed")
+			enc.Write(b)
+		} else {
+			test.CheckNoErr(t, WriteResponse(&enc, resp), "write failed")
+		}
+		// Padding.
+		enc.Write(make([]byte, 10))
+
+		got, err := ReadResponse(&enc, nil)
+		test.CheckNoErr(t, err, "read failed")
+		test.CheckOk(got.StatusCode == http.StatusNotFound, "status codes differ", t)
+		test.CheckOk(got.Status == "404 Not Found", "statuses differ", t)
+		test.CheckOk(got.Header.Get("Content-Type") == "text/plain", "headers differ", t)
+		content, err := io.ReadAll(got.Body)
+		test.CheckNoErr(t, err, "read failed")
+		test.CheckOk(string(content) == "not found", "contents differ", t)
+	}
+
+	resp := &http.Response{StatusCode: http.StatusContinue}
+	_, err := MarshalResponse(resp)
+	test.CheckIsErr(t, err, "informational response accepted")
+}
+
+func TestBinaryVectors(t *testing.T) {
+	// Known-length request of Section 5.1 of RFC 9292, which has padding.
+	enc, err := hex.DecodeString("000347455405687474707300" +
+		"0a2f68656c6c6f2e747874406c0a757365722d6167656e7434637572" +
+		"6c2f372e31362e33206c69626375726c2f372e31362e33204f70656e" +
+		"53534c2f302e392e376c207a6c69622f312e322e3304686f73740f77" +
+		"77772e6578616d706c652e636f6d0f6163636570742d6c616e677561" +
+		"676506656e2c206d6900000000000000000000000000000000")
+	test.CheckNoErr(t, err, "invalid hex")
+
+	req, err := ReadRequest(bytes.NewReader(enc))
+	test.CheckNoErr(t, err, "read failed")
+	test.CheckOk(req.Method == http.MethodGet, "methods differ", t)
+	test.CheckOk(req.Host == "www.example.com", "hosts differ", t)
+	test.CheckOk(req.RequestURI == "/hello.txt", "paths differ", t)
+	test.CheckOk(req.Header.Get("Accept-Language") == "en, mi", "headers differ", t)
+	content, err := io.ReadAll(req.Body)
+	test.CheckNoErr(t, err, "read failed")
+	test.CheckOk(len(content) == 0, "content not empty", t)
+
+	// Indeterminate-length response truncated after the header section,
+	// preceded by an informational response.
+	enc = []byte{
+		3,
+		0x40, 102, 4, 'l', 'i', 'n', 'k', 2, '<', '>', 0,
+		0x40, 200, 4, 'd', 'a', 't', 'e', 1, '0', 0,
+	}
+	resp, err := ReadResponse(bytes.NewReader(enc), nil)
+	test.CheckNoErr(t, err, "read failed")
+	test.CheckOk(resp.StatusCode == http.StatusOK, "status codes differ", t)
+	test.CheckOk(resp.Header.Get("Link") == "", "informational response not skipped", t)
+	test.CheckOk(resp.Header.Get("Date") == "0", "headers differ", t)
+	content, err = io.ReadAll(resp.Body)
+	test.CheckNoErr(t, err, "read failed")
+	test.CheckOk(len(content) == 0, "content not empty", t)
+
+	// Truncation within a section, and non-zero padding.
+	_, err = ReadResponse(bytes.NewReader(enc[:len(enc)-3]), nil)
+	test.CheckOk(err == ErrEncoding, "truncated response accepted", t)
+	_, err = ReadRequest(bytes.NewReader(enc))
+	test.CheckOk(err == ErrEncoding, "response read as a request", t)
+	_, err = ReadRequest(bytes.NewReader([]byte{0}))
+	test.CheckOk(err == ErrEncoding, "truncated request accepted", t)
+}
+
+func TestBinaryPadding(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+	enc, err := MarshalResponse(resp)
+	test.CheckNoErr(t, err, "marshal failed")
+	got, err := ReadResponse(bytes.NewReader(append(enc, 0, 1)), nil)
+	test.CheckNoErr(t, err, "read failed")
+	_, err = io.ReadAll(got.Body)
+	test.CheckOk(err == ErrEncoding, "non-zero padding accepted", t)
+}
+
+func TestVarint(t *testing.T) {
+	for _, x := range []uint64{0, 63, 64, 16383, 16384, 1<<30 - 1, 1 << 30, maxVarint} {
+		enc := appendVarint(nil, x)
+		got, err := readVarint(bytes.NewReader(enc))
+		test.CheckNoErr(t, err, "read failed")
+		test.CheckOk(got == x, fmt.Sprintf("varints differ: got %v want %v", got, x), t)
+		_, err = readVarint(bytes.NewReader(enc[:len(enc)-1]))
+		test.CheckIsErr(t, err, "truncated varint accepted")
+	}
+	err := test.CheckPanic(func() { appendVarint(nil, maxVarint+1) })
+	test.CheckNoErr(t, err, "varint out of range accepted")
+}
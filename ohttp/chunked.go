@@ -0,0 +1,302 @@
+package ohttp
+
+// Chunked Oblivious HTTP messages, see draft-ietf-ohai-chunked-ohttp.
+//
+// After the header, the encapsulated key, or the response nonce, a message
+// is a sequence of chunks, where the last one is marked with a zero length
+// and extends to the end of the message:
+//
+//	Non-Final Chunk {
+//	  Length (i) = 1..,
+//	  Sealed Chunk (..),
+//	}
+//
+//	Final Chunk {
+//	  Final Chunk Indicator (i) = 0,
+//	  Sealed Chunk (..),
+//	}
+//
+// The final chunk is sealed with the associated data "final", and the other
+// chunks with empty associated data.
+
+import (
+	"bufio"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/cloudflare/circl/hpke"
+)
+
+// maxChunkSize is the maximum size of a sealed chunk accepted when reading
+// chunked messages. Larger writes are split in several chunks.
+const maxChunkSize = 1 << 20
+
+// Size of the AEAD tag of the supported AEADs.
+const tagSize = 16
+
+var finalAAD = []byte("final")
+
+var (
+	ErrTruncated = errors.New("ohttp: chunked message is truncated")
+	ErrClosed    = errors.New("ohttp: chunked message is closed")
+)
+
+// sealFunc seals a chunk with the associated data.
+type sealFunc func(chunk, aad []byte) ([]byte, error)
+
+// chunkWriter seals the data written to it in chunks.
+type chunkWriter struct {
+	w      io.Writer
+	seal   sealFunc
+	closed bool
+	err    error
+}
+
+// Write seals p in one chunk, or in several ones if it is large.
+func (w *chunkWriter) Write(p []byte) (n int, err error) {
+	if w.closed {
+		return 0, ErrClosed
+	}
+	for len(p) > 0 && w.err == nil {
+		k := min(len(p), maxChunkSize-tagSize)
+		w.writeChunk(p[:k], nil)
+		if w.err == nil {
+			n += k
+		}
+		p = p[k:]
+	}
+	return n, w.err
+}
+
+// Close writes an empty final chunk. It does not close the underlying
+// io.Writer.
+func (w *chunkWriter) Close() error {
+	if w.closed {
+		return w.err
+	}
+	if w.err == nil {
+		w.writeChunk(nil, finalAAD)
+	}
+	w.closed = true
+	return w.err
+}
+
+func (w *chunkWriter) writeChunk(chunk, aad []byte) {
+	ct, err := w.seal(chunk, aad)
+	if err != nil {
+		w.err = err
+		return
+	}
+	var buf []byte
+	if aad == nil {
+		buf = appendVarint(nil, uint64(len(ct)))
+	} else {
+		buf = appendVarint(nil, 0)
+	}
+	if _, err = w.w.Write(append(buf, ct...)); err != nil {
+		w.err = err
+	}
+}
+
+// openFunc opens a chunk with the associated data.
+type openFunc func(chunk, aad []byte) ([]byte, error)
+
+// chunkReader opens the chunks read from an underlying reader.
+type chunkReader struct {
+	r     *bufio.Reader
+	open  openFunc
+	buf   []byte
+	final bool
+	err   error
+}
+
+func newChunkReader(r io.Reader, open openFunc) *chunkReader {
+	return &chunkReader{r: bufio.NewReader(r), open: open}
+}
+
+// Read returns the content of the chunks. It returns io.EOF after the final
+// chunk, and ErrTruncated if the message ends before it.
+func (r *chunkReader) Read(p []byte) (n int, err error) {
+	for len(r.buf) == 0 && r.err == nil {
+		if r.final {
+			r.err = io.EOF
+		} else {
+			r.readChunk()
+		}
+	}
+	if len(r.buf) > 0 {
+		n = copy(p, r.buf)
+		r.buf = r.buf[n:]
+		return n, nil
+	}
+	return 0, r.err
+}
+
+func (r *chunkReader) readChunk() {
+	size, err := readLength(r.r, maxChunkSize)
+	if err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			err = ErrTruncated
+		}
+		r.err = err
+		return
+	}
+
+	var ct, aad []byte
+	if size == 0 {
+		// The final chunk extends to the end of the message.
+		ct, err = io.ReadAll(io.LimitReader(r.r, maxChunkSize+1))
+		if err == nil && len(ct) > maxChunkSize {
+			err = ErrEncoding
+		}
+		aad = finalAAD
+		r.final = true
+	} else {
+		ct = make([]byte, size)
+		_, err = io.ReadFull(r.r, ct)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			err = ErrTruncated
+		}
+	}
+	if err != nil {
+		r.err = err
+		return
+	}
+
+	r.buf, err = r.open(ct, aad)
+	if err != nil {
+		r.err = ErrDecryption
+	}
+}
+
+// EncapsulateChunkedRequest writes the header of a chunked request to w,
+// and returns a writer that encrypts the request, which is usually encoded
+// with Binary HTTP, and the context to decrypt the response.
+//
+// Each Write is sent in its own chunk, so that the gateway can process it
+// without waiting for the rest of the request; wrap the writer in a
+// bufio.Writer to coalesce small writes. Close must be called to end the
+// request, and does not close w.
+func (c *Client) EncapsulateChunkedRequest(w io.Writer) (
+	io.WriteCloser, *ChunkedRequestContext, error,
+) {
+	prefix, sealer, err := c.setup(chunkedRequestLabel)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err = w.Write(prefix); err != nil {
+		return nil, nil, err
+	}
+	enc := prefix[headerSize:]
+	return &chunkWriter{w: w, seal: sealer.Seal}, &ChunkedRequestContext{enc, sealer}, nil
+}
+
+// ChunkedRequestContext is the state of a client to decrypt the response
+// to a chunked request.
+type ChunkedRequestContext struct {
+	enc []byte
+	ctx hpke.Context
+}
+
+// DecapsulateResponse returns a reader of the decrypted response read
+// from r. The response nonce is read from r on the first call to Read.
+func (c *ChunkedRequestContext) DecapsulateResponse(r io.Reader) io.Reader {
+	return &lazyReader{init: func() (io.Reader, error) {
+		nonce := make([]byte, responseNonceSize(c.ctx.Suite()))
+		if _, err := io.ReadFull(r, nonce); err != nil {
+			return nil, ErrTruncated
+		}
+		aead, aeadNonce, err := responseAEAD(c.ctx, chunkedResponseLabel, c.enc, nonce)
+		if err != nil {
+			return nil, err
+		}
+		counter := chunkCounter{aead: aead, nonce: aeadNonce}
+		return newChunkReader(r, counter.open), nil
+	}}
+}
+
+// DecapsulateChunkedRequest reads the header of a chunked request from r,
+// and returns a reader of the decrypted request, and the context to
+// encrypt the response.
+func (g *Gateway) DecapsulateChunkedRequest(r io.Reader) (
+	io.Reader, *ChunkedResponseContext, error,
+) {
+	enc, opener, err := g.setup(chunkedRequestLabel, r)
+	if err != nil {
+		return nil, nil, err
+	}
+	return newChunkReader(r, opener.Open), &ChunkedResponseContext{enc, opener}, nil
+}
+
+// ChunkedResponseContext is the state of a gateway to encrypt the response
+// to a chunked request.
+type ChunkedResponseContext struct {
+	enc []byte
+	ctx hpke.Context
+}
+
+// EncapsulateResponse writes the response nonce to w, and returns a writer
+// that encrypts the response. As for requests, each Write is sent in its
+// own chunk, and Close must be called to end the response.
+func (c *ChunkedResponseContext) EncapsulateResponse(w io.Writer) (io.WriteCloser, error) {
+	nonce := make([]byte, responseNonceSize(c.ctx.Suite()))
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	aead, aeadNonce, err := responseAEAD(c.ctx, chunkedResponseLabel, c.enc, nonce)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(nonce); err != nil {
+		return nil, err
+	}
+	counter := chunkCounter{aead: aead, nonce: aeadNonce}
+	return &chunkWriter{w: w, seal: counter.seal}, nil
+}
+
+// chunkCounter seals or opens the chunks of a response, whose nonces are
+// the XOR of the AEAD nonce with the index of the chunk.
+type chunkCounter struct {
+	aead    cipher.AEAD
+	nonce   []byte
+	counter uint64
+}
+
+func (c *chunkCounter) next() []byte {
+	nonce := append([]byte{}, c.nonce...)
+	var ctr [8]byte
+	binary.BigEndian.PutUint64(ctr[:], c.counter)
+	for i := range ctr {
+		nonce[len(nonce)-8+i] ^= ctr[i]
+	}
+	c.counter++
+	return nonce
+}
+
+func (c *chunkCounter) seal(chunk, aad []byte) ([]byte, error) {
+	return c.aead.Seal(nil, c.next(), chunk, aad), nil
+}
+
+func (c *chunkCounter) open(chunk, aad []byte) ([]byte, error) {
+	return c.aead.Open(nil, c.next(), chunk, aad)
+}
+
+// lazyReader defers the initialization of a reader to its first use.
+type lazyReader struct {
+	init func() (io.Reader, error)
+	r    io.Reader
+	err  error
+}
+
+func (l *lazyReader) Read(p []byte) (int, error) {
+	if l.r == nil && l.err == nil {
+		l.r, l.err = l.init()
+	}
+	if l.err != nil {
+		return 0, l.err
+	}
+	return l.r.Read(p)
+}
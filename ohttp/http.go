@@ -0,0 +1,443 @@
+package ohttp
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// maxMessageSize is the maximum size of the encapsulated requests and
+// responses that are not chunked, which are processed in memory.
+const maxMessageSize = 16 << 20
+
+// keyProblemType is the problem type sent by a gateway that cannot find
+// the key configuration of a request, see Section 5.3 of RFC 9458.
+const keyProblemType = `{"type":"https://iana.org/assignments/http-problem-types#ohttp-key"}`
+
+// ErrUnexpectedResponse is returned by Transport when the relay responds
+// with an error, or with a media type other than the one of the request.
+var ErrUnexpectedResponse = errors.New("ohttp: unexpected response from relay")
+
+// Handler returns an http.Handler that decapsulates the requests sent to
+// the gateway, forwards them to next, and encapsulates the responses.
+//
+// Requests of the message/ohttp-req media type are answered once next
+// returns, whereas the responses to chunked requests are streamed: calls to
+// the Flush method of the http.Flusher given to next send the response
+// written so far to the client. As the status and header of the response
+// are only sent on the first write or flush, a handler that answers parts
+// of a request before reading the rest of it must flush first.
+func (g *Gateway) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		switch mediaType {
+		case RequestMediaType:
+			g.serve(w, r, next)
+		case ChunkedRequestMediaType:
+			g.serveChunked(w, r, next)
+		default:
+			http.Error(w, "unsupported media type", http.StatusUnsupportedMediaType)
+		}
+	})
+}
+
+// KeysHandler returns an http.Handler that serves the key configurations
+// of the gateway.
+func (g *Gateway) KeysHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		configs, err := g.KeyConfigs()
+		if err != nil {
+			http.Error(w, "invalid key configuration", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", KeysMediaType)
+		_, _ = w.Write(configs)
+	})
+}
+
+// decapsulationError replies to a request that cannot be decapsulated.
+func decapsulationError(w http.ResponseWriter, err error) {
+	if errors.Is(err, ErrUnknownKey) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = io.WriteString(w, keyProblemType)
+		return
+	}
+	http.Error(w, "invalid encapsulated request", http.StatusBadRequest)
+}
+
+func (g *Gateway) serve(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	encRequest, err := io.ReadAll(io.LimitReader(r.Body, maxMessageSize+1))
+	if err != nil || len(encRequest) > maxMessageSize {
+		http.Error(w, "invalid encapsulated request", http.StatusBadRequest)
+		return
+	}
+	request, respCtx, err := g.DecapsulateRequest(encRequest)
+	if err != nil {
+		decapsulationError(w, err)
+		return
+	}
+
+	// Errors in the encapsulated request are reported in the encapsulated
+	// response.
+	rec := &responseRecorder{header: http.Header{}}
+	if inner, err := ReadRequest(bytes.NewReader(request)); err != nil {
+		http.Error(rec, "invalid binary request", http.StatusBadRequest)
+	} else {
+		next.ServeHTTP(rec, inner.WithContext(r.Context()))
+	}
+
+	encResponse, err := respCtx.EncapsulateResponse(rec.marshal())
+	if err != nil {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", ResponseMediaType)
+	_, _ = w.Write(encResponse)
+}
+
+func (g *Gateway) serveChunked(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	request, respCtx, err := g.DecapsulateChunkedRequest(r.Body)
+	if err != nil {
+		decapsulationError(w, err)
+		return
+	}
+
+	// The response is sent while the request is being read.
+	rc := http.NewResponseController(w)
+	_ = rc.EnableFullDuplex()
+	w.Header().Set("Content-Type", ChunkedResponseMediaType)
+	w.WriteHeader(http.StatusOK)
+	cw, err := respCtx.EncapsulateResponse(flushWriter{w, rc})
+	if err != nil {
+		return
+	}
+
+	sw := newStreamWriter(cw)
+	if inner, err := ReadRequest(request); err != nil {
+		http.Error(sw, "invalid binary request", http.StatusBadRequest)
+	} else {
+		next.ServeHTTP(sw, inner.WithContext(r.Context()))
+	}
+	if sw.finish() == nil {
+		_ = cw.Close()
+	}
+}
+
+// flushWriter flushes the data written to an http.ResponseWriter.
+type flushWriter struct {
+	w  io.Writer
+	rc *http.ResponseController
+}
+
+func (f flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	if err == nil {
+		if ferr := f.rc.Flush(); !errors.Is(ferr, http.ErrNotSupported) {
+			err = ferr
+		}
+	}
+	return n, err
+}
+
+// declaredTrailers returns the names of the trailers declared in the
+// Trailer field of h.
+func declaredTrailers(h http.Header) []string {
+	var names []string
+	for _, v := range h.Values("Trailer") {
+		for _, name := range strings.Split(v, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, http.CanonicalHeaderKey(name))
+			}
+		}
+	}
+	return names
+}
+
+// headerFields returns the fields of h that are not trailers.
+func headerFields(h http.Header, declared []string) http.Header {
+	header := h.Clone()
+	header.Del("Trailer")
+	for _, name := range declared {
+		header.Del(name)
+	}
+	for name := range header {
+		if strings.HasPrefix(name, http.TrailerPrefix) {
+			delete(header, name)
+		}
+	}
+	return header
+}
+
+// trailerFields returns the trailers of h, which are the declared ones,
+// and those whose name starts with http.TrailerPrefix.
+func trailerFields(h http.Header, declared []string) http.Header {
+	trailer := http.Header{}
+	for _, name := range declared {
+		if values := h.Values(name); len(values) > 0 {
+			trailer[name] = values
+		}
+	}
+	for name, values := range h {
+		if after, ok := strings.CutPrefix(name, http.TrailerPrefix); ok {
+			trailer[http.CanonicalHeaderKey(after)] = values
+		}
+	}
+	return trailer
+}
+
+// responseRecorder is the http.ResponseWriter of a request that is not
+// chunked, which buffers the response. Informational responses are not
+// sent.
+type responseRecorder struct {
+	header      http.Header
+	sent        http.Header
+	declared    []string
+	code        int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header { return r.header }
+
+func (r *responseRecorder) WriteHeader(code int) {
+	if r.wroteHeader || code < 200 {
+		return
+	}
+	r.code = code
+	r.declared = declaredTrailers(r.header)
+	r.sent = headerFields(r.header, r.declared)
+	r.wroteHeader = true
+}
+
+func (r *responseRecorder) Write(p []byte) (int, error) {
+	r.WriteHeader(http.StatusOK)
+	return r.body.Write(p)
+}
+
+// marshal returns the known-length encoding of the response.
+func (r *responseRecorder) marshal() []byte {
+	r.WriteHeader(http.StatusOK)
+	code := r.code
+	if !isFinalStatus(code) {
+		code = http.StatusInternalServerError
+	}
+	return appendKnownLengthResponse(nil, code, r.sent, r.body.Bytes(),
+		trailerFields(r.header, r.declared))
+}
+
+// streamWriter is the http.ResponseWriter of a chunked request, which
+// encodes the response in the indeterminate-length format as it is
+// written. Informational responses are not sent.
+type streamWriter struct {
+	w           *bufio.Writer
+	header      http.Header
+	declared    []string
+	wroteHeader bool
+	err         error
+}
+
+func newStreamWriter(w io.Writer) *streamWriter {
+	return &streamWriter{
+		w:      bufio.NewWriterSize(w, contentBufferSize),
+		header: http.Header{},
+	}
+}
+
+func (s *streamWriter) Header() http.Header { return s.header }
+
+func (s *streamWriter) WriteHeader(code int) {
+	if s.wroteHeader || code < 200 {
+		return
+	}
+	if !isFinalStatus(code) {
+		code = http.StatusInternalServerError
+	}
+	s.declared = declaredTrailers(s.header)
+	s.wroteHeader = true
+	s.write(appendResponseHead(nil, code, headerFields(s.header, s.declared)))
+}
+
+func (s *streamWriter) Write(p []byte) (int, error) {
+	s.WriteHeader(http.StatusOK)
+	if len(p) > 0 {
+		s.write(appendVarint(nil, uint64(len(p))))
+		s.write(p)
+	}
+	if s.err != nil {
+		return 0, s.err
+	}
+	return len(p), nil
+}
+
+// Flush sends the response written so far.
+func (s *streamWriter) Flush() {
+	s.WriteHeader(http.StatusOK)
+	if s.err == nil {
+		s.err = s.w.Flush()
+	}
+}
+
+// finish writes the end of the content and the trailers.
+func (s *streamWriter) finish() error {
+	s.WriteHeader(http.StatusOK)
+	b := appendVarint(nil, 0)
+	s.write(appendFieldSection(b, trailerFields(s.header, s.declared), false))
+	s.Flush()
+	return s.err
+}
+
+func (s *streamWriter) write(p []byte) {
+	if s.err == nil {
+		_, s.err = s.w.Write(p)
+	}
+}
+
+// Transport is an http.RoundTripper that sends requests to a gateway
+// through an Oblivious HTTP relay.
+//
+// The requests are sent to the gateway of the key configuration, whatever
+// their URL is, as the relay forwards all the requests to its gateway.
+type Transport struct {
+	// RelayURL is the URL of the relay resource.
+	RelayURL string
+	// Config is the key configuration of the gateway.
+	Config KeyConfig
+	// Chunked selects chunked Oblivious HTTP, which streams the bodies of
+	// the requests and responses.
+	Chunked bool
+	// Base is used to send the requests to the relay. If nil,
+	// http.DefaultTransport is used.
+	Base http.RoundTripper
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	client, err := NewClient(t.Config)
+	if err != nil {
+		closeBody(req)
+		return nil, err
+	}
+	if t.Chunked {
+		return t.roundTripChunked(client, req)
+	}
+
+	request, err := MarshalRequest(req)
+	closeBody(req)
+	if err != nil {
+		return nil, err
+	}
+	encRequest, reqCtx, err := client.EncapsulateRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.post(req, RequestMediaType, bytes.NewReader(encRequest), ResponseMediaType)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	encResponse, err := io.ReadAll(io.LimitReader(resp.Body, maxMessageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(encResponse) > maxMessageSize {
+		return nil, ErrEncoding
+	}
+	response, err := reqCtx.DecapsulateResponse(encResponse)
+	if err != nil {
+		return nil, err
+	}
+	return ReadResponse(bytes.NewReader(response), req)
+}
+
+func (t *Transport) roundTripChunked(client *Client, req *http.Request) (*http.Response, error) {
+	type setup struct {
+		ctx *ChunkedRequestContext
+		err error
+	}
+	ch := make(chan setup, 1)
+	pr, pw := io.Pipe()
+	go func() {
+		defer closeBody(req)
+		w, ctx, err := client.EncapsulateChunkedRequest(pw)
+		ch <- setup{ctx, err}
+		if err == nil {
+			if err = WriteRequest(w, req); err == nil {
+				err = w.Close()
+			}
+		}
+		_ = pw.CloseWithError(err)
+	}()
+
+	resp, err := t.post(req, ChunkedRequestMediaType, pr, ChunkedResponseMediaType)
+	if err != nil {
+		_ = pr.CloseWithError(err)
+		return nil, err
+	}
+	// The gateway reads the header of the request before responding, so
+	// the context is available.
+	s := <-ch
+	if s.err != nil {
+		resp.Body.Close()
+		return nil, s.err
+	}
+
+	inner, err := ReadResponse(s.ctx.DecapsulateResponse(resp.Body), req)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	inner.Body = readCloser{inner.Body, resp.Body}
+	return inner, nil
+}
+
+// post sends an encapsulated request to the relay, and checks the media
+// type of the response.
+func (t *Transport) post(
+	req *http.Request, reqType string, body io.Reader, respType string,
+) (*http.Response, error) {
+	outer, err := http.NewRequestWithContext(req.Context(), http.MethodPost, t.RelayURL, body)
+	if err != nil {
+		return nil, err
+	}
+	outer.Header.Set("Content-Type", reqType)
+	resp, err := t.base().RoundTrip(outer)
+	if err != nil {
+		return nil, err
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if resp.StatusCode != http.StatusOK || mediaType != respType {
+		resp.Body.Close()
+		return nil, ErrUnexpectedResponse
+	}
+	return resp, nil
+}
+
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+}
+
+// readCloser reads from a reader and closes a closer.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,147 @@
+package ohttp
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/circl/hpke"
+	"github.com/cloudflare/circl/internal/test"
+)
+
+func testTarget() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /echo", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Trailer", "X-Length")
+		w.Header().Set("X-Host", r.Host)
+		w.WriteHeader(http.StatusCreated)
+		n, _ := io.Copy(w, r.Body)
+		w.Header().Set("X-Length", r.Trailer.Get("X-Sent")+"/"+strconv.FormatInt(n, 10))
+	})
+	// Echoes the lines of the request as they arrive.
+	mux.HandleFunc("POST /lines", func(w http.ResponseWriter, r *http.Request) {
+		w.(http.Flusher).Flush()
+		s := bufio.NewScanner(r.Body)
+		for s.Scan() {
+			_, _ = io.WriteString(w, strings.ToUpper(s.Text())+"\n")
+			w.(http.Flusher).Flush()
+		}
+	})
+	return mux
+}
+
+func newTestGateway(t *testing.T) (*Gateway, *httptest.Server) {
+	key, err := GenerateKeyConfig(3, hpke.KEM_XWING, testAlgorithms...)
+	test.CheckNoErr(t, err, "key generation failed")
+	gateway, err := NewGateway(key)
+	test.CheckNoErr(t, err, "gateway creation failed")
+	server := httptest.NewServer(gateway.Handler(testTarget()))
+	t.Cleanup(server.Close)
+	return gateway, server
+}
+
+func TestTransport(t *testing.T) {
+	gateway, server := newTestGateway(t)
+	keys := httptest.NewServer(gateway.KeysHandler())
+	defer keys.Close()
+
+	resp, err := http.Get(keys.URL)
+	test.CheckNoErr(t, err, "fetching keys failed")
+	enc, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	test.CheckNoErr(t, err, "fetching keys failed")
+	test.CheckOk(resp.Header.Get("Content-Type") == KeysMediaType, "wrong media type", t)
+	configs, err := UnmarshalKeyConfigs(enc)
+	test.CheckNoErr(t, err, "unmarshal failed")
+
+	for _, chunked := range []bool{false, true} {
+		client := &http.Client{Transport: &Transport{
+			RelayURL: server.URL,
+			Config:   configs[0],
+			Chunked:  chunked,
+		}}
+		req, err := http.NewRequest(http.MethodPost, "https://target.example/echo",
+			strings.NewReader("hello"))
+		test.CheckNoErr(t, err, "request creation failed")
+		req.Trailer = http.Header{"X-Sent": {"5"}}
+
+		resp, err := client.Do(req)
+		test.CheckNoErr(t, err, "round trip failed")
+		body, err := io.ReadAll(resp.Body)
+		test.CheckNoErr(t, err, "read failed")
+		test.CheckNoErr(t, resp.Body.Close(), "close failed")
+
+		test.CheckOk(resp.StatusCode == http.StatusCreated, "status codes differ", t)
+		test.CheckOk(string(body) == "hello", "bodies differ", t)
+		test.CheckOk(resp.Header.Get("X-Host") == "target.example", "hosts differ", t)
+		test.CheckOk(resp.Header.Get("X-Length") == "", "trailer sent in header", t)
+		test.CheckOk(resp.Trailer.Get("X-Length") == "5/5", "trailers differ", t)
+	}
+}
+
+func TestTransportStreaming(t *testing.T) {
+	gateway, server := newTestGateway(t)
+	client := &http.Client{Transport: &Transport{
+		RelayURL: server.URL,
+		Config:   gateway.configs[0],
+		Chunked:  true,
+	}}
+
+	pr, pw := io.Pipe()
+	req, err := http.NewRequest(http.MethodPost, "https://target.example/lines", pr)
+	test.CheckNoErr(t, err, "request creation failed")
+	resp, err := client.Do(req)
+	test.CheckNoErr(t, err, "round trip failed")
+	defer resp.Body.Close()
+
+	// Each line is answered before the next one is sent.
+	r := bufio.NewReader(resp.Body)
+	for _, line := range []string{"first", "second"} {
+		_, err = io.WriteString(pw, line+"\n")
+		test.CheckNoErr(t, err, "write failed")
+		got, err := r.ReadString('\n')
+		test.CheckNoErr(t, err, "read failed")
+		test.CheckOk(got == strings.ToUpper(line)+"\n", "responses differ", t)
+	}
+	test.CheckNoErr(t, pw.Close(), "close failed")
+	rest, err := io.ReadAll(r)
+	test.CheckNoErr(t, err, "read failed")
+	test.CheckOk(len(rest) == 0, "unexpected response", t)
+}
+
+func TestTransportErrors(t *testing.T) {
+	_, server := newTestGateway(t)
+
+	// A key configuration unknown to the gateway.
+	key, err := GenerateKeyConfig(4, hpke.KEM_X25519_HKDF_SHA256, testAlgorithms...)
+	test.CheckNoErr(t, err, "key generation failed")
+	for _, chunked := range []bool{false, true} {
+		client := &http.Client{Transport: &Transport{
+			RelayURL: server.URL,
+			Config:   key.Config(),
+			Chunked:  chunked,
+		}}
+		_, err = client.Post("https://target.example/echo", "text/plain", strings.NewReader("x"))
+		test.CheckIsErr(t, err, "unknown key accepted")
+	}
+
+	resp, err := http.Post(server.URL, RequestMediaType, strings.NewReader("garbage"))
+	test.CheckNoErr(t, err, "post failed")
+	resp.Body.Close()
+	test.CheckOk(resp.StatusCode == http.StatusUnprocessableEntity, "unknown key accepted", t)
+	test.CheckOk(resp.Header.Get("Content-Type") == "application/problem+json", "wrong media type", t)
+
+	resp, err = http.Post(server.URL, RequestMediaType, strings.NewReader("bad"))
+	test.CheckNoErr(t, err, "post failed")
+	resp.Body.Close()
+	test.CheckOk(resp.StatusCode == http.StatusBadRequest, "invalid request accepted", t)
+
+	resp, err = http.Post(server.URL, "text/plain", strings.NewReader("garbage"))
+	test.CheckNoErr(t, err, "post failed")
+	resp.Body.Close()
+	test.CheckOk(resp.StatusCode == http.StatusUnsupportedMediaType, "invalid media type accepted", t)
+}
@@ -0,0 +1,210 @@
+package ohttp
+
+import (
+	"github.com/cloudflare/circl/hpke"
+	"github.com/cloudflare/circl/kem"
+	"golang.org/x/crypto/cryptobyte"
+)
+
+// SymmetricAlgorithm is a pair of HPKE KDF and AEAD supported by a key
+// configuration.
+type SymmetricAlgorithm struct {
+	KDF  hpke.KDF
+	AEAD hpke.AEAD
+}
+
+// isValid returns whether the algorithms can be used with Oblivious HTTP,
+// which needs a two-stage KDF and an AEAD for encryption.
+func (a SymmetricAlgorithm) isValid() bool {
+	return a.KDF.IsValid() && !a.KDF.IsOneStage() &&
+		a.AEAD.IsValid() && a.AEAD != hpke.AEAD_ExportOnly
+}
+
+// KeyConfig is the public key configuration of a gateway, which lets clients
+// encapsulate requests to it.
+type KeyConfig struct {
+	KeyID      uint8
+	KEM        hpke.KEM
+	PublicKey  kem.PublicKey
+	Algorithms []SymmetricAlgorithm
+}
+
+// MarshalBinary returns the encoding of the key configuration.
+func (c *KeyConfig) MarshalBinary() ([]byte, error) {
+	if !c.KEM.IsValid() || c.PublicKey == nil || len(c.Algorithms) == 0 ||
+		len(c.Algorithms) > 0xFFFF/4 {
+		return nil, ErrInvalidKeyConfig
+	}
+	pk, err := c.PublicKey.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if len(pk) != c.KEM.Scheme().PublicKeySize() {
+		return nil, ErrInvalidKeyConfig
+	}
+
+	var b cryptobyte.Builder
+	b.AddUint8(c.KeyID)
+	b.AddUint16(uint16(c.KEM))
+	b.AddBytes(pk)
+	b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+		for _, a := range c.Algorithms {
+			b.AddUint16(uint16(a.KDF))
+			b.AddUint16(uint16(a.AEAD))
+		}
+	})
+
+	return b.Bytes()
+}
+
+// UnmarshalBinary recovers a key configuration from its encoding. The
+// unsupported algorithms are skipped.
+func (c *KeyConfig) UnmarshalBinary(data []byte) error {
+	s := cryptobyte.String(data)
+	if !c.read(&s) || !s.Empty() {
+		return ErrInvalidKeyConfig
+	}
+	return nil
+}
+
+func (c *KeyConfig) read(s *cryptobyte.String) bool {
+	var (
+		keyID       uint8
+		kemID       uint16
+		pk          []byte
+		algorithms  cryptobyte.String
+		supportedSA []SymmetricAlgorithm
+	)
+	if !s.ReadUint8(&keyID) || !s.ReadUint16(&kemID) ||
+		!hpke.KEM(kemID).IsValid() {
+		return false
+	}
+	scheme := hpke.KEM(kemID).Scheme()
+	if !s.ReadBytes(&pk, scheme.PublicKeySize()) ||
+		!s.ReadUint16LengthPrefixed(&algorithms) ||
+		len(algorithms) == 0 || len(algorithms)%4 != 0 {
+		return false
+	}
+	for !algorithms.Empty() {
+		var kdf, aead uint16
+		_ = algorithms.ReadUint16(&kdf) && algorithms.ReadUint16(&aead)
+		a := SymmetricAlgorithm{hpke.KDF(kdf), hpke.AEAD(aead)}
+		if a.isValid() {
+			supportedSA = append(supportedSA, a)
+		}
+	}
+	if len(supportedSA) == 0 {
+		return false
+	}
+	pub, err := scheme.UnmarshalBinaryPublicKey(pk)
+	if err != nil {
+		return false
+	}
+
+	c.KeyID = keyID
+	c.KEM = hpke.KEM(kemID)
+	c.PublicKey = pub
+	c.Algorithms = supportedSA
+	return true
+}
+
+// MarshalKeyConfigs returns the encoding of a list of key configurations,
+// as served with the application/ohttp-keys media type.
+func MarshalKeyConfigs(configs ...KeyConfig) ([]byte, error) {
+	var b cryptobyte.Builder
+	for i := range configs {
+		enc, err := configs[i].MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+			b.AddBytes(enc)
+		})
+	}
+
+	return b.Bytes()
+}
+
+// UnmarshalKeyConfigs recovers a list of key configurations from the
+// encoding served with the application/ohttp-keys media type. The
+// configurations of unsupported KEMs, or without supported algorithms, are
+// skipped.
+func UnmarshalKeyConfigs(data []byte) ([]KeyConfig, error) {
+	var configs []KeyConfig
+	s := cryptobyte.String(data)
+	for !s.Empty() {
+		var enc cryptobyte.String
+		if !s.ReadUint16LengthPrefixed(&enc) {
+			return nil, ErrInvalidKeyConfig
+		}
+		var c KeyConfig
+		if c.read(&enc) && enc.Empty() {
+			configs = append(configs, c)
+		}
+	}
+	if len(configs) == 0 {
+		return nil, ErrInvalidKeyConfig
+	}
+
+	return configs, nil
+}
+
+// PrivateKeyConfig is a key configuration of a gateway together with its
+// private key.
+type PrivateKeyConfig struct {
+	config KeyConfig
+	sk     kem.PrivateKey
+}
+
+// NewPrivateKeyConfig returns the key configuration with the given key
+// identifier and algorithms for the private key sk of the KEM.
+func NewPrivateKeyConfig(
+	keyID uint8, kemID hpke.KEM, sk kem.PrivateKey, algorithms ...SymmetricAlgorithm,
+) (*PrivateKeyConfig, error) {
+	if !kemID.IsValid() || sk == nil || len(algorithms) == 0 {
+		return nil, ErrInvalidKeyConfig
+	}
+	for _, a := range algorithms {
+		if !a.isValid() {
+			return nil, ErrInvalidKeyConfig
+		}
+	}
+	c := &PrivateKeyConfig{
+		config: KeyConfig{
+			KeyID:      keyID,
+			KEM:        kemID,
+			PublicKey:  sk.Public(),
+			Algorithms: append([]SymmetricAlgorithm{}, algorithms...),
+		},
+		sk: sk,
+	}
+	// Checks that the key is of the KEM.
+	if _, err := c.config.MarshalBinary(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// GenerateKeyConfig returns a key configuration with the given key
+// identifier and algorithms for a fresh key pair of the KEM.
+func GenerateKeyConfig(
+	keyID uint8, kemID hpke.KEM, algorithms ...SymmetricAlgorithm,
+) (*PrivateKeyConfig, error) {
+	if !kemID.IsValid() {
+		return nil, ErrInvalidKeyConfig
+	}
+	_, sk, err := kemID.Scheme().GenerateKeyPair()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPrivateKeyConfig(keyID, kemID, sk, algorithms...)
+}
+
+// Config returns the public key configuration.
+func (c *PrivateKeyConfig) Config() KeyConfig {
+	config := c.config
+	config.Algorithms = append([]SymmetricAlgorithm{}, c.config.Algorithms...)
+	return config
+}
@@ -0,0 +1,311 @@
+// Package ohttp implements Oblivious HTTP.
+//
+// Oblivious HTTP lets a client send HTTP requests to a gateway through a
+// relay, such that the relay learns who the client is but not the content
+// of the requests, and the gateway learns the content of the requests but
+// not who the client is. Requests and responses are encrypted with HPKE
+// to a key configuration of the gateway, and the HTTP messages are encoded
+// with Binary HTTP.
+//
+// This package implements the key configuration format, and the
+// encapsulation of requests and responses of RFC 9458 [1], the chunked
+// variant of draft-ietf-ohai-chunked-ohttp [2] that allows streaming the
+// messages, and the known-length and indeterminate-length message formats
+// of Binary HTTP in RFC 9292 [3]. Any KEM of the hpke package can be used,
+// including the post-quantum hpke.KEM_XWING.
+//
+// The Client and Gateway types encapsulate byte strings, and Transport and
+// Gateway.Handler plug them into net/http.
+//
+// # References
+//
+// [1] RFC 9458: https://www.rfc-editor.org/info/rfc9458
+//
+// [2] draft-ietf-ohai-chunked-ohttp: https://datatracker.ietf.org/doc/draft-ietf-ohai-chunked-ohttp/
+//
+// [3] RFC 9292: https://www.rfc-editor.org/info/rfc9292
+package ohttp
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"io"
+
+	"github.com/cloudflare/circl/hpke"
+	"golang.org/x/crypto/cryptobyte"
+)
+
+// Media types of Oblivious HTTP messages.
+const (
+	KeysMediaType            = "application/ohttp-keys"
+	RequestMediaType         = "message/ohttp-req"
+	ResponseMediaType        = "message/ohttp-res"
+	ChunkedRequestMediaType  = "message/ohttp-chunked-req"
+	ChunkedResponseMediaType = "message/ohttp-chunked-res"
+)
+
+// Labels used in the derivation of keys.
+const (
+	requestLabel         = "message/bhttp request"
+	responseLabel        = "message/bhttp response"
+	chunkedRequestLabel  = "message/bhttp chunked request"
+	chunkedResponseLabel = "message/bhttp chunked response"
+)
+
+// Size of the header of an encapsulated request.
+const headerSize = 7
+
+var (
+	ErrInvalidKeyConfig = errors.New("ohttp: invalid key configuration")
+	ErrUnknownKey       = errors.New("ohttp: unknown key configuration")
+	ErrEncoding         = errors.New("ohttp: invalid encoding")
+	ErrDecryption       = errors.New("ohttp: decryption failed")
+)
+
+// Client encapsulates requests to a gateway.
+type Client struct {
+	config KeyConfig
+	alg    SymmetricAlgorithm
+}
+
+// NewClient returns a client for the key configuration of a gateway. The
+// first algorithms of the configuration are used.
+func NewClient(config KeyConfig) (*Client, error) {
+	if _, err := config.MarshalBinary(); err != nil {
+		return nil, err
+	}
+	for _, a := range config.Algorithms {
+		if a.isValid() {
+			return &Client{config, a}, nil
+		}
+	}
+	return nil, ErrInvalidKeyConfig
+}
+
+func (c *Client) suite() hpke.Suite {
+	return hpke.NewSuite(c.config.KEM, c.alg.KDF, c.alg.AEAD)
+}
+
+// header returns the header of the requests, which is the prefix of the
+// HPKE info string.
+func (c *Client) header() []byte {
+	var b cryptobyte.Builder
+	b.AddUint8(c.config.KeyID)
+	b.AddUint16(uint16(c.config.KEM))
+	b.AddUint16(uint16(c.alg.KDF))
+	b.AddUint16(uint16(c.alg.AEAD))
+	return b.BytesOrPanic()
+}
+
+// setup returns the prefix of the encapsulated request, and the HPKE
+// context to seal it.
+func (c *Client) setup(label string) ([]byte, hpke.Sealer, error) {
+	hdr := c.header()
+	sender, err := c.suite().NewSender(c.config.PublicKey, info(label, hdr))
+	if err != nil {
+		return nil, nil, err
+	}
+	enc, sealer, err := sender.Setup(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	return append(hdr, enc...), sealer, nil
+}
+
+// EncapsulateRequest encrypts the request, which is usually encoded with
+// Binary HTTP, and returns the context to decrypt the response.
+func (c *Client) EncapsulateRequest(request []byte) ([]byte, *RequestContext, error) {
+	encRequest, sealer, err := c.setup(requestLabel)
+	if err != nil {
+		return nil, nil, err
+	}
+	ct, err := sealer.Seal(request, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	enc := encRequest[headerSize:]
+	return append(encRequest, ct...), &RequestContext{enc, sealer}, nil
+}
+
+// RequestContext is the state of a client to decrypt the response to a
+// request.
+type RequestContext struct {
+	enc []byte
+	ctx hpke.Context
+}
+
+// DecapsulateResponse decrypts the response to the request.
+func (r *RequestContext) DecapsulateResponse(encResponse []byte) ([]byte, error) {
+	nonceSize := responseNonceSize(r.ctx.Suite())
+	if len(encResponse) < nonceSize {
+		return nil, ErrEncoding
+	}
+	aead, nonce, err := responseAEAD(r.ctx, responseLabel, r.enc, encResponse[:nonceSize])
+	if err != nil {
+		return nil, err
+	}
+	response, err := aead.Open(nil, nonce, encResponse[nonceSize:], nil)
+	if err != nil {
+		return nil, ErrDecryption
+	}
+	return response, nil
+}
+
+// Gateway decapsulates requests encrypted to its key configurations.
+type Gateway struct {
+	keys    map[uint8]*PrivateKeyConfig
+	configs []KeyConfig
+}
+
+// NewGateway returns a gateway for the given private key configurations,
+// which must have distinct key identifiers.
+func NewGateway(keys ...*PrivateKeyConfig) (*Gateway, error) {
+	if len(keys) == 0 {
+		return nil, ErrInvalidKeyConfig
+	}
+	g := &Gateway{keys: make(map[uint8]*PrivateKeyConfig)}
+	for _, k := range keys {
+		if _, ok := g.keys[k.config.KeyID]; ok {
+			return nil, ErrInvalidKeyConfig
+		}
+		g.keys[k.config.KeyID] = k
+		g.configs = append(g.configs, k.Config())
+	}
+	return g, nil
+}
+
+// KeyConfigs returns the encoding of the key configurations of the gateway,
+// to be served with the application/ohttp-keys media type.
+func (g *Gateway) KeyConfigs() ([]byte, error) {
+	return MarshalKeyConfigs(g.configs...)
+}
+
+// setup parses the header of an encapsulated request and the encapsulated
+// key from r, and returns the HPKE context to open it.
+func (g *Gateway) setup(label string, r io.Reader) ([]byte, hpke.Opener, error) {
+	var hdr [headerSize]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return nil, nil, ErrEncoding
+	}
+	var (
+		keyID     uint8
+		kemID     uint16
+		kdf, aead uint16
+	)
+	s := cryptobyte.String(hdr[:])
+	_ = s.ReadUint8(&keyID) && s.ReadUint16(&kemID) &&
+		s.ReadUint16(&kdf) && s.ReadUint16(&aead)
+
+	key, ok := g.keys[keyID]
+	if !ok || key.config.KEM != hpke.KEM(kemID) {
+		return nil, nil, ErrUnknownKey
+	}
+	alg := SymmetricAlgorithm{hpke.KDF(kdf), hpke.AEAD(aead)}
+	supported := false
+	for _, a := range key.config.Algorithms {
+		supported = supported || a == alg
+	}
+	if !supported {
+		return nil, nil, ErrUnknownKey
+	}
+
+	enc := make([]byte, key.config.KEM.Scheme().CiphertextSize())
+	if _, err := io.ReadFull(r, enc); err != nil {
+		return nil, nil, ErrEncoding
+	}
+	suite := hpke.NewSuite(key.config.KEM, alg.KDF, alg.AEAD)
+	receiver, err := suite.NewReceiver(key.sk, info(label, hdr[:]))
+	if err != nil {
+		return nil, nil, err
+	}
+	opener, err := receiver.Setup(enc)
+	if err != nil {
+		return nil, nil, ErrDecryption
+	}
+	return enc, opener, nil
+}
+
+// DecapsulateRequest decrypts an encapsulated request, and returns the
+// context to encrypt the response.
+func (g *Gateway) DecapsulateRequest(encRequest []byte) ([]byte, *ResponseContext, error) {
+	r := &byteReader{encRequest}
+	enc, opener, err := g.setup(requestLabel, r)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := opener.Open(r.b, nil)
+	if err != nil {
+		return nil, nil, ErrDecryption
+	}
+	return request, &ResponseContext{enc, opener}, nil
+}
+
+// ResponseContext is the state of a gateway to encrypt the response to a
+// request.
+type ResponseContext struct {
+	enc []byte
+	ctx hpke.Context
+}
+
+// EncapsulateResponse encrypts the response to the request.
+func (r *ResponseContext) EncapsulateResponse(response []byte) ([]byte, error) {
+	return r.encapsulateResponse(rand.Reader, response)
+}
+
+// encapsulateResponse encrypts the response with a response nonce read
+// from rnd.
+func (r *ResponseContext) encapsulateResponse(rnd io.Reader, response []byte) ([]byte, error) {
+	nonce := make([]byte, responseNonceSize(r.ctx.Suite()))
+	if _, err := io.ReadFull(rnd, nonce); err != nil {
+		return nil, err
+	}
+	aead, aeadNonce, err := responseAEAD(r.ctx, responseLabel, r.enc, nonce)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, aeadNonce, response, nil), nil
+}
+
+// info returns the HPKE info string of the requests with the header hdr.
+func info(label string, hdr []byte) []byte {
+	return append(append([]byte(label), 0), hdr...)
+}
+
+// responseNonceSize returns max(Nn, Nk) for the AEAD of the suite.
+func responseNonceSize(suite hpke.Suite) int {
+	_, _, aead := suite.Params()
+	return int(max(aead.KeySize(), aead.NonceSize()))
+}
+
+// responseAEAD returns the AEAD and the nonce used to encrypt the response
+// from the context of the request, the encapsulated key, and the response
+// nonce.
+func responseAEAD(ctx hpke.Context, label string, enc, responseNonce []byte) (
+	cipher.AEAD, []byte, error,
+) {
+	_, kdf, aead := ctx.Suite().Params()
+	secret := ctx.Export([]byte(label), uint(len(responseNonce)))
+	salt := append(append([]byte{}, enc...), responseNonce...)
+	prk := kdf.Extract(secret, salt)
+	key := kdf.Expand(prk, []byte("key"), aead.KeySize())
+	nonce := kdf.Expand(prk, []byte("nonce"), aead.NonceSize())
+	a, err := aead.New(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return a, nonce, nil
+}
+
+// byteReader is an io.Reader over a byte slice, which keeps the unread
+// bytes accessible.
+type byteReader struct{ b []byte }
+
+func (r *byteReader) Read(p []byte) (int, error) {
+	if len(r.b) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.b)
+	r.b = r.b[n:]
+	return n, nil
+}
@@ -0,0 +1,241 @@
+package ohttp
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/cloudflare/circl/hpke"
+	"github.com/cloudflare/circl/internal/test"
+)
+
+var testAlgorithms = []SymmetricAlgorithm{
+	{hpke.KDF_HKDF_SHA256, hpke.AEAD_AES128GCM},
+	{hpke.KDF_HKDF_SHA384, hpke.AEAD_ChaCha20Poly1305},
+}
+
+var testKEMs = []hpke.KEM{
+	hpke.KEM_X25519_HKDF_SHA256,
+	hpke.KEM_P256_HKDF_SHA256,
+	hpke.KEM_XWING,
+}
+
+func TestKeyConfig(t *testing.T) {
+	var configs []KeyConfig
+	for i, kemID := range testKEMs {
+		key, err := GenerateKeyConfig(uint8(i), kemID, testAlgorithms...)
+		test.CheckNoErr(t, err, "key generation failed")
+		config := key.Config()
+		configs = append(configs, config)
+
+		enc, err := config.MarshalBinary()
+		test.CheckNoErr(t, err, "marshal failed")
+		var got KeyConfig
+		test.CheckNoErr(t, got.UnmarshalBinary(enc), "unmarshal failed")
+		got2, err := got.MarshalBinary()
+		test.CheckNoErr(t, err, "marshal failed")
+		test.CheckOk(bytes.Equal(enc, got2), "key configurations differ", t)
+
+		test.CheckIsErr(t, got.UnmarshalBinary(enc[:len(enc)-1]), "truncated configuration accepted")
+	}
+
+	list, err := MarshalKeyConfigs(configs...)
+	test.CheckNoErr(t, err, "marshal failed")
+	// Configurations with an unknown KEM, and with unsupported algorithms
+	// only, are skipped.
+	unknownKEM := []byte{0, 5, 9, 0xFF, 0xFF, 1, 2}
+	enc, err := configs[0].MarshalBinary()
+	test.CheckNoErr(t, err, "marshal failed")
+	algsStart := len(enc) - 2 - 4*len(testAlgorithms)
+	unsupportedAlgs := append(enc[:algsStart:algsStart], 0, 4, 0, 1, 0xFF, 0xFF)
+	list = append(list, 0, byte(len(unknownKEM)))
+	list = append(list, unknownKEM...)
+	list = append(list, 0, byte(len(unsupportedAlgs)))
+	list = append(list, unsupportedAlgs...)
+
+	got, err := UnmarshalKeyConfigs(list)
+	test.CheckNoErr(t, err, "unmarshal failed")
+	test.CheckOk(len(got) == len(configs), "unsupported configurations not skipped", t)
+	for i := range got {
+		test.CheckOk(got[i].KEM == configs[i].KEM && got[i].KeyID == configs[i].KeyID,
+			"key configurations differ", t)
+	}
+
+	_, err = UnmarshalKeyConfigs(list[:len(list)-1])
+	test.CheckIsErr(t, err, "truncated list accepted")
+	_, err = GenerateKeyConfig(0, hpke.KEM_X25519_HKDF_SHA256,
+		SymmetricAlgorithm{hpke.KDF_HKDF_SHA256, hpke.AEAD_ExportOnly})
+	test.CheckIsErr(t, err, "export-only AEAD accepted")
+}
+
+func TestEncapsulation(t *testing.T) {
+	for i, kemID := range testKEMs {
+		t.Run(fmt.Sprint(kemID), func(t *testing.T) {
+			key, err := GenerateKeyConfig(uint8(i), kemID, testAlgorithms...)
+			test.CheckNoErr(t, err, "key generation failed")
+			gateway, err := NewGateway(key)
+			test.CheckNoErr(t, err, "gateway creation failed")
+			client, err := NewClient(key.Config())
+			test.CheckNoErr(t, err, "client creation failed")
+
+			request := []byte("request")
+			encRequest, reqCtx, err := client.EncapsulateRequest(request)
+			test.CheckNoErr(t, err, "encapsulation failed")
+			got, respCtx, err := gateway.DecapsulateRequest(encRequest)
+			test.CheckNoErr(t, err, "decapsulation failed")
+			test.CheckOk(bytes.Equal(got, request), "requests differ", t)
+
+			response := []byte("response")
+			encResponse, err := respCtx.EncapsulateResponse(response)
+			test.CheckNoErr(t, err, "encapsulation failed")
+			got, err = reqCtx.DecapsulateResponse(encResponse)
+			test.CheckNoErr(t, err, "decapsulation failed")
+			test.CheckOk(bytes.Equal(got, response), "responses differ", t)
+
+			forged := bytes.Clone(encRequest)
+			forged[0]++
+			_, _, err = gateway.DecapsulateRequest(forged)
+			test.CheckOk(err == ErrUnknownKey, "unknown key accepted", t)
+			forged = bytes.Clone(encRequest)
+			forged[len(forged)-1] ^= 1
+			_, _, err = gateway.DecapsulateRequest(forged)
+			test.CheckOk(err == ErrDecryption, "forged request accepted", t)
+			_, _, err = gateway.DecapsulateRequest(encRequest[:headerSize+1])
+			test.CheckOk(err == ErrEncoding, "truncated request accepted", t)
+
+			forged = bytes.Clone(encResponse)
+			forged[0] ^= 1
+			_, err = reqCtx.DecapsulateResponse(forged)
+			test.CheckOk(err == ErrDecryption, "forged response accepted", t)
+			_, err = reqCtx.DecapsulateResponse(encResponse[:3])
+			test.CheckOk(err == ErrEncoding, "truncated response accepted", t)
+		})
+	}
+}
+
+func TestChunked(t *testing.T) {
+	key, err := GenerateKeyConfig(7, hpke.KEM_XWING, testAlgorithms[1])
+	test.CheckNoErr(t, err, "key generation failed")
+	gateway, err := NewGateway(key)
+	test.CheckNoErr(t, err, "gateway creation failed")
+	client, err := NewClient(key.Config())
+	test.CheckNoErr(t, err, "client creation failed")
+
+	var encRequest bytes.Buffer
+	w, reqCtx, err := client.EncapsulateChunkedRequest(&encRequest)
+	test.CheckNoErr(t, err, "encapsulation failed")
+	request := []string{"first chunk", "", "second chunk", string(make([]byte, maxChunkSize))}
+	var sizes []int
+	for _, chunk := range request {
+		_, err = io.WriteString(w, chunk)
+		test.CheckNoErr(t, err, "write failed")
+		sizes = append(sizes, encRequest.Len())
+	}
+	test.CheckNoErr(t, w.Close(), "close failed")
+	_, err = w.Write([]byte{0})
+	test.CheckOk(err == ErrClosed, "write after close accepted", t)
+
+	r, respCtx, err := gateway.DecapsulateChunkedRequest(bytes.NewReader(encRequest.Bytes()))
+	test.CheckNoErr(t, err, "decapsulation failed")
+	got, err := io.ReadAll(r)
+	test.CheckNoErr(t, err, "read failed")
+	var want string
+	for _, chunk := range request {
+		want += chunk
+	}
+	test.CheckOk(string(got) == want, "requests differ", t)
+
+	// Truncation at a chunk boundary, and within a chunk.
+	for _, size := range []int{sizes[0], sizes[2] - 3} {
+		r, _, err = gateway.DecapsulateChunkedRequest(bytes.NewReader(encRequest.Bytes()[:size]))
+		test.CheckNoErr(t, err, "decapsulation failed")
+		_, err = io.ReadAll(r)
+		test.CheckOk(err == ErrTruncated, "truncated request accepted", t)
+	}
+
+	var encResponse bytes.Buffer
+	rw, err := respCtx.EncapsulateResponse(&encResponse)
+	test.CheckNoErr(t, err, "encapsulation failed")
+	_, err = io.WriteString(rw, "response ")
+	test.CheckNoErr(t, err, "write failed")
+	_, err = io.WriteString(rw, "in chunks")
+	test.CheckNoErr(t, err, "write failed")
+	test.CheckNoErr(t, rw.Close(), "close failed")
+
+	got, err = io.ReadAll(reqCtx.DecapsulateResponse(bytes.NewReader(encResponse.Bytes())))
+	test.CheckNoErr(t, err, "read failed")
+	test.CheckOk(string(got) == "response in chunks", "responses differ", t)
+
+	// The final chunk must be authenticated as such.
+	forged := bytes.Clone(encResponse.Bytes())
+	forged[responseNonceSize(reqCtx.ctx.Suite())] = 0
+	_, err = io.ReadAll(reqCtx.DecapsulateResponse(bytes.NewReader(forged)))
+	test.CheckOk(err == ErrDecryption, "forged final chunk accepted", t)
+	_, err = io.ReadAll(reqCtx.DecapsulateResponse(bytes.NewReader(encResponse.Bytes()[:20])))
+	test.CheckOk(err == ErrTruncated, "truncated response accepted", t)
+}
+
+// TestRFC9458 checks the example of Appendix A of RFC 9458. The client
+// side of the request is not checked, as the ephemeral key of the example
+// cannot be injected in the hpke package, which derives it from a seed.
+func TestRFC9458(t *testing.T) {
+	const (
+		keyConfig = "01002031e1f05a740102115220e9af918f738674aec95f54db6e04eb" +
+			"705aae8e79815500080001000100010003"
+		skR        = "3c168975674b2fa8e465970b79c8dcf09f1c741626480bd4c6162fc5b6a98e1a"
+		request    = "00034745540568747470730b6578616d706c652e636f6d012f"
+		encRequest = "010020000100014b28f881333e7c164ffc499ad9796f877f4e1051ee6d31" +
+			"bad19dec96c208b4726374e469135906992e1268c594d2a10c695d858c40a0" +
+			"26e7965e7d86b83dd440b2c0185204b4d63525"
+		response      = "0140c8"
+		responseNonce = "c789e7151fcba46158ca84b04464910d"
+		encResponse   = "c789e7151fcba46158ca84b04464910d86f9013e404feea014e7be4a44" +
+			"1f234f857fbd"
+	)
+
+	kemID := hpke.KEM_X25519_HKDF_SHA256
+	sk, err := kemID.Scheme().UnmarshalBinaryPrivateKey(mustDecode(t, skR))
+	test.CheckNoErr(t, err, "bad private key")
+	key, err := NewPrivateKeyConfig(1, kemID, sk,
+		SymmetricAlgorithm{hpke.KDF_HKDF_SHA256, hpke.AEAD_AES128GCM},
+		SymmetricAlgorithm{hpke.KDF_HKDF_SHA256, hpke.AEAD_ChaCha20Poly1305},
+	)
+	test.CheckNoErr(t, err, "bad key configuration")
+	config := key.Config()
+	got, err := config.MarshalBinary()
+	test.CheckNoErr(t, err, "marshal failed")
+	checkHex(t, got, keyConfig, "key configuration")
+
+	gateway, err := NewGateway(key)
+	test.CheckNoErr(t, err, "gateway creation failed")
+	got, respCtx, err := gateway.DecapsulateRequest(mustDecode(t, encRequest))
+	test.CheckNoErr(t, err, "decapsulation failed")
+	checkHex(t, got, request, "request")
+
+	got, err = respCtx.encapsulateResponse(
+		bytes.NewReader(mustDecode(t, responseNonce)), mustDecode(t, response))
+	test.CheckNoErr(t, err, "encapsulation failed")
+	checkHex(t, got, encResponse, "encapsulated response")
+
+	// Both sides share the HPKE context of the request.
+	reqCtx := &RequestContext{respCtx.enc, respCtx.ctx}
+	got, err = reqCtx.DecapsulateResponse(mustDecode(t, encResponse))
+	test.CheckNoErr(t, err, "decapsulation failed")
+	checkHex(t, got, response, "response")
+}
+
+func mustDecode(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	test.CheckNoErr(t, err, "bad hex string")
+	return b
+}
+
+func checkHex(t *testing.T, got []byte, want, name string) {
+	t.Helper()
+	if g := hex.EncodeToString(got); g != want {
+		test.ReportError(t, g, want, name)
+	}
+}
@@ -0,0 +1,62 @@
+package ohttp
+
+import (
+	"io"
+	"math"
+)
+
+// Variable-length integers of QUIC, see Section 16 of RFC 9000, which are
+// used by Binary HTTP and chunked Oblivious HTTP.
+
+const maxVarint = 1<<62 - 1
+
+// appendVarint appends the encoding of x to b. Panics if x is larger than
+// 2^62-1.
+func appendVarint(b []byte, x uint64) []byte {
+	switch {
+	case x < 1<<6:
+		return append(b, byte(x))
+	case x < 1<<14:
+		return append(b, 0x40|byte(x>>8), byte(x))
+	case x < 1<<30:
+		return append(b, 0x80|byte(x>>24), byte(x>>16), byte(x>>8), byte(x))
+	case x <= maxVarint:
+		return append(b, 0xc0|byte(x>>56), byte(x>>48), byte(x>>40),
+			byte(x>>32), byte(x>>24), byte(x>>16), byte(x>>8), byte(x))
+	default:
+		panic("ohttp: varint out of range")
+	}
+}
+
+// readVarint reads an integer from r. It returns io.EOF if r is empty, and
+// io.ErrUnexpectedEOF if r ends within the integer. Other errors of r are
+// returned as is.
+func readVarint(r io.ByteReader) (uint64, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	x := uint64(b & 0x3f)
+	for n := 1 << (b >> 6); n > 1; n-- {
+		b, err = r.ReadByte()
+		if err == io.EOF {
+			return 0, io.ErrUnexpectedEOF
+		} else if err != nil {
+			return 0, err
+		}
+		x = x<<8 | uint64(b)
+	}
+	return x, nil
+}
+
+// readLength reads an integer from r that is at most maxLen.
+func readLength(r io.ByteReader, maxLen int) (int, error) {
+	x, err := readVarint(r)
+	if err != nil {
+		return 0, err
+	}
+	if x > uint64(maxLen) || x > math.MaxInt32 {
+		return 0, ErrEncoding
+	}
+	return int(x), nil
+}
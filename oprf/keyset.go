@@ -0,0 +1,260 @@
+package oprf
+
+import (
+	"encoding/binary"
+	"errors"
+	"sync"
+)
+
+// KeyID identifies a key in a KeySet.
+type KeyID uint32
+
+var (
+	ErrKeyNotFound  = errors.New("oprf: key not found")
+	ErrDuplicateKey = errors.New("oprf: duplicated key identifier")
+	ErrActiveKey    = errors.New("oprf: cannot remove the active key")
+)
+
+// KeySet holds the keys of a server in the verifiable mode during key
+// rotation. Requests are evaluated with the active key, and outputs are
+// verified with either the active key or one of the previous keys still in
+// the grace window, so clients that obtained outputs before a rotation can
+// redeem them until the key expires. Clients learn which key evaluated their
+// request from the KeyID returned by Evaluate.
+//
+// A KeySet is safe for concurrent use.
+type KeySet struct {
+	mu    sync.RWMutex
+	p     params
+	grace uint
+	// keys is ordered from newest to oldest, so keys[0] is the active key.
+	keys []keySetEntry
+}
+
+// maxKeySetGrace is the largest grace window of a KeySet, which holds up to
+// grace+1 keys, so that both values fit in the 16 bits of its serialization.
+const maxKeySetGrace = 0xFFFE
+
+type keySetEntry struct {
+	id  KeyID
+	key *PrivateKey
+}
+
+// NewKeySet returns an empty key set for the suite that keeps up to grace
+// previous keys after a rotation. The grace window must not exceed 65534
+// keys.
+func NewKeySet(s Suite, grace uint) (*KeySet, error) {
+	p, ok := s.(params)
+	if !ok {
+		return nil, ErrInvalidSuite
+	}
+	if grace > maxKeySetGrace {
+		return nil, ErrInvalidInput
+	}
+	p.m = VerifiableMode
+
+	return &KeySet{p: p, grace: grace}, nil
+}
+
+// Rotate makes key the active key under the given identifier. The previously
+// active key enters the grace window, and the keys beyond the grace window
+// are removed from the set.
+func (ks *KeySet) Rotate(id KeyID, key *PrivateKey) error {
+	if key == nil || key.k == nil || key.k.IsZero() ||
+		*key.k.Group().Params() != *ks.p.group.Params() {
+		return ErrInvalidPrivateKey
+	}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	if ks.find(id) >= 0 {
+		return ErrDuplicateKey
+	}
+
+	n := min(uint(len(ks.keys)), ks.grace)
+	keys := make([]keySetEntry, 1, n+1)
+	keys[0] = keySetEntry{id, &PrivateKey{ks.p, key.k.Copy(), nil}}
+	// Caches the public key, so that concurrent evaluations do not race.
+	keys[0].key.Public()
+	ks.keys = append(keys, ks.keys[:n]...)
+
+	return nil
+}
+
+// Remove removes a previous key from the set before it leaves the grace
+// window, for example, if it was compromised. The active key cannot be
+// removed.
+func (ks *KeySet) Remove(id KeyID) error {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	i := ks.find(id)
+	switch {
+	case i < 0:
+		return ErrKeyNotFound
+	case i == 0:
+		return ErrActiveKey
+	}
+	ks.keys = append(ks.keys[:i], ks.keys[i+1:]...)
+
+	return nil
+}
+
+// Active returns the identifier of the active key.
+func (ks *KeySet) Active() (KeyID, error) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	if len(ks.keys) == 0 {
+		return 0, ErrNoKey
+	}
+
+	return ks.keys[0].id, nil
+}
+
+// IDs returns the identifiers of the keys in the set, from the active key to
+// the oldest one.
+func (ks *KeySet) IDs() []KeyID {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	ids := make([]KeyID, len(ks.keys))
+	for i := range ks.keys {
+		ids[i] = ks.keys[i].id
+	}
+
+	return ids
+}
+
+// PublicKey returns the public key with the given identifier.
+func (ks *KeySet) PublicKey(id KeyID) (*PublicKey, error) {
+	s, err := ks.Server(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.PublicKey(), nil
+}
+
+// Server returns a server for the key with the given identifier.
+func (ks *KeySet) Server(id KeyID) (VerifiableServer, error) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	i := ks.find(id)
+	if i < 0 {
+		return VerifiableServer{}, ErrKeyNotFound
+	}
+
+	return VerifiableServer{server{ks.p, ks.keys[i].key}}, nil
+}
+
+// Evaluate evaluates the request with the active key, and returns its
+// identifier.
+func (ks *KeySet) Evaluate(req *EvaluationRequest) (KeyID, *Evaluation, error) {
+	ks.mu.RLock()
+	if len(ks.keys) == 0 {
+		ks.mu.RUnlock()
+		return 0, nil, ErrNoKey
+	}
+	active := ks.keys[0]
+	ks.mu.RUnlock()
+
+	e, err := VerifiableServer{server{ks.p, active.key}}.Evaluate(req)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return active.id, e, nil
+}
+
+// VerifyFinalize checks that the output corresponds to the input under the key
+// with the given identifier, which must still be in the set.
+func (ks *KeySet) VerifyFinalize(id KeyID, input, expectedOutput []byte) bool {
+	s, err := ks.Server(id)
+	if err != nil {
+		return false
+	}
+
+	return s.VerifyFinalize(input, expectedOutput)
+}
+
+func (ks *KeySet) find(id KeyID) int {
+	for i := range ks.keys {
+		if ks.keys[i].id == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// MarshalBinary returns the serialization of the key set, which contains
+// private keys and must be stored securely. The format is
+//
+//	| grace (u16) | numKeys (u16) | id_0 (u32) | key_0 | ... |
+//
+// where keys are listed from the active key to the oldest one.
+func (ks *KeySet) MarshalBinary() ([]byte, error) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	scalarLen := int(ks.p.group.Params().ScalarLength)
+	out := make([]byte, 4, 4+len(ks.keys)*(4+scalarLen))
+	binary.BigEndian.PutUint16(out[0:2], uint16(ks.grace))
+	binary.BigEndian.PutUint16(out[2:4], uint16(len(ks.keys)))
+	for i := range ks.keys {
+		out = binary.BigEndian.AppendUint32(out, uint32(ks.keys[i].id))
+		k, err := ks.keys[i].key.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, k...)
+	}
+
+	return out, nil
+}
+
+// UnmarshalBinary recovers a key set from its serialization.
+func (ks *KeySet) UnmarshalBinary(s Suite, data []byte) error {
+	p, ok := s.(params)
+	if !ok {
+		return ErrInvalidSuite
+	}
+	p.m = VerifiableMode
+
+	if len(data) < 4 {
+		return ErrInvalidInput
+	}
+	grace := uint(binary.BigEndian.Uint16(data[0:2]))
+	n := int(binary.BigEndian.Uint16(data[2:4]))
+	data = data[4:]
+
+	scalarLen := int(p.group.Params().ScalarLength)
+	if len(data) != n*(4+scalarLen) || grace > maxKeySetGrace || uint(n) > grace+1 {
+		return ErrInvalidInput
+	}
+
+	keys := make([]keySetEntry, n)
+	for i := range keys {
+		keys[i].id = KeyID(binary.BigEndian.Uint32(data[0:4]))
+		for j := range keys[:i] {
+			if keys[j].id == keys[i].id {
+				return ErrDuplicateKey
+			}
+		}
+		keys[i].key = new(PrivateKey)
+		if err := keys[i].key.UnmarshalBinary(p, data[4:4+scalarLen]); err != nil {
+			return err
+		}
+		keys[i].key.Public()
+		data = data[4+scalarLen:]
+	}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	ks.p, ks.grace, ks.keys = p, grace, keys
+
+	return nil
+}
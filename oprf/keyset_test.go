@@ -0,0 +1,82 @@
+package oprf
+
+import (
+	"crypto/rand"
+	"slices"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+func TestKeySet(t *testing.T) {
+	const grace = 2
+	suite := SuiteP384
+	input := []byte("input")
+
+	_, err := NewKeySet(suite, 0xFFFF)
+	test.CheckIsErr(t, err, "should fail with a grace window that cannot be serialized")
+	ks, err := NewKeySet(suite, grace)
+	test.CheckNoErr(t, err, "failed to create key set")
+	_, err = ks.Active()
+	test.CheckIsErr(t, err, "empty key set has no active key")
+	_, _, err = ks.Evaluate(&EvaluationRequest{})
+	test.CheckIsErr(t, err, "empty key set cannot evaluate")
+
+	type issued struct {
+		id     KeyID
+		output []byte
+	}
+	var outputs []issued
+	for id := KeyID(1); id <= 4; id++ {
+		key, err := GenerateKey(suite, rand.Reader)
+		test.CheckNoErr(t, err, "failed to generate key")
+		test.CheckNoErr(t, ks.Rotate(id, key), "failed to rotate")
+		test.CheckIsErr(t, ks.Rotate(id, key), "should fail with a duplicated id")
+
+		active, err := ks.Active()
+		test.CheckNoErr(t, err, "failed to get active key")
+		test.CheckOk(active == id, "rotated key must be active", t)
+
+		pkS, err := ks.PublicKey(active)
+		test.CheckNoErr(t, err, "failed to get public key")
+		client := NewVerifiableClient(suite, pkS)
+		finData, evalReq, err := client.Blind([][]byte{input})
+		test.CheckNoErr(t, err, "failed to blind")
+		gotID, eval, err := ks.Evaluate(evalReq)
+		test.CheckNoErr(t, err, "failed to evaluate")
+		test.CheckOk(gotID == id, "evaluation must use the active key", t)
+		out, err := client.Finalize(finData, eval)
+		test.CheckNoErr(t, err, "failed to finalize")
+		outputs = append(outputs, issued{gotID, out[0]})
+	}
+
+	// Only the active key and the grace window remain.
+	want := []KeyID{4, 3, 2}
+	if got := ks.IDs(); !slices.Equal(got, want) {
+		test.ReportError(t, got, want)
+	}
+	for _, o := range outputs {
+		got := ks.VerifyFinalize(o.id, input, o.output)
+		test.CheckOk(got == slices.Contains(want, o.id), "bad verification of output", t)
+	}
+	test.CheckOk(!ks.VerifyFinalize(4, input, outputs[2].output), "output must be bound to its key", t)
+
+	var ks2 KeySet
+	enc, err := ks.MarshalBinary()
+	test.CheckNoErr(t, err, "failed to marshal")
+	test.CheckNoErr(t, ks2.UnmarshalBinary(suite, enc), "failed to unmarshal")
+	enc2, err := ks2.MarshalBinary()
+	test.CheckNoErr(t, err, "failed to marshal")
+	if !slices.Equal(enc, enc2) {
+		test.ReportError(t, enc2, enc)
+	}
+	test.CheckOk(ks2.VerifyFinalize(3, input, outputs[2].output), "unmarshaled key set must verify", t)
+	test.CheckIsErr(t, ks2.UnmarshalBinary(suite, enc[:len(enc)-1]), "should fail with truncated data")
+	test.CheckIsErr(t, ks2.UnmarshalBinary(SuiteP256, enc), "should fail with another suite")
+	test.CheckIsErr(t, ks2.UnmarshalBinary(suite, []byte{0xFF, 0xFF, 0, 0}), "should fail with a too large grace window")
+
+	test.CheckIsErr(t, ks.Remove(4), "should fail removing the active key")
+	test.CheckIsErr(t, ks.Remove(1), "should fail removing an expired key")
+	test.CheckNoErr(t, ks.Remove(3), "failed to remove key")
+	test.CheckOk(!ks.VerifyFinalize(3, input, outputs[2].output), "removed key must not verify", t)
+}
@@ -41,6 +41,27 @@
 // All three modes can perform batches of PRF evaluations, so passing an array
 // of inputs will produce an array of outputs.
 //
+// # Threshold Evaluation
+//
+// In the Base and Verifiable modes, the private key can be Shamir-shared
+// among n servers with PrivateKey.Split, or with a distributed key generation
+// from the secretsharing package. Each server evaluates the request using its
+// KeyShare and returns a PartialEvaluation. The client combines the partial
+// evaluations of any t+1 servers using Lagrange interpolation in the exponent,
+// obtaining the same outputs as with the private key. In the Verifiable mode,
+// each partial evaluation carries a proof that is checked against the public
+// key of the share, which is derived from the commitment to the key.
+//
+// The Partial Oblivious mode is not supported in threshold, since the
+// evaluation uses the inverse of the tweaked key, which is not linear in the
+// key shares.
+//
+// # Key Rotation
+//
+// A KeySet manages the keys of a server in the Verifiable mode across
+// rotations. Requests are evaluated with the active key, and outputs can be
+// verified under previous keys during a grace window.
+//
 // Warning: Server operations for the SuiteP256, SuiteP384, and SuiteP521
 // suites are currently not constant time in the server's private key.
 //
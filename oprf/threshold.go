@@ -0,0 +1,244 @@
+package oprf
+
+import (
+	"io"
+
+	"github.com/cloudflare/circl/group"
+	"github.com/cloudflare/circl/math/polynomial"
+	"github.com/cloudflare/circl/secretsharing"
+	"github.com/cloudflare/circl/zk/dleq"
+)
+
+// KeyShare is a share of a PrivateKey held by one of the servers in threshold
+// mode. The private key is Shamir-shared, so that the evaluation of any t+1
+// servers can be combined by the client into the evaluation under the
+// private key, while t servers learn nothing about it.
+type KeyShare struct {
+	id  group.Scalar
+	key *PrivateKey
+}
+
+// Split shares the private key among n servers, such that the evaluations of
+// any t+1 servers are needed to obtain an output, where 0 <= t < n. It also
+// returns the commitment to the key, which is public and is used by clients
+// in the verifiable mode. The first element of the commitment is the public
+// key.
+func (k *PrivateKey) Split(rnd io.Reader, t, n uint) ([]KeyShare, secretsharing.SecretCommitment, error) {
+	if t >= n {
+		return nil, nil, ErrInvalidInput
+	}
+
+	ss := secretsharing.New(rnd, t, k.k)
+	shares := ss.Share(n)
+	keyShares := make([]KeyShare, n)
+	for i := range shares {
+		keyShares[i] = KeyShare{shares[i].ID, &PrivateKey{k.p, shares[i].Value, nil}}
+	}
+
+	return keyShares, ss.CommitSecret(), nil
+}
+
+// NewKeyShare returns a KeyShare from a share of a secret sharing, for
+// example, produced by a distributed key generation.
+func NewKeyShare(s Suite, share secretsharing.Share) (*KeyShare, error) {
+	p, ok := s.(params)
+	if !ok {
+		return nil, ErrInvalidSuite
+	}
+	wantGroup := *p.group.Params()
+	if share.ID == nil || share.Value == nil ||
+		*share.ID.Group().Params() != wantGroup ||
+		*share.Value.Group().Params() != wantGroup ||
+		share.ID.IsZero() || share.Value.IsZero() {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	return &KeyShare{share.ID.Copy(), &PrivateKey{p, share.Value.Copy(), nil}}, nil
+}
+
+// ID returns the identifier of the share.
+func (k *KeyShare) ID() group.Scalar { return k.id.Copy() }
+
+// Public returns the public key of the share, which verifies the partial
+// evaluations of the server in the verifiable mode.
+func (k *KeyShare) Public() *PublicKey { return k.key.Public() }
+
+// PartialEvaluation is the evaluation of a server holding the KeyShare with
+// the given ID. In the verifiable mode, the proof shows that the server used
+// its key share.
+type PartialEvaluation struct {
+	ID group.Scalar
+	Evaluation
+}
+
+// ThresholdServer evaluates requests in the base mode using a KeyShare.
+type ThresholdServer struct {
+	id group.Scalar
+	s  Server
+}
+
+// VerifiableThresholdServer evaluates requests in the verifiable mode using a
+// KeyShare.
+type VerifiableThresholdServer struct {
+	id group.Scalar
+	s  VerifiableServer
+}
+
+// NewThresholdServer returns a server in the base mode for the given share.
+func NewThresholdServer(s Suite, key *KeyShare) ThresholdServer {
+	if key == nil {
+		panic(ErrNoKey)
+	}
+	return ThresholdServer{key.id, NewServer(s, key.key)}
+}
+
+// NewVerifiableThresholdServer returns a server in the verifiable mode for
+// the given share.
+func NewVerifiableThresholdServer(s Suite, key *KeyShare) VerifiableThresholdServer {
+	if key == nil {
+		panic(ErrNoKey)
+	}
+	return VerifiableThresholdServer{key.id, NewVerifiableServer(s, key.key)}
+}
+
+// PublicKey returns the public key of the server's KeyShare.
+func (s ThresholdServer) PublicKey() *PublicKey { return s.s.PublicKey() }
+
+// PublicKey returns the public key of the server's KeyShare.
+func (s VerifiableThresholdServer) PublicKey() *PublicKey { return s.s.PublicKey() }
+
+// Evaluate returns the partial evaluation of the request under the key share.
+func (s ThresholdServer) Evaluate(req *EvaluationRequest) (*PartialEvaluation, error) {
+	e, err := s.s.Evaluate(req)
+	if err != nil {
+		return nil, err
+	}
+	return &PartialEvaluation{s.id.Copy(), *e}, nil
+}
+
+// Evaluate returns the partial evaluation of the request under the key share,
+// together with a proof that the key share was used.
+func (s VerifiableThresholdServer) Evaluate(req *EvaluationRequest) (*PartialEvaluation, error) {
+	e, err := s.s.Evaluate(req)
+	if err != nil {
+		return nil, err
+	}
+	return &PartialEvaluation{s.id.Copy(), *e}, nil
+}
+
+// Combine returns the evaluation under the private key from the partial
+// evaluations of at least t+1 servers in the base mode, using Lagrange
+// interpolation in the exponent. The result is passed to Finalize.
+func (c Client) Combine(t uint, partials []PartialEvaluation) (*Evaluation, error) {
+	return c.combine(t, partials)
+}
+
+func (c client) combine(t uint, partials []PartialEvaluation) (*Evaluation, error) {
+	if uint(len(partials)) <= t {
+		return nil, ErrInvalidInput
+	}
+
+	l := len(partials[0].Elements)
+	wantGroup := *c.params.group.Params()
+	ids := make([]group.Scalar, len(partials))
+	for i := range partials {
+		id := partials[i].ID
+		if id == nil || *id.Group().Params() != wantGroup || id.IsZero() ||
+			len(partials[i].Elements) != l {
+			return nil, ErrInvalidInput
+		}
+		for j := range ids[:i] {
+			if ids[j].IsEqual(id) {
+				return nil, ErrInvalidInput
+			}
+		}
+		for _, e := range partials[i].Elements {
+			if e == nil || *e.Group().Params() != wantGroup {
+				return nil, ErrInvalidInput
+			}
+		}
+		ids[i] = id
+	}
+
+	zero := c.params.group.NewScalar()
+	elements := make([]Evaluated, l)
+	for j := range elements {
+		elements[j] = c.params.group.Identity()
+	}
+	tmp := c.params.group.NewElement()
+	for i := range partials {
+		lambda := polynomial.LagrangeBase(uint(i), ids, zero)
+		for j := range elements {
+			elements[j].Add(elements[j], tmp.Mul(partials[i].Elements[j], lambda))
+		}
+	}
+
+	return &Evaluation{elements, nil}, nil
+}
+
+// VerifiableThresholdClient is a client in the verifiable mode that obtains
+// partial evaluations from several servers holding shares of the private key.
+type VerifiableThresholdClient struct {
+	client
+	com secretsharing.SecretCommitment
+}
+
+// NewVerifiableThresholdClient returns a client for the servers holding the
+// shares of the key committed in com, as returned by PrivateKey.Split. The
+// evaluations of len(com) servers are needed to obtain an output, and the
+// public key of the servers is com[0].
+func NewVerifiableThresholdClient(s Suite, com secretsharing.SecretCommitment) VerifiableThresholdClient {
+	p, ok := s.(params)
+	if !ok || len(com) == 0 || com[0] == nil || com[0].IsIdentity() {
+		panic(ErrNoKey)
+	}
+	for _, c := range com {
+		if c == nil || *c.Group().Params() != *p.group.Params() {
+			panic(ErrNoKey)
+		}
+	}
+	p.m = VerifiableMode
+
+	return VerifiableThresholdClient{client{p}, com}
+}
+
+// PublicKey returns the public key of the servers.
+func (c VerifiableThresholdClient) PublicKey() *PublicKey {
+	return &PublicKey{c.params, c.com[0].Copy()}
+}
+
+// Finalize verifies the proofs of the partial evaluations against the public
+// key of each share, combines them, and computes the outputs. The outputs are
+// the same as the ones of a VerifiableClient interacting with a
+// VerifiableServer holding the private key.
+func (c VerifiableThresholdClient) Finalize(f *FinalizeData, partials []PartialEvaluation) (outputs [][]byte, err error) {
+	t := uint(len(c.com) - 1)
+	e, err := c.combine(t, partials)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.validate(f, e); err != nil {
+		return nil, err
+	}
+
+	verifier := dleq.Verifier{Params: c.getDLEQParams()}
+	for i := range partials {
+		shareKey := c.shareKey(partials[i].ID)
+		if shareKey.IsIdentity() || !verifier.VerifyBatchRFC9497(
+			shareKey,
+			f.evalReq.Elements,
+			partials[i].Elements,
+			partials[i].Proof,
+		) {
+			return nil, ErrInvalidProof
+		}
+	}
+
+	return c.client.finalize(f, e, nil)
+}
+
+// shareKey returns the public key of the share with the given ID, which is
+// the committed polynomial evaluated at id in the exponent.
+func (c VerifiableThresholdClient) shareKey(id group.Scalar) group.Element {
+	return secretsharing.EvalCommitment(c.com, id)
+}
@@ -0,0 +1,130 @@
+package oprf
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/secretsharing"
+)
+
+func TestThreshold(t *testing.T) {
+	const threshold, numServers = 2, 5
+	inputs := [][]byte{[]byte("first input"), []byte("second input")}
+
+	for _, suite := range []Suite{SuiteRistretto255, SuiteP256} {
+		t.Run(suite.Identifier(), func(t *testing.T) {
+			key, err := GenerateKey(suite, rand.Reader)
+			test.CheckNoErr(t, err, "failed to generate key")
+			shares, com, err := key.Split(rand.Reader, threshold, numServers)
+			test.CheckNoErr(t, err, "failed to split key")
+			test.CheckOk(com[0].IsEqual(key.Public().e), "commitment must start with the public key", t)
+
+			t.Run("Base", func(t *testing.T) {
+				server := NewServer(suite, key)
+				client := NewClient(suite)
+				finData, evalReq, err := client.Blind(inputs)
+				test.CheckNoErr(t, err, "failed to blind")
+
+				partials := make([]PartialEvaluation, numServers)
+				for i := range shares {
+					p, err := NewThresholdServer(suite, &shares[i]).Evaluate(evalReq)
+					test.CheckNoErr(t, err, "failed to evaluate")
+					partials[i] = *p
+				}
+
+				for _, subset := range [][]PartialEvaluation{
+					partials[:threshold+1],
+					partials[numServers-threshold-1:],
+					partials,
+				} {
+					e, err := client.Combine(threshold, subset)
+					test.CheckNoErr(t, err, "failed to combine")
+					outputs, err := client.Finalize(finData, e)
+					test.CheckNoErr(t, err, "failed to finalize")
+					for i := range inputs {
+						want, err := server.FullEvaluate(inputs[i])
+						test.CheckNoErr(t, err, "failed to fully evaluate")
+						if !bytes.Equal(outputs[i], want) {
+							test.ReportError(t, outputs[i], want)
+						}
+					}
+				}
+
+				_, err = client.Combine(threshold, partials[:threshold])
+				test.CheckIsErr(t, err, "should fail with too few partials")
+				repeated := []PartialEvaluation{partials[0], partials[0], partials[1]}
+				_, err = client.Combine(threshold, repeated)
+				test.CheckIsErr(t, err, "should fail with repeated partials")
+			})
+
+			t.Run("Verifiable", func(t *testing.T) {
+				server := NewVerifiableServer(suite, key)
+				client := NewVerifiableThresholdClient(suite, com)
+				test.CheckOk(client.PublicKey().e.IsEqual(key.Public().e), "bad public key", t)
+				finData, evalReq, err := client.Blind(inputs)
+				test.CheckNoErr(t, err, "failed to blind")
+
+				partials := make([]PartialEvaluation, numServers)
+				for i := range shares {
+					s := NewVerifiableThresholdServer(suite, &shares[i])
+					test.CheckOk(s.PublicKey().e.IsEqual(client.shareKey(shares[i].ID())), "bad share public key", t)
+					p, err := s.Evaluate(evalReq)
+					test.CheckNoErr(t, err, "failed to evaluate")
+					partials[i] = *p
+				}
+
+				outputs, err := client.Finalize(finData, partials[1:threshold+2])
+				test.CheckNoErr(t, err, "failed to finalize")
+				for i := range inputs {
+					want, err := server.FullEvaluate(inputs[i])
+					test.CheckNoErr(t, err, "failed to fully evaluate")
+					if !bytes.Equal(outputs[i], want) {
+						test.ReportError(t, outputs[i], want)
+					}
+				}
+
+				// A server evaluating with a wrong key share is detected.
+				faulty := partials[0]
+				faulty.Elements = append([]Evaluated{}, faulty.Elements...)
+				faulty.Elements[0] = suite.Group().NewElement().Add(faulty.Elements[0], suite.Group().Generator())
+				_, err = client.Finalize(finData, []PartialEvaluation{faulty, partials[1], partials[2]})
+				test.CheckIsErr(t, err, "should fail with a faulty partial")
+
+				// A valid partial presented with a different ID is detected.
+				swapped := partials[0]
+				swapped.ID = partials[3].ID
+				_, err = client.Finalize(finData, []PartialEvaluation{swapped, partials[1], partials[2]})
+				test.CheckIsErr(t, err, "should fail with a mislabeled partial")
+
+				_, err = client.Finalize(finData, partials[:threshold])
+				test.CheckIsErr(t, err, "should fail with too few partials")
+			})
+		})
+	}
+}
+
+func TestNewKeyShare(t *testing.T) {
+	suite := SuiteRistretto255
+	key, err := GenerateKey(suite, rand.Reader)
+	test.CheckNoErr(t, err, "failed to generate key")
+	ss := secretsharing.New(rand.Reader, 1, key.k)
+	shares := ss.Share(3)
+
+	keyShares := make([]*KeyShare, len(shares))
+	for i := range shares {
+		keyShares[i], err = NewKeyShare(suite, shares[i])
+		test.CheckNoErr(t, err, "failed to create key share")
+		test.CheckOk(keyShares[i].ID().IsEqual(shares[i].ID), "bad share ID", t)
+	}
+
+	zero := secretsharing.Share{ID: shares[0].ID, Value: suite.Group().NewScalar()}
+	_, err = NewKeyShare(suite, zero)
+	test.CheckIsErr(t, err, "should fail with a zero share")
+	_, err = NewKeyShare(SuiteP256, shares[0])
+	test.CheckIsErr(t, err, "should fail with a share of another group")
+
+	_, _, err = key.Split(rand.Reader, 3, 3)
+	test.CheckIsErr(t, err, "should fail with threshold above servers")
+}
@@ -0,0 +1,146 @@
+package privacypass
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"io"
+
+	"github.com/cloudflare/circl/blindsign/blindrsa"
+	"github.com/cloudflare/circl/oprf"
+)
+
+// Client requests tokens from an issuer to answer the challenges of origins.
+type Client struct {
+	tokenType TokenType
+	keyID     [KeyIDSize]byte
+	voprf     oprf.VerifiableClient
+	brsa      blindrsa.Client
+}
+
+// NewPrivateClient returns a client for the tokens of type PrivateToken of
+// the issuer with the given key, as returned by PrivateIssuer.TokenKey.
+func NewPrivateClient(tokenKey []byte) (Client, error) {
+	pk := new(oprf.PublicKey)
+	if err := pk.UnmarshalBinary(privateSuite, tokenKey); err != nil {
+		return Client{}, ErrInvalidTokenKey
+	}
+	// Keys are identified by their canonical encoding.
+	enc, err := pk.MarshalBinary()
+	if err != nil {
+		return Client{}, err
+	}
+
+	return Client{
+		tokenType: PrivateToken,
+		keyID:     sha256.Sum256(enc),
+		voprf:     oprf.NewVerifiableClient(privateSuite, pk),
+	}, nil
+}
+
+// NewPublicClient returns a client for the tokens of type PublicToken of the
+// issuer with the given key, as returned by PublicIssuer.TokenKey.
+func NewPublicClient(tokenKey []byte) (Client, error) {
+	pk, err := unmarshalTokenKey(tokenKey)
+	if err != nil {
+		return Client{}, err
+	}
+	c, err := blindrsa.NewClient(publicVariant, pk)
+	if err != nil {
+		return Client{}, err
+	}
+
+	return Client{
+		tokenType: PublicToken,
+		keyID:     sha256.Sum256(tokenKey),
+		brsa:      c,
+	}, nil
+}
+
+// TokenRequestState is kept by the client between Request and Finalize.
+type TokenRequestState struct {
+	token   Token
+	finData *oprf.FinalizeData
+	brsa    blindrsa.State
+}
+
+// Request returns a request for a token that answers the challenge, and the
+// state to finalize the token once the issuer responds. If rnd is nil,
+// crypto/rand.Reader is used.
+func (c Client) Request(rnd io.Reader, challenge *TokenChallenge) (*TokenRequest, *TokenRequestState, error) {
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+	if challenge == nil || challenge.TokenType != c.tokenType {
+		return nil, nil, ErrInvalidTokenType
+	}
+
+	digest, err := challenge.digest()
+	if err != nil {
+		return nil, nil, err
+	}
+	st := &TokenRequestState{token: Token{
+		TokenType:       c.tokenType,
+		ChallengeDigest: digest,
+		TokenKeyID:      c.keyID,
+	}}
+	if _, err = io.ReadFull(rnd, st.token.Nonce[:]); err != nil {
+		return nil, nil, err
+	}
+	input := st.token.input()
+
+	req := &TokenRequest{TokenType: c.tokenType, TruncatedTokenKeyID: c.keyID[KeyIDSize-1]}
+	switch c.tokenType {
+	case PrivateToken:
+		blind := privateSuite.Group().RandomNonZeroScalar(rnd)
+		finData, evalReq, err := c.voprf.DeterministicBlind([][]byte{input}, []oprf.Blind{blind})
+		if err != nil {
+			return nil, nil, err
+		}
+		st.finData = finData
+		req.BlindedMsg, err = evalReq.Elements[0].MarshalBinaryCompress()
+		if err != nil {
+			return nil, nil, err
+		}
+	case PublicToken:
+		req.BlindedMsg, st.brsa, err = c.brsa.Blind(rnd, input)
+		if err != nil {
+			return nil, nil, err
+		}
+	default:
+		return nil, nil, ErrInvalidTokenType
+	}
+
+	return req, st, nil
+}
+
+// Finalize returns the token from the encoding of the issuer's TokenResponse,
+// and returns an error if the issuer did not respond correctly.
+func (c Client) Finalize(st *TokenRequestState, resp []byte) (*Token, error) {
+	if st == nil || st.token.TokenType != c.tokenType {
+		return nil, ErrInvalidTokenType
+	}
+
+	token := st.token
+	switch c.tokenType {
+	case PrivateToken:
+		eval, err := privateResponse(privateSuite.Group(), resp)
+		if err != nil {
+			return nil, err
+		}
+		outputs, err := c.voprf.Finalize(st.finData, eval)
+		if err != nil {
+			return nil, err
+		}
+		token.Authenticator = outputs[0]
+	case PublicToken:
+		sig, err := c.brsa.Finalize(st.brsa, resp)
+		if err != nil {
+			return nil, err
+		}
+		token.Authenticator = sig
+	default:
+		return nil, ErrInvalidTokenType
+	}
+
+	return &token, nil
+}
@@ -0,0 +1,390 @@
+// Package privacypass implements the Privacy Pass protocol for issuance and
+// redemption of tokens.
+//
+// Privacy Pass lets a client obtain tokens from an issuer, and redeem them
+// later with an origin, such that the origin learns only that the token is
+// valid, and the issuer cannot link the redemption to the issuance. This
+// package implements the TokenChallenge and Token structures of RFC 9577 [1],
+// and the issuance protocols of RFC 9578 [2] for both token types:
+//
+//   - PrivateToken (0x0001): privately verifiable tokens, using the
+//     VOPRF(P-384, SHA-384) protocol from the oprf package. Only the issuer,
+//     or an origin that shares its key, can verify tokens.
+//   - PublicToken (0x0002): publicly verifiable tokens, using the
+//     RSABSSA-SHA384-PSS-Deterministic blind signatures from the blindrsa
+//     package. Anyone with the issuer's public key can verify tokens.
+//
+// # Protocol Overview
+//
+//	Origin                    Client                          Issuer
+//	=================================================================
+//	ch = Challenge()
+//	                  ch
+//	            ----------->
+//	                          req, st = Request(ch)
+//	                                              req
+//	                                          ---------->
+//	                                                   resp = Issue(req)
+//	                                              resp
+//	                                          <----------
+//	                          token = Finalize(st, resp)
+//	                 token
+//	            <-----------
+//	Verify(ch, token)
+//
+// Origins must also prevent double spending, for example, by keeping track
+// of the nonces of the redeemed tokens; this is not handled by the package.
+//
+// # References
+//
+// [1] RFC 9577: https://www.rfc-editor.org/info/rfc9577
+//
+// [2] RFC 9578: https://www.rfc-editor.org/info/rfc9578
+package privacypass
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/cryptobyte"
+)
+
+// TokenType identifies the issuance protocol of a token.
+type TokenType uint16
+
+const (
+	// PrivateToken is the token type for VOPRF(P-384, SHA-384).
+	PrivateToken TokenType = 0x0001
+	// PublicToken is the token type for Blind RSA (2048-bit).
+	PublicToken TokenType = 0x0002
+)
+
+const (
+	// NonceSize is the size in bytes of the nonce of a token.
+	NonceSize = 32
+	// KeyIDSize is the size in bytes of a token key identifier.
+	KeyIDSize = 32
+	// redemptionContextSize is the size in bytes of a non-empty redemption
+	// context.
+	redemptionContextSize = 32
+)
+
+var (
+	ErrInvalidTokenType  = errors.New("privacypass: invalid token type")
+	ErrInvalidTokenKey   = errors.New("privacypass: invalid token key")
+	ErrInvalidKeyID      = errors.New("privacypass: token key identifier mismatch")
+	ErrInvalidToken      = errors.New("privacypass: invalid token")
+	ErrChallengeMismatch = errors.New("privacypass: token does not match the challenge")
+	ErrEncoding          = errors.New("privacypass: invalid encoding")
+)
+
+// authenticatorSize returns the size in bytes of the authenticator of the
+// token type, or zero for unsupported types.
+func (t TokenType) authenticatorSize() int {
+	switch t {
+	case PrivateToken:
+		return privateNk
+	case PublicToken:
+		return publicNk
+	default:
+		return 0
+	}
+}
+
+// blindedMsgSize returns the size in bytes of the blinded message in a
+// TokenRequest of the token type, or zero for unsupported types.
+func (t TokenType) blindedMsgSize() int {
+	switch t {
+	case PrivateToken:
+		return privateNe
+	case PublicToken:
+		return publicNk
+	default:
+		return 0
+	}
+}
+
+// TokenChallenge is sent by an origin to request a token from the client.
+type TokenChallenge struct {
+	TokenType  TokenType
+	IssuerName string
+	// RedemptionContext is either empty or 32 bytes, and binds the token to
+	// a context chosen by the origin, for example, a session.
+	RedemptionContext []byte
+	// OriginInfo lists the names of the origins that accept the token. If
+	// empty, the token can be redeemed with any origin.
+	OriginInfo []string
+}
+
+// MarshalBinary returns the encoding of the challenge.
+func (c *TokenChallenge) MarshalBinary() ([]byte, error) {
+	if c.TokenType.authenticatorSize() == 0 {
+		return nil, ErrInvalidTokenType
+	}
+	if len(c.IssuerName) == 0 ||
+		(len(c.RedemptionContext) != 0 && len(c.RedemptionContext) != redemptionContextSize) {
+		return nil, ErrEncoding
+	}
+
+	var b cryptobyte.Builder
+	b.AddUint16(uint16(c.TokenType))
+	b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+		b.AddBytes([]byte(c.IssuerName))
+	})
+	b.AddUint8LengthPrefixed(func(b *cryptobyte.Builder) {
+		b.AddBytes(c.RedemptionContext)
+	})
+	b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+		b.AddBytes([]byte(strings.Join(c.OriginInfo, ",")))
+	})
+
+	return b.Bytes()
+}
+
+// UnmarshalBinary recovers a challenge from its encoding.
+func (c *TokenChallenge) UnmarshalBinary(data []byte) error {
+	var (
+		tokenType                             uint16
+		issuerName, redemptionCtx, originInfo cryptobyte.String
+	)
+	s := cryptobyte.String(data)
+	if !s.ReadUint16(&tokenType) ||
+		!s.ReadUint16LengthPrefixed(&issuerName) ||
+		!s.ReadUint8LengthPrefixed(&redemptionCtx) ||
+		!s.ReadUint16LengthPrefixed(&originInfo) ||
+		!s.Empty() ||
+		len(issuerName) == 0 ||
+		(len(redemptionCtx) != 0 && len(redemptionCtx) != redemptionContextSize) {
+		return ErrEncoding
+	}
+	if TokenType(tokenType).authenticatorSize() == 0 {
+		return ErrInvalidTokenType
+	}
+
+	c.TokenType = TokenType(tokenType)
+	c.IssuerName = string(issuerName)
+	c.RedemptionContext = nil
+	if len(redemptionCtx) != 0 {
+		c.RedemptionContext = append([]byte{}, redemptionCtx...)
+	}
+	c.OriginInfo = nil
+	if len(originInfo) != 0 {
+		c.OriginInfo = strings.Split(string(originInfo), ",")
+	}
+
+	return nil
+}
+
+// digest returns the hash of the encoded challenge.
+func (c *TokenChallenge) digest() ([sha256.Size]byte, error) {
+	enc, err := c.MarshalBinary()
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+
+	return sha256.Sum256(enc), nil
+}
+
+// Token is presented by the client to the origin to redeem it.
+type Token struct {
+	TokenType       TokenType
+	Nonce           [NonceSize]byte
+	ChallengeDigest [sha256.Size]byte
+	TokenKeyID      [KeyIDSize]byte
+	Authenticator   []byte
+}
+
+// MarshalBinary returns the encoding of the token.
+func (t *Token) MarshalBinary() ([]byte, error) {
+	size := t.TokenType.authenticatorSize()
+	if size == 0 {
+		return nil, ErrInvalidTokenType
+	}
+	if len(t.Authenticator) != size {
+		return nil, ErrEncoding
+	}
+
+	out := t.input()
+	return append(out, t.Authenticator...), nil
+}
+
+// UnmarshalBinary recovers a token from its encoding.
+func (t *Token) UnmarshalBinary(data []byte) error {
+	var tokenType uint16
+	s := cryptobyte.String(data)
+	if !s.ReadUint16(&tokenType) {
+		return ErrEncoding
+	}
+	size := TokenType(tokenType).authenticatorSize()
+	if size == 0 {
+		return ErrInvalidTokenType
+	}
+
+	var authenticator []byte
+	if !s.CopyBytes(t.Nonce[:]) ||
+		!s.CopyBytes(t.ChallengeDigest[:]) ||
+		!s.CopyBytes(t.TokenKeyID[:]) ||
+		!s.ReadBytes(&authenticator, size) ||
+		!s.Empty() {
+		return ErrEncoding
+	}
+	t.TokenType = TokenType(tokenType)
+	t.Authenticator = append([]byte{}, authenticator...)
+
+	return nil
+}
+
+// input returns the token input, that is, the encoding of the token without
+// the authenticator, which is the message evaluated by the issuer.
+func (t *Token) input() []byte {
+	out := make([]byte, 0, 2+NonceSize+sha256.Size+KeyIDSize+t.TokenType.authenticatorSize())
+	out = append(out, byte(t.TokenType>>8), byte(t.TokenType))
+	out = append(out, t.Nonce[:]...)
+	out = append(out, t.ChallengeDigest[:]...)
+	out = append(out, t.TokenKeyID[:]...)
+
+	return out
+}
+
+// TokenRequest is sent by the client to the issuer to obtain a token.
+type TokenRequest struct {
+	TokenType TokenType
+	// TruncatedTokenKeyID is the last byte of the token key identifier.
+	TruncatedTokenKeyID uint8
+	BlindedMsg          []byte
+}
+
+// MarshalBinary returns the encoding of the request.
+func (r *TokenRequest) MarshalBinary() ([]byte, error) {
+	size := r.TokenType.blindedMsgSize()
+	if size == 0 {
+		return nil, ErrInvalidTokenType
+	}
+	if len(r.BlindedMsg) != size {
+		return nil, ErrEncoding
+	}
+
+	out := make([]byte, 0, 3+size)
+	out = append(out, byte(r.TokenType>>8), byte(r.TokenType), r.TruncatedTokenKeyID)
+	return append(out, r.BlindedMsg...), nil
+}
+
+// UnmarshalBinary recovers a request from its encoding.
+func (r *TokenRequest) UnmarshalBinary(data []byte) error {
+	var tokenType uint16
+	s := cryptobyte.String(data)
+	if !s.ReadUint16(&tokenType) {
+		return ErrEncoding
+	}
+	size := TokenType(tokenType).blindedMsgSize()
+	if size == 0 {
+		return ErrInvalidTokenType
+	}
+
+	var keyID uint8
+	var blindedMsg []byte
+	if !s.ReadUint8(&keyID) || !s.ReadBytes(&blindedMsg, size) || !s.Empty() {
+		return ErrEncoding
+	}
+	r.TokenType = TokenType(tokenType)
+	r.TruncatedTokenKeyID = keyID
+	r.BlindedMsg = append([]byte{}, blindedMsg...)
+
+	return nil
+}
+
+// checkRequest checks that the request is for the token type and key.
+func checkRequest(req *TokenRequest, tokenType TokenType, keyID *[KeyIDSize]byte) error {
+	if req == nil || len(req.BlindedMsg) != tokenType.blindedMsgSize() {
+		return ErrEncoding
+	}
+	if req.TokenType != tokenType {
+		return ErrInvalidTokenType
+	}
+	if req.TruncatedTokenKeyID != keyID[KeyIDSize-1] {
+		return ErrInvalidKeyID
+	}
+
+	return nil
+}
+
+// checkToken checks that the token is for the token type and key.
+func checkToken(token *Token, tokenType TokenType, keyID *[KeyIDSize]byte) error {
+	if token == nil || len(token.Authenticator) != tokenType.authenticatorSize() {
+		return ErrInvalidToken
+	}
+	if token.TokenType != tokenType {
+		return ErrInvalidTokenType
+	}
+	if subtle.ConstantTimeCompare(token.TokenKeyID[:], keyID[:]) != 1 {
+		return ErrInvalidKeyID
+	}
+
+	return nil
+}
+
+// TokenVerifier verifies the authenticator of tokens.
+type TokenVerifier interface {
+	// TokenType returns the type of the tokens verified.
+	TokenType() TokenType
+	// Verify returns an error if the token is not valid.
+	Verify(token *Token) error
+}
+
+// Origin challenges clients to present tokens of an issuer, and verifies
+// them.
+type Origin struct {
+	issuerName string
+	originInfo []string
+	verifier   TokenVerifier
+}
+
+// NewOrigin returns an origin that accepts the tokens of the named issuer,
+// which are verified with v. The originInfo lists the names of the origins
+// that accept the same tokens, which may be empty.
+func NewOrigin(issuerName string, originInfo []string, v TokenVerifier) Origin {
+	return Origin{issuerName, originInfo, v}
+}
+
+// Challenge returns a new challenge for a client. If rnd is not nil, the
+// challenge has a random redemption context, so the token can only be used
+// to answer this challenge. Otherwise, tokens can be obtained ahead of time,
+// and used for any challenge of the origin.
+func (o Origin) Challenge(rnd io.Reader) (*TokenChallenge, error) {
+	c := &TokenChallenge{
+		TokenType:  o.verifier.TokenType(),
+		IssuerName: o.issuerName,
+		OriginInfo: o.originInfo,
+	}
+	if rnd != nil {
+		c.RedemptionContext = make([]byte, redemptionContextSize)
+		if _, err := io.ReadFull(rnd, c.RedemptionContext); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// Verify checks that the token answers the challenge, and that it was issued
+// by the issuer.
+func (o Origin) Verify(challenge *TokenChallenge, token *Token) error {
+	if challenge == nil || token == nil {
+		return ErrInvalidToken
+	}
+	if challenge.TokenType != o.verifier.TokenType() || token.TokenType != challenge.TokenType {
+		return ErrInvalidTokenType
+	}
+	digest, err := challenge.digest()
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(digest[:], token.ChallengeDigest[:]) != 1 {
+		return ErrChallengeMismatch
+	}
+
+	return o.verifier.Verify(token)
+}
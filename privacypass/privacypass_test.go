@@ -0,0 +1,157 @@
+package privacypass
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/oprf"
+)
+
+type issuer interface {
+	TokenVerifier
+	TokenKey() []byte
+	Issue(req *TokenRequest) ([]byte, error)
+}
+
+func newIssuers(t testing.TB) []issuer {
+	oprfKey, err := oprf.GenerateKey(oprf.SuiteP384, rand.Reader)
+	test.CheckNoErr(t, err, "failed to generate key")
+	private, err := NewPrivateIssuer(oprfKey)
+	test.CheckNoErr(t, err, "failed to create issuer")
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	test.CheckNoErr(t, err, "failed to generate key")
+	public, err := NewPublicIssuer(rsaKey)
+	test.CheckNoErr(t, err, "failed to create issuer")
+
+	return []issuer{private, public}
+}
+
+func newClient(t testing.TB, i issuer) Client {
+	var c Client
+	var err error
+	switch i.TokenType() {
+	case PrivateToken:
+		c, err = NewPrivateClient(i.TokenKey())
+	case PublicToken:
+		c, err = NewPublicClient(i.TokenKey())
+	}
+	test.CheckNoErr(t, err, "failed to create client")
+	return c
+}
+
+func TestIssuance(t *testing.T) {
+	for _, issuer := range newIssuers(t) {
+		origin := NewOrigin("issuer.example", []string{"a.example", "b.example"}, issuer)
+		client := newClient(t, issuer)
+
+		for _, rnd := range []io.Reader{nil, rand.Reader} {
+			ch, err := origin.Challenge(rnd)
+			test.CheckNoErr(t, err, "failed to create challenge")
+
+			req, st, err := client.Request(rand.Reader, ch)
+			test.CheckNoErr(t, err, "failed to request")
+			var gotReq TokenRequest
+			test.CheckMarshal(t, req, &gotReq)
+
+			resp, err := issuer.Issue(&gotReq)
+			test.CheckNoErr(t, err, "failed to issue")
+			token, err := client.Finalize(st, resp)
+			test.CheckNoErr(t, err, "failed to finalize")
+
+			var gotToken Token
+			test.CheckMarshal(t, token, &gotToken)
+			test.CheckNoErr(t, origin.Verify(ch, &gotToken), "token must be valid")
+
+			// The token does not answer other challenges.
+			other := *ch
+			other.OriginInfo = []string{"c.example"}
+			test.CheckIsErr(t, origin.Verify(&other, token), "should fail with another challenge")
+
+			// The authenticator is bound to the token input.
+			forged := *token
+			forged.Nonce[0] ^= 1
+			test.CheckIsErr(t, origin.Verify(ch, &forged), "should fail with a modified token")
+
+			// An issuer cannot answer with a response for other request. A nil
+			// reader defaults to crypto/rand.
+			req2, _, err := client.Request(nil, ch)
+			test.CheckNoErr(t, err, "failed to request")
+			resp2, err := issuer.Issue(req2)
+			test.CheckNoErr(t, err, "failed to issue")
+			_, err = client.Finalize(st, resp2)
+			test.CheckIsErr(t, err, "should fail with a response for other request")
+		}
+
+		// Requests for another key are rejected.
+		ch, err := origin.Challenge(nil)
+		test.CheckNoErr(t, err, "failed to create challenge")
+		req, _, err := client.Request(rand.Reader, ch)
+		test.CheckNoErr(t, err, "failed to request")
+		req.TruncatedTokenKeyID ^= 1
+		_, err = issuer.Issue(req)
+		test.CheckIsErr(t, err, "should fail with another key")
+	}
+}
+
+func TestTokenChallenge(t *testing.T) {
+	ch := &TokenChallenge{
+		TokenType:         PublicToken,
+		IssuerName:        "issuer.example",
+		RedemptionContext: bytes.Repeat([]byte{0xab}, 32),
+		OriginInfo:        []string{"a.example", "b.example"},
+	}
+	var got TokenChallenge
+	test.CheckMarshal(t, ch, &got)
+
+	enc, err := ch.MarshalBinary()
+	test.CheckNoErr(t, err, "failed to marshal")
+	test.CheckIsErr(t, got.UnmarshalBinary(enc[:len(enc)-1]), "should fail with truncated data")
+	test.CheckIsErr(t, got.UnmarshalBinary(append(enc, 0)), "should fail with trailing data")
+
+	for _, bad := range []TokenChallenge{
+		{TokenType: 0x0003, IssuerName: "issuer.example"},
+		{TokenType: PrivateToken},
+		{TokenType: PrivateToken, IssuerName: "issuer.example", RedemptionContext: []byte{1}},
+	} {
+		_, err := bad.MarshalBinary()
+		test.CheckIsErr(t, err, "should fail with invalid challenge")
+	}
+}
+
+func TestTokenKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	test.CheckNoErr(t, err, "failed to generate key")
+	enc, err := marshalTokenKey(&rsaKey.PublicKey)
+	test.CheckNoErr(t, err, "failed to marshal key")
+
+	// SubjectPublicKeyInfo with the RSASSA-PSS object identifier, as in the
+	// test vectors of RFC 9578.
+	wantPrefix, _ := hex.DecodeString("30820152303d06092a864886f70d01010a3030a00d3" +
+		"00b0609608648016503040202a11a301806092a864886f70d010108300b06096086480165" +
+		"03040202a2030201300382010f003082010a0282010100")
+	if !bytes.HasPrefix(enc, wantPrefix) {
+		test.ReportError(t, enc[:len(wantPrefix)], wantPrefix)
+	}
+
+	pk, err := unmarshalTokenKey(enc)
+	test.CheckNoErr(t, err, "failed to unmarshal key")
+	test.CheckOk(pk.Equal(&rsaKey.PublicKey), "keys must be equal", t)
+	_, err = unmarshalTokenKey(enc[:len(enc)-1])
+	test.CheckIsErr(t, err, "should fail with truncated key")
+
+	small, err := rsa.GenerateKey(rand.Reader, 1024)
+	test.CheckNoErr(t, err, "failed to generate key")
+	_, err = NewPublicIssuer(small)
+	test.CheckIsErr(t, err, "should fail with a small key")
+
+	p256Key, err := oprf.GenerateKey(oprf.SuiteP256, rand.Reader)
+	test.CheckNoErr(t, err, "failed to generate key")
+	_, err = NewPrivateIssuer(p256Key)
+	test.CheckIsErr(t, err, "should fail with a key of another suite")
+}
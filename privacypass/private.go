@@ -0,0 +1,122 @@
+package privacypass
+
+import (
+	"crypto/sha256"
+
+	"github.com/cloudflare/circl/group"
+	"github.com/cloudflare/circl/oprf"
+	"github.com/cloudflare/circl/zk/dleq"
+)
+
+// Sizes in bytes for PrivateToken: a serialized element, a serialized scalar,
+// and the authenticator, which is an OPRF output.
+const (
+	privateNe = 49
+	privateNs = 48
+	privateNk = 48
+)
+
+var privateSuite = oprf.SuiteP384
+
+// PrivateIssuer issues and verifies tokens of type PrivateToken.
+type PrivateIssuer struct {
+	key    *oprf.PrivateKey
+	server oprf.VerifiableServer
+	keyID  [KeyIDSize]byte
+}
+
+// NewPrivateIssuer returns an issuer with a private key for the
+// oprf.SuiteP384 suite.
+func NewPrivateIssuer(key *oprf.PrivateKey) (*PrivateIssuer, error) {
+	if key == nil {
+		return nil, ErrInvalidTokenKey
+	}
+
+	// Decoding the key checks that it belongs to the P-384 suite.
+	enc, err := key.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	k := new(oprf.PrivateKey)
+	if err = k.UnmarshalBinary(privateSuite, enc); err != nil {
+		return nil, ErrInvalidTokenKey
+	}
+	pub, err := k.Public().MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &PrivateIssuer{k, oprf.NewVerifiableServer(privateSuite, k), sha256.Sum256(pub)}, nil
+}
+
+// TokenType returns PrivateToken.
+func (i *PrivateIssuer) TokenType() TokenType { return PrivateToken }
+
+// TokenKey returns the encoding of the issuer's public key, which clients use
+// to request tokens.
+func (i *PrivateIssuer) TokenKey() []byte {
+	enc, _ := i.key.Public().MarshalBinary()
+	return enc
+}
+
+// TokenKeyID returns the identifier of the issuer's key.
+func (i *PrivateIssuer) TokenKeyID() [KeyIDSize]byte { return i.keyID }
+
+// Issue evaluates the request, and returns the encoding of the TokenResponse,
+// which is the evaluated element followed by the proof of evaluation.
+func (i *PrivateIssuer) Issue(req *TokenRequest) ([]byte, error) {
+	if err := checkRequest(req, PrivateToken, &i.keyID); err != nil {
+		return nil, err
+	}
+
+	g := privateSuite.Group()
+	blinded := g.NewElement()
+	if err := blinded.UnmarshalBinary(req.BlindedMsg); err != nil || blinded.IsIdentity() {
+		return nil, ErrEncoding
+	}
+
+	eval, err := i.server.Evaluate(&oprf.EvaluationRequest{Elements: []oprf.Blinded{blinded}})
+	if err != nil {
+		return nil, err
+	}
+	out, err := eval.Elements[0].MarshalBinaryCompress()
+	if err != nil {
+		return nil, err
+	}
+	proof, err := eval.Proof.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(out, proof...), nil
+}
+
+// Verify checks that the token was issued with the issuer's key.
+func (i *PrivateIssuer) Verify(token *Token) error {
+	if err := checkToken(token, PrivateToken, &i.keyID); err != nil {
+		return err
+	}
+	if !i.server.VerifyFinalize(token.input(), token.Authenticator) {
+		return ErrInvalidToken
+	}
+
+	return nil
+}
+
+// privateResponse decodes the TokenResponse for PrivateToken.
+func privateResponse(g group.Group, data []byte) (*oprf.Evaluation, error) {
+	if len(data) != privateNe+2*privateNs {
+		return nil, ErrEncoding
+	}
+
+	e := g.NewElement()
+	if err := e.UnmarshalBinary(data[:privateNe]); err != nil {
+		return nil, ErrEncoding
+	}
+	proof := new(dleq.Proof)
+	if err := proof.UnmarshalBinary(g, data[privateNe:]); err != nil {
+		return nil, ErrEncoding
+	}
+
+	return &oprf.Evaluation{Elements: []oprf.Evaluated{e}, Proof: proof}, nil
+}
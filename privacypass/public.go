@@ -0,0 +1,143 @@
+package privacypass
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+
+	"github.com/cloudflare/circl/blindsign/blindrsa"
+	"golang.org/x/crypto/cryptobyte"
+	"golang.org/x/crypto/cryptobyte/asn1"
+)
+
+// publicNk is the size in bytes of the RSA modulus for PublicToken.
+const publicNk = 256
+
+const publicVariant = blindrsa.SHA384PSSDeterministic
+
+// rsaPSSAlgorithmID is the DER encoding of the AlgorithmIdentifier of
+// RSASSA-PSS with SHA-384, MGF1 with SHA-384, and 48-byte salt, which is
+// used to encode token keys.
+var rsaPSSAlgorithmID = []byte{
+	0x30, 0x3d, 0x06, 0x09, 0x2a, 0x86, 0x48, 0x86, 0xf7, 0x0d, 0x01, 0x01,
+	0x0a, 0x30, 0x30, 0xa0, 0x0d, 0x30, 0x0b, 0x06, 0x09, 0x60, 0x86, 0x48,
+	0x01, 0x65, 0x03, 0x04, 0x02, 0x02, 0xa1, 0x1a, 0x30, 0x18, 0x06, 0x09,
+	0x2a, 0x86, 0x48, 0x86, 0xf7, 0x0d, 0x01, 0x01, 0x08, 0x30, 0x0b, 0x06,
+	0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x02, 0xa2, 0x03,
+	0x02, 0x01, 0x30,
+}
+
+// marshalTokenKey returns the SubjectPublicKeyInfo encoding of the public
+// key with the RSASSA-PSS object identifier.
+func marshalTokenKey(pk *rsa.PublicKey) ([]byte, error) {
+	var b cryptobyte.Builder
+	b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		b.AddBytes(rsaPSSAlgorithmID)
+		b.AddASN1BitString(x509.MarshalPKCS1PublicKey(pk))
+	})
+
+	return b.Bytes()
+}
+
+// unmarshalTokenKey recovers a public key from its SubjectPublicKeyInfo
+// encoding with the RSASSA-PSS object identifier.
+func unmarshalTokenKey(data []byte) (*rsa.PublicKey, error) {
+	var spki, algID cryptobyte.String
+	var bits []byte
+	s := cryptobyte.String(data)
+	if !s.ReadASN1(&spki, asn1.SEQUENCE) || !s.Empty() ||
+		!spki.ReadASN1Element(&algID, asn1.SEQUENCE) ||
+		!bytes.Equal(algID, rsaPSSAlgorithmID) ||
+		!spki.ReadASN1BitStringAsBytes(&bits) || !spki.Empty() {
+		return nil, ErrInvalidTokenKey
+	}
+
+	pk, err := x509.ParsePKCS1PublicKey(bits)
+	if err != nil || (pk.N.BitLen()+7)/8 != publicNk {
+		return nil, ErrInvalidTokenKey
+	}
+
+	return pk, nil
+}
+
+// PublicVerifier verifies tokens of type PublicToken.
+type PublicVerifier struct {
+	tokenKey []byte
+	keyID    [KeyIDSize]byte
+	verifier blindrsa.Verifier
+}
+
+// NewPublicVerifier returns a verifier from the encoding of the issuer's
+// public key, as returned by PublicIssuer.TokenKey.
+func NewPublicVerifier(tokenKey []byte) (*PublicVerifier, error) {
+	pk, err := unmarshalTokenKey(tokenKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPublicVerifier(pk, tokenKey)
+}
+
+func newPublicVerifier(pk *rsa.PublicKey, tokenKey []byte) (*PublicVerifier, error) {
+	v, err := blindrsa.NewVerifier(publicVariant, pk)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PublicVerifier{append([]byte{}, tokenKey...), sha256.Sum256(tokenKey), v}, nil
+}
+
+// TokenType returns PublicToken.
+func (v *PublicVerifier) TokenType() TokenType { return PublicToken }
+
+// TokenKey returns the encoding of the issuer's public key.
+func (v *PublicVerifier) TokenKey() []byte { return append([]byte{}, v.tokenKey...) }
+
+// TokenKeyID returns the identifier of the issuer's key.
+func (v *PublicVerifier) TokenKeyID() [KeyIDSize]byte { return v.keyID }
+
+// Verify checks that the token was signed with the issuer's key.
+func (v *PublicVerifier) Verify(token *Token) error {
+	if err := checkToken(token, PublicToken, &v.keyID); err != nil {
+		return err
+	}
+	if v.verifier.Verify(token.input(), token.Authenticator) != nil {
+		return ErrInvalidToken
+	}
+
+	return nil
+}
+
+// PublicIssuer issues tokens of type PublicToken.
+type PublicIssuer struct {
+	PublicVerifier
+	signer blindrsa.Signer
+}
+
+// NewPublicIssuer returns an issuer with a 2048-bit RSA private key.
+func NewPublicIssuer(sk *rsa.PrivateKey) (*PublicIssuer, error) {
+	if sk == nil || (sk.N.BitLen()+7)/8 != publicNk {
+		return nil, ErrInvalidTokenKey
+	}
+	tokenKey, err := marshalTokenKey(&sk.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	v, err := newPublicVerifier(&sk.PublicKey, tokenKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PublicIssuer{*v, blindrsa.NewSigner(sk)}, nil
+}
+
+// Issue signs the request, and returns the encoding of the TokenResponse,
+// which is the blind signature.
+func (i *PublicIssuer) Issue(req *TokenRequest) ([]byte, error) {
+	if err := checkRequest(req, PublicToken, &i.keyID); err != nil {
+		return nil, err
+	}
+
+	return i.signer.BlindSign(req.BlindedMsg)
+}
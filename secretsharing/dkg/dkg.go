@@ -0,0 +1,305 @@
+// Package dkg provides a distributed key generation protocol.
+//
+// This package implements a Pedersen distributed key generation (DKG) [1],
+// in which n participants jointly generate a (t,n) Shamir secret sharing of
+// a random secret without any trusted dealer. No participant learns the
+// secret, and any subset of at least t+1 participants holding shares can use
+// it, for example, to sign with FROST or to run a threshold decryption.
+//
+// Each participant acts as a dealer of a Feldman verifiable secret sharing of
+// a random value. The final secret is the sum of the values dealt by the
+// qualified participants. As in the DKG of FROST [2], every participant also
+// proves knowledge of the value it deals, which prevents rogue-key attacks.
+//
+// The protocol assumes authenticated private channels and a reliable
+// broadcast channel between participants, and runs as follows:
+//
+//  1. Round1: each participant broadcasts a Broadcast message and sends a
+//     PrivateShare to every other participant.
+//  2. Round2: each participant checks the messages received, and broadcasts
+//     a Complaint against each participant that sent an invalid share.
+//  3. Justify: each participant with complaints against it broadcasts the
+//     requested shares, so anyone can check them.
+//  4. Finalize: each participant disqualifies the dealers that misbehaved,
+//     and outputs its secretsharing.Share, the group public key, and the
+//     commitment to the shared secret.
+//
+// All honest participants compute the same set of qualified participants,
+// group public key, and commitment, as long as they receive the same
+// broadcast messages.
+//
+// Warning: With the group.P256, group.P384, or group.P521 groups, the
+// protocol is currently not constant time in the secret values.
+//
+// References
+//
+//	[1] Gennaro, Jarecki, Krawczyk, and Rabin. Secure Distributed Key Generation for Discrete-Log Based Cryptosystems. https://doi.org/10.1007/s00145-006-0347-3
+//	[2] Komlo and Goldberg. FROST: Flexible Round-Optimized Schnorr Threshold Signatures. https://ia.cr/2020/852
+package dkg
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/cloudflare/circl/group"
+	"github.com/cloudflare/circl/secretsharing"
+	"github.com/cloudflare/circl/zk/dl"
+)
+
+var (
+	ErrParams       = errors.New("dkg: invalid parameters")
+	ErrRound        = errors.New("dkg: function called out of order")
+	ErrQualified    = errors.New("dkg: not enough qualified participants")
+	ErrMissingShare = errors.New("dkg: missing share from a qualified participant")
+)
+
+// Broadcast is the message that a participant broadcasts in the first round.
+// It contains the commitment to the participant's secret polynomial, and a
+// proof of knowledge of the value being dealt.
+type Broadcast struct {
+	From       uint
+	Commitment secretsharing.SecretCommitment
+	Proof      dl.Proof
+}
+
+// PrivateShare is a share of the value dealt by the participant From, which
+// is sent privately to the participant To. It is also used to publicly
+// reveal a share in response to a Complaint.
+type PrivateShare struct {
+	From, To uint
+	Share    secretsharing.Share
+}
+
+// Complaint is broadcast by the participant From to denounce that the
+// participant Against did not send a valid share.
+type Complaint struct {
+	From, Against uint
+}
+
+// Result is the output of the protocol for a participant.
+type Result struct {
+	// Share is the participant's share of the secret.
+	Share secretsharing.Share
+	// PublicKey is the group public key, that is, secret times the generator.
+	PublicKey group.Element
+	// Commitment is the commitment to the shared secret, which allows
+	// verifying any share with secretsharing.Verify.
+	Commitment secretsharing.SecretCommitment
+	// Qualified lists the participants whose values form the secret.
+	Qualified []uint
+}
+
+// Participant runs the distributed key generation. Participants are
+// identified by an integer in [1, n], which is also the ID of their share.
+type Participant struct {
+	g        group.Group
+	t, n, id uint
+	context  []byte
+	secret   group.Scalar
+	ss       secretsharing.SecretSharing
+	round    int
+
+	coms         map[uint]secretsharing.SecretCommitment
+	shares       map[uint]secretsharing.Share
+	disqualified map[uint]bool
+}
+
+// NewParticipant returns the participant id out of n participants for a DKG
+// producing a (t,n) secret sharing, where 0 < t < n and 1 <= id <= n. The
+// context must be unique to the protocol execution and be the same for all
+// participants; it is used to bind the proofs of knowledge.
+func NewParticipant(rnd io.Reader, g group.Group, t, n, id uint, context []byte) (*Participant, error) {
+	if t == 0 || t >= n || id == 0 || id > n {
+		return nil, ErrParams
+	}
+
+	secret := g.RandomNonZeroScalar(rnd)
+	return &Participant{
+		g:            g,
+		t:            t,
+		n:            n,
+		id:           id,
+		context:      slices.Clone(context),
+		secret:       secret,
+		ss:           secretsharing.New(rnd, t, secret),
+		coms:         make(map[uint]secretsharing.SecretCommitment),
+		shares:       make(map[uint]secretsharing.Share),
+		disqualified: make(map[uint]bool),
+	}, nil
+}
+
+// ID returns the identifier of the participant.
+func (p *Participant) ID() uint { return p.id }
+
+func (p *Participant) shareID(id uint) group.Scalar {
+	return p.g.NewScalar().SetUint64(uint64(id))
+}
+
+func (p *Participant) userID(id uint) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(id))
+}
+
+// Round1 returns the message to be broadcast to all participants, and the
+// shares to be sent privately to each of the other participants.
+func (p *Participant) Round1(rnd io.Reader) (*Broadcast, []PrivateShare, error) {
+	if p.round != 0 {
+		return nil, nil, ErrRound
+	}
+	p.round++
+
+	com := p.ss.CommitSecret()
+	proof := dl.Prove(p.g, p.g.Generator(), com[0], p.secret, p.userID(p.id), p.context, rnd)
+	p.coms[p.id] = com
+	p.shares[p.id] = p.ss.ShareWithID(p.shareID(p.id))
+
+	shares := make([]PrivateShare, 0, p.n-1)
+	for j := uint(1); j <= p.n; j++ {
+		if j != p.id {
+			shares = append(shares, PrivateShare{p.id, j, p.ss.ShareWithID(p.shareID(j))})
+		}
+	}
+
+	return &Broadcast{p.id, com, proof}, shares, nil
+}
+
+// Round2 processes the messages broadcast by all participants and the
+// shares sent to this participant. Participants with a missing or invalid
+// broadcast message are disqualified. It returns the complaints to be
+// broadcast against participants that did not send a valid share.
+func (p *Participant) Round2(broadcasts []Broadcast, shares []PrivateShare) ([]Complaint, error) {
+	if p.round != 1 {
+		return nil, ErrRound
+	}
+	p.round++
+
+	// The participant's own broadcast is also checked, so all participants
+	// agree on which ones are disqualified.
+	for i := uint(1); i <= p.n; i++ {
+		k := slices.IndexFunc(broadcasts, func(b Broadcast) bool { return b.From == i })
+		if k < 0 || !p.checkBroadcast(&broadcasts[k]) {
+			p.disqualified[i] = true
+		} else if i != p.id {
+			p.coms[i] = broadcasts[k].Commitment
+		}
+	}
+
+	for i := range shares {
+		s := &shares[i]
+		if s.To == p.id && s.From != p.id && p.checkShare(s) {
+			p.shares[s.From] = s.Share
+		}
+	}
+
+	var complaints []Complaint
+	for i := uint(1); i <= p.n; i++ {
+		if _, ok := p.shares[i]; !ok && !p.disqualified[i] {
+			complaints = append(complaints, Complaint{p.id, i})
+		}
+	}
+
+	return complaints, nil
+}
+
+func (p *Participant) checkBroadcast(b *Broadcast) bool {
+	if uint(len(b.Commitment)) != p.t+1 || b.Proof.V == nil || b.Proof.R == nil {
+		return false
+	}
+	for _, c := range b.Commitment {
+		if c == nil {
+			return false
+		}
+	}
+	return dl.Verify(p.g, p.g.Generator(), b.Commitment[0], b.Proof, p.userID(b.From), p.context)
+}
+
+// checkShare returns true if the share was dealt by a participant that is not
+// disqualified, and is consistent with the dealer's commitment.
+func (p *Participant) checkShare(s *PrivateShare) bool {
+	com, ok := p.coms[s.From]
+	return ok && !p.disqualified[s.From] &&
+		s.To >= 1 && s.To <= p.n &&
+		s.Share.ID != nil && s.Share.Value != nil &&
+		s.Share.ID.IsEqual(p.shareID(s.To)) &&
+		secretsharing.Verify(p.t, s.Share, com)
+}
+
+// Justify returns the shares that this participant must publicly reveal to
+// answer the complaints against it.
+func (p *Participant) Justify(complaints []Complaint) ([]PrivateShare, error) {
+	if p.round != 2 {
+		return nil, ErrRound
+	}
+	p.round++
+
+	var justifications []PrivateShare
+	for _, c := range complaints {
+		if c.Against == p.id && c.From >= 1 && c.From <= p.n && c.From != p.id {
+			justifications = append(justifications,
+				PrivateShare{p.id, c.From, p.ss.ShareWithID(p.shareID(c.From))})
+		}
+	}
+	return justifications, nil
+}
+
+// Finalize processes all the complaints and justifications broadcast by the
+// participants. A participant is disqualified if there is a complaint against
+// it that was not answered with a valid share. It returns an error if the
+// number of qualified participants is not above the threshold t.
+func (p *Participant) Finalize(complaints []Complaint, justifications []PrivateShare) (*Result, error) {
+	if p.round != 3 {
+		return nil, ErrRound
+	}
+	p.round++
+
+	for _, c := range complaints {
+		if c.From < 1 || c.From > p.n || c.From == c.Against || p.disqualified[c.Against] {
+			continue
+		}
+		k := slices.IndexFunc(justifications, func(s PrivateShare) bool {
+			return s.From == c.Against && s.To == c.From
+		})
+		if k < 0 || !p.checkShare(&justifications[k]) {
+			p.disqualified[c.Against] = true
+			continue
+		}
+		if c.From == p.id {
+			p.shares[c.Against] = justifications[k].Share
+		}
+	}
+
+	var qualified []uint
+	for i := uint(1); i <= p.n; i++ {
+		if !p.disqualified[i] {
+			qualified = append(qualified, i)
+		}
+	}
+	if uint(len(qualified)) <= p.t {
+		return nil, fmt.Errorf("%w: got %v, threshold %v", ErrQualified, len(qualified), p.t)
+	}
+
+	value := p.g.NewScalar()
+	com := make(secretsharing.SecretCommitment, p.t+1)
+	for k := range com {
+		com[k] = p.g.Identity()
+	}
+	for _, i := range qualified {
+		share, ok := p.shares[i]
+		if !ok {
+			return nil, ErrMissingShare
+		}
+		value.Add(value, share.Value)
+		for k := range com {
+			com[k].Add(com[k], p.coms[i][k])
+		}
+	}
+
+	return &Result{
+		Share:      secretsharing.Share{ID: p.shareID(p.id), Value: value},
+		PublicKey:  com[0].Copy(),
+		Commitment: com,
+		Qualified:  qualified,
+	}, nil
+}
@@ -0,0 +1,243 @@
+package dkg_test
+
+import (
+	"crypto/rand"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/cloudflare/circl/group"
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/secretsharing"
+	"github.com/cloudflare/circl/secretsharing/dkg"
+	"github.com/cloudflare/circl/sign/ed25519"
+	"github.com/cloudflare/circl/tss/frost"
+)
+
+var context = []byte("dkg test")
+
+// messages exchanged in a run of the protocol, with hooks for corrupting
+// them.
+type execution struct {
+	g            group.Group
+	t, n         uint
+	parties      []*dkg.Participant
+	broadcasts   []dkg.Broadcast
+	shares       []dkg.PrivateShare
+	complaints   []dkg.Complaint
+	justifies    []dkg.PrivateShare
+	afterRound1  func(e *execution)
+	afterJustify func(e *execution)
+}
+
+func (e *execution) run(t *testing.T) []*dkg.Result {
+	t.Helper()
+	e.parties = make([]*dkg.Participant, e.n)
+	for i := range e.parties {
+		p, err := dkg.NewParticipant(rand.Reader, e.g, e.t, e.n, uint(i+1), context)
+		test.CheckNoErr(t, err, "failed to create participant")
+		e.parties[i] = p
+
+		b, s, err := p.Round1(rand.Reader)
+		test.CheckNoErr(t, err, "round1 failed")
+		e.broadcasts = append(e.broadcasts, *b)
+		e.shares = append(e.shares, s...)
+	}
+
+	if e.afterRound1 != nil {
+		e.afterRound1(e)
+	}
+
+	for _, p := range e.parties {
+		c, err := p.Round2(e.broadcasts, e.shares)
+		test.CheckNoErr(t, err, "round2 failed")
+		e.complaints = append(e.complaints, c...)
+	}
+
+	for _, p := range e.parties {
+		j, err := p.Justify(e.complaints)
+		test.CheckNoErr(t, err, "justify failed")
+		e.justifies = append(e.justifies, j...)
+	}
+
+	if e.afterJustify != nil {
+		e.afterJustify(e)
+	}
+
+	results := make([]*dkg.Result, e.n)
+	for i, p := range e.parties {
+		r, err := p.Finalize(e.complaints, e.justifies)
+		test.CheckNoErr(t, err, "finalize failed")
+		results[i] = r
+	}
+	return results
+}
+
+// checkResults checks that all participants agree on the outcome, and that
+// the shares are a valid sharing of the group private key.
+func checkResults(t *testing.T, g group.Group, threshold uint, results []*dkg.Result, qualified []uint) {
+	t.Helper()
+	shares := make([]secretsharing.Share, len(results))
+	for i, r := range results {
+		if !slices.Equal(r.Qualified, qualified) {
+			test.ReportError(t, r.Qualified, qualified, i)
+		}
+		if !r.PublicKey.IsEqual(results[0].PublicKey) {
+			test.ReportError(t, r.PublicKey, results[0].PublicKey, i)
+		}
+		test.CheckOk(secretsharing.Verify(threshold, r.Share, results[0].Commitment), "invalid share", t)
+		shares[i] = r.Share
+	}
+
+	secret, err := secretsharing.Recover(threshold, shares)
+	test.CheckNoErr(t, err, "failed to recover")
+	got := g.NewElement().MulGen(secret)
+	if !got.IsEqual(results[0].PublicKey) {
+		test.ReportError(t, got, results[0].PublicKey)
+	}
+}
+
+func allIDs(n uint) (ids []uint) {
+	for i := uint(1); i <= n; i++ {
+		ids = append(ids, i)
+	}
+	return
+}
+
+func TestDKG(t *testing.T) {
+	for _, g := range []group.Group{group.P256, group.Ristretto255, group.Ed25519} {
+		t.Run(fmt.Sprint(g), func(t *testing.T) {
+			for _, tn := range [][2]uint{{1, 2}, {1, 3}, {2, 5}, {4, 7}} {
+				e := &execution{g: g, t: tn[0], n: tn[1]}
+				results := e.run(t)
+				test.CheckOk(len(e.complaints) == 0, "unexpected complaints", t)
+				checkResults(t, g, tn[0], results, allIDs(tn[1]))
+			}
+		})
+	}
+}
+
+func TestDKGMisbehavior(t *testing.T) {
+	const threshold, n = 2, 5
+	g := group.P256
+
+	t.Run("invalidProof", func(t *testing.T) {
+		e := &execution{g: g, t: threshold, n: n}
+		e.afterRound1 = func(e *execution) {
+			e.broadcasts[1].Proof.R.Add(e.broadcasts[1].Proof.R, g.NewScalar().SetUint64(1))
+		}
+		results := e.run(t)
+		checkResults(t, g, threshold, results, []uint{1, 3, 4, 5})
+	})
+
+	t.Run("missingBroadcast", func(t *testing.T) {
+		e := &execution{g: g, t: threshold, n: n}
+		e.afterRound1 = func(e *execution) { e.broadcasts = e.broadcasts[1:] }
+		results := e.run(t)
+		checkResults(t, g, threshold, results, []uint{2, 3, 4, 5})
+	})
+
+	t.Run("badShareJustified", func(t *testing.T) {
+		e := &execution{g: g, t: threshold, n: n}
+		e.afterRound1 = func(e *execution) { corruptShare(e, 3, 4) }
+		results := e.run(t)
+		test.CheckOk(slices.Equal(e.complaints, []dkg.Complaint{{From: 4, Against: 3}}), "expected one complaint", t)
+		checkResults(t, g, threshold, results, allIDs(n))
+	})
+
+	t.Run("badShareNotJustified", func(t *testing.T) {
+		e := &execution{g: g, t: threshold, n: n}
+		e.afterRound1 = func(e *execution) { corruptShare(e, 3, 4) }
+		e.afterJustify = func(e *execution) {
+			for i := range e.justifies {
+				e.justifies[i].Share.Value.Add(e.justifies[i].Share.Value, g.NewScalar().SetUint64(1))
+			}
+		}
+		results := e.run(t)
+		checkResults(t, g, threshold, results, []uint{1, 2, 4, 5})
+	})
+
+	t.Run("tooManyFaulty", func(t *testing.T) {
+		// Only the participant itself sends messages.
+		p, err := dkg.NewParticipant(rand.Reader, g, threshold, n, 1, context)
+		test.CheckNoErr(t, err, "failed to create participant")
+		b, s, err := p.Round1(rand.Reader)
+		test.CheckNoErr(t, err, "round1 failed")
+		_, err = p.Round2([]dkg.Broadcast{*b}, s)
+		test.CheckNoErr(t, err, "round2 failed")
+		_, err = p.Justify(nil)
+		test.CheckNoErr(t, err, "justify failed")
+		_, err = p.Finalize(nil, nil)
+		test.CheckIsErr(t, err, "finalize must fail")
+	})
+}
+
+// corruptShare modifies the share sent from one participant to another.
+func corruptShare(e *execution, from, to uint) {
+	for i := range e.shares {
+		if e.shares[i].From == from && e.shares[i].To == to {
+			v := e.shares[i].Share.Value.Copy()
+			e.shares[i].Share.Value = v.Add(v, e.g.NewScalar().SetUint64(1))
+		}
+	}
+}
+
+func TestDKGErrors(t *testing.T) {
+	g := group.Ristretto255
+	for _, params := range [][3]uint{{0, 3, 1}, {3, 3, 1}, {1, 3, 0}, {1, 3, 4}} {
+		_, err := dkg.NewParticipant(rand.Reader, g, params[0], params[1], params[2], context)
+		test.CheckIsErr(t, err, "should fail with invalid params")
+	}
+
+	p, err := dkg.NewParticipant(rand.Reader, g, 1, 2, 1, context)
+	test.CheckNoErr(t, err, "failed to create participant")
+	_, err = p.Round2(nil, nil)
+	test.CheckIsErr(t, err, "should fail out of order")
+	_, err = p.Justify(nil)
+	test.CheckIsErr(t, err, "should fail out of order")
+	_, err = p.Finalize(nil, nil)
+	test.CheckIsErr(t, err, "should fail out of order")
+	_, _, err = p.Round1(rand.Reader)
+	test.CheckNoErr(t, err, "round1 failed")
+	_, _, err = p.Round1(rand.Reader)
+	test.CheckIsErr(t, err, "should fail out of order")
+}
+
+func TestDKGWithFrost(t *testing.T) {
+	const threshold, n = 2, 4
+	s := frost.Ed25519
+	e := &execution{g: s.Group(), t: threshold, n: n}
+	results := e.run(t)
+
+	groupPublicKey, err := frost.NewPublicKey(s, results[0].PublicKey)
+	test.CheckNoErr(t, err, "invalid public key")
+	peers := make([]frost.PeerSigner, threshold+1)
+	for i := range peers {
+		peers[i], err = frost.NewPeerSigner(s, threshold, n, results[i].Share, groupPublicKey)
+		test.CheckNoErr(t, err, "failed to create signer")
+	}
+
+	msg := []byte("dealerless")
+	nonces := make([]*frost.Nonce, len(peers))
+	coms := make([]frost.Commitment, len(peers))
+	for i := range peers {
+		nonce, com, errCom := peers[i].Commit(rand.Reader)
+		test.CheckNoErr(t, errCom, "commit failed")
+		nonces[i], coms[i] = nonce, *com
+	}
+	shares := make([]frost.SignShare, len(peers))
+	for i := range peers {
+		share, errSign := peers[i].Sign(msg, nonces[i], coms)
+		test.CheckNoErr(t, errSign, "sign failed")
+		shares[i] = *share
+	}
+
+	coordinator, err := frost.NewCoordinator(s, groupPublicKey, threshold, n)
+	test.CheckNoErr(t, err, "failed to create coordinator")
+	signature, err := coordinator.Aggregate(msg, coms, shares)
+	test.CheckNoErr(t, err, "failed to aggregate")
+
+	pubKey, err := groupPublicKey.MarshalBinary()
+	test.CheckNoErr(t, err, "failed to marshal")
+	test.CheckOk(ed25519.Verify(pubKey, msg, signature), "invalid signature", t)
+}
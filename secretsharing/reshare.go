@@ -0,0 +1,164 @@
+package secretsharing
+
+import (
+	"errors"
+	"io"
+
+	"github.com/cloudflare/circl/group"
+	"github.com/cloudflare/circl/math/polynomial"
+)
+
+var (
+	errReshareDealers = errors.New("secretsharing: invalid set of dealers")
+	errShareIDs       = errors.New("secretsharing: shares must have the same ID")
+	errCommitmentLen  = errors.New("secretsharing: commitments of different length")
+)
+
+// Reshare returns a SecretSharing with threshold t of the old share s. The
+// holder of s acts as a dealer in a resharing protocol.
+func Reshare(rnd io.Reader, t uint, s Share) SecretSharing {
+	return New(rnd, t, s.Value)
+}
+
+// VerifyReshare returns true if the share s was produced by resharing with
+// threshold t the old share with ID dealerID, such that dealerCom is the
+// commitment of that resharing, and it is consistent with the commitment
+// oldCom of the old sharing.
+func VerifyReshare(t uint, s Share, dealerID group.Scalar, dealerCom, oldCom SecretCommitment) bool {
+	if len(dealerCom) == 0 || len(oldCom) == 0 || dealerID.IsZero() {
+		return false
+	}
+	return dealerCom[0].IsEqual(EvalCommitment(oldCom, dealerID)) && Verify(t, s, dealerCom)
+}
+
+// CombineReshares returns the new share from the shares dealt by a set of
+// old shareholders when resharing, where shares[i] was dealt by the holder
+// with ID dealerIDs[i]. The set of dealers must have more than t elements,
+// where t is the threshold of the old sharing, and dealerIDs must be
+// different.
+func CombineReshares(t uint, dealerIDs []group.Scalar, shares []Share) (Share, error) {
+	if uint(len(dealerIDs)) <= t || len(dealerIDs) != len(shares) {
+		return Share{}, errReshareDealers
+	}
+
+	id := shares[0].ID
+	lambdas, err := lagrangeAtZero(dealerIDs)
+	if err != nil {
+		return Share{}, err
+	}
+
+	g := id.Group()
+	value := g.NewScalar()
+	tmp := g.NewScalar()
+	for i := range shares {
+		if !shares[i].ID.IsEqual(id) {
+			return Share{}, errShareIDs
+		}
+		value.Add(value, tmp.Mul(lambdas[i], shares[i].Value))
+	}
+
+	return Share{ID: id.Copy(), Value: value}, nil
+}
+
+// CombineReshareCommitments returns the commitment to the new sharing from the
+// commitments broadcast by a set of old shareholders when resharing, where
+// dealerComs[i] was produced by the holder with ID dealerIDs[i]. As in
+// CombineReshares, there must be more than t dealers, where t is the threshold
+// of the old sharing. The result allows verifying the new shares with Verify.
+func CombineReshareCommitments(t uint, dealerIDs []group.Scalar, dealerComs []SecretCommitment) (SecretCommitment, error) {
+	if uint(len(dealerIDs)) <= t || len(dealerIDs) != len(dealerComs) {
+		return nil, errReshareDealers
+	}
+
+	lambdas, err := lagrangeAtZero(dealerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	g := dealerIDs[0].Group()
+	c := make(SecretCommitment, len(dealerComs[0]))
+	for k := range c {
+		c[k] = g.Identity()
+	}
+
+	tmp := g.NewElement()
+	for i := range dealerComs {
+		if len(dealerComs[i]) != len(c) {
+			return nil, errCommitmentLen
+		}
+		for k := range c {
+			c[k].Add(c[k], tmp.Mul(dealerComs[i][k], lambdas[i]))
+		}
+	}
+
+	return c, nil
+}
+
+// NewRefresh returns a SecretSharing of zero with threshold t over the
+// scalars of the group g. Each shareholder uses it to deal shares that
+// re-randomize the shares of the other holders.
+func NewRefresh(rnd io.Reader, t uint, g group.Group) SecretSharing {
+	return New(rnd, t, g.NewScalar())
+}
+
+// VerifyRefresh returns true if the share s was produced by a refresh with
+// threshold t and commitment c, that is, c commits to a sharing of zero.
+func VerifyRefresh(t uint, s Share, c SecretCommitment) bool {
+	return len(c) != 0 && c[0].IsIdentity() && Verify(t, s, c)
+}
+
+// Refresh returns the share s updated with the shares dealt by all the
+// holders during a refresh. The shares must have the same ID as s.
+func Refresh(s Share, shares []Share) (Share, error) {
+	value := s.Value.Copy()
+	for i := range shares {
+		if !shares[i].ID.IsEqual(s.ID) {
+			return Share{}, errShareIDs
+		}
+		value.Add(value, shares[i].Value)
+	}
+
+	return Share{ID: s.ID.Copy(), Value: value}, nil
+}
+
+// RefreshCommitment returns the commitment c updated with the commitments
+// broadcast by all the holders during a refresh.
+func RefreshCommitment(c SecretCommitment, refreshComs []SecretCommitment) (SecretCommitment, error) {
+	out := make(SecretCommitment, len(c))
+	for k := range c {
+		out[k] = c[k].Copy()
+	}
+
+	for i := range refreshComs {
+		if len(refreshComs[i]) != len(c) {
+			return nil, errCommitmentLen
+		}
+		for k := range c {
+			out[k].Add(out[k], refreshComs[i][k])
+		}
+	}
+
+	return out, nil
+}
+
+// lagrangeAtZero returns the Lagrange coefficients at zero for the set of
+// nodes x, which must be non-zero and different.
+func lagrangeAtZero(x []group.Scalar) ([]group.Scalar, error) {
+	for i := range x {
+		if x[i].IsZero() {
+			return nil, errZeroID
+		}
+		for j := range x[:i] {
+			if x[i].IsEqual(x[j]) {
+				return nil, errReshareDealers
+			}
+		}
+	}
+
+	zero := x[0].Group().NewScalar()
+	l := make([]group.Scalar, len(x))
+	for i := range x {
+		l[i] = polynomial.LagrangeBase(uint(i), x, zero)
+	}
+	return l, nil
+}
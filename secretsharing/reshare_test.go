@@ -0,0 +1,143 @@
+package secretsharing_test
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/cloudflare/circl/group"
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/secretsharing"
+)
+
+func TestReshare(tt *testing.T) {
+	g := group.P256
+	t := uint(2)
+	n := uint(5)
+
+	secret := g.RandomScalar(rand.Reader)
+	ss := secretsharing.New(rand.Reader, t, secret)
+	shares := ss.Share(n)
+	coms := ss.CommitSecret()
+
+	// Redistribute from a (2,5) to a (3,7) sharing, dealt by a subset of t+1
+	// old holders.
+	newT := uint(3)
+	newN := uint(7)
+	dealers := []secretsharing.Share{shares[4], shares[0], shares[2]}
+	dealerIDs := make([]group.Scalar, len(dealers))
+	dealerComs := make([]secretsharing.SecretCommitment, len(dealers))
+	subShares := make([][]secretsharing.Share, len(dealers))
+	for i := range dealers {
+		rs := secretsharing.Reshare(rand.Reader, newT, dealers[i])
+		dealerIDs[i] = dealers[i].ID
+		dealerComs[i] = rs.CommitSecret()
+		subShares[i] = rs.Share(newN)
+	}
+
+	newComs, err := secretsharing.CombineReshareCommitments(t, dealerIDs, dealerComs)
+	test.CheckNoErr(tt, err, "failed to combine commitments")
+	test.CheckOk(newComs[0].IsEqual(coms[0]), "commitment to a different secret", tt)
+
+	newShares := make([]secretsharing.Share, newN)
+	for j := range newShares {
+		received := make([]secretsharing.Share, len(dealers))
+		for i := range dealers {
+			received[i] = subShares[i][j]
+			ok := secretsharing.VerifyReshare(newT, received[i], dealerIDs[i], dealerComs[i], coms)
+			test.CheckOk(ok, "invalid reshared share", tt)
+		}
+		newShares[j], err = secretsharing.CombineReshares(t, dealerIDs, received)
+		test.CheckNoErr(tt, err, "failed to combine shares")
+		test.CheckOk(secretsharing.Verify(newT, newShares[j], newComs), "invalid new share", tt)
+	}
+
+	got, err := secretsharing.Recover(newT, newShares[2:])
+	test.CheckNoErr(tt, err, "should recover secret")
+	if !got.IsEqual(secret) {
+		test.ReportError(tt, got, secret, t, newT)
+	}
+	_, err = secretsharing.Recover(newT, newShares[:newT])
+	test.CheckIsErr(tt, err, "should not recover secret")
+
+	tt.Run("badDealer", func(ttt *testing.T) {
+		// A dealer resharing a value other than its old share is detected.
+		bad := secretsharing.Share{ID: dealers[0].ID, Value: g.RandomScalar(rand.Reader)}
+		rs := secretsharing.Reshare(rand.Reader, newT, bad)
+		s := rs.Share(1)[0]
+		ok := secretsharing.VerifyReshare(newT, s, bad.ID, rs.CommitSecret(), coms)
+		test.CheckOk(!ok, "verify must fail due to bad dealer", ttt)
+		ok = secretsharing.VerifyReshare(newT, subShares[0][0], dealerIDs[1], dealerComs[0], coms)
+		test.CheckOk(!ok, "verify must fail due to wrong dealer ID", ttt)
+	})
+
+	tt.Run("errors", func(ttt *testing.T) {
+		received := []secretsharing.Share{subShares[0][0], subShares[1][0], subShares[2][0]}
+		_, err := secretsharing.CombineReshares(t, dealerIDs[:2], received)
+		test.CheckIsErr(ttt, err, "should fail with mismatched lengths")
+		_, err = secretsharing.CombineReshares(t, dealerIDs[:2], received[:2])
+		test.CheckIsErr(ttt, err, "should fail with t dealers")
+		_, err = secretsharing.CombineReshareCommitments(t, dealerIDs[:2], dealerComs[:2])
+		test.CheckIsErr(ttt, err, "should fail with t dealers")
+		dupIDs := []group.Scalar{dealerIDs[0], dealerIDs[1], dealerIDs[0]}
+		_, err = secretsharing.CombineReshares(t, dupIDs, received)
+		test.CheckIsErr(ttt, err, "should fail with duplicated dealers")
+		_, err = secretsharing.CombineReshareCommitments(t, dupIDs, dealerComs)
+		test.CheckIsErr(ttt, err, "should fail with duplicated dealers")
+		received[1] = subShares[1][1]
+		_, err = secretsharing.CombineReshares(t, dealerIDs, received)
+		test.CheckIsErr(ttt, err, "should fail with different share IDs")
+	})
+}
+
+func TestRefresh(tt *testing.T) {
+	g := group.Ristretto255
+	t := uint(2)
+	n := uint(5)
+
+	secret := g.RandomScalar(rand.Reader)
+	ss := secretsharing.New(rand.Reader, t, secret)
+	shares := ss.Share(n)
+	coms := ss.CommitSecret()
+
+	refreshComs := make([]secretsharing.SecretCommitment, n)
+	deltas := make([][]secretsharing.Share, n)
+	for i := range deltas {
+		rs := secretsharing.NewRefresh(rand.Reader, t, g)
+		refreshComs[i] = rs.CommitSecret()
+		deltas[i] = rs.Share(n)
+	}
+
+	newComs, err := secretsharing.RefreshCommitment(coms, refreshComs)
+	test.CheckNoErr(tt, err, "failed to refresh commitment")
+
+	newShares := make([]secretsharing.Share, n)
+	for j := range newShares {
+		received := make([]secretsharing.Share, n)
+		for i := range deltas {
+			received[i] = deltas[i][j]
+			test.CheckOk(secretsharing.VerifyRefresh(t, received[i], refreshComs[i]), "invalid refresh share", tt)
+		}
+		newShares[j], err = secretsharing.Refresh(shares[j], received)
+		test.CheckNoErr(tt, err, "failed to refresh")
+		test.CheckOk(!newShares[j].Value.IsEqual(shares[j].Value), "share was not refreshed", tt)
+		test.CheckOk(secretsharing.Verify(t, newShares[j], newComs), "invalid refreshed share", tt)
+	}
+
+	got, err := secretsharing.Recover(t, newShares[1:])
+	test.CheckNoErr(tt, err, "should recover secret")
+	if !got.IsEqual(secret) {
+		test.ReportError(tt, got, secret, t, n)
+	}
+
+	tt.Run("badRefresh", func(ttt *testing.T) {
+		// A refresh that does not share zero is detected.
+		rs := secretsharing.New(rand.Reader, t, g.RandomNonZeroScalar(rand.Reader))
+		ok := secretsharing.VerifyRefresh(t, rs.Share(1)[0], rs.CommitSecret())
+		test.CheckOk(!ok, "verify must fail due to non-zero secret", ttt)
+
+		_, err := secretsharing.Refresh(shares[0], deltas[0])
+		test.CheckIsErr(ttt, err, "should fail with different share IDs")
+		_, err = secretsharing.RefreshCommitment(coms, [][]group.Element{coms[:t]})
+		test.CheckIsErr(ttt, err, "should fail with mismatched commitments")
+	})
+}
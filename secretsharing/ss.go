@@ -13,6 +13,19 @@
 // The SecretSharing can be verifiable (compatible with Feldman secret sharing)
 // using the CommitSecret and Verify functions.
 //
+// Shares can be redistributed without recovering the secret [3]. Reshare
+// allows any subset of at least t+1 shareholders to deal a new (t',n')
+// sharing of the same secret: each new holder checks the shares received with
+// VerifyReshare and combines them with CombineReshares, and the commitment to
+// the new sharing is given by CombineReshareCommitments. All new holders must
+// combine the shares dealt by the same set of old holders.
+//
+// Shares can also be refreshed, keeping the threshold and the IDs, so that
+// shares leaked before a refresh are useless afterwards [4]. Each holder deals
+// a sharing of zero given by NewRefresh, every holder checks the shares
+// received with VerifyRefresh and adds them to its share with Refresh, and
+// the commitment is updated with RefreshCommitment.
+//
 // In this implementation, secret sharing is defined over the scalar field of
 // a prime order group.
 //
@@ -23,6 +36,8 @@
 //
 //	[1] Shamir, How to share a secret. https://dl.acm.org/doi/10.1145/359168.359176/
 //	[2] Feldman, A practical scheme for non-interactive verifiable secret sharing. https://ieeexplore.ieee.org/document/4568297/
+//	[3] Desmedt and Jajodia, Redistributing secret shares to new access structures and its applications. https://csis.gmu.edu/media/techreports/ISSE-TR-97-01.pdf
+//	[4] Herzberg et al., Proactive secret sharing or: How to cope with perpetual leakage. https://doi.org/10.1007/3-540-44750-4_27
 package secretsharing
 
 import (
@@ -114,15 +129,22 @@ func Verify(t uint, s Share, c SecretCommitment) bool {
 		return false
 	}
 
-	g := s.ID.Group()
+	polI := s.ID.Group().NewElement().MulGen(s.Value)
+	return polI.IsEqual(EvalCommitment(c, s.ID))
+}
+
+// EvalCommitment returns the commitment to the share with the given ID, that
+// is, the polynomial committed in c evaluated at id in the exponent. It is the
+// public key of the share when c is the commitment of a shared private key.
+// Panics if c is empty.
+func EvalCommitment(c SecretCommitment, id group.Scalar) group.Element {
 	lc := len(c) - 1
-	sum := g.NewElement().Set(c[lc])
+	sum := c[lc].Copy()
 	for i := lc - 1; i >= 0; i-- {
-		sum.Mul(sum, s.ID)
+		sum.Mul(sum, id)
 		sum.Add(sum, c[i])
 	}
-	polI := g.NewElement().MulGen(s.Value)
-	return polI.IsEqual(sum)
+	return sum
 }
 
 // Recover returns a secret provided more than t different shares are given.
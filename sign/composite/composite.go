@@ -0,0 +1,417 @@
+// Package composite implements the composite ML-DSA signature schemes of
+// draft-ietf-lamps-pq-composite-sigs, which combine ML-DSA with a
+// traditional signature algorithm.
+//
+// A composite signature is valid only if both component signatures are. Both
+// components sign the message representative
+//
+//	M' = Prefix ‖ Label ‖ len(ctx) ‖ ctx ‖ PH(M)
+//
+// where Prefix is the string "CompositeAlgorithmSignatures2025", Label the
+// domain separator of the combination, and PH its pre-hash function. The
+// ML-DSA component uses the Label as its context.
+//
+// Public keys, private keys and signatures are the concatenations of those
+// of the components, ML-DSA first. The ML-DSA private key is its seed.
+// Traditional keys and signatures are encoded as follows:
+//
+//	Ed25519, Ed448:  as in RFC 8032, private keys are seeds.
+//	ECDSA:           uncompressed points, ECPrivateKey, and Ecdsa-Sig-Value.
+//	RSA:             RSAPublicKey and RSAPrivateKey.
+//
+// As the DER encodings of ECDSA signatures and RSA private keys vary in
+// length, the corresponding sizes reported by a Scheme are upper bounds.
+//
+// The combinations over brainpool curves are not supported.
+//
+// Reference: https://datatracker.ietf.org/doc/draft-ietf-lamps-pq-composite-sigs/
+package composite
+
+import (
+	"crypto"
+	"crypto/elliptic"
+	cryptoRand "crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/asn1"
+	"errors"
+	"io"
+
+	"github.com/cloudflare/circl/internal/sha3"
+	"github.com/cloudflare/circl/sign"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa44"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa65"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa87"
+)
+
+// Prefix is prepended to the message representative of every composite
+// signature.
+const Prefix = "CompositeAlgorithmSignatures2025"
+
+// SeedSize is the size of the seeds from which keys are derived.
+const SeedSize = 32
+
+// Returns ML-DSA-44 with RSASSA-PSS 2048 and SHA-256.
+func MLDSA44RSA2048PSS() sign.Scheme { return mldsa44RSA2048PSS }
+
+// Returns ML-DSA-44 with RSASSA-PKCS1-v1_5 2048 and SHA-256.
+func MLDSA44RSA2048PKCS15() sign.Scheme { return mldsa44RSA2048PKCS15 }
+
+// Returns ML-DSA-44 with Ed25519.
+func MLDSA44Ed25519() sign.Scheme { return mldsa44Ed25519 }
+
+// Returns ML-DSA-44 with ECDSA over P-256.
+func MLDSA44P256() sign.Scheme { return mldsa44P256 }
+
+// Returns ML-DSA-65 with RSASSA-PSS 3072.
+func MLDSA65RSA3072PSS() sign.Scheme { return mldsa65RSA3072PSS }
+
+// Returns ML-DSA-65 with RSASSA-PKCS1-v1_5 3072.
+func MLDSA65RSA3072PKCS15() sign.Scheme { return mldsa65RSA3072PKCS15 }
+
+// Returns ML-DSA-65 with RSASSA-PSS 4096.
+func MLDSA65RSA4096PSS() sign.Scheme { return mldsa65RSA4096PSS }
+
+// Returns ML-DSA-65 with RSASSA-PKCS1-v1_5 4096.
+func MLDSA65RSA4096PKCS15() sign.Scheme { return mldsa65RSA4096PKCS15 }
+
+// Returns ML-DSA-65 with ECDSA over P-256.
+func MLDSA65P256() sign.Scheme { return mldsa65P256 }
+
+// Returns ML-DSA-65 with ECDSA over P-384.
+func MLDSA65P384() sign.Scheme { return mldsa65P384 }
+
+// Returns ML-DSA-65 with Ed25519.
+func MLDSA65Ed25519() sign.Scheme { return mldsa65Ed25519 }
+
+// Returns ML-DSA-87 with ECDSA over P-384.
+func MLDSA87P384() sign.Scheme { return mldsa87P384 }
+
+// Returns ML-DSA-87 with Ed448.
+func MLDSA87Ed448() sign.Scheme { return mldsa87Ed448 }
+
+// Returns ML-DSA-87 with RSASSA-PSS 3072.
+func MLDSA87RSA3072PSS() sign.Scheme { return mldsa87RSA3072PSS }
+
+// Returns ML-DSA-87 with RSASSA-PSS 4096.
+func MLDSA87RSA4096PSS() sign.Scheme { return mldsa87RSA4096PSS }
+
+// Returns ML-DSA-87 with ECDSA over P-521.
+func MLDSA87P521() sign.Scheme { return mldsa87P521 }
+
+// See draft-ietf-lamps-pq-composite-sigs -- Section 7.
+var (
+	mldsa44RSA2048PSS = &scheme{
+		"MLDSA44-RSA2048-PSS-SHA256", 37, mldsa44.Scheme(),
+		rsaTrad{2048, crypto.SHA256, true}, sha256Sum,
+	}
+	mldsa44RSA2048PKCS15 = &scheme{
+		"MLDSA44-RSA2048-PKCS15-SHA256", 38, mldsa44.Scheme(),
+		rsaTrad{2048, crypto.SHA256, false}, sha256Sum,
+	}
+	mldsa44Ed25519 = &scheme{
+		"MLDSA44-Ed25519-SHA512", 39, mldsa44.Scheme(),
+		ed25519Trad{}, sha512Sum,
+	}
+	mldsa44P256 = &scheme{
+		"MLDSA44-ECDSA-P256-SHA256", 40, mldsa44.Scheme(),
+		ecdsaTrad{elliptic.P256(), crypto.SHA256}, sha256Sum,
+	}
+	mldsa65RSA3072PSS = &scheme{
+		"MLDSA65-RSA3072-PSS-SHA512", 41, mldsa65.Scheme(),
+		rsaTrad{3072, crypto.SHA256, true}, sha512Sum,
+	}
+	mldsa65RSA3072PKCS15 = &scheme{
+		"MLDSA65-RSA3072-PKCS15-SHA512", 42, mldsa65.Scheme(),
+		rsaTrad{3072, crypto.SHA256, false}, sha512Sum,
+	}
+	mldsa65RSA4096PSS = &scheme{
+		"MLDSA65-RSA4096-PSS-SHA512", 43, mldsa65.Scheme(),
+		rsaTrad{4096, crypto.SHA384, true}, sha512Sum,
+	}
+	mldsa65RSA4096PKCS15 = &scheme{
+		"MLDSA65-RSA4096-PKCS15-SHA512", 44, mldsa65.Scheme(),
+		rsaTrad{4096, crypto.SHA384, false}, sha512Sum,
+	}
+	mldsa65P256 = &scheme{
+		"MLDSA65-ECDSA-P256-SHA512", 45, mldsa65.Scheme(),
+		ecdsaTrad{elliptic.P256(), crypto.SHA256}, sha512Sum,
+	}
+	mldsa65P384 = &scheme{
+		"MLDSA65-ECDSA-P384-SHA512", 46, mldsa65.Scheme(),
+		ecdsaTrad{elliptic.P384(), crypto.SHA384}, sha512Sum,
+	}
+	mldsa65Ed25519 = &scheme{
+		"MLDSA65-Ed25519-SHA512", 48, mldsa65.Scheme(),
+		ed25519Trad{}, sha512Sum,
+	}
+	mldsa87P384 = &scheme{
+		"MLDSA87-ECDSA-P384-SHA512", 49, mldsa87.Scheme(),
+		ecdsaTrad{elliptic.P384(), crypto.SHA384}, sha512Sum,
+	}
+	mldsa87Ed448 = &scheme{
+		"MLDSA87-Ed448-SHAKE256", 51, mldsa87.Scheme(),
+		ed448Trad{}, shake256Sum,
+	}
+	mldsa87RSA3072PSS = &scheme{
+		"MLDSA87-RSA3072-PSS-SHA512", 52, mldsa87.Scheme(),
+		rsaTrad{3072, crypto.SHA256, true}, sha512Sum,
+	}
+	mldsa87RSA4096PSS = &scheme{
+		"MLDSA87-RSA4096-PSS-SHA512", 53, mldsa87.Scheme(),
+		rsaTrad{4096, crypto.SHA384, true}, sha512Sum,
+	}
+	mldsa87P521 = &scheme{
+		"MLDSA87-ECDSA-P521-SHA512", 54, mldsa87.Scheme(),
+		ecdsaTrad{elliptic.P521(), crypto.SHA512}, sha512Sum,
+	}
+)
+
+func sha256Sum(msg []byte) []byte { h := sha256.Sum256(msg); return h[:] }
+func sha512Sum(msg []byte) []byte { h := sha512.Sum512(msg); return h[:] }
+
+func shake256Sum(msg []byte) []byte {
+	var h [64]byte
+	s := sha3.NewShake256()
+	_, _ = s.Write(msg)
+	_, _ = s.Read(h[:])
+	return h[:]
+}
+
+var errSignature = errors.New("sign/composite: signing failed")
+
+type scheme struct {
+	name    string
+	oid     int // last arc of the OID under id-alg.
+	mldsa   sign.Scheme
+	trad    traditional
+	preHash func([]byte) []byte
+}
+
+// PublicKey is a composite public key.
+type PublicKey struct {
+	scheme *scheme
+	mldsa  sign.PublicKey
+	trad   crypto.PublicKey
+}
+
+// PrivateKey is a composite private key.
+type PrivateKey struct {
+	scheme *scheme
+	mldsa  sign.PrivateKey
+	trad   crypto.Signer
+}
+
+// label returns the domain separator of the combination.
+func (s *scheme) label() string { return "COMPSIG-" + s.name }
+
+// messagePrime returns the message representative M'. The context must not
+// be longer than 255 bytes.
+func (s *scheme) messagePrime(msg, ctx []byte) []byte {
+	ph := s.preHash(msg)
+	label := s.label()
+	mp := make([]byte, 0, len(Prefix)+len(label)+1+len(ctx)+len(ph))
+	mp = append(mp, Prefix...)
+	mp = append(mp, label...)
+	mp = append(mp, byte(len(ctx)))
+	mp = append(mp, ctx...)
+	return append(mp, ph...)
+}
+
+func (s *scheme) signTo(sk *PrivateKey, msg, ctx []byte) ([]byte, error) {
+	if len(ctx) > 255 {
+		return nil, sign.ErrContextTooLong
+	}
+	mp := s.messagePrime(msg, ctx)
+	sig := s.mldsa.Sign(sk.mldsa, mp, &sign.SignatureOpts{Context: s.label()})
+	tradSig, err := s.trad.sign(sk.trad, mp)
+	if err != nil {
+		return nil, errSignature
+	}
+	return append(sig, tradSig...), nil
+}
+
+func (s *scheme) verify(pk *PublicKey, msg, ctx, sig []byte) bool {
+	n := s.mldsa.SignatureSize()
+	if len(ctx) > 255 || len(sig) < n {
+		return false
+	}
+	mp := s.messagePrime(msg, ctx)
+	opts := &sign.SignatureOpts{Context: s.label()}
+	ok := s.mldsa.Verify(pk.mldsa, mp, sig[:n], opts)
+	return s.trad.verify(pk.trad, mp, sig[n:]) && ok
+}
+
+// Sign signs msg without context. opts must not request a pre-hashed
+// message.
+func (sk *PrivateKey) Sign(
+	rand io.Reader, msg []byte, opts crypto.SignerOpts,
+) ([]byte, error) {
+	if opts != nil && opts.HashFunc() != crypto.Hash(0) {
+		return nil, errors.New("sign/composite: cannot sign hashed message")
+	}
+	return sk.scheme.signTo(sk, msg, nil)
+}
+
+// Public returns the *PublicKey corresponding to this private key.
+func (sk *PrivateKey) Public() crypto.PublicKey {
+	return &PublicKey{
+		sk.scheme,
+		sk.mldsa.Public().(sign.PublicKey),
+		sk.trad.Public(),
+	}
+}
+
+func (sk *PrivateKey) Scheme() sign.Scheme { return sk.scheme }
+func (pk *PublicKey) Scheme() sign.Scheme  { return pk.scheme }
+
+func (sk *PrivateKey) Equal(other crypto.PrivateKey) bool {
+	o, ok := other.(*PrivateKey)
+	if !ok || sk.scheme != o.scheme || !sk.mldsa.Equal(o.mldsa) {
+		return false
+	}
+	trad, ok := sk.trad.(interface{ Equal(crypto.PrivateKey) bool })
+	return ok && trad.Equal(o.trad)
+}
+
+func (pk *PublicKey) Equal(other crypto.PublicKey) bool {
+	o, ok := other.(*PublicKey)
+	if !ok || pk.scheme != o.scheme || !pk.mldsa.Equal(o.mldsa) {
+		return false
+	}
+	trad, ok := pk.trad.(interface{ Equal(crypto.PublicKey) bool })
+	return ok && trad.Equal(o.trad)
+}
+
+func (sk *PrivateKey) MarshalBinary() ([]byte, error) {
+	seed := sk.mldsa.(sign.Seeded).Seed()
+	return append(append([]byte{}, seed...), sk.scheme.trad.marshalPrivateKey(sk.trad)...), nil
+}
+
+func (pk *PublicKey) MarshalBinary() ([]byte, error) {
+	ret, err := pk.mldsa.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return append(ret, pk.scheme.trad.marshalPublicKey(pk.trad)...), nil
+}
+
+func (s *scheme) Name() string { return s.name }
+
+func (s *scheme) PublicKeySize() int {
+	return s.mldsa.PublicKeySize() + s.trad.publicKeySize()
+}
+
+func (s *scheme) PrivateKeySize() int {
+	return s.mldsa.SeedSize() + s.trad.privateKeySize()
+}
+
+func (s *scheme) SignatureSize() int {
+	return s.mldsa.SignatureSize() + s.trad.signatureSize()
+}
+
+func (s *scheme) SeedSize() int         { return SeedSize }
+func (s *scheme) SupportsContext() bool { return true }
+
+func (s *scheme) Oid() asn1.ObjectIdentifier {
+	return asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 6, s.oid}
+}
+
+func (s *scheme) GenerateKey() (sign.PublicKey, sign.PrivateKey, error) {
+	mldsaSeed := make([]byte, s.mldsa.SeedSize())
+	if _, err := io.ReadFull(cryptoRand.Reader, mldsaSeed); err != nil {
+		return nil, nil, err
+	}
+	_, mldsaSk := s.mldsa.DeriveKey(mldsaSeed)
+	trad, err := s.trad.generateKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	sk := &PrivateKey{s, mldsaSk, trad}
+	return sk.Public().(*PublicKey), sk, nil
+}
+
+// DeriveKey expands the seed with SHAKE256 into the seed of the ML-DSA key
+// and the randomness for the traditional key. The draft does not specify
+// the derivation of traditional keys, so the keys derived from a seed are
+// specific to this package. In particular, RSA keys are derived in
+// variable time.
+//
+// Panics if seed is not of length SeedSize.
+func (s *scheme) DeriveKey(seed []byte) (sign.PublicKey, sign.PrivateKey) {
+	if len(seed) != SeedSize {
+		panic(sign.ErrSeedSize)
+	}
+	h := sha3.NewShake256()
+	_, _ = h.Write(seed)
+	mldsaSeed := make([]byte, s.mldsa.SeedSize())
+	_, _ = h.Read(mldsaSeed)
+	_, mldsaSk := s.mldsa.DeriveKey(mldsaSeed)
+	sk := &PrivateKey{s, mldsaSk, s.trad.deriveKey(&h)}
+	return sk.Public().(*PublicKey), sk
+}
+
+func (s *scheme) UnmarshalBinaryPublicKey(buf []byte) (sign.PublicKey, error) {
+	n := s.mldsa.PublicKeySize()
+	if len(buf) < n {
+		return nil, sign.ErrPubKeySize
+	}
+	mldsaPk, err := s.mldsa.UnmarshalBinaryPublicKey(buf[:n])
+	if err != nil {
+		return nil, err
+	}
+	trad, err := s.trad.unmarshalPublicKey(buf[n:])
+	if err != nil {
+		return nil, err
+	}
+	return &PublicKey{s, mldsaPk, trad}, nil
+}
+
+func (s *scheme) UnmarshalBinaryPrivateKey(buf []byte) (sign.PrivateKey, error) {
+	n := s.mldsa.SeedSize()
+	if len(buf) < n {
+		return nil, sign.ErrPrivKeySize
+	}
+	_, mldsaSk := s.mldsa.DeriveKey(buf[:n])
+	trad, err := s.trad.unmarshalPrivateKey(buf[n:])
+	if err != nil {
+		return nil, err
+	}
+	return &PrivateKey{s, mldsaSk, trad}, nil
+}
+
+func (s *scheme) Sign(
+	sk sign.PrivateKey,
+	msg []byte,
+	opts *sign.SignatureOpts,
+) []byte {
+	priv, ok := sk.(*PrivateKey)
+	if !ok || priv.scheme != s {
+		panic(sign.ErrTypeMismatch)
+	}
+	var ctx []byte
+	if opts != nil {
+		ctx = []byte(opts.Context)
+	}
+	sig, err := s.signTo(priv, msg, ctx)
+	if err != nil {
+		panic(err)
+	}
+	return sig
+}
+
+func (s *scheme) Verify(
+	pk sign.PublicKey,
+	msg, sig []byte,
+	opts *sign.SignatureOpts,
+) bool {
+	pub, ok := pk.(*PublicKey)
+	if !ok || pub.scheme != s {
+		panic(sign.ErrTypeMismatch)
+	}
+	var ctx []byte
+	if opts != nil {
+		ctx = []byte(opts.Context)
+	}
+	return s.verify(pub, msg, ctx, sig)
+}
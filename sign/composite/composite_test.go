@@ -0,0 +1,160 @@
+package composite
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"crypto/sha512"
+	"math/big"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign"
+	"github.com/cloudflare/circl/sign/ed25519"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa44"
+)
+
+var testSchemes = []*scheme{
+	mldsa44RSA2048PSS,
+	mldsa44RSA2048PKCS15,
+	mldsa44Ed25519,
+	mldsa44P256,
+	mldsa65RSA3072PSS,
+	mldsa65RSA3072PKCS15,
+	mldsa65RSA4096PSS,
+	mldsa65RSA4096PKCS15,
+	mldsa65P256,
+	mldsa65P384,
+	mldsa65Ed25519,
+	mldsa87P384,
+	mldsa87Ed448,
+	mldsa87RSA3072PSS,
+	mldsa87RSA4096PSS,
+	mldsa87P521,
+}
+
+func TestMessagePrime(t *testing.T) {
+	var seed [SeedSize]byte
+	pk, sk := mldsa44Ed25519.DeriveKey(seed[:])
+	msg := []byte("message")
+	ctx := []byte("context")
+	sig := mldsa44Ed25519.Sign(sk, msg, &sign.SignatureOpts{Context: string(ctx)})
+
+	// M' = Prefix ‖ Label ‖ len(ctx) ‖ ctx ‖ SHA-512(M).
+	digest := sha512.Sum512(msg)
+	mp := []byte("CompositeAlgorithmSignatures2025COMPSIG-MLDSA44-Ed25519-SHA512")
+	mp = append(mp, byte(len(ctx)))
+	mp = append(mp, ctx...)
+	mp = append(mp, digest[:]...)
+
+	pub := pk.(*PublicKey)
+	ok := mldsa44.Verify(pub.mldsa.(*mldsa44.PublicKey), mp,
+		[]byte("COMPSIG-MLDSA44-Ed25519-SHA512"), sig[:mldsa44.SignatureSize])
+	test.CheckOk(ok, "ML-DSA component does not sign M'", t)
+	ok = ed25519.Verify(pub.trad.(ed25519.PublicKey), mp, sig[mldsa44.SignatureSize:])
+	test.CheckOk(ok, "Ed25519 component does not sign M'", t)
+}
+
+func TestDeriveKey(t *testing.T) {
+	for _, s := range testSchemes {
+		// Deriving large RSA keys is slow.
+		if r, ok := s.trad.(rsaTrad); ok && r.bits > 2048 {
+			continue
+		}
+		t.Run(s.Name(), func(t *testing.T) {
+			seed := make([]byte, SeedSize)
+			seed[0] = 1
+			pk, sk := s.DeriveKey(seed)
+			pk2, sk2 := s.DeriveKey(seed)
+			test.CheckOk(pk.Equal(pk2) && sk.Equal(sk2), "derivation is not deterministic", t)
+
+			seed[0] = 2
+			pk2, sk2 = s.DeriveKey(seed)
+			test.CheckOk(!pk.Equal(pk2) && !sk.Equal(sk2), "seeds derive the same key", t)
+
+			packed, err := sk.MarshalBinary()
+			test.CheckNoErr(t, err, "marshal failed")
+			sk2, err = s.UnmarshalBinaryPrivateKey(packed)
+			test.CheckNoErr(t, err, "unmarshal failed")
+			test.CheckOk(sk.Equal(sk2), "private keys differ", t)
+			test.CheckOk(pk.Equal(sk2.Public()), "public keys differ", t)
+
+			if r, ok := s.trad.(rsaTrad); ok {
+				checkRSAKey(t, r.bits, sk.(*PrivateKey).trad.(*rsa.PrivateKey))
+			}
+		})
+	}
+}
+
+// checkRSAKey checks the conditions of Appendix A.1.3 of FIPS 186-5 on the
+// primes and the private exponent.
+func checkRSAKey(t *testing.T, bits int, sk *rsa.PrivateKey) {
+	one := big.NewInt(1)
+	p, q := sk.Primes[0], sk.Primes[1]
+	diff := new(big.Int).Abs(new(big.Int).Sub(p, q))
+	test.CheckOk(diff.BitLen() > bits/2-100, "primes are too close", t)
+	test.CheckOk(sk.D.BitLen() > bits/2, "private exponent is too small", t)
+
+	p1, q1 := new(big.Int).Sub(p, one), new(big.Int).Sub(q, one)
+	lambda := new(big.Int).Div(new(big.Int).Mul(p1, q1), new(big.Int).GCD(nil, nil, p1, q1))
+	test.CheckOk(sk.D.Cmp(lambda) < 0, "private exponent is not reduced modulo lcm(p-1, q-1)", t)
+	ed := new(big.Int).Mul(sk.D, big.NewInt(int64(sk.E)))
+	test.CheckOk(ed.Mod(ed, lambda).Cmp(one) == 0, "invalid private exponent", t)
+}
+
+func TestInvalid(t *testing.T) {
+	s := mldsa65P256
+	pk, sk, err := s.GenerateKey()
+	test.CheckNoErr(t, err, "key generation failed")
+	pk2, sk2, err := s.GenerateKey()
+	test.CheckNoErr(t, err, "key generation failed")
+	msg := []byte("message")
+	sig := s.Sign(sk, msg, nil)
+	sig2 := s.Sign(sk2, msg, nil)
+	n := mldsa65P256.mldsa.SignatureSize()
+
+	test.CheckOk(s.Verify(pk, msg, sig, nil), "verification failed", t)
+	test.CheckOk(!s.Verify(pk2, msg, sig, nil), "signature verified with another key", t)
+	test.CheckOk(!s.Verify(pk, msg, sig[:n-1], nil), "truncated signature verified", t)
+
+	// Each component must verify.
+	mixed := append(append([]byte{}, sig[:n]...), sig2[n:]...)
+	test.CheckOk(!s.Verify(pk, msg, mixed, nil), "signature verified with another ECDSA signature", t)
+	mixed = append(append([]byte{}, sig2[:n]...), sig[n:]...)
+	test.CheckOk(!s.Verify(pk, msg, mixed, nil), "signature verified with another ML-DSA signature", t)
+	test.CheckOk(!s.Verify(pk, msg, append(sig, 0), nil), "signature with trailing data verified", t)
+
+	// Signatures are bound to the combination.
+	pk3, _ := mldsa65Ed25519.DeriveKey(make([]byte, SeedSize))
+	err = test.CheckPanic(func() { s.Verify(pk3, msg, sig, nil) })
+	test.CheckNoErr(t, err, "verification with another scheme's key did not panic")
+
+	long := &sign.SignatureOpts{Context: string(make([]byte, 256))}
+	test.CheckOk(!s.Verify(pk, msg, sig, long), "long context accepted", t)
+	err = test.CheckPanic(func() { s.Sign(sk, msg, long) })
+	test.CheckNoErr(t, err, "signing with a long context did not panic")
+
+	packedPk, err := pk.MarshalBinary()
+	test.CheckNoErr(t, err, "marshal failed")
+	packedSk, err := sk.MarshalBinary()
+	test.CheckNoErr(t, err, "marshal failed")
+	for _, k := range []int{0, s.mldsa.PublicKeySize(), len(packedPk) - 1} {
+		_, err = s.UnmarshalBinaryPublicKey(packedPk[:k])
+		test.CheckIsErr(t, err, "truncated public key accepted")
+	}
+	for _, k := range []int{0, SeedSize, len(packedSk) - 1} {
+		_, err = s.UnmarshalBinaryPrivateKey(packedSk[:k])
+		test.CheckIsErr(t, err, "truncated private key accepted")
+	}
+	_, err = mldsa65P384.UnmarshalBinaryPrivateKey(packedSk)
+	test.CheckIsErr(t, err, "private key of another curve accepted")
+}
+
+func TestSignerInterface(t *testing.T) {
+	pk, sk := mldsa87Ed448.DeriveKey(make([]byte, SeedSize))
+	msg := []byte("message")
+	sig, err := sk.Sign(nil, msg, nil)
+	test.CheckNoErr(t, err, "signing failed")
+	test.CheckOk(mldsa87Ed448.Verify(pk, msg, sig, nil), "verification failed", t)
+	test.CheckOk(!bytes.Equal(sig, mldsa87Ed448.Sign(sk, msg, &sign.SignatureOpts{Context: "a"})),
+		"context ignored", t)
+}
@@ -0,0 +1,366 @@
+package composite
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/cloudflare/circl/sign/ed25519"
+	"github.com/cloudflare/circl/sign/ed448"
+)
+
+var errTradKey = errors.New("sign/composite: invalid traditional key")
+
+// traditional is the traditional component of a composite scheme. Its keys
+// are crypto.Signer and crypto.PublicKey of the corresponding standard (or
+// circl) package, and messages are hashed as required by the algorithm.
+type traditional interface {
+	generateKey() (crypto.Signer, error)
+	// deriveKey deterministically derives a private key from the output of
+	// an extendable-output function.
+	deriveKey(xof io.Reader) crypto.Signer
+	marshalPublicKey(crypto.PublicKey) []byte
+	unmarshalPublicKey([]byte) (crypto.PublicKey, error)
+	marshalPrivateKey(crypto.Signer) []byte
+	unmarshalPrivateKey([]byte) (crypto.Signer, error)
+	sign(sk crypto.Signer, msg []byte) ([]byte, error)
+	verify(pk crypto.PublicKey, msg, sig []byte) bool
+
+	// Sizes of the encodings. For ECDSA signatures and RSA private keys,
+	// which are DER encoded, these are upper bounds.
+	publicKeySize() int
+	privateKeySize() int
+	signatureSize() int
+}
+
+type ed25519Trad struct{}
+
+func (ed25519Trad) generateKey() (crypto.Signer, error) {
+	_, sk, err := ed25519.GenerateKey(rand.Reader)
+	return sk, err
+}
+
+func (ed25519Trad) deriveKey(xof io.Reader) crypto.Signer {
+	var seed [ed25519.SeedSize]byte
+	_, _ = io.ReadFull(xof, seed[:])
+	return ed25519.NewKeyFromSeed(seed[:])
+}
+
+func (ed25519Trad) marshalPublicKey(pk crypto.PublicKey) []byte {
+	return append([]byte{}, pk.(ed25519.PublicKey)...)
+}
+
+func (ed25519Trad) unmarshalPublicKey(b []byte) (crypto.PublicKey, error) {
+	if len(b) != ed25519.PublicKeySize {
+		return nil, errTradKey
+	}
+	return ed25519.PublicKey(append([]byte{}, b...)), nil
+}
+
+func (ed25519Trad) marshalPrivateKey(sk crypto.Signer) []byte {
+	return sk.(ed25519.PrivateKey).Seed()
+}
+
+func (ed25519Trad) unmarshalPrivateKey(b []byte) (crypto.Signer, error) {
+	if len(b) != ed25519.SeedSize {
+		return nil, errTradKey
+	}
+	return ed25519.NewKeyFromSeed(b), nil
+}
+
+func (ed25519Trad) sign(sk crypto.Signer, msg []byte) ([]byte, error) {
+	return ed25519.Sign(sk.(ed25519.PrivateKey), msg), nil
+}
+
+func (ed25519Trad) verify(pk crypto.PublicKey, msg, sig []byte) bool {
+	return ed25519.Verify(pk.(ed25519.PublicKey), msg, sig)
+}
+
+func (ed25519Trad) publicKeySize() int  { return ed25519.PublicKeySize }
+func (ed25519Trad) privateKeySize() int { return ed25519.SeedSize }
+func (ed25519Trad) signatureSize() int  { return ed25519.SignatureSize }
+
+type ed448Trad struct{}
+
+func (ed448Trad) generateKey() (crypto.Signer, error) {
+	_, sk, err := ed448.GenerateKey(rand.Reader)
+	return sk, err
+}
+
+func (ed448Trad) deriveKey(xof io.Reader) crypto.Signer {
+	var seed [ed448.SeedSize]byte
+	_, _ = io.ReadFull(xof, seed[:])
+	return ed448.NewKeyFromSeed(seed[:])
+}
+
+func (ed448Trad) marshalPublicKey(pk crypto.PublicKey) []byte {
+	return append([]byte{}, pk.(ed448.PublicKey)...)
+}
+
+func (ed448Trad) unmarshalPublicKey(b []byte) (crypto.PublicKey, error) {
+	if len(b) != ed448.PublicKeySize {
+		return nil, errTradKey
+	}
+	return ed448.PublicKey(append([]byte{}, b...)), nil
+}
+
+func (ed448Trad) marshalPrivateKey(sk crypto.Signer) []byte {
+	return sk.(ed448.PrivateKey).Seed()
+}
+
+func (ed448Trad) unmarshalPrivateKey(b []byte) (crypto.Signer, error) {
+	if len(b) != ed448.SeedSize {
+		return nil, errTradKey
+	}
+	return ed448.NewKeyFromSeed(b), nil
+}
+
+func (ed448Trad) sign(sk crypto.Signer, msg []byte) ([]byte, error) {
+	return ed448.Sign(sk.(ed448.PrivateKey), msg, ""), nil
+}
+
+func (ed448Trad) verify(pk crypto.PublicKey, msg, sig []byte) bool {
+	return ed448.Verify(pk.(ed448.PublicKey), msg, sig, "")
+}
+
+func (ed448Trad) publicKeySize() int  { return ed448.PublicKeySize }
+func (ed448Trad) privateKeySize() int { return ed448.SeedSize }
+func (ed448Trad) signatureSize() int  { return ed448.SignatureSize }
+
+// ecdsaTrad is ECDSA with the given hash function. Public keys are
+// uncompressed points, private keys ECPrivateKey structures (RFC 5915) and
+// signatures Ecdsa-Sig-Value structures (RFC 3279).
+type ecdsaTrad struct {
+	curve elliptic.Curve
+	hash  crypto.Hash
+}
+
+func (t ecdsaTrad) generateKey() (crypto.Signer, error) {
+	return ecdsa.GenerateKey(t.curve, rand.Reader)
+}
+
+func (t ecdsaTrad) deriveKey(xof io.Reader) crypto.Signer {
+	n := t.curve.Params().N
+	d := make([]byte, (n.BitLen()+7)/8)
+	for {
+		_, _ = io.ReadFull(xof, d)
+		// Rejection sampling: only keep as many bits as the order has.
+		d[0] &= byte(0xff >> (8*len(d) - n.BitLen()))
+		if sk, err := ecdsa.ParseRawPrivateKey(t.curve, d); err == nil {
+			return sk
+		}
+	}
+}
+
+func (t ecdsaTrad) marshalPublicKey(pk crypto.PublicKey) []byte {
+	b, err := pk.(*ecdsa.PublicKey).Bytes()
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (t ecdsaTrad) unmarshalPublicKey(b []byte) (crypto.PublicKey, error) {
+	pk, err := ecdsa.ParseUncompressedPublicKey(t.curve, b)
+	if err != nil {
+		return nil, errTradKey
+	}
+	return pk, nil
+}
+
+func (t ecdsaTrad) marshalPrivateKey(sk crypto.Signer) []byte {
+	b, err := x509.MarshalECPrivateKey(sk.(*ecdsa.PrivateKey))
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (t ecdsaTrad) unmarshalPrivateKey(b []byte) (crypto.Signer, error) {
+	sk, err := x509.ParseECPrivateKey(b)
+	if err != nil || sk.Curve != t.curve {
+		return nil, errTradKey
+	}
+	return sk, nil
+}
+
+func (t ecdsaTrad) sign(sk crypto.Signer, msg []byte) ([]byte, error) {
+	h := t.hash.New()
+	_, _ = h.Write(msg)
+	return ecdsa.SignASN1(rand.Reader, sk.(*ecdsa.PrivateKey), h.Sum(nil))
+}
+
+func (t ecdsaTrad) verify(pk crypto.PublicKey, msg, sig []byte) bool {
+	h := t.hash.New()
+	_, _ = h.Write(msg)
+	return ecdsa.VerifyASN1(pk.(*ecdsa.PublicKey), h.Sum(nil), sig)
+}
+
+func (t ecdsaTrad) publicKeySize() int {
+	return 1 + 2*((t.curve.Params().BitSize+7)/8)
+}
+
+func (t ecdsaTrad) privateKeySize() int {
+	// version, privateKey, [0] parameters (OID of the named curve), and
+	// [1] publicKey (bit string of the uncompressed point).
+	oidLen := 8
+	if t.curve != elliptic.P256() {
+		oidLen = 5
+	}
+	scalar := derLen(1) + derLen((t.curve.Params().BitSize+7)/8)
+	params := derLen(derLen(oidLen))
+	point := derLen(derLen(1 + t.publicKeySize()))
+	return derLen(scalar + params + point)
+}
+
+func (t ecdsaTrad) signatureSize() int {
+	// Two integers with possibly a leading zero byte.
+	return derLen(2 * derLen(t.curve.Params().N.BitLen()/8+1))
+}
+
+// rsaTrad is RSASSA-PSS with MGF1 and a salt as long as the hash, or
+// RSASSA-PKCS1-v1_5 with the given hash function. Keys are RSAPublicKey and
+// RSAPrivateKey structures (RFC 8017) with public exponent 65537.
+type rsaTrad struct {
+	bits int
+	hash crypto.Hash
+	pss  bool
+}
+
+const rsaPublicExponent = 65537
+
+func (t rsaTrad) generateKey() (crypto.Signer, error) {
+	return rsa.GenerateKey(rand.Reader, t.bits)
+}
+
+// deriveKey derives an RSA key by drawing the primes from the output of the
+// extendable-output function. There is no standard way to do this: the
+// resulting keys are only reproducible with this package. The keys meet
+// the conditions on p, q and d of Appendix A.1.3 of FIPS 186-5, and d is
+// computed modulo lcm(p-1, q-1). As the primality tests and the modular
+// arithmetic of math/big are not constant time, the derivation may leak
+// information on the key through timing.
+func (t rsaTrad) deriveKey(xof io.Reader) crypto.Signer {
+	e := big.NewInt(rsaPublicExponent)
+	one := big.NewInt(1)
+	// |p-q| and d must be greater than 2^(bits/2-100) and 2^(bits/2).
+	minDiff := new(big.Int).Lsh(one, uint(t.bits/2-100))
+	minD := new(big.Int).Lsh(one, uint(t.bits/2))
+	for {
+		p := derivePrime(xof, t.bits/2, e)
+		q := derivePrime(xof, t.bits/2, e)
+		if new(big.Int).Abs(new(big.Int).Sub(p, q)).Cmp(minDiff) <= 0 {
+			continue
+		}
+		sk := &rsa.PrivateKey{
+			PublicKey: rsa.PublicKey{N: new(big.Int).Mul(p, q), E: rsaPublicExponent},
+			Primes:    []*big.Int{p, q},
+		}
+		p1, q1 := new(big.Int).Sub(p, one), new(big.Int).Sub(q, one)
+		gcd := new(big.Int).GCD(nil, nil, p1, q1)
+		lambda := new(big.Int).Div(new(big.Int).Mul(p1, q1), gcd)
+		sk.D = new(big.Int).ModInverse(e, lambda)
+		if sk.D == nil || sk.D.Cmp(minD) <= 0 || sk.N.BitLen() != t.bits {
+			continue
+		}
+		sk.Precompute()
+		if sk.Validate() == nil {
+			return sk
+		}
+	}
+}
+
+// derivePrime returns a prime p of the given bit length such that p-1 is
+// coprime with e. The two top bits are set, so that p is greater than
+// sqrt(2)*2^(bits-1) and the product of two such primes has twice the bit
+// length.
+func derivePrime(xof io.Reader, bits int, e *big.Int) *big.Int {
+	b := make([]byte, bits/8)
+	p, r := new(big.Int), new(big.Int)
+	for {
+		_, _ = io.ReadFull(xof, b)
+		b[0] |= 0xc0
+		b[len(b)-1] |= 1
+		p.SetBytes(b)
+		if r.Mod(p, e).Cmp(big.NewInt(1)) != 0 && p.ProbablyPrime(20) {
+			return p
+		}
+	}
+}
+
+func (t rsaTrad) marshalPublicKey(pk crypto.PublicKey) []byte {
+	return x509.MarshalPKCS1PublicKey(pk.(*rsa.PublicKey))
+}
+
+func (t rsaTrad) unmarshalPublicKey(b []byte) (crypto.PublicKey, error) {
+	pk, err := x509.ParsePKCS1PublicKey(b)
+	if err != nil || pk.N.BitLen() != t.bits || pk.E != rsaPublicExponent {
+		return nil, errTradKey
+	}
+	return pk, nil
+}
+
+func (t rsaTrad) marshalPrivateKey(sk crypto.Signer) []byte {
+	return x509.MarshalPKCS1PrivateKey(sk.(*rsa.PrivateKey))
+}
+
+func (t rsaTrad) unmarshalPrivateKey(b []byte) (crypto.Signer, error) {
+	sk, err := x509.ParsePKCS1PrivateKey(b)
+	if err != nil || sk.N.BitLen() != t.bits || sk.E != rsaPublicExponent {
+		return nil, errTradKey
+	}
+	return sk, nil
+}
+
+func (t rsaTrad) sign(sk crypto.Signer, msg []byte) ([]byte, error) {
+	h := t.hash.New()
+	_, _ = h.Write(msg)
+	if t.pss {
+		return rsa.SignPSS(rand.Reader, sk.(*rsa.PrivateKey), t.hash, h.Sum(nil),
+			&rsa.PSSOptions{SaltLength: t.hash.Size(), Hash: t.hash})
+	}
+	return rsa.SignPKCS1v15(nil, sk.(*rsa.PrivateKey), t.hash, h.Sum(nil))
+}
+
+func (t rsaTrad) verify(pk crypto.PublicKey, msg, sig []byte) bool {
+	h := t.hash.New()
+	_, _ = h.Write(msg)
+	if t.pss {
+		return rsa.VerifyPSS(pk.(*rsa.PublicKey), t.hash, h.Sum(nil), sig,
+			&rsa.PSSOptions{SaltLength: t.hash.Size(), Hash: t.hash}) == nil
+	}
+	return rsa.VerifyPKCS1v15(pk.(*rsa.PublicKey), t.hash, h.Sum(nil), sig) == nil
+}
+
+func (t rsaTrad) publicKeySize() int {
+	// The modulus has a leading zero byte; the exponent takes three bytes.
+	return derLen(derLen(t.bits/8+1) + derLen(3))
+}
+
+func (t rsaTrad) privateKeySize() int {
+	// version, n, e, d, p, q, d mod (p-1), d mod (q-1), and q^-1 mod p, each
+	// with possibly a leading zero byte.
+	n := derLen(t.bits/8 + 1)
+	half := derLen(t.bits/16 + 1)
+	return derLen(derLen(1) + 2*n + derLen(3) + 5*half)
+}
+
+func (t rsaTrad) signatureSize() int { return t.bits / 8 }
+
+// derLen returns the length of a DER encoding with n bytes of contents.
+func derLen(n int) int {
+	switch {
+	case n < 0x80:
+		return 2 + n
+	case n < 0x100:
+		return 3 + n
+	default:
+		return 4 + n
+	}
+}
@@ -165,7 +165,7 @@ var (
 
 func main() {
 	generateModePackageFiles()
-	generateACVPTest()
+	generateTests()
 	generateParamsFiles()
 	generateSourceFiles()
 }
@@ -233,15 +233,24 @@ func generateModePackageFiles() {
 	}
 }
 
-// Generates modeX/dilithium.go from templates/pkg.templ.go
-func generateACVPTest() {
-	tl, err := template.ParseFiles("templates/acvp.templ.go")
+// Generates the tests of the modes from templates/acvp.templ.go,
+// templates/prehash.templ.go, which are specific to ML-DSA, and
+// templates/stream.templ.go
+func generateTests() {
+	generateTest("acvp", true)
+	generateTest("prehash", true)
+	generateTest("stream", false)
+}
+
+// Generates modeX/name_test.go from templates/name.templ.go
+func generateTest(name string, nistOnly bool) {
+	tl, err := template.ParseFiles("templates/" + name + ".templ.go")
 	if err != nil {
 		panic(err)
 	}
 
 	for _, mode := range Modes {
-		if !strings.HasPrefix(mode.Name, "ML-DSA") {
+		if nistOnly && !mode.NIST() {
 			continue
 		}
 
@@ -258,9 +267,9 @@ func generateACVPTest() {
 
 		offset := strings.Index(string(res), TemplateWarning)
 		if offset == -1 {
-			panic("Missing template warning in pkg.templ.go")
+			panic("Missing template warning in " + name + ".templ.go")
 		}
-		err = os.WriteFile(mode.PkgPath()+"/acvp_test.go", res[offset:], 0o644)
+		err = os.WriteFile(mode.PkgPath()+"/"+name+"_test.go", res[offset:], 0o644)
 		if err != nil {
 			panic(err)
 		}
@@ -64,6 +64,53 @@ func SignTo(sk *PrivateKey, msg, sig []byte) {
 	)
 }
 
+// Signer signs a message written to it in chunks, such that the message
+// does not need to be held in memory.
+type Signer struct {
+	sk *PrivateKey
+	h  *internal.MuHash
+}
+
+// NewSigner returns a Signer for messages signed by sk. The signatures are
+// the same as those of SignTo.
+func NewSigner(sk *PrivateKey) *Signer {
+	return &Signer{sk, (*internal.PrivateKey)(sk).MuHash()}
+}
+
+// Write appends p to the message. It never returns an error.
+func (s *Signer) Write(p []byte) (int, error) { return s.h.Write(p) }
+
+// SignTo signs the message written so far, and writes the signature into
+// sig. It will panic if sig is not of length at least SignatureSize.
+func (s *Signer) SignTo(sig []byte) {
+	var mu [64]byte
+	s.h.Sum(&mu)
+	internal.SignMuTo((*internal.PrivateKey)(s.sk), &mu, [32]byte{}, sig)
+}
+
+// Verifier verifies signatures of a message written to it in chunks, such
+// that the message does not need to be held in memory.
+type Verifier struct {
+	pk *PublicKey
+	h  *internal.MuHash
+}
+
+// NewVerifier returns a Verifier for messages signed by pk.
+func NewVerifier(pk *PublicKey) *Verifier {
+	return &Verifier{pk, (*internal.PublicKey)(pk).MuHash()}
+}
+
+// Write appends p to the message. It never returns an error.
+func (v *Verifier) Write(p []byte) (int, error) { return v.h.Write(p) }
+
+// Verify checks whether the given signature of the message written so far
+// is valid.
+func (v *Verifier) Verify(sig []byte) bool {
+	var mu [64]byte
+	v.h.Sum(&mu)
+	return internal.VerifyMu((*internal.PublicKey)(v.pk), &mu, sig)
+}
+
 // Verify checks whether the given signature by pk on msg is valid.
 func Verify(pk *PublicKey, msg, sig []byte) bool {
 	return internal.Verify(
@@ -264,13 +264,60 @@ func (sk *PrivateKey) computeT0andT1(t0, t1 *VecK) {
 	t.Power2Round(t0, t1)
 }
 
+// ComputeMu computes the message representative μ = CRH(tr ‖ msg) for
+// the public key pk.
+func ComputeMu(pk *PublicKey, msg func(io.Writer), mu *[64]byte) {
+	computeMu(&pk.tr, msg, mu)
+}
+
+func computeMu(tr *[TRSize]byte, msg func(io.Writer), mu *[64]byte) {
+	h := sha3.NewShake256()
+	_, _ = h.Write(tr[:])
+	msg(&h)
+	_, _ = h.Read(mu[:])
+}
+
+// MuHash computes the message representative μ = CRH(tr ‖ msg) of a
+// message written to it in chunks.
+type MuHash struct {
+	h sha3.State
+}
+
+// MuHash returns a MuHash for messages verified with pk.
+func (pk *PublicKey) MuHash() *MuHash { return newMuHash(&pk.tr) }
+
+// MuHash returns a MuHash for messages signed with sk.
+func (sk *PrivateKey) MuHash() *MuHash { return newMuHash(&sk.tr) }
+
+func newMuHash(tr *[TRSize]byte) *MuHash {
+	m := &MuHash{h: sha3.NewShake256()}
+	_, _ = m.h.Write(tr[:])
+	return m
+}
+
+func (m *MuHash) Write(p []byte) (int, error) { return m.h.Write(p) }
+
+// Sum writes μ of the message written so far into mu. It does not change
+// the state of the hash.
+func (m *MuHash) Sum(mu *[64]byte) {
+	h := m.h // a copy of the state
+	_, _ = h.Read(mu[:])
+}
+
 // Verify checks whether the given signature by pk on msg is valid.
 //
 // For Dilithium this is the top-level verification function.
 // In ML-DSA, this is ML-DSA.Verify_internal.
 func Verify(pk *PublicKey, msg func(io.Writer), signature []byte) bool {
-	var sig unpackedSignature
 	var mu [64]byte
+	computeMu(&pk.tr, msg, &mu)
+	return VerifyMu(pk, &mu, signature)
+}
+
+// VerifyMu checks whether the given signature by pk on the message
+// representative μ is valid.
+func VerifyMu(pk *PublicKey, mu *[64]byte, signature []byte) bool {
+	var sig unpackedSignature
 	var zh VecL
 	var Az, Az2dct1, w1 VecK
 	var ch common.Poly
@@ -283,12 +330,6 @@ func Verify(pk *PublicKey, msg func(io.Writer), signature []byte) bool {
 		return false
 	}
 
-	// μ = CRH(tr ‖ msg)
-	h := sha3.NewShake256()
-	_, _ = h.Write(pk.tr[:])
-	msg(&h)
-	_, _ = h.Read(mu[:])
-
 	// Compute Az
 	zh = sig.z
 	zh.NTT()
@@ -321,7 +362,7 @@ func Verify(pk *PublicKey, msg func(io.Writer), signature []byte) bool {
 	w1.PackW1(w1Packed[:])
 
 	// c' = H(μ, w₁)
-	h.Reset()
+	h := sha3.NewShake256()
 	_, _ = h.Write(mu[:])
 	_, _ = h.Write(w1Packed[:])
 	_, _ = h.Read(cp[:])
@@ -333,10 +374,20 @@ func Verify(pk *PublicKey, msg func(io.Writer), signature []byte) bool {
 //
 // For Dilithium this is the top-level signing function. For ML-DSA
 // this is ML-DSA.Sign_internal.
+func SignTo(sk *PrivateKey, msg func(io.Writer), rnd [32]byte, signature []byte) {
+	var mu [64]byte
+
+	//  μ = CRH(tr ‖ msg)
+	computeMu(&sk.tr, msg, &mu)
+	SignMuTo(sk, &mu, rnd, signature)
+}
+
+// SignMuTo signs the given message representative μ and writes the
+// signature into signature.
 //
 //nolint:funlen
-func SignTo(sk *PrivateKey, msg func(io.Writer), rnd [32]byte, signature []byte) {
-	var mu, rhop [64]byte
+func SignMuTo(sk *PrivateKey, mu *[64]byte, rnd [32]byte, signature []byte) {
+	var rhop [64]byte
 	var w1Packed [PolyW1Size * K]byte
 	var y, yh VecL
 	var w, w0, w1, w0mcs2, ct0, w0mcs2pct0 VecK
@@ -348,14 +399,8 @@ func SignTo(sk *PrivateKey, msg func(io.Writer), rnd [32]byte, signature []byte)
 		panic("Signature does not fit in that byteslice")
 	}
 
-	//  μ = CRH(tr ‖ msg)
-	h := sha3.NewShake256()
-	_, _ = h.Write(sk.tr[:])
-	msg(&h)
-	_, _ = h.Read(mu[:])
-
 	// ρ' = CRH(key ‖ μ)
-	h.Reset()
+	h := sha3.NewShake256()
 	_, _ = h.Write(sk.key[:])
 	if NIST {
 		_, _ = h.Write(rnd[:])
@@ -0,0 +1,195 @@
+package fndsa
+
+// Encoding of public keys, private keys and signatures. See Section 3.11 of
+// the specification. Bits are packed starting from the most significant bit
+// of each byte.
+
+// modqEncode packs the coefficients of x, in [0, q), in 14 bits each.
+func modqEncode(out []byte, x []uint16) {
+	acc, accLen := uint32(0), 0
+	for _, v := range x {
+		acc = acc<<14 | uint32(v)
+		accLen += 14
+		for accLen >= 8 {
+			accLen -= 8
+			out[0] = byte(acc >> accLen)
+			out = out[1:]
+		}
+	}
+	if accLen > 0 {
+		out[0] = byte(acc << (8 - accLen))
+	}
+}
+
+// modqDecode unpacks x from in, which must have the exact length. It fails
+// if a coefficient is not in [0, q), or if the padding bits are not zero.
+func modqDecode(x []uint16, in []byte) bool {
+	if len(in) != (len(x)*14+7)/8 {
+		return false
+	}
+	acc, accLen := uint32(0), 0
+	u := 0
+	for _, b := range in {
+		acc = acc<<8 | uint32(b)
+		accLen += 8
+		if accLen >= 14 {
+			accLen -= 14
+			w := (acc >> accLen) & 0x3FFF
+			if w >= q {
+				return false
+			}
+			x[u] = uint16(w)
+			u++
+		}
+	}
+	return acc&(1<<accLen-1) == 0
+}
+
+// trimEncode packs the coefficients of x, which must be in
+// [-2^(bits-1)+1, 2^(bits-1)-1], in bits bits each.
+func trimEncode(out []byte, x []int8, bits int) {
+	mask := uint32(1)<<bits - 1
+	acc, accLen := uint32(0), 0
+	for _, v := range x {
+		acc = acc<<bits | (uint32(v) & mask)
+		accLen += bits
+		for accLen >= 8 {
+			accLen -= 8
+			out[0] = byte(acc >> accLen)
+			out = out[1:]
+		}
+	}
+	if accLen > 0 {
+		out[0] = byte(acc << (8 - accLen))
+	}
+}
+
+// trimFits returns whether the coefficients of x can be encoded in bits bits.
+func trimFits(x []int8, bits int) bool {
+	lim := int8(1<<(bits-1) - 1)
+	for _, v := range x {
+		if v < -lim || v > lim {
+			return false
+		}
+	}
+	return true
+}
+
+// trimDecode unpacks x from in, which must have the exact length. It fails
+// if a coefficient is -2^(bits-1), or if the padding bits are not zero.
+func trimDecode(x []int8, in []byte, bits int) bool {
+	if len(in) != (len(x)*bits+7)/8 {
+		return false
+	}
+	mask1 := uint32(1)<<bits - 1
+	mask2 := uint32(1) << (bits - 1)
+	acc, accLen := uint32(0), 0
+	u := 0
+	for _, b := range in {
+		acc = acc<<8 | uint32(b)
+		accLen += 8
+		for accLen >= bits && u < len(x) {
+			accLen -= bits
+			w := (acc >> accLen) & mask1
+			if w == mask2 {
+				return false
+			}
+			w |= -(w & mask2)
+			x[u] = int8(int32(w))
+			u++
+		}
+	}
+	return acc&(1<<accLen-1) == 0
+}
+
+// compEncode compresses x into out, and returns the number of bytes used.
+// It fails if a coefficient is not in [-2047, 2047], or if out is too
+// short. See Algorithm 17 of the specification.
+func compEncode(out []byte, x []int16) (int, bool) {
+	acc, accLen, v := uint32(0), 0, 0
+	for _, t := range x {
+		if t < -2047 || t > 2047 {
+			return 0, false
+		}
+
+		// Sign bit, then the low 7 bits of the absolute value.
+		acc <<= 1
+		if t < 0 {
+			t = -t
+			acc |= 1
+		}
+		w := uint32(t)
+		acc = acc<<7 | w&127
+		w >>= 7
+
+		// The high bits are in unary: w zeros followed by a one.
+		acc = acc<<(w+1) | 1
+		accLen += 8 + int(w) + 1
+
+		for accLen >= 8 {
+			accLen -= 8
+			if v >= len(out) {
+				return 0, false
+			}
+			out[v] = byte(acc >> accLen)
+			v++
+		}
+	}
+	if accLen > 0 {
+		if v >= len(out) {
+			return 0, false
+		}
+		out[v] = byte(acc << (8 - accLen))
+		v++
+	}
+	return v, true
+}
+
+// compDecode decompresses x from in, and returns the number of bytes used.
+// It fails on invalid encodings, which are those with a coefficient outside
+// [-2047, 2047], a "minus zero", or non-zero padding bits. See Algorithm 18
+// of the specification.
+func compDecode(x []int16, in []byte) (int, bool) {
+	acc, accLen, v := uint32(0), 0, 0
+	for u := range x {
+		if v >= len(in) {
+			return 0, false
+		}
+		acc = acc<<8 | uint32(in[v])
+		v++
+		b := acc >> accLen
+		s := b & 128
+		m := b & 127
+
+		for {
+			if accLen == 0 {
+				if v >= len(in) {
+					return 0, false
+				}
+				acc = acc<<8 | uint32(in[v])
+				v++
+				accLen = 8
+			}
+			accLen--
+			if (acc>>accLen)&1 != 0 {
+				break
+			}
+			m += 128
+			if m > 2047 {
+				return 0, false
+			}
+		}
+
+		if s != 0 && m == 0 {
+			return 0, false
+		}
+		x[u] = int16(m)
+		if s != 0 {
+			x[u] = -x[u]
+		}
+	}
+	if acc&(1<<accLen-1) != 0 {
+		return 0, false
+	}
+	return v, true
+}
@@ -0,0 +1,100 @@
+package fndsa
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestCodec(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 100 {
+		h := make([]uint16, 512)
+		for i := range h {
+			h[i] = uint16(r.IntN(q))
+		}
+		buf := make([]byte, 14*512/8)
+		modqEncode(buf, h)
+		h2 := make([]uint16, 512)
+		if !modqDecode(h2, buf) {
+			t.Fatal("modqDecode failed")
+		}
+		for i := range h {
+			if h[i] != h2[i] {
+				t.Fatal("modqDecode mismatch")
+			}
+		}
+
+		for bits := 5; bits <= 8; bits++ {
+			f := make([]int8, 512)
+			lim := 1<<(bits-1) - 1
+			for i := range f {
+				f[i] = int8(r.IntN(2*lim+1) - lim)
+			}
+			buf := make([]byte, bits*512/8)
+			trimEncode(buf, f, bits)
+			f2 := make([]int8, 512)
+			if !trimDecode(f2, buf, bits) {
+				t.Fatal("trimDecode failed")
+			}
+			for i := range f {
+				if f[i] != f2[i] {
+					t.Fatal("trimDecode mismatch")
+				}
+			}
+		}
+
+		s := make([]int16, 512)
+		for i := range s {
+			s[i] = int16(r.NormFloat64() * 165)
+		}
+		buf = make([]byte, 1000)
+		k, ok := compEncode(buf, s)
+		if !ok {
+			t.Fatal("compEncode failed")
+		}
+		s2 := make([]int16, 512)
+		if k2, ok := compDecode(s2, buf[:k]); !ok || k2 != k {
+			t.Fatal("compDecode failed")
+		}
+		for i := range s {
+			if s[i] != s2[i] {
+				t.Fatal("compDecode mismatch")
+			}
+		}
+		if _, ok := compDecode(s2, buf[:k-1]); ok {
+			t.Fatal("compDecode accepted a truncated encoding")
+		}
+	}
+}
+
+func TestCompInvalid(t *testing.T) {
+	x := make([]int16, 2)
+	for _, tc := range []struct {
+		in []byte
+		ok bool
+	}{
+		{[]byte{0x01, 0x80, 0xC0}, true},        // 1, 1
+		{[]byte{0x01, 0x80, 0xC0, 0x00}, false}, // 1, 1, then a trailing byte
+		{[]byte{0x81, 0x80, 0xC0}, true},        // -1, 1
+		{[]byte{0x00, 0x80, 0x40}, true},        // 0, 0
+		{[]byte{0x80, 0x80, 0x40}, false},       // minus zero, 0
+		{[]byte{0x00, 0x80, 0x60}, false},       // 0, 0, with a padding bit set
+		{[]byte{0x00, 0x00, 0x00, 0x00}, false}, // unary part too long
+	} {
+		k, ok := compDecode(x, tc.in)
+		if ok && k != len(tc.in) {
+			ok = false
+		}
+		if ok != tc.ok {
+			t.Fatalf("compDecode(%x): got %v, want %v", tc.in, ok, tc.ok)
+		}
+	}
+
+	// Coefficients outside [-2047, 2047] cannot be encoded.
+	if _, ok := compEncode(make([]byte, 100), []int16{2048, 0}); ok {
+		t.Fatal("compEncode accepted 2048")
+	}
+	if _, ok := compEncode(make([]byte, 1), []int16{100, 100}); ok {
+		t.Fatal("compEncode accepted a short buffer")
+	}
+}
@@ -0,0 +1,233 @@
+package fndsa
+
+// Polynomials modulo X^n+1 with real coefficients are represented in FFT
+// form by their values at the n/2 roots of X^n+1 with a positive imaginary
+// part: the real parts are in the first half of the slice, and the
+// imaginary parts in the second half. The other n/2 values are the
+// conjugates of these.
+//
+// The order of operations follows the reference implementation, so that
+// results are identical.
+
+// fpcMul returns (a_re + i·a_im)·(b_re + i·b_im).
+func fpcMul(aRe, aIm, bRe, bIm fpr) (fpr, fpr) {
+	return fprSub(fprMul(aRe, bRe), fprMul(aIm, bIm)),
+		fprAdd(fprMul(aRe, bIm), fprMul(aIm, bRe))
+}
+
+// fpcDiv returns (a_re + i·a_im)/(b_re + i·b_im).
+func fpcDiv(aRe, aIm, bRe, bIm fpr) (fpr, fpr) {
+	m := fprInv(fprAdd(fprSqr(bRe), fprSqr(bIm)))
+	bRe = fprMul(bRe, m)
+	bIm = fprMul(fprNeg(bIm), m)
+	return fpcMul(aRe, aIm, bRe, bIm)
+}
+
+// fft converts f, of length 2^logn, to FFT form in place.
+func fft(f []fpr, logn uint) {
+	n := 1 << logn
+	hn := n >> 1
+	t := hn
+	for u, m := uint(1), 2; u < logn; u, m = u+1, m<<1 {
+		ht := t >> 1
+		hm := m >> 1
+		for i1, j1 := 0, 0; i1 < hm; i1, j1 = i1+1, j1+t {
+			sRe := fprGMTab[(m+i1)<<1]
+			sIm := fprGMTab[(m+i1)<<1+1]
+			for j := j1; j < j1+ht; j++ {
+				xRe, xIm := f[j], f[j+hn]
+				yRe, yIm := fpcMul(f[j+ht], f[j+ht+hn], sRe, sIm)
+				f[j], f[j+hn] = fprAdd(xRe, yRe), fprAdd(xIm, yIm)
+				f[j+ht], f[j+ht+hn] = fprSub(xRe, yRe), fprSub(xIm, yIm)
+			}
+		}
+		t = ht
+	}
+}
+
+// ifft converts f, of length 2^logn, from FFT form in place.
+func ifft(f []fpr, logn uint) {
+	n := 1 << logn
+	hn := n >> 1
+	t := 1
+	m := n
+	for u := logn; u > 1; u-- {
+		hm := m >> 1
+		dt := t << 1
+		for i1, j1 := 0, 0; j1 < hn; i1, j1 = i1+1, j1+dt {
+			sRe := fprGMTab[(hm+i1)<<1]
+			sIm := fprNeg(fprGMTab[(hm+i1)<<1+1])
+			for j := j1; j < j1+t; j++ {
+				xRe, xIm := f[j], f[j+hn]
+				yRe, yIm := f[j+t], f[j+t+hn]
+				f[j], f[j+hn] = fprAdd(xRe, yRe), fprAdd(xIm, yIm)
+				xRe, xIm = fprSub(xRe, yRe), fprSub(xIm, yIm)
+				f[j+t], f[j+t+hn] = fpcMul(xRe, xIm, sRe, sIm)
+			}
+		}
+		t = dt
+		m = hm
+	}
+
+	// Divide by n/2.
+	if logn > 0 {
+		ni := fpr(uint64(1024-logn) << 52)
+		for u := range f[:n] {
+			f[u] = fprMul(f[u], ni)
+		}
+	}
+}
+
+func polyAdd(a, b []fpr) {
+	for u := range a {
+		a[u] = fprAdd(a[u], b[u])
+	}
+}
+
+func polySub(a, b []fpr) {
+	for u := range a {
+		a[u] = fprSub(a[u], b[u])
+	}
+}
+
+func polyNeg(a []fpr) {
+	for u := range a {
+		a[u] = fprNeg(a[u])
+	}
+}
+
+// polyAdjFFT replaces a with its adjoint.
+func polyAdjFFT(a []fpr) {
+	hn := len(a) >> 1
+	polyNeg(a[hn:])
+}
+
+// polyMulFFT sets a to a·b.
+func polyMulFFT(a, b []fpr) {
+	hn := len(a) >> 1
+	for u := range hn {
+		a[u], a[u+hn] = fpcMul(a[u], a[u+hn], b[u], b[u+hn])
+	}
+}
+
+// polyMulAdjFFT sets a to a·adj(b).
+func polyMulAdjFFT(a, b []fpr) {
+	hn := len(a) >> 1
+	for u := range hn {
+		a[u], a[u+hn] = fpcMul(a[u], a[u+hn], b[u], fprNeg(b[u+hn]))
+	}
+}
+
+// polyMulSelfAdjFFT sets a to a·adj(a).
+func polyMulSelfAdjFFT(a []fpr) {
+	hn := len(a) >> 1
+	for u := range hn {
+		a[u] = fprAdd(fprSqr(a[u]), fprSqr(a[u+hn]))
+		a[u+hn] = fprZero
+	}
+}
+
+func polyMulConst(a []fpr, x fpr) {
+	for u := range a {
+		a[u] = fprMul(a[u], x)
+	}
+}
+
+// polyInvNorm2FFT sets d to 1/(a·adj(a) + b·adj(b)). The result is
+// self-adjoint, so only the first half of d is written.
+func polyInvNorm2FFT(d, a, b []fpr) {
+	hn := len(a) >> 1
+	for u := range hn {
+		d[u] = fprInv(fprAdd(
+			fprAdd(fprSqr(a[u]), fprSqr(a[u+hn])),
+			fprAdd(fprSqr(b[u]), fprSqr(b[u+hn]))))
+	}
+}
+
+// polyAddMulAdjFFT sets d to bigF·adj(f) + bigG·adj(g).
+func polyAddMulAdjFFT(d, bigF, bigG, f, g []fpr) {
+	hn := len(d) >> 1
+	for u := range hn {
+		aRe, aIm := fpcMul(bigF[u], bigF[u+hn], f[u], fprNeg(f[u+hn]))
+		bRe, bIm := fpcMul(bigG[u], bigG[u+hn], g[u], fprNeg(g[u+hn]))
+		d[u], d[u+hn] = fprAdd(aRe, bRe), fprAdd(aIm, bIm)
+	}
+}
+
+// polyMulAutoAdjFFT sets a to a·b, where b is self-adjoint and only the
+// first half of b is used.
+func polyMulAutoAdjFFT(a, b []fpr) {
+	hn := len(a) >> 1
+	for u := range hn {
+		a[u] = fprMul(a[u], b[u])
+		a[u+hn] = fprMul(a[u+hn], b[u])
+	}
+}
+
+// polyDivAutoAdjFFT sets a to a/b, where b is self-adjoint and only the
+// first half of b is used.
+func polyDivAutoAdjFFT(a, b []fpr) {
+	hn := len(a) >> 1
+	for u := range hn {
+		ib := fprInv(b[u])
+		a[u] = fprMul(a[u], ib)
+		a[u+hn] = fprMul(a[u+hn], ib)
+	}
+}
+
+// polyLDLFFT computes the LDL decomposition of the self-adjoint matrix
+// [[g00, g01], [adj(g01), g11]]. On output, g01 holds l10 and g11 holds d11;
+// d00 equals g00.
+func polyLDLFFT(g00, g01, g11 []fpr) {
+	hn := len(g00) >> 1
+	for u := range hn {
+		muRe, muIm := fpcDiv(g01[u], g01[u+hn], g00[u], g00[u+hn])
+		tRe, tIm := fpcMul(muRe, muIm, g01[u], fprNeg(g01[u+hn]))
+		g11[u], g11[u+hn] = fprSub(g11[u], tRe), fprSub(g11[u+hn], tIm)
+		g01[u], g01[u+hn] = muRe, fprNeg(muIm)
+	}
+}
+
+// polySplitFFT computes f0 and f1, of half the size, such that
+// f = f0(X^2) + X·f1(X^2), all in FFT form.
+func polySplitFFT(f0, f1, f []fpr, logn uint) {
+	n := 1 << logn
+	hn := n >> 1
+	qn := hn >> 1
+
+	// For logn = 1, there is a single complex value, and the loop below
+	// is skipped.
+	f0[0] = f[0]
+	f1[0] = f[hn]
+
+	for u := range qn {
+		aRe, aIm := f[u<<1], f[u<<1+hn]
+		bRe, bIm := f[u<<1+1], f[u<<1+1+hn]
+
+		f0[u] = fprHalf(fprAdd(aRe, bRe))
+		f0[u+qn] = fprHalf(fprAdd(aIm, bIm))
+
+		tRe, tIm := fpcMul(fprSub(aRe, bRe), fprSub(aIm, bIm),
+			fprGMTab[(u+hn)<<1], fprNeg(fprGMTab[(u+hn)<<1+1]))
+		f1[u] = fprHalf(tRe)
+		f1[u+qn] = fprHalf(tIm)
+	}
+}
+
+// polyMergeFFT is the inverse of polySplitFFT.
+func polyMergeFFT(f, f0, f1 []fpr, logn uint) {
+	n := 1 << logn
+	hn := n >> 1
+	qn := hn >> 1
+
+	f[0] = f0[0]
+	f[hn] = f1[0]
+
+	for u := range qn {
+		aRe, aIm := f0[u], f0[u+qn]
+		bRe, bIm := fpcMul(f1[u], f1[u+qn],
+			fprGMTab[(u+hn)<<1], fprGMTab[(u+hn)<<1+1])
+		f[u<<1], f[u<<1+hn] = fprAdd(aRe, bRe), fprAdd(aIm, bIm)
+		f[u<<1+1], f[u<<1+1+hn] = fprSub(aRe, bRe), fprSub(aIm, bIm)
+	}
+}
@@ -0,0 +1,64 @@
+package fndsa
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func randPoly(r *rand.Rand, logn uint) ([]int64, []fpr) {
+	a := make([]int64, 1<<logn)
+	f := make([]fpr, 1<<logn)
+	for i := range a {
+		a[i] = r.Int64N(4096) - 2048
+		f[i] = fprOf(a[i])
+	}
+	return a, f
+}
+
+func checkPoly(t *testing.T, got []fpr, want []int64) {
+	t.Helper()
+	for i := range want {
+		if v := fprRint(got[i]); v != want[i] {
+			t.Fatalf("coefficient %v: got %v (%v), want %v",
+				i, v, math.Float64frombits(uint64(got[i])), want[i])
+		}
+	}
+}
+
+func TestFFT(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for logn := uint(1); logn <= 10; logn++ {
+		n := 1 << logn
+		a, fa := randPoly(r, logn)
+		b, fb := randPoly(r, logn)
+
+		// Negacyclic product of a and b.
+		c := make([]int64, n)
+		for i := range n {
+			for j := range n {
+				if i+j < n {
+					c[i+j] += a[i] * b[j]
+				} else {
+					c[i+j-n] -= a[i] * b[j]
+				}
+			}
+		}
+
+		fft(fa, logn)
+		fft(fb, logn)
+		fc := append([]fpr(nil), fa...)
+		polyMulFFT(fc, fb)
+		ifft(fc, logn)
+		checkPoly(t, fc, c)
+
+		// Splitting and merging are inverse operations.
+		f0 := make([]fpr, max(1, n/2))
+		f1 := make([]fpr, max(1, n/2))
+		polySplitFFT(f0, f1, fa, logn)
+		fm := make([]fpr, n)
+		polyMergeFFT(fm, f0, f1, logn)
+		ifft(fm, logn)
+		checkPoly(t, fm, a)
+	}
+}
@@ -0,0 +1,325 @@
+//go:generate go run gen.go
+
+// Package fndsa implements the FN-DSA signature scheme, formerly known as
+// Falcon, as described in the Falcon specification v1.2
+//
+// https://falcon-sign.info/falcon.pdf
+//
+// Two parameter sets are supported: [Falcon512] for NIST security level 1,
+// and [Falcon1024] for level 5.
+//
+// Signing uses floating-point arithmetic, which is emulated with integer
+// operations in constant time. Hence, signatures do not depend on the
+// floating-point unit of the platform, and timings do not leak the private
+// key. As in the reference implementation, key generation solves the NTRU
+// equation with modular arithmetic over small primes, also in constant time.
+//
+// Signatures use the padded format, so they have a fixed size.
+package fndsa
+
+import (
+	"crypto"
+	cryptoRand "crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"io"
+
+	"github.com/cloudflare/circl/internal/sha3"
+)
+
+const (
+	// SeedSize is the size of seeds for NewKeyFromSeed.
+	SeedSize = 48
+
+	// NonceSize is the size of the random nonce in signatures.
+	NonceSize = 40
+)
+
+// ID identifies a parameter set of FN-DSA.
+// Note that the zero value is not a valid identifier.
+type ID uint8
+
+const (
+	Falcon512  ID = iota + 1 // Falcon-512, with n = 512
+	Falcon1024               // Falcon-1024, with n = 1024
+	_MaxParams
+)
+
+// ErrParam is returned or panicked on invalid parameter sets.
+var ErrParam = errors.New("fndsa: invalid parameter set")
+
+// params contains the constants of a parameter set.
+type params struct {
+	id       ID
+	name     string
+	logn     uint   // n = 2^logn
+	fgBits   int    // Bits per coefficient of f and g in private keys
+	sigSize  int    // Size of padded signatures
+	l2bound  uint32 // Bound on the squared norm of signatures
+	sigmaMin fpr    // Minimal standard deviation of the sampler
+	invSigma fpr    // 1/σ
+}
+
+var supportedParams = [_MaxParams - 1]params{
+	{
+		id: Falcon512, name: "Falcon-512", logn: 9, fgBits: 6,
+		sigSize: 666, l2bound: 34034726,
+		sigmaMin: fprSigmaMin512, invSigma: fprInvSigma512,
+	},
+	{
+		id: Falcon1024, name: "Falcon-1024", logn: 10, fgBits: 5,
+		sigSize: 1280, l2bound: 70265242,
+		sigmaMin: fprSigmaMin1024, invSigma: fprInvSigma1024,
+	},
+}
+
+// IsValid returns true if the parameter set is supported.
+func (id ID) IsValid() bool { return 0 < id && id < _MaxParams }
+
+func (id ID) String() string {
+	if !id.IsValid() {
+		return ErrParam.Error()
+	}
+	return supportedParams[id-1].name
+}
+
+func (id ID) params() *params {
+	if !id.IsValid() {
+		panic(ErrParam)
+	}
+	return &supportedParams[id-1]
+}
+
+// PublicKeySize returns the size of packed public keys.
+func (id ID) PublicKeySize() int { return 1 + (14<<id.params().logn)/8 }
+
+// PrivateKeySize returns the size of packed private keys.
+func (id ID) PrivateKeySize() int {
+	p := id.params()
+	return 1 + (2*p.fgBits+8)<<p.logn/8
+}
+
+// SignatureSize returns the size of signatures.
+func (id ID) SignatureSize() int { return id.params().sigSize }
+
+// PublicKey is an FN-DSA public key.
+type PublicKey struct {
+	id ID
+	h  []uint16
+}
+
+// PrivateKey is an FN-DSA private key.
+type PrivateKey struct {
+	id         ID
+	f, g       []int8
+	bigF, bigG []int8
+	pk         PublicKey
+}
+
+func (sk *PrivateKey) params() *params { return sk.id.params() }
+
+// GenerateKey generates a key pair for the given parameter set, using
+// entropy from rand. If rand is nil, crypto/rand.Reader will be used.
+func GenerateKey(rand io.Reader, id ID) (*PublicKey, *PrivateKey, error) {
+	var seed [SeedSize]byte
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+	if _, err := io.ReadFull(rand, seed[:]); err != nil {
+		return nil, nil, err
+	}
+	pk, sk := NewKeyFromSeed(id, &seed)
+	return pk, sk, nil
+}
+
+// NewKeyFromSeed derives a key pair for the given parameter set from a seed.
+// The seed is expanded with SHAKE256, as in the reference implementation.
+func NewKeyFromSeed(id ID, seed *[SeedSize]byte) (*PublicKey, *PrivateKey) {
+	p := id.params()
+	rng := sha3.NewShake256()
+	_, _ = rng.Write(seed[:])
+	f, g, bigF, bigG, h := p.keyGen(&rng)
+	pk := PublicKey{id: id, h: h}
+	return &pk, &PrivateKey{id: id, f: f, g: g, bigF: bigF, bigG: bigG, pk: pk}
+}
+
+// Sign returns a signature of msg, using entropy from rand. If rand is nil,
+// crypto/rand.Reader will be used.
+func Sign(rand io.Reader, sk *PrivateKey, msg []byte) ([]byte, error) {
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+	var seed [48]byte
+	sig := make([]byte, sk.id.SignatureSize())
+	nonce := sig[1 : 1+NonceSize]
+	if _, err := io.ReadFull(rand, nonce); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(rand, seed[:]); err != nil {
+		return nil, err
+	}
+	sk.signTo(sig, seed[:], msg)
+	return sig, nil
+}
+
+// signTo writes a signature with the nonce given in sig, using the seed to
+// initialize the sampler.
+func (sk *PrivateKey) signTo(sig, seed, msg []byte) {
+	p := sk.params()
+	rng := sha3.NewShake256()
+	_, _ = rng.Write(seed)
+
+	sig[0] = 0x30 + byte(p.logn)
+	hm := hashToPoint(sig[1:1+NonceSize], msg, p.logn)
+	for {
+		// Signatures that do not fit in the padded format are discarded.
+		s2 := sk.signRaw(&rng, hm)
+		body := sig[1+NonceSize:]
+		if k, ok := compEncode(body, s2); ok {
+			clear(body[k:])
+			return
+		}
+	}
+}
+
+// Verify checks whether sig is a valid signature of msg under pk.
+func Verify(pk *PublicKey, msg, sig []byte) bool {
+	p := pk.id.params()
+	if len(sig) != p.sigSize || sig[0] != 0x30+byte(p.logn) {
+		return false
+	}
+	s2 := make([]int16, 1<<p.logn)
+	k, ok := compDecode(s2, sig[1+NonceSize:])
+	if !ok {
+		return false
+	}
+	for _, b := range sig[1+NonceSize+k:] {
+		if b != 0 {
+			return false
+		}
+	}
+	hm := hashToPoint(sig[1:1+NonceSize], msg, p.logn)
+	return p.verifyRaw(hm, s2, pk.h)
+}
+
+// MarshalBinary packs the public key.
+func (pk *PublicKey) MarshalBinary() ([]byte, error) {
+	p := pk.id.params()
+	buf := make([]byte, pk.id.PublicKeySize())
+	buf[0] = byte(p.logn)
+	modqEncode(buf[1:], pk.h)
+	return buf, nil
+}
+
+// UnmarshalBinary unpacks a public key of the parameter set given by its
+// header byte.
+func (pk *PublicKey) UnmarshalBinary(buf []byte) error {
+	id, ok := idFromHeader(buf, 0x00)
+	if !ok {
+		return ErrParam
+	}
+	h := make([]uint16, 1<<id.params().logn)
+	if !modqDecode(h, buf[1:]) {
+		return errors.New("fndsa: invalid public key")
+	}
+	pk.id, pk.h = id, h
+	return nil
+}
+
+// Equal returns whether the two public keys are equal.
+func (pk *PublicKey) Equal(other crypto.PublicKey) bool {
+	castOther, ok := other.(*PublicKey)
+	if !ok || pk.id != castOther.id {
+		return false
+	}
+	for i := range pk.h {
+		if pk.h[i] != castOther.h[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalBinary packs the private key.
+func (sk *PrivateKey) MarshalBinary() ([]byte, error) {
+	p := sk.params()
+	n := 1 << p.logn
+	buf := make([]byte, sk.id.PrivateKeySize())
+	buf[0] = 0x50 + byte(p.logn)
+	k := p.fgBits * n / 8
+	trimEncode(buf[1:], sk.f, p.fgBits)
+	trimEncode(buf[1+k:], sk.g, p.fgBits)
+	trimEncode(buf[1+2*k:], sk.bigF, 8)
+	return buf, nil
+}
+
+// UnmarshalBinary unpacks a private key of the parameter set given by its
+// header byte.
+func (sk *PrivateKey) UnmarshalBinary(buf []byte) error {
+	id, ok := idFromHeader(buf, 0x50)
+	if !ok {
+		return ErrParam
+	}
+	p := id.params()
+	n := 1 << p.logn
+	if len(buf) != id.PrivateKeySize() {
+		return errors.New("fndsa: invalid private key")
+	}
+	k := p.fgBits * n / 8
+	f, g, bigF := make([]int8, n), make([]int8, n), make([]int8, n)
+	if !trimDecode(f, buf[1:1+k], p.fgBits) ||
+		!trimDecode(g, buf[1+k:1+2*k], p.fgBits) ||
+		!trimDecode(bigF, buf[1+2*k:], 8) {
+		return errors.New("fndsa: invalid private key")
+	}
+	bigG, ok := completePrivate(f, g, bigF, p.logn)
+	if !ok {
+		return errors.New("fndsa: invalid private key")
+	}
+	h, ok := computePublic(f, g, p.logn)
+	if !ok {
+		return errors.New("fndsa: invalid private key")
+	}
+	*sk = PrivateKey{
+		id: id, f: f, g: g, bigF: bigF, bigG: bigG,
+		pk: PublicKey{id: id, h: h},
+	}
+	return nil
+}
+
+// idFromHeader returns the parameter set given in the header byte of an
+// encoded key.
+func idFromHeader(buf []byte, tag byte) (ID, bool) {
+	if len(buf) == 0 {
+		return 0, false
+	}
+	for i := range supportedParams {
+		if buf[0] == tag+byte(supportedParams[i].logn) {
+			return supportedParams[i].id, true
+		}
+	}
+	return 0, false
+}
+
+// Public returns the public key corresponding to the private key.
+func (sk *PrivateKey) Public() crypto.PublicKey { pk := sk.pk; return &pk }
+
+// Equal returns whether the two private keys are equal.
+func (sk *PrivateKey) Equal(other crypto.PrivateKey) bool {
+	castOther, ok := other.(*PrivateKey)
+	if !ok || sk.id != castOther.id {
+		return false
+	}
+	a, _ := sk.MarshalBinary()
+	b, _ := castOther.MarshalBinary()
+	return subtle.ConstantTimeCompare(a, b) == 1
+}
+
+// Sign signs msg with a random nonce. Pre-hashing is not supported, so
+// opts.HashFunc() must be zero.
+func (sk *PrivateKey) Sign(rand io.Reader, msg []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if opts.HashFunc() != crypto.Hash(0) {
+		return nil, errors.New("fndsa: cannot sign hashed message")
+	}
+	return Sign(rand, sk, msg)
+}
@@ -0,0 +1,149 @@
+package fndsa
+
+import (
+	"bytes"
+	"crypto"
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+var allIDs = [...]ID{Falcon512, Falcon1024}
+
+func TestFndsa(t *testing.T) {
+	for _, id := range allIDs {
+		t.Run(id.String(), func(t *testing.T) {
+			t.Run("Keys", func(t *testing.T) { testKeys(t, id) })
+			t.Run("Sign", func(t *testing.T) { testSign(t, id) })
+		})
+	}
+}
+
+func testKeys(t *testing.T, id ID) {
+	var seed [SeedSize]byte
+	pk0, sk0 := NewKeyFromSeed(id, &seed)
+	pk1, sk1 := NewKeyFromSeed(id, &seed)
+	test.CheckOk(pk0.Equal(pk1), "public key not equal", t)
+	test.CheckOk(sk0.Equal(sk1), "private key not equal", t)
+	test.CheckOk(pk0.Equal(sk0.Public()), "public key not equal", t)
+
+	seed[0] = 1
+	pk2, sk2 := NewKeyFromSeed(id, &seed)
+	test.CheckOk(!pk0.Equal(pk2), "public key equal", t)
+	test.CheckOk(!sk0.Equal(sk2), "private key equal", t)
+
+	ppk, err := pk0.MarshalBinary()
+	test.CheckNoErr(t, err, "MarshalBinary failed")
+	test.CheckOk(len(ppk) == id.PublicKeySize(), "bad public key size", t)
+	psk, err := sk0.MarshalBinary()
+	test.CheckNoErr(t, err, "MarshalBinary failed")
+	test.CheckOk(len(psk) == id.PrivateKeySize(), "bad private key size", t)
+
+	var pk3 PublicKey
+	var sk3 PrivateKey
+	test.CheckNoErr(t, pk3.UnmarshalBinary(ppk), "UnmarshalBinary failed")
+	test.CheckNoErr(t, sk3.UnmarshalBinary(psk), "UnmarshalBinary failed")
+	test.CheckOk(pk0.Equal(&pk3), "public key not equal", t)
+	test.CheckOk(sk0.Equal(&sk3), "private key not equal", t)
+	test.CheckOk(pk0.Equal(sk3.Public()), "public key not equal", t)
+
+	// The private key unpacks G from f, g and F.
+	test.CheckOk(bytes.Equal(
+		int8Bytes(sk0.bigG), int8Bytes(sk3.bigG)), "G not equal", t)
+}
+
+func testSign(t *testing.T, id ID) {
+	pk, sk, err := GenerateKey(nil, id)
+	test.CheckNoErr(t, err, "GenerateKey failed")
+
+	for i := range 10 {
+		msg := []byte(fmt.Sprintf("Alice and Bob %d", i))
+		sig, err := sk.Sign(nil, msg, crypto.Hash(0))
+		test.CheckNoErr(t, err, "Sign failed")
+		test.CheckOk(len(sig) == id.SignatureSize(), "bad signature size", t)
+		test.CheckOk(Verify(pk, msg, sig), "Verify failed", t)
+
+		test.CheckOk(!Verify(pk, msg[1:], sig), "Verify accepted other message", t)
+		test.CheckOk(!Verify(pk, msg, sig[:len(sig)-1]), "Verify accepted short signature", t)
+		for _, j := range []int{0, 1, NonceSize + 1, len(sig) / 2} {
+			sig[j] ^= 1
+			test.CheckOk(!Verify(pk, msg, sig), "Verify accepted altered signature", t)
+			sig[j] ^= 1
+		}
+
+		// Non-zero padding.
+		sig[len(sig)-1] = 1
+		test.CheckOk(!Verify(pk, msg, sig), "Verify accepted non-zero padding", t)
+	}
+
+	_, err = sk.Sign(nil, []byte("msg"), crypto.SHA256)
+	test.CheckIsErr(t, err, "Sign should reject hashed messages")
+}
+
+func int8Bytes(x []int8) []byte {
+	b := make([]byte, len(x))
+	for i := range x {
+		b[i] = byte(x[i])
+	}
+	return b
+}
+
+func TestInvalidKeys(t *testing.T) {
+	for _, id := range allIDs {
+		var seed [SeedSize]byte
+		pk, sk := NewKeyFromSeed(id, &seed)
+		ppk, _ := pk.MarshalBinary()
+		psk, _ := sk.MarshalBinary()
+
+		var pk2 PublicKey
+		var sk2 PrivateKey
+		test.CheckIsErr(t, pk2.UnmarshalBinary(nil), "empty public key")
+		test.CheckIsErr(t, pk2.UnmarshalBinary(ppk[:len(ppk)-1]), "short public key")
+		test.CheckIsErr(t, sk2.UnmarshalBinary(psk[:len(psk)-1]), "short private key")
+		test.CheckIsErr(t, pk2.UnmarshalBinary(psk), "private key as public key")
+		test.CheckIsErr(t, sk2.UnmarshalBinary(ppk), "public key as private key")
+
+		// The first coefficient of h is 0x3FFF >= q.
+		bad := bytes.Clone(ppk)
+		bad[1], bad[2] = 0xFF, bad[2]|0xFC
+		test.CheckIsErr(t, pk2.UnmarshalBinary(bad), "coefficient of h out of range")
+
+		// The first coefficient of f is -2^(fgBits-1).
+		bits := id.params().fgBits
+		bad = bytes.Clone(psk)
+		bad[1] = bad[1]&(0xFF>>bits) | byte(1<<(bits-1))<<(8-bits)
+		test.CheckIsErr(t, sk2.UnmarshalBinary(bad), "coefficient of f out of range")
+
+		// A random F gives a G with large coefficients.
+		bad = bytes.Clone(psk)
+		for i := len(bad) - 1<<id.params().logn; i < len(bad); i++ {
+			bad[i] = byte(i)
+		}
+		test.CheckIsErr(t, sk2.UnmarshalBinary(bad), "invalid F")
+	}
+}
+
+func BenchmarkFndsa(b *testing.B) {
+	for _, id := range allIDs {
+		var seed [SeedSize]byte
+		pk, sk := NewKeyFromSeed(id, &seed)
+		msg := []byte("Alice and Bob")
+		sig, _ := Sign(nil, sk, msg)
+		b.Run(id.String()+"/GenerateKey", func(b *testing.B) {
+			for range b.N {
+				_, _, _ = GenerateKey(nil, id)
+			}
+		})
+		b.Run(id.String()+"/Sign", func(b *testing.B) {
+			for range b.N {
+				_, _ = Sign(nil, sk, msg)
+			}
+		})
+		b.Run(id.String()+"/Verify", func(b *testing.B) {
+			for range b.N {
+				_ = Verify(pk, msg, sig)
+			}
+		})
+	}
+}
@@ -0,0 +1,324 @@
+package fndsa
+
+import "math/bits"
+
+// fpr is a binary64 floating-point value, stored as its IEEE-754 encoding.
+//
+// All operations are emulated with integer arithmetic in constant time, so
+// that signing produces the same results on every platform. Subnormal
+// numbers, infinities and NaNs are not supported, since they never appear
+// when computing with the values used by FN-DSA. Rounding is always to the
+// nearest value, with ties broken to even.
+type fpr uint64
+
+const (
+	fprZero fpr = 0
+	fprOne  fpr = 0x3FF0000000000000
+)
+
+// fprUrsh returns x >> n, for 0 <= n < 64, with a running time independent
+// of n.
+func fprUrsh(x uint64, n uint) uint64 {
+	x ^= (x ^ (x >> 32)) & -uint64(n>>5)
+	return x >> (n & 31)
+}
+
+// fprIrsh returns x >> n, for 0 <= n < 64, with a running time independent
+// of n.
+func fprIrsh(x int64, n uint) int64 {
+	x ^= (x ^ (x >> 32)) & -int64(n>>5)
+	return x >> (n & 31)
+}
+
+// fprUlsh returns x << n, for 0 <= n < 64, with a running time independent
+// of n.
+func fprUlsh(x uint64, n uint) uint64 {
+	x ^= (x ^ (x << 32)) & -uint64(n>>5)
+	return x << (n & 31)
+}
+
+// fprPack returns the value (-1)^s·m·2^e, correctly rounded. The mantissa
+// m must be either zero or in the [2^54, 2^55) range, where the two least
+// significant bits are the rounding bit and a "sticky" bit, respectively.
+// If the exponent is too low, zero is returned.
+func fprPack(s uint64, e int, m uint64) fpr {
+	e += 1076
+	t := uint64(e) >> 63
+	m &= t - 1
+	t = m >> 54
+	e &= -int(t)
+	x := (s<<63 | m>>2) + uint64(uint32(e))<<52
+	x += (0xC8 >> (m & 7)) & 1
+	return fpr(x)
+}
+
+// fprNorm64 shifts m to the left until its most significant bit is set, and
+// adjusts e so that m·2^e does not change. A zero m is returned unchanged.
+func fprNorm64(m uint64, e int) (uint64, int) {
+	for k := uint(32); k > 0; k >>= 1 {
+		t := m >> (64 - k)
+		mask := ((t | -t) >> 63) - 1
+		m ^= (m ^ (m << k)) & mask
+		e -= int(k) & int(mask)
+	}
+	return m, e
+}
+
+// fprScaled returns i·2^sc.
+func fprScaled(i int64, sc int) fpr {
+	s := uint64(i) >> 63
+	m := (uint64(i) ^ -s) + s
+	m, e := fprNorm64(m, sc+9)
+	// Keep 55 bits, the last one being sticky.
+	m |= ((m & 0x1FF) + 0x1FF)
+	m >>= 9
+	return fprPack(s, e, m)
+}
+
+// fprOf returns i as a floating-point value.
+func fprOf(i int64) fpr { return fprScaled(i, 0) }
+
+func fprNeg(x fpr) fpr { return x ^ 1<<63 }
+
+// fprHalf returns x/2.
+func fprHalf(x fpr) fpr {
+	x -= 1 << 52
+	t := ((uint64(x>>52) & 0x7FF) + 1) >> 11
+	return x & fpr(t-1)
+}
+
+// fprDouble returns 2·x.
+func fprDouble(x fpr) fpr {
+	return x + fpr((((uint64(x>>52)&0x7FF)+0x7FF)>>11)<<52)
+}
+
+func fprAdd(x, y fpr) fpr {
+	// Swap the operands so that |x| >= |y|; on equality, x must be
+	// non-negative, so that x + (-x) is +0.
+	m := uint64(1)<<63 - 1
+	za := (uint64(x) & m) - (uint64(y) & m)
+	cs := za>>63 | ((1 - (-za)>>63) & (uint64(x) >> 63))
+	m = uint64(x^y) & -cs
+	x ^= fpr(m)
+	y ^= fpr(m)
+
+	// Extract the mantissas, with three extra bits of precision, and the
+	// exponents, such that x = xu·2^ex.
+	ex := int(x >> 52)
+	sx := ex >> 11
+	ex &= 0x7FF
+	xu := ((uint64(x) & (1<<52 - 1)) | uint64(uint32((ex+0x7FF)>>11))<<52) << 3
+	ex -= 1078
+	ey := int(y >> 52)
+	sy := ey >> 11
+	ey &= 0x7FF
+	yu := ((uint64(y) & (1<<52 - 1)) | uint64(uint32((ey+0x7FF)>>11))<<52) << 3
+	ey -= 1078
+
+	// Align y with x, keeping a sticky bit. If the exponents are too far
+	// apart, y does not matter.
+	cc := ex - ey
+	yu &= -uint64(uint32(cc-60) >> 31)
+	cc &= 63
+	m = fprUlsh(1, uint(cc)) - 1
+	yu |= (yu & m) + m
+	yu = fprUrsh(yu, uint(cc))
+
+	// Add or subtract, then normalize to 55 bits.
+	xu += yu - ((yu << 1) & -uint64(sx^sy))
+	xu, ex = fprNorm64(xu, ex)
+	xu |= uint64(uint32(xu)&0x1FF) + 0x1FF
+	xu >>= 9
+	ex += 9
+	return fprPack(uint64(sx), ex, xu)
+}
+
+func fprSub(x, y fpr) fpr { return fprAdd(x, fprNeg(y)) }
+
+func fprMul(x, y fpr) fpr {
+	xu := uint64(x)&(1<<52-1) | 1<<52
+	yu := uint64(y)&(1<<52-1) | 1<<52
+
+	// The product is in the [2^104, 2^106) range. Keep its top bits in
+	// [2^54, 2^56), with a sticky bit, then normalize to [2^54, 2^55).
+	hi, lo := bits.Mul64(xu, yu)
+	zu := hi<<14 | lo>>50
+	zu |= ((lo & (1<<50 - 1)) + (1<<50 - 1)) >> 50
+	zv := zu>>1 | zu&1
+	w := zu >> 55
+	zu ^= (zu ^ zv) & -w
+
+	ex := int(x>>52) & 0x7FF
+	ey := int(y>>52) & 0x7FF
+	e := ex + ey - 2100 + int(w)
+	s := uint64(x^y) >> 63
+
+	// If either operand is zero, so is the result.
+	d := ((ex + 0x7FF) & (ey + 0x7FF)) >> 11
+	zu &= -uint64(d)
+	return fprPack(s, e, zu)
+}
+
+func fprSqr(x fpr) fpr { return fprMul(x, x) }
+
+func fprDiv(x, y fpr) fpr {
+	xu := uint64(x)&(1<<52-1) | 1<<52
+	yu := uint64(y)&(1<<52-1) | 1<<52
+
+	// Divide bit by bit, for 55 bits of quotient, followed by a sticky
+	// bit that is set if the remainder is not zero.
+	qu := uint64(0)
+	for range 55 {
+		b := ((xu - yu) >> 63) - 1
+		xu -= b & yu
+		qu |= b & 1
+		xu <<= 1
+		qu <<= 1
+	}
+	qu |= (xu | -xu) >> 63
+
+	// The quotient is in [2^54, 2^56); normalize it to [2^54, 2^55).
+	qu2 := qu>>1 | qu&1
+	w := qu >> 55
+	qu ^= (qu ^ qu2) & -w
+
+	ex := int(x>>52) & 0x7FF
+	ey := int(y>>52) & 0x7FF
+	e := ex - ey - 55 + int(w)
+	s := uint64(x^y) >> 63
+
+	// If x is zero, so is the result.
+	d := (ex + 0x7FF) >> 11
+	s &= uint64(d)
+	e &= -d
+	qu &= -uint64(d)
+	return fprPack(s, e, qu)
+}
+
+func fprInv(x fpr) fpr { return fprDiv(fprOne, x) }
+
+// fprSqrt returns the square root of x, which must be non-negative.
+func fprSqrt(x fpr) fpr {
+	xu := uint64(x)&(1<<52-1) | 1<<52
+	ex := int(x>>52) & 0x7FF
+	e := ex - 1023
+
+	// Make the exponent even, then halve it.
+	xu += xu & -uint64(e&1)
+	e >>= 1
+	xu <<= 1
+
+	// xu represents a value in [1, 4) with 53 fractional bits. Compute
+	// the square root bit by bit.
+	qu, s, r := uint64(0), uint64(0), uint64(1)<<53
+	for range 54 {
+		t := s + r
+		b := ((xu - t) >> 63) - 1
+		s += (r << 1) & b
+		xu -= t & b
+		qu += r & b
+		xu <<= 1
+		r >>= 1
+	}
+
+	// Append a sticky bit for the remainder.
+	qu <<= 1
+	qu |= (xu | -xu) >> 63
+	e -= 54
+
+	// The square root of zero is zero.
+	qu &= -uint64((ex + 0x7FF) >> 11)
+	return fprPack(0, e, qu)
+}
+
+// fprLt returns 1 if x < y, and 0 otherwise.
+func fprLt(x, y fpr) int {
+	sx := int64(x)
+	sy := int64(y)
+	sy &^= (sx ^ sy) >> 63
+	cc0 := int((sx-sy)>>63) & 1
+	cc1 := int((sy-sx)>>63) & 1
+	return cc0 ^ ((cc0 ^ cc1) & int((x&y)>>63))
+}
+
+// fprRint returns x rounded to the nearest integer, with ties broken to
+// even. The result must fit in 63 bits.
+func fprRint(x fpr) int64 {
+	m := ((uint64(x) << 10) | 1<<62) & (1<<63 - 1)
+	e := 1085 - (int(x>>52) & 0x7FF)
+
+	// Shifting by 64 bits or more gives zero, which also covers x = 0.
+	m &= -uint64(uint32(e-64) >> 31)
+	e &= 63
+
+	// Gather the lowest kept bit, the highest dropped bit and a sticky
+	// bit for the other dropped bits, then round.
+	d := fprUlsh(m, uint(63-e))
+	dd := uint32(d) | (uint32(d>>32) & 0x1FFFFFFF)
+	f := uint32(d>>61) | ((dd | -dd) >> 31)
+	m = fprUrsh(m, uint(e)) + uint64((0xC8>>f)&1)
+
+	s := int64(x >> 63)
+	return (int64(m) ^ -s) + s
+}
+
+// fprFloor returns the largest integer not greater than x. The result must
+// fit in 63 bits.
+func fprFloor(x fpr) int64 {
+	e := int(x>>52) & 0x7FF
+	t := int64(x >> 63)
+	xi := int64(((uint64(x) << 10) | 1<<62) & (1<<63 - 1))
+	xi = (xi ^ -t) + t
+	cc := 1085 - e
+	xi = fprIrsh(xi, uint(cc&63))
+
+	// Shifting by 64 bits or more gives 0 or -1, depending on the sign.
+	xi ^= (xi ^ -t) & -int64(uint32(63-cc)>>31)
+	return xi
+}
+
+// fprTrunc returns x rounded toward zero. The result must fit in 63 bits.
+func fprTrunc(x fpr) int64 {
+	e := int(x>>52) & 0x7FF
+	xu := ((uint64(x) << 10) | 1<<62) & (1<<63 - 1)
+	cc := 1085 - e
+	xu = fprUrsh(xu, uint(cc&63))
+	xu &= -uint64(uint32(cc-64) >> 31)
+
+	t := uint64(x) >> 63
+	xu = (xu ^ -t) + t
+	return int64(xu)
+}
+
+// fprExpmP63 returns 2^63·ccs·exp(-x), rounded to an integer, for
+// 0 <= x < ln(2) and 0 <= ccs <= 1.
+func fprExpmP63(x, ccs fpr) uint64 {
+	// Polynomial approximation of exp(-x) in fixed-point arithmetic,
+	// evaluated with Horner's rule. The coefficients are those of the
+	// Falcon reference implementation.
+	c := [...]uint64{
+		0x00000004741183A3,
+		0x00000036548CFC06,
+		0x0000024FDCBF140A,
+		0x0000171D939DE045,
+		0x0000D00CF58F6F84,
+		0x000680681CF796E3,
+		0x002D82D8305B0FEA,
+		0x011111110E066FD0,
+		0x0555555555070F00,
+		0x155555555581FF00,
+		0x400000000002B400,
+		0x7FFFFFFFFFFF4800,
+		0x8000000000000000,
+	}
+
+	y := c[0]
+	z := uint64(fprTrunc(fprMul(x, fprPtwo63))) << 1
+	for _, cu := range c[1:] {
+		hi, _ := bits.Mul64(z, y)
+		y = cu - hi
+	}
+	z = uint64(fprTrunc(fprMul(ccs, fprPtwo63))) << 1
+	y, _ = bits.Mul64(z, y)
+	return y
+}
@@ -0,0 +1,110 @@
+package fndsa
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+// randFloat returns a random value with an exponent in [-lim, lim).
+func randFloat(r *rand.Rand, lim int) float64 {
+	x := math.Ldexp(1+r.Float64(), r.IntN(2*lim)-lim)
+	if r.IntN(2) == 0 {
+		x = -x
+	}
+	return x
+}
+
+func checkFpr(t *testing.T, op string, got fpr, want float64, args ...float64) {
+	t.Helper()
+	if uint64(got) != math.Float64bits(want) {
+		t.Fatalf("%v%v: got %v (%016x), want %v (%016x)", op, args,
+			math.Float64frombits(uint64(got)), uint64(got), want, math.Float64bits(want))
+	}
+}
+
+func TestFprArith(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for i := range 100000 {
+		lim := 60
+		if i%2 == 0 {
+			lim = 4
+		}
+		x, y := randFloat(r, lim), randFloat(r, lim)
+		if i%10 == 0 {
+			// Exercise cancellation.
+			y = -x * (1 + math.Ldexp(float64(r.IntN(256)), -52))
+		}
+		fx, fy := fpr(math.Float64bits(x)), fpr(math.Float64bits(y))
+
+		// Conversions prevent fused operations.
+		checkFpr(t, "add", fprAdd(fx, fy), float64(x+y), x, y)
+		checkFpr(t, "sub", fprSub(fx, fy), float64(x-y), x, y)
+		checkFpr(t, "mul", fprMul(fx, fy), float64(x*y), x, y)
+		checkFpr(t, "div", fprDiv(fx, fy), float64(x/y), x, y)
+		checkFpr(t, "sqrt", fprSqrt(fx&^(1<<63)), math.Sqrt(math.Abs(x)), x)
+		checkFpr(t, "half", fprHalf(fx), x/2, x)
+		checkFpr(t, "double", fprDouble(fx), 2*x, x)
+
+		lt := 0
+		if x < y {
+			lt = 1
+		}
+		if fprLt(fx, fy) != lt {
+			t.Fatalf("lt(%v, %v) != %v", x, y, lt)
+		}
+	}
+
+	for _, x := range []float64{0, 1, -1, 0.5, 3} {
+		fx := fpr(math.Float64bits(x))
+		checkFpr(t, "add", fprAdd(fx, fprZero), x, x)
+		checkFpr(t, "mul", fprMul(fx, fprZero), x*0, x)
+		checkFpr(t, "half", fprHalf(fprZero), 0)
+	}
+	checkFpr(t, "sqrt", fprSqrt(fprZero), 0)
+	checkFpr(t, "add", fprAdd(fprOne, fprNeg(fprOne)), 0)
+}
+
+func TestFprConv(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	for i := range 100000 {
+		n := r.Int64() >> r.IntN(64)
+		sc := r.IntN(40) - 20
+		checkFpr(t, "scaled", fprScaled(n, sc), math.Ldexp(float64(n), sc), float64(n))
+
+		x := randFloat(r, 40)
+		if i%2 == 0 {
+			// Exercise ties.
+			x = float64(int64(x*4)) / 4
+		}
+		fx := fpr(math.Float64bits(x))
+		if got, want := fprRint(fx), int64(math.RoundToEven(x)); got != want {
+			t.Fatalf("rint(%v): got %v, want %v", x, got, want)
+		}
+		if got, want := fprFloor(fx), int64(math.Floor(x)); got != want {
+			t.Fatalf("floor(%v): got %v, want %v", x, got, want)
+		}
+		if got, want := fprTrunc(fx), int64(math.Trunc(x)); got != want {
+			t.Fatalf("trunc(%v): got %v, want %v", x, got, want)
+		}
+	}
+	for _, x := range []float64{0, 0.25, -0.25, 0.5, -0.5, 1.5, -1.5, 2.5} {
+		fx := fpr(math.Float64bits(x))
+		if got, want := fprRint(fx), int64(math.RoundToEven(x)); got != want {
+			t.Fatalf("rint(%v): got %v, want %v", x, got, want)
+		}
+	}
+}
+
+func TestFprExpm(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+	for range 10000 {
+		x := r.Float64() * math.Ln2
+		ccs := r.Float64()
+		got := float64(fprExpmP63(fpr(math.Float64bits(x)), fpr(math.Float64bits(ccs))))
+		want := math.Ldexp(ccs*math.Exp(-x), 63)
+		if math.Abs(got-want) > math.Ldexp(1, 14) {
+			t.Fatalf("expm(%v, %v): got %v, want %v", x, ccs, got, want)
+		}
+	}
+}
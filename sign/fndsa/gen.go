@@ -0,0 +1,196 @@
+//go:build ignore
+// +build ignore
+
+// Autogenerates the floating-point constants used by FN-DSA, encoded as
+// the raw bits of IEEE-754 binary64 values, and the small primes used to
+// solve the NTRU equation.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"math"
+	"math/big"
+	"os"
+)
+
+const prec = 300
+
+// Number of small primes, enough to represent the largest integers that
+// appear when solving the NTRU equation for n = 1024.
+const numPrimes = 308
+
+// Decimal constants, rounded to the nearest binary64 value.
+var constants = []struct{ name, value, comment string }{
+	{"fprQ", "12289", "q"},
+	{"fprInverseOfQ", "1/12289", "1/q"},
+	{"fprInv2SqrSigma0", "0.150865048875372721532312163019", "1/(2·1.8205²)"},
+	{"fprLog2", "ln2", "ln(2)"},
+	{"fprInvLog2", "1/ln2", "1/ln(2)"},
+	{"fprBnormMax", "16822.4121", "(1.17²)·q"},
+	{"fprPtwo63", "9223372036854775808", "2^63"},
+	{"fprPtwo31", "2147483648", "2^31"},
+	{"fprPtwo31m1", "2147483647", "2^31-1"},
+	{"fprMtwo31m1", "-2147483647", "-(2^31-1)"},
+	{"fprTwo", "2", "2"},
+	{"fprOneHalf", "0.5", "1/2"},
+	{"fprSigmaMin512", "1.2778336969128335860256340575729042", "σ_min for n = 512"},
+	{"fprSigmaMin1024", "1.2982803343442918539708792538826807", "σ_min for n = 1024"},
+	{"fprInvSigma512", "0.0060336696681577241031668062510953022", "1/σ for n = 512"},
+	{"fprInvSigma1024", "0.0059386453095331159950250124336477482", "1/σ for n = 1024"},
+}
+
+func newFloat() *big.Float { return new(big.Float).SetPrec(prec) }
+
+// ln2 returns ln(2) = Σ 1/(k·2^k).
+func ln2() *big.Float {
+	sum := newFloat()
+	for k := 1; k < prec; k++ {
+		t := newFloat().SetMantExp(big.NewFloat(1), -k)
+		sum.Add(sum, t.Quo(t, newFloat().SetInt64(int64(k))))
+	}
+	return sum
+}
+
+// pi returns π computed with Machin's formula.
+func pi() *big.Float {
+	atanInv := func(x int64) *big.Float {
+		sum := newFloat()
+		xx := newFloat().SetInt64(x * x)
+		p := newFloat().Quo(newFloat().SetInt64(1), newFloat().SetInt64(x))
+		for k := int64(0); k < prec; k++ {
+			t := newFloat().Quo(p, newFloat().SetInt64(2*k+1))
+			if k%2 == 0 {
+				sum.Add(sum, t)
+			} else {
+				sum.Sub(sum, t)
+			}
+			p.Quo(p, xx)
+		}
+		return sum
+	}
+	a := atanInv(5)
+	a.Mul(a, newFloat().SetInt64(16))
+	b := atanInv(239)
+	b.Mul(b, newFloat().SetInt64(4))
+	return a.Sub(a, b)
+}
+
+// cosSin returns cos(x) and sin(x) for |x| <= π using Taylor series.
+func cosSin(x *big.Float) (*big.Float, *big.Float) {
+	c, s := newFloat().SetInt64(1), newFloat().Set(x)
+	t := newFloat().SetInt64(1)
+	x2 := newFloat().Mul(x, x)
+	u := newFloat().Set(x)
+	for k := int64(1); k < 200; k++ {
+		t.Mul(t, x2)
+		t.Quo(t, newFloat().SetInt64(-(2*k-1)*(2*k)))
+		c.Add(c, t)
+		u.Mul(u, x2)
+		u.Quo(u, newFloat().SetInt64(-(2*k)*(2*k+1)))
+		s.Add(s, u)
+	}
+	return c, s
+}
+
+func bits(x *big.Float) uint64 {
+	// Values that should be zero may carry a residual error.
+	if x.MantExp(nil) < -200 {
+		return 0
+	}
+	f, _ := x.Float64()
+	return math.Float64bits(f)
+}
+
+func eval(v string) *big.Float {
+	switch v {
+	case "ln2":
+		return ln2()
+	case "1/ln2":
+		return newFloat().Quo(newFloat().SetInt64(1), ln2())
+	case "1/12289":
+		return newFloat().Quo(newFloat().SetInt64(1), newFloat().SetInt64(12289))
+	}
+	x, ok := newFloat().SetString(v)
+	if !ok {
+		panic(v)
+	}
+	return x
+}
+
+func smallPrimes() [][3]uint64 {
+	var out [][3]uint64
+	prod := big.NewInt(1)
+	for p := int64(1<<31 - 2047); len(out) < numPrimes; p -= 2048 {
+		bp := big.NewInt(p)
+		if !bp.ProbablyPrime(20) {
+			continue
+		}
+
+		// x^((p-1)/2048) has order 2048 if its 1024-th power is not 1.
+		e := big.NewInt((p - 1) / 2048)
+		g := new(big.Int)
+		for x := int64(2); ; x++ {
+			g.Exp(big.NewInt(x), e, bp)
+			if new(big.Int).Exp(g, big.NewInt(1024), bp).Cmp(big.NewInt(1)) != 0 {
+				break
+			}
+		}
+
+		// s is in Montgomery representation, with R = 2^31.
+		s := new(big.Int).ModInverse(new(big.Int).Mod(prod, bp), bp)
+		s.Lsh(s, 31).Mod(s, bp)
+		out = append(out, [3]uint64{uint64(p), g.Uint64(), s.Uint64()})
+		prod.Mul(prod, bp)
+	}
+	return out
+}
+
+func main() {
+	buf := new(bytes.Buffer)
+	fmt.Fprintln(buf, "// Code generated by gen.go. DO NOT EDIT.")
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "package fndsa")
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "const (")
+	for _, c := range constants {
+		fmt.Fprintf(buf, "\t%s fpr = 0x%016X // %s\n", c.name, bits(eval(c.value)), c.comment)
+	}
+	fmt.Fprintln(buf, ")")
+	fmt.Fprintln(buf)
+
+	p := pi()
+	fmt.Fprintln(buf, "// fprGMTab[2k] and fprGMTab[2k+1] are the real and imaginary parts of")
+	fmt.Fprintln(buf, "// exp(iπ·rev(k)/1024), where rev reverses the order of 10 bits.")
+	fmt.Fprintln(buf, "var fprGMTab = [2048]fpr{")
+	for k := 0; k < 1024; k++ {
+		r := 0
+		for j := 0; j < 10; j++ {
+			r |= ((k >> j) & 1) << (9 - j)
+		}
+		x := newFloat().Mul(p, newFloat().SetInt64(int64(r)))
+		x.Quo(x, newFloat().SetInt64(1024))
+		c, s := cosSin(x)
+		fmt.Fprintf(buf, "\t0x%016X, 0x%016X,\n", bits(c), bits(s))
+	}
+	fmt.Fprintln(buf, "}")
+	fmt.Fprintln(buf)
+
+	fmt.Fprintln(buf, "// primes are the largest primes p < 2^31 with p = 1 mod 2048, in")
+	fmt.Fprintln(buf, "// decreasing order, each with a primitive 2048-th root of unity g modulo p,")
+	fmt.Fprintln(buf, "// and s = 2^31/(p_0·…·p_(k-1)) mod p, for the k previous primes p_i.")
+	fmt.Fprintln(buf, "var primes = [...]smallPrime{")
+	for _, sp := range smallPrimes() {
+		fmt.Fprintf(buf, "\t{%d, %d, %d},\n", sp[0], sp[1], sp[2])
+	}
+	fmt.Fprintln(buf, "}")
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		panic(err)
+	}
+	if err := os.WriteFile("tables.go", code, 0o600); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,92 @@
+package fndsa
+
+// Code to generate the NIST "PQCsignKAT" test vectors.
+// See PQCgenKAT_sign.c and katrng.c in the reference implementation.
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/nist"
+	"github.com/cloudflare/circl/internal/test"
+)
+
+// drbgReader reads from the deterministic generator of the NIST KATs.
+type drbgReader struct{ g *nist.DRBG }
+
+func (r drbgReader) Read(p []byte) (int, error) { r.g.Fill(p); return len(p), nil }
+
+func TestPQCgenKATSign(t *testing.T) {
+	for _, tc := range []struct {
+		id   ID
+		want string
+	}{
+		// TODO crossreference with falcon512-KAT.rsp and falcon1024-KAT.rsp
+		// 		of the reference implementation, which are not vendored.
+		{Falcon512, "dd75c946fdedef4ec46a2bee7e10c65c9126f1a839b9ced6921fd45f7354b5cd"},
+		{Falcon1024, "6cc0ef7f2fcae372412583c43e24a4f85476d9348db319327298cc5c2d3f8ff6"},
+	} {
+		t.Run(tc.id.String(), func(t *testing.T) {
+			var seed [48]byte
+			for i := 0; i < 48; i++ {
+				seed[i] = byte(i)
+			}
+			f := sha256.New()
+			g := nist.NewDRBG(&seed)
+			mustWrite(t, f, "# %s\n\n", tc.id)
+			for i := 0; i < 100; i++ {
+				mlen := 33 * (i + 1)
+				g.Fill(seed[:])
+				msg := make([]byte, mlen)
+				g.Fill(msg[:])
+
+				mustWrite(t, f, "count = %d\n", i)
+				mustWrite(t, f, "seed = %X\n", seed)
+				mustWrite(t, f, "mlen = %d\n", mlen)
+				mustWrite(t, f, "msg = %X\n", msg)
+
+				g2 := nist.NewDRBG(&seed)
+				pk, sk, err := GenerateKey(drbgReader{&g2}, tc.id)
+				test.CheckNoErr(t, err, "GenerateKey failed")
+				ppk, err := pk.MarshalBinary()
+				test.CheckNoErr(t, err, "MarshalBinary failed")
+				psk, err := sk.MarshalBinary()
+				test.CheckNoErr(t, err, "MarshalBinary failed")
+				mustWrite(t, f, "pk = %X\n", ppk)
+				mustWrite(t, f, "sk = %X\n", psk)
+
+				sig, err := Sign(drbgReader{&g2}, sk, msg)
+				test.CheckNoErr(t, err, "Sign failed")
+				if !Verify(pk, msg, sig) {
+					t.Fatal()
+				}
+
+				// The KATs use the compressed format, without padding: the
+				// signed message is the length of the signature on two
+				// bytes, the nonce, the message, and the signature with
+				// header 0x20+logn. The last byte of the compressed
+				// signature is never zero.
+				esig := append([]byte{0x20 + byte(tc.id.params().logn)},
+					bytes.TrimRight(sig[1+NonceSize:], "\x00")...)
+				sm := binary.BigEndian.AppendUint16(nil, uint16(len(esig)))
+				sm = append(sm, sig[1:1+NonceSize]...)
+				sm = append(sm, msg...)
+				sm = append(sm, esig...)
+				mustWrite(t, f, "smlen = %d\n", len(sm))
+				mustWrite(t, f, "sm = %X\n\n", sm)
+			}
+			if fmt.Sprintf("%x", f.Sum(nil)) != tc.want {
+				t.Fatal()
+			}
+		})
+	}
+}
+
+func mustWrite(t *testing.T, f io.Writer, format string, data ...any) {
+	_, err := fmt.Fprintf(f, format, data...)
+	test.CheckNoErr(t, err, "fprintf failed")
+}
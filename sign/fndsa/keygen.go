@@ -0,0 +1,149 @@
+package fndsa
+
+import (
+	"encoding/binary"
+
+	"github.com/cloudflare/circl/internal/sha3"
+)
+
+// gauss1024 is the cumulative distribution table used to sample the
+// coefficients of f and g, for a discrete Gaussian of standard deviation
+// 1.17·√(q/2048): gauss1024[0] is 2^63 times the probability of a zero,
+// and gauss1024[k] is 2^63 times the probability of an absolute value
+// larger than k, given that it is non-zero.
+var gauss1024 = [...]uint64{
+	1283868770400643928, 6416574995475331444, 4078260278032692663,
+	2353523259288686585, 1227179971273316331, 575931623374121527,
+	242543240509105209, 91437049221049666, 30799446349977173,
+	9255276791179340, 2478152334826140, 590642893610164,
+	125206034929641, 23590435911403, 3948334035941,
+	586753615614, 77391054539, 9056793210,
+	940121950, 86539696, 7062824,
+	510971, 32764, 1862,
+	94, 4, 0,
+}
+
+func getU64(rng *sha3.State) uint64 {
+	var buf [8]byte
+	_, _ = rng.Read(buf[:])
+	return binary.LittleEndian.Uint64(buf[:])
+}
+
+// mkgauss samples a value following a discrete Gaussian of standard
+// deviation 1.17·√(q/2n), as the sum of 1024/n samples for n = 1024.
+func mkgauss(rng *sha3.State, logn uint) int {
+	val := 0
+	for range 1 << (10 - logn) {
+		// The first value gives the sign, and whether the sample is zero.
+		r := getU64(rng)
+		neg := uint32(r >> 63)
+		r &^= 1 << 63
+		f := uint32((r - gauss1024[0]) >> 63)
+
+		// The second value gives the absolute value, if not zero. The whole
+		// table is read, in constant time.
+		v := uint32(0)
+		r = getU64(rng)
+		r &^= 1 << 63
+		for k := 1; k < len(gauss1024); k++ {
+			t := uint32((r-gauss1024[k])>>63) ^ 1
+			v |= uint32(k) & -(t & (f ^ 1))
+			f |= t
+		}
+
+		v = (v ^ -neg) + neg
+		val += int(int32(v))
+	}
+	return val
+}
+
+// polySmallMkgauss samples f with coefficients in [-127, 127], such that
+// the sum of the coefficients is odd. Otherwise, the resultant of f and
+// X^n+1 would be even, and the NTRU equation could not be solved.
+func polySmallMkgauss(rng *sha3.State, f []int8, logn uint) {
+	mod2 := 0
+	for u := range f {
+		for {
+			s := mkgauss(rng, logn)
+			if s < -127 || s > 127 {
+				continue
+			}
+			if u == len(f)-1 {
+				if mod2^(s&1) == 0 {
+					continue
+				}
+			} else {
+				mod2 ^= s & 1
+			}
+			f[u] = int8(s)
+			break
+		}
+	}
+}
+
+// polySmallSqnorm returns the squared norm of f.
+func polySmallSqnorm(f []int8) uint32 {
+	s := uint32(0)
+	for _, v := range f {
+		s += uint32(int32(v) * int32(v))
+	}
+	return s
+}
+
+// keyGen generates the private basis (f, g, F, G) and the public key h,
+// using randomness from rng. See Algorithm 4 of the specification.
+func (p *params) keyGen(rng *sha3.State) (f, g, bigF, bigG []int8, h []uint16) {
+	n := 1 << p.logn
+	f = make([]int8, n)
+	g = make([]int8, n)
+	rt1 := make([]fpr, n)
+	rt2 := make([]fpr, n)
+	rt3 := make([]fpr, n)
+	for {
+		polySmallMkgauss(rng, f, p.logn)
+		polySmallMkgauss(rng, g, p.logn)
+
+		// The coefficients must be encodable in private keys.
+		if !trimFits(f, p.fgBits) || !trimFits(g, p.fgBits) {
+			continue
+		}
+
+		// The squared norm of (g, -f) must be below (1.17²)·q.
+		if polySmallSqnorm(f)+polySmallSqnorm(g) >= 16823 {
+			continue
+		}
+
+		// The same bound applies to the Gram-Schmidt norm
+		// (q·adj(f)/(f·adj(f) + g·adj(g)), q·adj(g)/(f·adj(f) + g·adj(g))).
+		copy(rt1, smallToFpr(f))
+		copy(rt2, smallToFpr(g))
+		fft(rt1, p.logn)
+		fft(rt2, p.logn)
+		polyInvNorm2FFT(rt3, rt1, rt2)
+		polyAdjFFT(rt1)
+		polyAdjFFT(rt2)
+		polyMulConst(rt1, fprQ)
+		polyMulConst(rt2, fprQ)
+		polyMulAutoAdjFFT(rt1, rt3)
+		polyMulAutoAdjFFT(rt2, rt3)
+		ifft(rt1, p.logn)
+		ifft(rt2, p.logn)
+		bnorm := fprZero
+		for u := range n {
+			bnorm = fprAdd(bnorm, fprSqr(rt1[u]))
+			bnorm = fprAdd(bnorm, fprSqr(rt2[u]))
+		}
+		if fprLt(bnorm, fprBnormMax) == 0 {
+			continue
+		}
+
+		var ok bool
+		if h, ok = computePublic(f, g, p.logn); !ok {
+			continue
+		}
+		if bigF, bigG, ok = solveNTRU(f, g, p.logn); !ok {
+			continue
+		}
+		return f, g, bigF, bigG, h
+	}
+}
@@ -0,0 +1,200 @@
+package fndsa
+
+// Arithmetic of polynomials modulo q and X^n+1, with n a power of two up
+// to 1024, using the number-theoretic transform.
+
+// q is the modulus of FN-DSA.
+const q = 12289
+
+var (
+	// zetas[k] = ψ^rev(k), where ψ is a primitive 2048-th root of unity
+	// modulo q and rev reverses the order of 10 bits. The first n entries
+	// are the roots used by a transform of size n.
+	zetas [1024]uint32
+	// invZetas[k] = zetas[k]⁻¹.
+	invZetas [1024]uint32
+	// invN[logn] = 2^(-logn) mod q.
+	invN [11]uint32
+)
+
+func init() {
+	// 11 generates the multiplicative group modulo q, which has order
+	// 12288 = 6·2048.
+	psi := modqPow(11, 6)
+	for k := range zetas {
+		r := 0
+		for j := range 10 {
+			r |= ((k >> j) & 1) << (9 - j)
+		}
+		zetas[k] = modqPow(psi, uint32(r))
+		invZetas[k] = modqInv(zetas[k])
+	}
+	for logn := range invN {
+		invN[logn] = modqInv(uint32(1) << logn)
+	}
+}
+
+func modqMul(a, b uint32) uint32 { return a * b % q }
+
+func modqAdd(a, b uint32) uint32 { return (a + b) % q }
+
+func modqSub(a, b uint32) uint32 { return (a + q - b) % q }
+
+// modqPow returns a^e mod q, in time that depends only on the bit length of
+// the exponent.
+func modqPow(a, e uint32) uint32 {
+	r := uint32(1)
+	for ; e != 0; e >>= 1 {
+		m := -(e & 1)
+		r = modqMul(r, (a&m)|(1&^m))
+		a = modqMul(a, a)
+	}
+	return r
+}
+
+// modqInv returns a⁻¹ mod q, or zero if a is zero.
+func modqInv(a uint32) uint32 { return modqPow(a, q-2) }
+
+// ntt converts a, with coefficients in [0, q), to NTT form in place.
+func ntt(a []uint32) {
+	n := len(a)
+	k := 0
+	for l := n >> 1; l > 0; l >>= 1 {
+		for s := 0; s < n; s += 2 * l {
+			k++
+			z := zetas[k]
+			for j := s; j < s+l; j++ {
+				t := modqMul(z, a[j+l])
+				a[j+l] = modqSub(a[j], t)
+				a[j] = modqAdd(a[j], t)
+			}
+		}
+	}
+}
+
+// invNtt converts a from NTT form in place.
+func invNtt(a []uint32, logn uint) {
+	n := len(a)
+	k := n
+	for l := 1; l < n; l <<= 1 {
+		for s := n - 2*l; s >= 0; s -= 2 * l {
+			k--
+			z := invZetas[k]
+			for j := s; j < s+l; j++ {
+				t := a[j]
+				a[j] = modqAdd(t, a[j+l])
+				a[j+l] = modqMul(z, modqSub(t, a[j+l]))
+			}
+		}
+	}
+	for j := range a {
+		a[j] = modqMul(a[j], invN[logn])
+	}
+}
+
+// smallToModq reduces the coefficients of f modulo q.
+func smallToModq(f []int8) []uint32 {
+	r := make([]uint32, len(f))
+	for i := range f {
+		r[i] = uint32(int32(f[i]) + q)
+	}
+	return r
+}
+
+// modqCenter returns the representative of a in [-q/2, q/2].
+func modqCenter(a uint32) int32 {
+	w := int32(a)
+	return w - (q & ((q/2 - w) >> 31))
+}
+
+// computePublic returns h = g/f mod q, or false if f is not invertible.
+func computePublic(f, g []int8, logn uint) ([]uint16, bool) {
+	tf := smallToModq(f)
+	tg := smallToModq(g)
+	ntt(tf)
+	ntt(tg)
+	zero := uint32(0)
+	for u := range tf {
+		zero |= (tf[u] - 1) >> 31
+		tg[u] = modqMul(tg[u], modqInv(tf[u]))
+	}
+	invNtt(tg, logn)
+	h := make([]uint16, len(tg))
+	for u := range tg {
+		h[u] = uint16(tg[u])
+	}
+	return h, zero == 0
+}
+
+// completePrivate returns G such that fG - gF = q, or false if f is not
+// invertible or G does not have small coefficients.
+func completePrivate(f, g, bigF []int8, logn uint) ([]int8, bool) {
+	tf := smallToModq(f)
+	tg := smallToModq(g)
+	tF := smallToModq(bigF)
+	ntt(tf)
+	ntt(tg)
+	ntt(tF)
+	bad := uint32(0)
+	for u := range tf {
+		bad |= (tf[u] - 1) >> 31
+		tg[u] = modqMul(modqMul(tg[u], tF[u]), modqInv(tf[u]))
+	}
+	invNtt(tg, logn)
+	bigG := make([]int8, len(tg))
+	for u := range tg {
+		w := modqCenter(tg[u])
+		bad |= uint32(127-w)>>31 | uint32(w+127)>>31
+		bigG[u] = int8(w)
+	}
+	return bigG, bad == 0
+}
+
+// verifyRaw checks that s1 = hm - s2·h is such that (s1, s2) is short. See
+// Algorithm 16 of the specification.
+func (p *params) verifyRaw(hm []uint16, s2 []int16, h []uint16) bool {
+	t := make([]uint32, len(s2))
+	th := make([]uint32, len(h))
+	for u := range s2 {
+		t[u] = uint32(int32(s2[u]) + q)
+		th[u] = uint32(h[u])
+	}
+	ntt(t)
+	ntt(th)
+	for u := range t {
+		t[u] = modqMul(t[u], th[u])
+	}
+	invNtt(t, p.logn)
+
+	// -s1 = s2·h - hm.
+	s1 := make([]int16, len(t))
+	for u := range t {
+		s1[u] = int16(modqCenter(modqSub(t[u], uint32(hm[u]))))
+	}
+	return p.isShort(s1, s2)
+}
+
+// isShort returns whether the squared norm of (s1, s2) is acceptable.
+func (p *params) isShort(s1, s2 []int16) bool {
+	s, ng := uint32(0), uint32(0)
+	for u := range s1 {
+		z := int32(s1[u])
+		s += uint32(z * z)
+		ng |= s
+	}
+	s |= -(ng >> 31)
+	return p.isShortHalf(s, s2)
+}
+
+// isShortHalf returns whether the squared norm of (s1, s2) is acceptable,
+// given the squared norm sqn of s1, saturated to 2^32-1.
+func (p *params) isShortHalf(sqn uint32, s2 []int16) bool {
+	ng := -(sqn >> 31)
+	for u := range s2 {
+		z := int32(s2[u])
+		sqn += uint32(z * z)
+		ng |= sqn
+	}
+	sqn |= -(ng >> 31)
+	return sqn <= p.l2bound
+}
@@ -0,0 +1,721 @@
+package fndsa
+
+// Solving the NTRU equation fG - gF = q, following Algorithms 6 and 7 of the
+// specification: the equation is reduced recursively to degree one with
+// field norms, solved with an extended GCD, and the solutions are lifted
+// back and reduced with Babai's round-off algorithm.
+//
+// This is a port of the solver of the reference implementation, and gives
+// the same results. Big integers are kept in RNS form whenever possible,
+// so that products are computed with the NTT modulo small primes, and all
+// the computations run in constant time.
+
+// maxBlSmall[depth] is the maximum size, in 31-bit words, of the
+// coefficients of (f, g) at the given depth, for n = 1024.
+var maxBlSmall = [...]int{1, 1, 2, 2, 4, 7, 14, 27, 53, 106, 209}
+
+// maxBlLarge[depth] is the maximum size, in 31-bit words, of the
+// coefficients of the unreduced (F, G) at the given depth, for n = 1024.
+var maxBlLarge = [...]int{2, 2, 5, 7, 12, 21, 40, 78, 157, 308}
+
+// bitLength[depth] is the average and the standard deviation of the
+// bit length of the coefficients of (f, g) at the given depth, for n = 1024.
+var bitLength = [...]struct{ avg, std int }{
+	{4, 0},
+	{11, 1},
+	{24, 1},
+	{50, 1},
+	{102, 1},
+	{202, 2},
+	{401, 4},
+	{794, 5},
+	{1577, 8},
+	{3138, 13},
+	{6308, 25},
+}
+
+// Up to this depth, the products k·f and k·g of the reduction are computed
+// with the NTT.
+const depthIntFG = 4
+
+// polyBigToFp converts the signed integers of flen words at f[0],
+// f[fstride], f[2·fstride], ... to floating-point values.
+func polyBigToFp(d []fpr, f []uint32, flen, fstride int) {
+	if flen == 0 {
+		clear(d)
+		return
+	}
+	for u := range d {
+		x := f[u*fstride : u*fstride+flen]
+
+		// Negate the value if it is negative, converting it word by word,
+		// then negate each word again.
+		neg := -(x[flen-1] >> 30)
+		xm := neg >> 1
+		cc := neg & 1
+		r, fsc := fprZero, fprOne
+		for _, w := range x {
+			w = (w ^ xm) + cc
+			cc = w >> 31
+			w &= 0x7FFFFFFF
+			w -= (w << 1) & neg
+			r = fprAdd(r, fprMul(fprOf(int64(int32(w))), fsc))
+			fsc = fprMul(fsc, fprPtwo31)
+		}
+		d[u] = r
+	}
+}
+
+// polySubScaled sets F to F - k·f·2^(31·sch+scl) modulo X^n+1, for
+// coefficients of f of flen words and coefficients of F of flenF words.
+func polySubScaled(bigF []uint32, flenF, strideF int, f []uint32, flen, stride int,
+	k []int32, sch, scl uint32,
+) {
+	n := len(k)
+	for u := range n {
+		kf := -k[u]
+		x := u * strideF
+		for v := range n {
+			y := f[v*stride : v*stride+flen]
+			zintAddScaledMulSmall(bigF[x:x+flenF], y, kf, sch, scl)
+			if u+v == n-1 {
+				x = 0
+				kf = -kf
+			} else {
+				x += strideF
+			}
+		}
+	}
+}
+
+// polySubScaledNTT is polySubScaled, computing k·f with the NTT.
+func polySubScaledNTT(bigF []uint32, flenF, strideF int, f []uint32, flen, stride int,
+	k []int32, sch, scl uint32, logn uint,
+) {
+	n := 1 << logn
+	tlen := flen + 1
+	gm := make([]uint32, n)
+	igm := make([]uint32, n)
+	fk := make([]uint32, n*tlen)
+	t1 := make([]uint32, n)
+
+	// Compute k·f modulo enough primes, and rebuild it.
+	for u := range tlen {
+		m := newModp(primes[u].p)
+		rx := m.rx(flen)
+		m.mkgm2(gm, igm, logn, primes[u].g)
+		for v := range n {
+			t1[v] = m.set(k[v])
+		}
+		m.ntt(t1, 1, gm, logn)
+		for v := range n {
+			fk[v*tlen+u] = zintModSmallSigned(f[v*stride:v*stride+flen], m, rx)
+		}
+		m.ntt(fk[u:], tlen, gm, logn)
+		for v := range n {
+			x := &fk[v*tlen+u]
+			*x = m.mul(m.mul(t1[v], *x), m.r2)
+		}
+		m.intt(fk[u:], tlen, igm, logn)
+	}
+	zintRebuildCRT(fk, tlen, tlen, n, true)
+
+	for u := range n {
+		zintSubScaled(bigF[u*strideF:u*strideF+flenF], fk[u*tlen:(u+1)*tlen], sch, scl)
+	}
+}
+
+// makeFGStep computes the field norms of f and g, of degree 2^logn and
+// coefficients of maxBlSmall[depth] words, in RNS form. The inputs are in
+// NTT form if inNTT is true, and so are the outputs if outNTT is true.
+func makeFGStep(fs, gs []uint32, logn, depth uint, inNTT, outNTT bool) (fd, gd []uint32) {
+	n := 1 << logn
+	hn := n >> 1
+	slen := maxBlSmall[depth]
+	tlen := maxBlSmall[depth+1]
+	fd = make([]uint32, hn*tlen)
+	gd = make([]uint32, hn*tlen)
+	gm := make([]uint32, n)
+	igm := make([]uint32, n)
+	t1 := make([]uint32, n)
+
+	// The residues modulo the first slen primes are known; the other
+	// residues need the inputs to be rebuilt with the CRT.
+	norm := func(m modp, d []uint32, u int) {
+		for v := range hn {
+			d[v*tlen+u] = m.mul(m.mul(t1[2*v], t1[2*v+1]), m.r2)
+		}
+	}
+	for u := range slen {
+		m := newModp(primes[u].p)
+		m.mkgm2(gm, igm, logn, primes[u].g)
+		for _, pair := range [...]struct{ s, d []uint32 }{{fs, fd}, {gs, gd}} {
+			for v := range n {
+				t1[v] = pair.s[v*slen+u]
+			}
+			if !inNTT {
+				m.ntt(t1, 1, gm, logn)
+			}
+			norm(m, pair.d, u)
+			if inNTT {
+				m.intt(pair.s[u:], slen, igm, logn)
+			}
+		}
+		if !outNTT {
+			m.intt(fd[u:], tlen, igm, logn-1)
+			m.intt(gd[u:], tlen, igm, logn-1)
+		}
+	}
+
+	zintRebuildCRT(fs, slen, slen, n, true)
+	zintRebuildCRT(gs, slen, slen, n, true)
+	for u := slen; u < tlen; u++ {
+		m := newModp(primes[u].p)
+		rx := m.rx(slen)
+		m.mkgm2(gm, igm, logn, primes[u].g)
+		for _, pair := range [...]struct{ s, d []uint32 }{{fs, fd}, {gs, gd}} {
+			for v := range n {
+				t1[v] = zintModSmallSigned(pair.s[v*slen:(v+1)*slen], m, rx)
+			}
+			m.ntt(t1, 1, gm, logn)
+			norm(m, pair.d, u)
+		}
+		if !outNTT {
+			m.intt(fd[u:], tlen, igm, logn-1)
+			m.intt(gd[u:], tlen, igm, logn-1)
+		}
+	}
+	return fd, gd
+}
+
+// makeFG computes the field norms of f and g, of degree n = 2^logn,
+// applied depth times, in RNS form with maxBlSmall[depth] words. They are
+// in NTT form if outNTT is true.
+func makeFG(f, g []int8, logn, depth uint, outNTT bool) (ft, gt []uint32) {
+	n := 1 << logn
+	ft = make([]uint32, n)
+	gt = make([]uint32, n)
+	m := newModp(primes[0].p)
+	for u := range n {
+		ft[u] = m.set(int32(f[u]))
+		gt[u] = m.set(int32(g[u]))
+	}
+
+	if depth == 0 && outNTT {
+		gm := make([]uint32, n)
+		igm := make([]uint32, n)
+		m.mkgm2(gm, igm, logn, primes[0].g)
+		m.ntt(ft, 1, gm, logn)
+		m.ntt(gt, 1, gm, logn)
+		return ft, gt
+	}
+	for d := range depth {
+		ft, gt = makeFGStep(ft, gt, logn-d, d, d != 0, d+1 < depth || outNTT)
+	}
+	return ft, gt
+}
+
+// solveDeepest solves the NTRU equation for the field norms of f and g at
+// depth logn, which are integers. The solution has maxBlSmall[logn] words.
+func solveDeepest(f, g []int8, logn uint) (bigF, bigG []uint32, ok bool) {
+	slen := maxBlSmall[logn]
+	fp, gp := makeFG(f, g, logn, logn, false)
+	zintRebuildCRT(fp, slen, slen, 1, false)
+	zintRebuildCRT(gp, slen, slen, 1, false)
+
+	// f·u - g·v = 1, hence f·(qu) - g·(qv) = q.
+	bigF = make([]uint32, slen)
+	bigG = make([]uint32, slen)
+	if !zintBezout(bigG, bigF, fp, gp) {
+		return nil, nil, false
+	}
+	if zintMulSmall(bigF, q) != 0 || zintMulSmall(bigG, q) != 0 {
+		return nil, nil, false
+	}
+	return bigF, bigG, true
+}
+
+// liftFG computes the unreduced solution (F, G) at the given depth, of
+// degree n = 2^(logTop-depth), from the solution (Fd, Gd) of the next
+// depth with coefficients of dlen words. F and G have coefficients of llen
+// words, in RNS form. fx and gx are f and g at the given depth modulo the
+// u-th prime, in NTT form.
+//
+// F(X) = Fd(X²)·g(-X) and G(X) = Gd(X²)·f(-X).
+func liftFG(bigFt, bigGt []uint32, fx, gx []uint32, m modp, u, llen int,
+	gm, igm []uint32, logn uint,
+) {
+	hn := 1 << (logn - 1)
+	fp := make([]uint32, hn)
+	gp := make([]uint32, hn)
+	for v := range hn {
+		fp[v] = bigFt[v*llen+u]
+		gp[v] = bigGt[v*llen+u]
+	}
+	m.ntt(fp, 1, gm, logn-1)
+	m.ntt(gp, 1, gm, logn-1)
+
+	// In NTT form, the values at w and -w are adjacent, and
+	// Fd(w²) = fp[v].
+	for v := range hn {
+		ftA, ftB := fx[2*v], fx[2*v+1]
+		gtA, gtB := gx[2*v], gx[2*v+1]
+		mFp := m.mul(fp[v], m.r2)
+		mGp := m.mul(gp[v], m.r2)
+		bigFt[2*v*llen+u] = m.mul(gtB, mFp)
+		bigFt[(2*v+1)*llen+u] = m.mul(gtA, mFp)
+		bigGt[2*v*llen+u] = m.mul(ftB, mGp)
+		bigGt[(2*v+1)*llen+u] = m.mul(ftA, mGp)
+	}
+	m.intt(bigFt[u:], llen, igm, logn)
+	m.intt(bigGt[u:], llen, igm, logn)
+}
+
+// reduceFd sets the first n/2 coefficients of Ft and Gt, of llen words, to
+// Fd and Gd, of dlen words, modulo each of the first llen primes.
+func reduceFd(bigFt, bigGt, bigFd, bigGd []uint32, hn, dlen, llen int) {
+	for u := range llen {
+		m := newModp(primes[u].p)
+		rx := m.rx(dlen)
+		for v := range hn {
+			bigFt[v*llen+u] = zintModSmallSigned(bigFd[v*dlen:(v+1)*dlen], m, rx)
+			bigGt[v*llen+u] = zintModSmallSigned(bigGd[v*dlen:(v+1)*dlen], m, rx)
+		}
+	}
+}
+
+// solveIntermediate solves the NTRU equation at the given depth, from the
+// solution (Fd, Gd) of the next depth. The solution has coefficients of
+// maxBlSmall[depth] words.
+func solveIntermediate(f, g []int8, logTop, depth uint, bigFd, bigGd []uint32,
+) (bigF, bigG []uint32, ok bool) {
+	logn := logTop - depth
+	n := 1 << logn
+	hn := n >> 1
+	slen := maxBlSmall[depth]
+	dlen := maxBlSmall[depth+1]
+	llen := maxBlLarge[depth]
+
+	// f and g at this depth, in NTT form modulo the first slen primes.
+	ft, gt := makeFG(f, g, logTop, depth, true)
+
+	bigFt := make([]uint32, n*llen)
+	bigGt := make([]uint32, n*llen)
+	reduceFd(bigFt, bigGt, bigFd, bigGd, hn, dlen, llen)
+
+	// Compute F and G modulo llen primes, then rebuild them.
+	gm := make([]uint32, n)
+	igm := make([]uint32, n)
+	fx := make([]uint32, n)
+	gx := make([]uint32, n)
+	for u := range llen {
+		m := newModp(primes[u].p)
+
+		// Once the slen first primes are processed, f and g are out of
+		// NTT form, and can be rebuilt.
+		if u == slen {
+			zintRebuildCRT(ft, slen, slen, n, true)
+			zintRebuildCRT(gt, slen, slen, n, true)
+		}
+		m.mkgm2(gm, igm, logn, primes[u].g)
+		if u < slen {
+			for v := range n {
+				fx[v] = ft[v*slen+u]
+				gx[v] = gt[v*slen+u]
+			}
+			m.intt(ft[u:], slen, igm, logn)
+			m.intt(gt[u:], slen, igm, logn)
+		} else {
+			rx := m.rx(slen)
+			for v := range n {
+				fx[v] = zintModSmallSigned(ft[v*slen:(v+1)*slen], m, rx)
+				gx[v] = zintModSmallSigned(gt[v*slen:(v+1)*slen], m, rx)
+			}
+			m.ntt(fx, 1, gm, logn)
+			m.ntt(gx, 1, gm, logn)
+		}
+		liftFG(bigFt, bigGt, fx, gx, m, u, llen, gm, igm, logn)
+	}
+	zintRebuildCRT(bigFt, llen, llen, n, true)
+	zintRebuildCRT(bigGt, llen, llen, n, true)
+
+	// Babai's reduction, with floating-point approximations of the
+	// coefficients: only their top 10 words are used, and (f, g) are
+	// scaled down by 2^scaleFG.
+	rlen := min(slen, 10)
+	rt3 := make([]fpr, n)
+	rt4 := make([]fpr, n)
+	rt5 := make([]fpr, n)
+	polyBigToFp(rt3, ft[slen-rlen:], rlen, slen)
+	polyBigToFp(rt4, gt[slen-rlen:], rlen, slen)
+	scalefg := 31 * (slen - rlen)
+
+	// Bounds on the bit length of the coefficients of (f, g).
+	minBlfg := bitLength[depth].avg - 6*bitLength[depth].std
+	maxBlfg := bitLength[depth].avg + 6*bitLength[depth].std
+
+	// rt5 = 1/(f·adj(f) + g·adj(g)), and rt3, rt4 = adj(f), adj(g).
+	fft(rt3, logn)
+	fft(rt4, logn)
+	polyInvNorm2FFT(rt5, rt3, rt4)
+	polyAdjFFT(rt3)
+	polyAdjFFT(rt4)
+
+	// Each iteration computes k = (F·adj(f) + G·adj(g))/(f·adj(f) + g·adj(g)),
+	// scaled by 2^(-scaleK) to fit in 31 bits, and subtracts k·(f, g)·2^scaleK
+	// from (F, G). The scale decreases down to zero.
+	flenFG := llen
+	maxBlFG := 31 * llen
+	scaleK := maxBlFG - minBlfg
+	rt1 := make([]fpr, n)
+	rt2 := make([]fpr, n)
+	k := make([]int32, n)
+	for {
+		rlen = min(flenFG, 10)
+		scaleFG := 31 * (flenFG - rlen)
+		polyBigToFp(rt1, bigFt[flenFG-rlen:], rlen, llen)
+		polyBigToFp(rt2, bigGt[flenFG-rlen:], rlen, llen)
+
+		fft(rt1, logn)
+		fft(rt2, logn)
+		polyMulFFT(rt1, rt3)
+		polyMulFFT(rt2, rt4)
+		polyAdd(rt2, rt1)
+		polyMulAutoAdjFFT(rt2, rt5)
+		ifft(rt2, logn)
+
+		// rt2 is scaled by 2^(scaleFG-scalefg), and must be scaled by
+		// 2^scaleK instead. The scale is public.
+		dc := scaleK - scaleFG + scalefg
+		pt := fprOneHalf
+		if dc < 0 {
+			dc = -dc
+			pt = fprTwo
+		}
+		pdc := fprOne
+		for ; dc != 0; dc >>= 1 {
+			if dc&1 != 0 {
+				pdc = fprMul(pdc, pt)
+			}
+			pt = fprSqr(pt)
+		}
+
+		for u := range k {
+			// Out of range values imply that (f, g) is rejected, so this
+			// does not leak information on the private key.
+			xv := fprMul(rt2[u], pdc)
+			if fprLt(fprMtwo31m1, xv) == 0 || fprLt(xv, fprPtwo31m1) == 0 {
+				return nil, nil, false
+			}
+			k[u] = int32(fprRint(xv))
+		}
+
+		sch := uint32(scaleK / 31)
+		scl := uint32(scaleK % 31)
+		if depth <= depthIntFG {
+			polySubScaledNTT(bigFt, flenFG, llen, ft, slen, slen, k, sch, scl, logn)
+			polySubScaledNTT(bigGt, flenFG, llen, gt, slen, slen, k, sch, scl, logn)
+		} else {
+			polySubScaled(bigFt, flenFG, llen, ft, slen, slen, k, sch, scl)
+			polySubScaled(bigGt, flenFG, llen, gt, slen, slen, k, sch, scl)
+		}
+
+		// Update the bound on the size of (F, G), assuming that (f, g)
+		// has the maximal size.
+		if newMaxBlFG := scaleK + maxBlfg + 10; newMaxBlFG < maxBlFG {
+			maxBlFG = newMaxBlFG
+			if flenFG*31 >= maxBlFG+31 {
+				flenFG--
+			}
+		}
+
+		// Each iteration removes at least 25 bits, until k is unscaled.
+		if scaleK <= 0 {
+			break
+		}
+		scaleK = max(scaleK-25, 0)
+	}
+
+	// Sign-extend (F, G) if they were shortened below slen words, and
+	// return them with coefficients of slen words.
+	bigF = make([]uint32, n*slen)
+	bigG = make([]uint32, n*slen)
+	for u := range n {
+		x := bigFt[u*llen : u*llen+slen]
+		y := bigGt[u*llen : u*llen+slen]
+		if flenFG < slen {
+			sx := -(x[flenFG-1] >> 30) >> 1
+			sy := -(y[flenFG-1] >> 30) >> 1
+			for v := flenFG; v < slen; v++ {
+				x[v], y[v] = sx, sy
+			}
+		}
+		copy(bigF[u*slen:], x)
+		copy(bigG[u*slen:], y)
+	}
+	return bigF, bigG, true
+}
+
+// solveDepth1 is solveIntermediate specialized for depth 1, where the
+// Babai reduction takes a single step. The solution has coefficients of
+// one word.
+func solveDepth1(f, g []int8, logTop uint, bigFd, bigGd []uint32,
+) (bigF, bigG []uint32, ok bool) {
+	const depth = 1
+	nTop := 1 << logTop
+	logn := logTop - depth
+	n := 1 << logn
+	hn := n >> 1
+	slen := maxBlSmall[depth]
+	dlen := maxBlSmall[depth+1]
+	llen := maxBlLarge[depth]
+
+	bigFt := make([]uint32, n*llen)
+	bigGt := make([]uint32, n*llen)
+	reduceFd(bigFt, bigGt, bigFd, bigGd, hn, dlen, llen)
+
+	// Compute F and G modulo llen primes, recomputing f and g at depth 1
+	// from the full f and g, and keep f and g modulo the first slen primes.
+	ft := make([]uint32, n*slen)
+	gt := make([]uint32, n*slen)
+	gm := make([]uint32, nTop)
+	igm := make([]uint32, nTop)
+	fx := make([]uint32, nTop)
+	gx := make([]uint32, nTop)
+	for u := range llen {
+		m := newModp(primes[u].p)
+
+		// The tables for degree n are the first halves of the tables for
+		// degree nTop.
+		m.mkgm2(gm, igm, logTop, primes[u].g)
+		for v := range nTop {
+			fx[v] = m.set(int32(f[v]))
+			gx[v] = m.set(int32(g[v]))
+		}
+		m.ntt(fx, 1, gm, logTop)
+		m.ntt(gx, 1, gm, logTop)
+		for e := logTop; e > logn; e-- {
+			m.polyRecRes(fx, e)
+			m.polyRecRes(gx, e)
+		}
+		liftFG(bigFt, bigGt, fx, gx, m, u, llen, gm, igm, logn)
+
+		if u < slen {
+			m.intt(fx, 1, igm, logn)
+			m.intt(gx, 1, igm, logn)
+			for v := range n {
+				ft[v*slen+u] = fx[v]
+				gt[v*slen+u] = gx[v]
+			}
+		}
+	}
+	zintRebuildCRT(bigFt, llen, llen, n, true)
+	zintRebuildCRT(bigGt, llen, llen, n, true)
+	zintRebuildCRT(ft, slen, slen, n, true)
+	zintRebuildCRT(gt, slen, slen, n, true)
+
+	// The coefficients are small enough to be converted to floating-point
+	// values without scaling.
+	rt1 := make([]fpr, n)
+	rt2 := make([]fpr, n)
+	rt3 := make([]fpr, n)
+	rt4 := make([]fpr, n)
+	rt5 := make([]fpr, n)
+	rt6 := make([]fpr, n)
+	polyBigToFp(rt1, bigFt, llen, llen)
+	polyBigToFp(rt2, bigGt, llen, llen)
+	polyBigToFp(rt3, ft, slen, slen)
+	polyBigToFp(rt4, gt, slen, slen)
+	fft(rt1, logn)
+	fft(rt2, logn)
+	fft(rt3, logn)
+	fft(rt4, logn)
+
+	// rt5 = (F·adj(f) + G·adj(g))/(f·adj(f) + g·adj(g)), rounded.
+	polyAddMulAdjFFT(rt5, rt1, rt2, rt3, rt4)
+	polyInvNorm2FFT(rt6, rt3, rt4)
+	polyMulAutoAdjFFT(rt5, rt6)
+	ifft(rt5, logn)
+	for u, z := range rt5 {
+		if fprLt(z, fprPtwo63) == 0 || fprLt(fprNeg(fprPtwo63), z) == 0 {
+			return nil, nil, false
+		}
+		rt5[u] = fprOf(fprRint(z))
+	}
+	fft(rt5, logn)
+
+	// (F, G) -= k·(f, g).
+	polyMulFFT(rt3, rt5)
+	polyMulFFT(rt4, rt5)
+	polySub(rt1, rt3)
+	polySub(rt2, rt4)
+	ifft(rt1, logn)
+	ifft(rt2, logn)
+
+	bigF = make([]uint32, n)
+	bigG = make([]uint32, n)
+	for u := range n {
+		bigF[u] = uint32(fprRint(rt1[u]))
+		bigG[u] = uint32(fprRint(rt2[u]))
+	}
+	return bigF, bigG, true
+}
+
+// solveDepth0 solves the NTRU equation at the top level, from the solution
+// (Fd, Gd) at depth 1. All the values fit in 31 bits, so computations are
+// done modulo the first prime.
+func solveDepth0(f, g []int8, logn uint, bigFd, bigGd []uint32) (bigF, bigG []int32) {
+	n := 1 << logn
+	hn := n >> 1
+	m := newModp(primes[0].p)
+	gm := make([]uint32, n)
+	igm := make([]uint32, n)
+	m.mkgm2(gm, igm, logn, primes[0].g)
+
+	// F = Fd(X²)·g(-X) and G = Gd(X²)·f(-X), in NTT form.
+	fp := make([]uint32, n)
+	gp := make([]uint32, n)
+	for u := range hn {
+		fp[u] = m.set(zintOneToPlain(bigFd[u]))
+		gp[u] = m.set(zintOneToPlain(bigGd[u]))
+	}
+	ft := make([]uint32, n)
+	gt := make([]uint32, n)
+	for u := range n {
+		ft[u] = m.set(int32(f[u]))
+		gt[u] = m.set(int32(g[u]))
+	}
+	m.ntt(ft, 1, gm, logn)
+	m.ntt(gt, 1, gm, logn)
+	liftFG(fp, gp, ft, gt, m, 0, 1, gm, igm, logn)
+	m.ntt(fp, 1, gm, logn)
+	m.ntt(gp, 1, gm, logn)
+
+	// t2 = F·adj(f) + G·adj(g) and t3 = f·adj(f) + g·adj(g), computed
+	// exactly modulo p.
+	t2 := make([]uint32, n)
+	t3 := make([]uint32, n)
+	t4 := make([]uint32, n)
+	t5 := make([]uint32, n)
+	for i, x := range [...]struct {
+		a  []int8
+		bA []uint32
+	}{{f, fp}, {g, gp}} {
+		t4[0] = m.set(int32(x.a[0]))
+		t5[0] = t4[0]
+		for u := 1; u < n; u++ {
+			t4[u] = m.set(int32(x.a[u]))
+			t5[n-u] = m.set(-int32(x.a[u]))
+		}
+		m.ntt(t4, 1, gm, logn)
+		m.ntt(t5, 1, gm, logn)
+		for u := range n {
+			w := m.mul(t5[u], m.r2)
+			if i == 0 {
+				t2[u] = m.mul(w, x.bA[u])
+				t3[u] = m.mul(w, t4[u])
+			} else {
+				t2[u] = m.add(t2[u], m.mul(w, x.bA[u]))
+				t3[u] = m.add(t3[u], m.mul(w, t4[u]))
+			}
+		}
+	}
+	m.intt(t2, 1, igm, logn)
+	m.intt(t3, 1, igm, logn)
+
+	// k = round(t2/t3), with the FFT. Since t3 is self-adjoint, its FFT
+	// form is real.
+	rt2 := make([]fpr, n)
+	rt3 := make([]fpr, n)
+	for u := range n {
+		rt2[u] = fprOf(int64(m.norm(t3[u])))
+		rt3[u] = fprOf(int64(m.norm(t2[u])))
+	}
+	fft(rt2, logn)
+	fft(rt3, logn)
+	polyDivAutoAdjFFT(rt3, rt2)
+	ifft(rt3, logn)
+	t1 := make([]uint32, n)
+	for u := range n {
+		t1[u] = m.set(int32(fprRint(rt3[u])))
+	}
+
+	// (F, G) -= k·(f, g).
+	for u := range n {
+		t4[u] = m.set(int32(f[u]))
+		t5[u] = m.set(int32(g[u]))
+	}
+	m.ntt(t1, 1, gm, logn)
+	m.ntt(t4, 1, gm, logn)
+	m.ntt(t5, 1, gm, logn)
+	for u := range n {
+		kw := m.mul(t1[u], m.r2)
+		fp[u] = m.sub(fp[u], m.mul(kw, t4[u]))
+		gp[u] = m.sub(gp[u], m.mul(kw, t5[u]))
+	}
+	m.intt(fp, 1, igm, logn)
+	m.intt(gp, 1, igm, logn)
+
+	bigF = make([]int32, n)
+	bigG = make([]int32, n)
+	for u := range n {
+		bigF[u] = m.norm(fp[u])
+		bigG[u] = m.norm(gp[u])
+	}
+	return bigF, bigG
+}
+
+// solveNTRU returns F and G with coefficients in [-127, 127] such that
+// fG - gF = q, or false if there is no such solution. The degree n must be
+// at least 8.
+func solveNTRU(f, g []int8, logn uint) (bigF, bigG []int8, ok bool) {
+	Fd, Gd, ok := solveDeepest(f, g, logn)
+	if !ok {
+		return nil, nil, false
+	}
+	for depth := logn - 1; depth > 1; depth-- {
+		Fd, Gd, ok = solveIntermediate(f, g, logn, depth, Fd, Gd)
+		if !ok {
+			return nil, nil, false
+		}
+	}
+	if Fd, Gd, ok = solveDepth1(f, g, logn, Fd, Gd); !ok {
+		return nil, nil, false
+	}
+	sF, sG := solveDepth0(f, g, logn, Fd, Gd)
+
+	n := 1 << logn
+	bigF, bigG = make([]int8, n), make([]int8, n)
+	for u := range n {
+		if sF[u] < -127 || sF[u] > 127 || sG[u] < -127 || sG[u] > 127 {
+			return nil, nil, false
+		}
+		bigF[u], bigG[u] = int8(sF[u]), int8(sG[u])
+	}
+
+	// Check the solution modulo a small prime, which is enough since all
+	// the coefficients are small.
+	m := newModp(primes[0].p)
+	gm := make([]uint32, n)
+	igm := make([]uint32, n)
+	m.mkgm2(gm, igm, logn, primes[0].g)
+	ft, gt := make([]uint32, n), make([]uint32, n)
+	bFt, bGt := make([]uint32, n), make([]uint32, n)
+	for u := range n {
+		ft[u] = m.set(int32(f[u]))
+		gt[u] = m.set(int32(g[u]))
+		bFt[u] = m.set(int32(bigF[u]))
+		bGt[u] = m.set(int32(bigG[u]))
+	}
+	m.ntt(ft, 1, gm, logn)
+	m.ntt(gt, 1, gm, logn)
+	m.ntt(bFt, 1, gm, logn)
+	m.ntt(bGt, 1, gm, logn)
+	r := m.mul(q, 1)
+	for u := range n {
+		if m.sub(m.mul(ft[u], bGt[u]), m.mul(gt[u], bFt[u])) != r {
+			return nil, nil, false
+		}
+	}
+	return bigF, bigG, true
+}
@@ -0,0 +1,192 @@
+package fndsa
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/cloudflare/circl/internal/sha3"
+)
+
+// prng is the ChaCha20-based generator used by the Gaussian sampler. It
+// runs eight ChaCha20 instances with consecutive counters and interleaves
+// their output by 32-bit words, as in the reference implementation.
+type prng struct {
+	buf   [512]byte
+	state [56]byte // key and nonce, then a little-endian 64-bit counter
+	ptr   int
+}
+
+// init seeds p with 56 bytes taken from src.
+func (p *prng) init(src *sha3.State) {
+	_, _ = src.Read(p.state[:])
+	p.refill()
+}
+
+func chachaQround(s *[16]uint32, a, b, c, d int) {
+	s[a] += s[b]
+	s[d] = bits.RotateLeft32(s[d]^s[a], 16)
+	s[c] += s[d]
+	s[b] = bits.RotateLeft32(s[b]^s[c], 12)
+	s[a] += s[b]
+	s[d] = bits.RotateLeft32(s[d]^s[a], 8)
+	s[c] += s[d]
+	s[b] = bits.RotateLeft32(s[b]^s[c], 7)
+}
+
+func (p *prng) refill() {
+	cw := [4]uint32{0x61707865, 0x3320646e, 0x79622d32, 0x6b206574}
+	var key [12]uint32
+	for i := range key {
+		key[i] = binary.LittleEndian.Uint32(p.state[4*i:])
+	}
+	cc := binary.LittleEndian.Uint64(p.state[48:])
+	for u := range 8 {
+		var s [16]uint32
+		copy(s[:4], cw[:])
+		copy(s[4:], key[:])
+		s[14] ^= uint32(cc)
+		s[15] ^= uint32(cc >> 32)
+		for range 10 {
+			chachaQround(&s, 0, 4, 8, 12)
+			chachaQround(&s, 1, 5, 9, 13)
+			chachaQround(&s, 2, 6, 10, 14)
+			chachaQround(&s, 3, 7, 11, 15)
+			chachaQround(&s, 0, 5, 10, 15)
+			chachaQround(&s, 1, 6, 11, 12)
+			chachaQround(&s, 2, 7, 8, 13)
+			chachaQround(&s, 3, 4, 9, 14)
+		}
+		for v := range 4 {
+			s[v] += cw[v]
+		}
+		for v := 4; v < 14; v++ {
+			s[v] += key[v-4]
+		}
+		s[14] += key[10] ^ uint32(cc)
+		s[15] += key[11] ^ uint32(cc>>32)
+		cc++
+		for v := range 16 {
+			binary.LittleEndian.PutUint32(p.buf[u<<2+v<<5:], s[v])
+		}
+	}
+	binary.LittleEndian.PutUint64(p.state[48:], cc)
+	p.ptr = 0
+}
+
+func (p *prng) getU64() uint64 {
+	if p.ptr >= len(p.buf)-9 {
+		p.refill()
+	}
+	v := binary.LittleEndian.Uint64(p.buf[p.ptr:])
+	p.ptr += 8
+	return v
+}
+
+func (p *prng) getU8() uint32 {
+	v := p.buf[p.ptr]
+	p.ptr++
+	if p.ptr == len(p.buf) {
+		p.refill()
+	}
+	return uint32(v)
+}
+
+// gaussian0 samples z >= 0 following a half-Gaussian distribution of
+// standard deviation σ₀ = 1.8205, in constant time, using the reverse
+// cumulative distribution table (RCDT) of the specification.
+func gaussian0(p *prng) int {
+	// Each row is a 72-bit value, split in three 24-bit limbs with the most
+	// significant limb first.
+	dist := [...]uint32{
+		10745844, 3068844, 3741698,
+		5559083, 1580863, 8248194,
+		2260429, 13669192, 2736639,
+		708981, 4421575, 10046180,
+		169348, 7122675, 4136815,
+		30538, 13063405, 7650655,
+		4132, 14505003, 7826148,
+		417, 16768101, 11363290,
+		31, 8444042, 8086568,
+		1, 12844466, 265321,
+		0, 1232676, 13644283,
+		0, 38047, 9111839,
+		0, 870, 6138264,
+		0, 14, 12545723,
+		0, 0, 3104126,
+		0, 0, 28824,
+		0, 0, 198,
+		0, 0, 1,
+	}
+
+	lo := p.getU64()
+	hi := p.getU8()
+	v0 := uint32(lo) & 0xFFFFFF
+	v1 := uint32(lo>>24) & 0xFFFFFF
+	v2 := uint32(lo>>48) | hi<<16
+
+	z := 0
+	for u := 0; u < len(dist); u += 3 {
+		cc := (v0 - dist[u+2]) >> 31
+		cc = (v1 - dist[u+1] - cc) >> 31
+		cc = (v2 - dist[u] - cc) >> 31
+		z += int(cc)
+	}
+	return z
+}
+
+// berExp returns true with probability ccs·exp(-x), for x >= 0.
+func berExp(p *prng, x, ccs fpr) bool {
+	// Reduce x modulo ln(2): x = s·ln(2) + r.
+	s := int(fprTrunc(fprMul(x, fprInvLog2)))
+	r := fprSub(x, fprMul(fprOf(int64(s)), fprLog2))
+
+	// Saturate s to 63; this only happens with negligible probability,
+	// and the error is below the sampler precision.
+	sw := uint32(s)
+	sw ^= (sw ^ 63) & -((63 - sw) >> 31)
+	s = int(sw)
+
+	// exp(-x) = 2^(-s)·exp(-r). Compare the bits of a random value with
+	// those of the probability, and stop at the first difference.
+	z := ((fprExpmP63(r, ccs) << 1) - 1) >> uint(s)
+	var w uint32
+	for i := 64; i > 0; {
+		i -= 8
+		w = p.getU8() - (uint32(z>>uint(i)) & 0xFF)
+		if w != 0 {
+			break
+		}
+	}
+	return w>>31 != 0
+}
+
+// sampler samples an integer following a discrete Gaussian distribution of
+// center mu and standard deviation 1/isigma. See Algorithm 15 of the
+// specification.
+type sampler struct {
+	p        prng
+	sigmaMin fpr
+}
+
+func (sp *sampler) sample(mu, isigma fpr) int64 {
+	// Center is mu = s + r, with s an integer and 0 <= r < 1.
+	s := fprFloor(mu)
+	r := fprSub(mu, fprOf(s))
+
+	dss := fprHalf(fprSqr(isigma))
+	ccs := fprMul(isigma, sp.sigmaMin)
+
+	for {
+		// Sample z from a bimodal Gaussian around 0 and 1, then use
+		// rejection sampling to get the target distribution.
+		z0 := gaussian0(&sp.p)
+		b := int(sp.p.getU8() & 1)
+		z := b + ((b<<1)-1)*z0
+
+		x := fprMul(fprSqr(fprSub(fprOf(int64(z)), r)), dss)
+		x = fprSub(x, fprMul(fprOf(int64(z0*z0)), fprInv2SqrSigma0))
+		if berExp(&sp.p, x, ccs) {
+			return s + int64(z)
+		}
+	}
+}
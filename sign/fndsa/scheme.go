@@ -0,0 +1,96 @@
+package fndsa
+
+import (
+	cryptoRand "crypto/rand"
+
+	"github.com/cloudflare/circl/sign"
+)
+
+// Scheme returns the generic signature scheme of the parameter set.
+func (id ID) Scheme() sign.Scheme { return scheme{id.params()} }
+
+type scheme struct{ *params }
+
+var (
+	_ sign.PublicKey  = (*PublicKey)(nil)
+	_ sign.PrivateKey = (*PrivateKey)(nil)
+)
+
+func (pk *PublicKey) Scheme() sign.Scheme  { return pk.id.Scheme() }
+func (sk *PrivateKey) Scheme() sign.Scheme { return sk.id.Scheme() }
+
+func (s scheme) Name() string          { return s.name }
+func (s scheme) PublicKeySize() int    { return s.id.PublicKeySize() }
+func (s scheme) PrivateKeySize() int   { return s.id.PrivateKeySize() }
+func (s scheme) SignatureSize() int    { return s.sigSize }
+func (s scheme) SeedSize() int         { return SeedSize }
+func (s scheme) SupportsContext() bool { return false }
+
+func (s scheme) GenerateKey() (sign.PublicKey, sign.PrivateKey, error) {
+	return GenerateKey(cryptoRand.Reader, s.id)
+}
+
+func (s scheme) Sign(
+	sk sign.PrivateKey,
+	msg []byte,
+	opts *sign.SignatureOpts,
+) []byte {
+	priv, ok := sk.(*PrivateKey)
+	if !ok || priv.id != s.id {
+		panic(sign.ErrTypeMismatch)
+	}
+	if opts != nil && opts.Context != "" {
+		panic(sign.ErrContextNotSupported)
+	}
+	sig, err := Sign(cryptoRand.Reader, priv, msg)
+	if err != nil {
+		panic(err)
+	}
+	return sig
+}
+
+func (s scheme) Verify(
+	pk sign.PublicKey,
+	msg, sig []byte,
+	opts *sign.SignatureOpts,
+) bool {
+	pub, ok := pk.(*PublicKey)
+	if !ok || pub.id != s.id {
+		panic(sign.ErrTypeMismatch)
+	}
+	if opts != nil && opts.Context != "" {
+		panic(sign.ErrContextNotSupported)
+	}
+	return Verify(pub, msg, sig)
+}
+
+func (s scheme) DeriveKey(seed []byte) (sign.PublicKey, sign.PrivateKey) {
+	if len(seed) != SeedSize {
+		panic(sign.ErrSeedSize)
+	}
+	var tmp [SeedSize]byte
+	copy(tmp[:], seed)
+	return NewKeyFromSeed(s.id, &tmp)
+}
+
+func (s scheme) UnmarshalBinaryPublicKey(buf []byte) (sign.PublicKey, error) {
+	var pk PublicKey
+	if err := pk.UnmarshalBinary(buf); err != nil {
+		return nil, err
+	}
+	if pk.id != s.id {
+		return nil, sign.ErrPubKeySize
+	}
+	return &pk, nil
+}
+
+func (s scheme) UnmarshalBinaryPrivateKey(buf []byte) (sign.PrivateKey, error) {
+	var sk PrivateKey
+	if err := sk.UnmarshalBinary(buf); err != nil {
+		return nil, err
+	}
+	if sk.id != s.id {
+		return nil, sign.ErrPrivKeySize
+	}
+	return &sk, nil
+}
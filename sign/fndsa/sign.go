@@ -0,0 +1,185 @@
+package fndsa
+
+import "github.com/cloudflare/circl/internal/sha3"
+
+// hashToPoint hashes the nonce and the message to a polynomial with
+// coefficients modulo q. It is not constant time, which is fine since its
+// inputs are public. See Algorithm 3 of the specification.
+func hashToPoint(nonce, msg []byte, logn uint) []uint16 {
+	h := sha3.NewShake256()
+	_, _ = h.Write(nonce)
+	_, _ = h.Write(msg)
+
+	c := make([]uint16, 1<<logn)
+	var buf [2]byte
+	for i := 0; i < len(c); {
+		_, _ = h.Read(buf[:])
+		w := uint32(buf[0])<<8 | uint32(buf[1])
+		if w < 5*q {
+			c[i] = uint16(w % q)
+			i++
+		}
+	}
+	return c
+}
+
+func smallToFpr(f []int8) []fpr {
+	r := make([]fpr, len(f))
+	for i := range f {
+		r[i] = fprOf(int64(f[i]))
+	}
+	return r
+}
+
+// signRaw returns the short polynomial s2 such that s1 + s2·h = hm, where
+// (s1, s2) is short. Randomness for the sampler is drawn from rng.
+func (sk *PrivateKey) signRaw(rng *sha3.State, hm []uint16) []int16 {
+	p := sk.params()
+	n := 1 << p.logn
+
+	// The lattice basis B = [[g, -f], [G, -F]] in FFT form.
+	b00, b01 := smallToFpr(sk.g), smallToFpr(sk.f)
+	b10, b11 := smallToFpr(sk.bigG), smallToFpr(sk.bigF)
+	fft(b00, p.logn)
+	fft(b01, p.logn)
+	fft(b10, p.logn)
+	fft(b11, p.logn)
+	polyNeg(b01)
+	polyNeg(b11)
+
+	g00, g01, g11 := make([]fpr, n), make([]fpr, n), make([]fpr, n)
+	t0, t1 := make([]fpr, n), make([]fpr, n)
+	tmp := make([]fpr, 4*n)
+	for {
+		sp := sampler{sigmaMin: p.sigmaMin}
+		sp.p.init(rng)
+		if s2, ok := p.doSign(&sp, hm, b00, b01, b10, b11, g00, g01, g11, t0, t1, tmp); ok {
+			return s2
+		}
+	}
+}
+
+// doSign makes one attempt at computing a signature. See Algorithm 10 of the
+// specification.
+func (p *params) doSign(sp *sampler, hm []uint16,
+	b00, b01, b10, b11, g00, g01, g11, t0, t1, tmp []fpr,
+) ([]int16, bool) {
+	logn := p.logn
+	n := 1 << logn
+
+	// The Gram matrix G = B·B*.
+	copy(g00, b00)
+	polyMulSelfAdjFFT(g00)
+	copy(tmp[:n], b01)
+	polyMulSelfAdjFFT(tmp[:n])
+	polyAdd(g00, tmp[:n])
+
+	copy(g01, b01)
+	polyMulAdjFFT(g01, b11)
+	copy(tmp[:n], b00)
+	polyMulAdjFFT(tmp[:n], b10)
+	polyAdd(g01, tmp[:n])
+
+	copy(g11, b10)
+	polyMulSelfAdjFFT(g11)
+	copy(tmp[:n], b11)
+	polyMulSelfAdjFFT(tmp[:n])
+	polyAdd(g11, tmp[:n])
+
+	// The target vector is t = (hm, 0)·B⁻¹.
+	for u := range t0 {
+		t0[u] = fprOf(int64(hm[u]))
+	}
+	fft(t0, logn)
+	copy(t1, t0)
+	polyMulFFT(t1, b01)
+	polyMulConst(t1, fprNeg(fprInverseOfQ))
+	polyMulFFT(t0, b11)
+	polyMulConst(t0, fprInverseOfQ)
+
+	ffSampling(sp, t0, t1, g00, g01, g11, p.invSigma, logn, tmp)
+
+	// The lattice point is z·B; s = (hm, 0) - z·B.
+	tx, ty := tmp[:n], tmp[n:2*n]
+	copy(tx, t0)
+	copy(ty, t1)
+	polyMulFFT(tx, b00)
+	polyMulFFT(ty, b10)
+	polyAdd(tx, ty)
+	copy(ty, t0)
+	polyMulFFT(ty, b01)
+	copy(t0, tx)
+	polyMulFFT(t1, b11)
+	polyAdd(t1, ty)
+	ifft(t0, logn)
+	ifft(t1, logn)
+
+	sqn, ng := uint32(0), uint32(0)
+	for u := range n {
+		z := int32(hm[u]) - int32(fprRint(t0[u]))
+		sqn += uint32(z * z)
+		ng |= sqn
+	}
+	sqn |= -(ng >> 31)
+
+	s2 := make([]int16, n)
+	for u := range n {
+		s2[u] = int16(-fprRint(t1[u]))
+	}
+	return s2, p.isShortHalf(sqn, s2)
+}
+
+// ffSampling samples z close to the target t = (t0, t1) using the Gram
+// matrix [[g00, g01], [adj(g01), g11]]; z overwrites t. The LDL tree is
+// computed on the fly, and the Gram matrix is destroyed. The scratch buffer
+// tmp must have room for 4n values. See Algorithm 11 of the specification.
+func ffSampling(sp *sampler, t0, t1, g00, g01, g11 []fpr, invSigma fpr,
+	logn uint, tmp []fpr,
+) {
+	// The leaf of the LDL tree is just g00, normalized with regard to σ.
+	if logn == 0 {
+		leaf := fprMul(fprSqrt(g00[0]), invSigma)
+		t0[0] = fprOf(sp.sample(t0[0], leaf))
+		t1[0] = fprOf(sp.sample(t1[0], leaf))
+		return
+	}
+
+	n := 1 << logn
+	hn := n >> 1
+
+	// Decompose G into LDL in place: d00 = g00, d11 in g11, l10 in g01.
+	polyLDLFFT(g00, g01, g11)
+
+	// Split d00 and d11 into half-size Gram matrices, and move l10 to tmp.
+	// The left sub-tree is (g00[:hn], g00[hn:], g01[:hn]) and the right
+	// one is (g11[:hn], g11[hn:], g01[hn:]).
+	polySplitFFT(tmp[:hn], tmp[hn:n], g00, logn)
+	copy(g00, tmp[:n])
+	polySplitFFT(tmp[:hn], tmp[hn:n], g11, logn)
+	copy(g11, tmp[:n])
+	copy(tmp[:n], g01)
+	copy(g01[:hn], g00[:hn])
+	copy(g01[hn:], g11[:hn])
+
+	// Sample z1 from the split t1 and the right sub-tree, and merge the
+	// result into tmp[2n:3n].
+	z1 := tmp[n : 2*n]
+	polySplitFFT(z1[:hn], z1[hn:], t1, logn)
+	ffSampling(sp, z1[:hn], z1[hn:], g11[:hn], g11[hn:], g01[hn:],
+		invSigma, logn-1, tmp[2*n:])
+	polyMergeFFT(tmp[2*n:3*n], z1[:hn], z1[hn:], logn)
+
+	// t0 += (t1 - z1)·l10, and z1 replaces t1.
+	copy(z1, t1)
+	polySub(z1, tmp[2*n:3*n])
+	copy(t1, tmp[2*n:3*n])
+	polyMulFFT(tmp[:n], z1)
+	polyAdd(t0, tmp[:n])
+
+	// Sample z0 from the split t0 and the left sub-tree.
+	z0 := tmp[:n]
+	polySplitFFT(z0[:hn], z0[hn:], t0, logn)
+	ffSampling(sp, z0[:hn], z0[hn:], g00[:hn], g00[hn:], g01[:hn],
+		invSigma, logn-1, tmp[n:])
+	polyMergeFFT(t0, z0[:hn], z0[hn:], logn)
+}
@@ -0,0 +1,1365 @@
+// Code generated by gen.go. DO NOT EDIT.
+
+package fndsa
+
+const (
+	fprQ             fpr = 0x40C8008000000000 // q
+	fprInverseOfQ    fpr = 0x3F1554E39097A782 // 1/q
+	fprInv2SqrSigma0 fpr = 0x3FC34F8BC183BBC2 // 1/(2·1.8205²)
+	fprLog2          fpr = 0x3FE62E42FEFA39EF // ln(2)
+	fprInvLog2       fpr = 0x3FF71547652B82FE // 1/ln(2)
+	fprBnormMax      fpr = 0x40D06D9A5FD8ADAC // (1.17²)·q
+	fprPtwo63        fpr = 0x43E0000000000000 // 2^63
+	fprPtwo31        fpr = 0x41E0000000000000 // 2^31
+	fprPtwo31m1      fpr = 0x41DFFFFFFFC00000 // 2^31-1
+	fprMtwo31m1      fpr = 0xC1DFFFFFFFC00000 // -(2^31-1)
+	fprTwo           fpr = 0x4000000000000000 // 2
+	fprOneHalf       fpr = 0x3FE0000000000000 // 1/2
+	fprSigmaMin512   fpr = 0x3FF47201BF1F7A75 // σ_min for n = 512
+	fprSigmaMin1024  fpr = 0x3FF4C5C19990C764 // σ_min for n = 1024
+	fprInvSigma512   fpr = 0x3F78B6C2DE64C7CA // 1/σ for n = 512
+	fprInvSigma1024  fpr = 0x3F78531EF6311AE3 // 1/σ for n = 1024
+)
+
+// fprGMTab[2k] and fprGMTab[2k+1] are the real and imaginary parts of
+// exp(iπ·rev(k)/1024), where rev reverses the order of 10 bits.
+var fprGMTab = [2048]fpr{
+	0x3FF0000000000000, 0x0000000000000000,
+	0x0000000000000000, 0x3FF0000000000000,
+	0x3FE6A09E667F3BCD, 0x3FE6A09E667F3BCD,
+	0xBFE6A09E667F3BCD, 0x3FE6A09E667F3BCD,
+	0x3FED906BCF328D46, 0x3FD87DE2A6AEA963,
+	0xBFD87DE2A6AEA963, 0x3FED906BCF328D46,
+	0x3FD87DE2A6AEA963, 0x3FED906BCF328D46,
+	0xBFED906BCF328D46, 0x3FD87DE2A6AEA963,
+	0x3FEF6297CFF75CB0, 0x3FC8F8B83C69A60B,
+	0xBFC8F8B83C69A60B, 0x3FEF6297CFF75CB0,
+	0x3FE1C73B39AE68C8, 0x3FEA9B66290EA1A3,
+	0xBFEA9B66290EA1A3, 0x3FE1C73B39AE68C8,
+	0x3FEA9B66290EA1A3, 0x3FE1C73B39AE68C8,
+	0xBFE1C73B39AE68C8, 0x3FEA9B66290EA1A3,
+	0x3FC8F8B83C69A60B, 0x3FEF6297CFF75CB0,
+	0xBFEF6297CFF75CB0, 0x3FC8F8B83C69A60B,
+	0x3FEFD88DA3D12526, 0x3FB917A6BC29B42C,
+	0xBFB917A6BC29B42C, 0x3FEFD88DA3D12526,
+	0x3FE44CF325091DD6, 0x3FE8BC806B151741,
+	0xBFE8BC806B151741, 0x3FE44CF325091DD6,
+	0x3FEC38B2F180BDB1, 0x3FDE2B5D3806F63B,
+	0xBFDE2B5D3806F63B, 0x3FEC38B2F180BDB1,
+	0x3FD294062ED59F06, 0x3FEE9F4156C62DDA,
+	0xBFEE9F4156C62DDA, 0x3FD294062ED59F06,
+	0x3FEE9F4156C62DDA, 0x3FD294062ED59F06,
+	0xBFD294062ED59F06, 0x3FEE9F4156C62DDA,
+	0x3FDE2B5D3806F63B, 0x3FEC38B2F180BDB1,
+	0xBFEC38B2F180BDB1, 0x3FDE2B5D3806F63B,
+	0x3FE8BC806B151741, 0x3FE44CF325091DD6,
+	0xBFE44CF325091DD6, 0x3FE8BC806B151741,
+	0x3FB917A6BC29B42C, 0x3FEFD88DA3D12526,
+	0xBFEFD88DA3D12526, 0x3FB917A6BC29B42C,
+	0x3FEFF621E3796D7E, 0x3FA91F65F10DD814,
+	0xBFA91F65F10DD814, 0x3FEFF621E3796D7E,
+	0x3FE57D69348CECA0, 0x3FE7B5DF226AAFAF,
+	0xBFE7B5DF226AAFAF, 0x3FE57D69348CECA0,
+	0x3FECED7AF43CC773, 0x3FDB5D1009E15CC0,
+	0xBFDB5D1009E15CC0, 0x3FECED7AF43CC773,
+	0x3FD58F9A75AB1FDD, 0x3FEE212104F686E5,
+	0xBFEE212104F686E5, 0x3FD58F9A75AB1FDD,
+	0x3FEF0A7EFB9230D7, 0x3FCF19F97B215F1B,
+	0xBFCF19F97B215F1B, 0x3FEF0A7EFB9230D7,
+	0x3FE073879922FFEE, 0x3FEB728345196E3E,
+	0xBFEB728345196E3E, 0x3FE073879922FFEE,
+	0x3FE9B3E047F38741, 0x3FE30FF7FCE17035,
+	0xBFE30FF7FCE17035, 0x3FE9B3E047F38741,
+	0x3FC2C8106E8E613A, 0x3FEFA7557F08A517,
+	0xBFEFA7557F08A517, 0x3FC2C8106E8E613A,
+	0x3FEFA7557F08A517, 0x3FC2C8106E8E613A,
+	0xBFC2C8106E8E613A, 0x3FEFA7557F08A517,
+	0x3FE30FF7FCE17035, 0x3FE9B3E047F38741,
+	0xBFE9B3E047F38741, 0x3FE30FF7FCE17035,
+	0x3FEB728345196E3E, 0x3FE073879922FFEE,
+	0xBFE073879922FFEE, 0x3FEB728345196E3E,
+	0x3FCF19F97B215F1B, 0x3FEF0A7EFB9230D7,
+	0xBFEF0A7EFB9230D7, 0x3FCF19F97B215F1B,
+	0x3FEE212104F686E5, 0x3FD58F9A75AB1FDD,
+	0xBFD58F9A75AB1FDD, 0x3FEE212104F686E5,
+	0x3FDB5D1009E15CC0, 0x3FECED7AF43CC773,
+	0xBFECED7AF43CC773, 0x3FDB5D1009E15CC0,
+	0x3FE7B5DF226AAFAF, 0x3FE57D69348CECA0,
+	0xBFE57D69348CECA0, 0x3FE7B5DF226AAFAF,
+	0x3FA91F65F10DD814, 0x3FEFF621E3796D7E,
+	0xBFEFF621E3796D7E, 0x3FA91F65F10DD814,
+	0x3FEFFD886084CD0D, 0x3F992155F7A3667E,
+	0xBF992155F7A3667E, 0x3FEFFD886084CD0D,
+	0x3FE610B7551D2CDF, 0x3FE72D0837EFFF96,
+	0xBFE72D0837EFFF96, 0x3FE610B7551D2CDF,
+	0x3FED4134D14DC93A, 0x3FD9EF7943A8ED8A,
+	0xBFD9EF7943A8ED8A, 0x3FED4134D14DC93A,
+	0x3FD7088530FA459F, 0x3FEDDB13B6CCC23C,
+	0xBFEDDB13B6CCC23C, 0x3FD7088530FA459F,
+	0x3FEF38F3AC64E589, 0x3FCC0B826A7E4F63,
+	0xBFCC0B826A7E4F63, 0x3FEF38F3AC64E589,
+	0x3FE11EB3541B4B23, 0x3FEB090A58150200,
+	0xBFEB090A58150200, 0x3FE11EB3541B4B23,
+	0x3FEA29A7A0462782, 0x3FE26D054CDD12DF,
+	0xBFE26D054CDD12DF, 0x3FEA29A7A0462782,
+	0x3FC5E214448B3FC6, 0x3FEF8764FA714BA9,
+	0xBFEF8764FA714BA9, 0x3FC5E214448B3FC6,
+	0x3FEFC26470E19FD3, 0x3FBF564E56A9730E,
+	0xBFBF564E56A9730E, 0x3FEFC26470E19FD3,
+	0x3FE3AFFA292050B9, 0x3FE93A22499263FB,
+	0xBFE93A22499263FB, 0x3FE3AFFA292050B9,
+	0x3FEBD7C0AC6F952A, 0x3FDF8BA4DBF89ABA,
+	0xBFDF8BA4DBF89ABA, 0x3FEBD7C0AC6F952A,
+	0x3FD111D262B1F677, 0x3FEED740E7684963,
+	0xBFEED740E7684963, 0x3FD111D262B1F677,
+	0x3FEE6288EC48E112, 0x3FD4135C94176601,
+	0xBFD4135C94176601, 0x3FEE6288EC48E112,
+	0x3FDCC66E9931C45E, 0x3FEC954B213411F5,
+	0xBFEC954B213411F5, 0x3FDCC66E9931C45E,
+	0x3FE83B0E0BFF976E, 0x3FE4E6CABBE3E5E9,
+	0xBFE4E6CABBE3E5E9, 0x3FE83B0E0BFF976E,
+	0x3FB2D52092CE19F6, 0x3FEFE9CDAD01883A,
+	0xBFEFE9CDAD01883A, 0x3FB2D52092CE19F6,
+	0x3FEFE9CDAD01883A, 0x3FB2D52092CE19F6,
+	0xBFB2D52092CE19F6, 0x3FEFE9CDAD01883A,
+	0x3FE4E6CABBE3E5E9, 0x3FE83B0E0BFF976E,
+	0xBFE83B0E0BFF976E, 0x3FE4E6CABBE3E5E9,
+	0x3FEC954B213411F5, 0x3FDCC66E9931C45E,
+	0xBFDCC66E9931C45E, 0x3FEC954B213411F5,
+	0x3FD4135C94176601, 0x3FEE6288EC48E112,
+	0xBFEE6288EC48E112, 0x3FD4135C94176601,
+	0x3FEED740E7684963, 0x3FD111D262B1F677,
+	0xBFD111D262B1F677, 0x3FEED740E7684963,
+	0x3FDF8BA4DBF89ABA, 0x3FEBD7C0AC6F952A,
+	0xBFEBD7C0AC6F952A, 0x3FDF8BA4DBF89ABA,
+	0x3FE93A22499263FB, 0x3FE3AFFA292050B9,
+	0xBFE3AFFA292050B9, 0x3FE93A22499263FB,
+	0x3FBF564E56A9730E, 0x3FEFC26470E19FD3,
+	0xBFEFC26470E19FD3, 0x3FBF564E56A9730E,
+	0x3FEF8764FA714BA9, 0x3FC5E214448B3FC6,
+	0xBFC5E214448B3FC6, 0x3FEF8764FA714BA9,
+	0x3FE26D054CDD12DF, 0x3FEA29A7A0462782,
+	0xBFEA29A7A0462782, 0x3FE26D054CDD12DF,
+	0x3FEB090A58150200, 0x3FE11EB3541B4B23,
+	0xBFE11EB3541B4B23, 0x3FEB090A58150200,
+	0x3FCC0B826A7E4F63, 0x3FEF38F3AC64E589,
+	0xBFEF38F3AC64E589, 0x3FCC0B826A7E4F63,
+	0x3FEDDB13B6CCC23C, 0x3FD7088530FA459F,
+	0xBFD7088530FA459F, 0x3FEDDB13B6CCC23C,
+	0x3FD9EF7943A8ED8A, 0x3FED4134D14DC93A,
+	0xBFED4134D14DC93A, 0x3FD9EF7943A8ED8A,
+	0x3FE72D0837EFFF96, 0x3FE610B7551D2CDF,
+	0xBFE610B7551D2CDF, 0x3FE72D0837EFFF96,
+	0x3F992155F7A3667E, 0x3FEFFD886084CD0D,
+	0xBFEFFD886084CD0D, 0x3F992155F7A3667E,
+	0x3FEFFF62169B92DB, 0x3F8921D1FCDEC784,
+	0xBF8921D1FCDEC784, 0x3FEFFF62169B92DB,
+	0x3FE6591925F0783D, 0x3FE6E74454EAA8AF,
+	0xBFE6E74454EAA8AF, 0x3FE6591925F0783D,
+	0x3FED696173C9E68B, 0x3FD9372A63BC93D7,
+	0xBFD9372A63BC93D7, 0x3FED696173C9E68B,
+	0x3FD7C3A9311DCCE7, 0x3FEDB6526238A09B,
+	0xBFEDB6526238A09B, 0x3FD7C3A9311DCCE7,
+	0x3FEF4E603B0B2F2D, 0x3FCA82A025B00451,
+	0xBFCA82A025B00451, 0x3FEF4E603B0B2F2D,
+	0x3FE1734D63DEDB49, 0x3FEAD2BC9E21D511,
+	0xBFEAD2BC9E21D511, 0x3FE1734D63DEDB49,
+	0x3FEA63091B02FAE2, 0x3FE21A799933EB59,
+	0xBFE21A799933EB59, 0x3FEA63091B02FAE2,
+	0x3FC76DD9DE50BF31, 0x3FEF7599A3A12077,
+	0xBFEF7599A3A12077, 0x3FC76DD9DE50BF31,
+	0x3FEFCE15FD6DA67B, 0x3FBC3785C79EC2D5,
+	0xBFBC3785C79EC2D5, 0x3FEFCE15FD6DA67B,
+	0x3FE3FED9534556D4, 0x3FE8FBCCA3EF940D,
+	0xBFE8FBCCA3EF940D, 0x3FE3FED9534556D4,
+	0x3FEC08C426725549, 0x3FDEDC1952EF78D6,
+	0xBFDEDC1952EF78D6, 0x3FEC08C426725549,
+	0x3FD1D3443F4CDB3E, 0x3FEEBBD8C8DF0B74,
+	0xBFEEBBD8C8DF0B74, 0x3FD1D3443F4CDB3E,
+	0x3FEE817BAB4CD10D, 0x3FD35410C2E18152,
+	0xBFD35410C2E18152, 0x3FEE817BAB4CD10D,
+	0x3FDD79775B86E389, 0x3FEC678B3488739B,
+	0xBFEC678B3488739B, 0x3FDD79775B86E389,
+	0x3FE87C400FBA2EBF, 0x3FE49A449B9B0939,
+	0xBFE49A449B9B0939, 0x3FE87C400FBA2EBF,
+	0x3FB5F6D00A9AA419, 0x3FEFE1CAFCBD5B09,
+	0xBFEFE1CAFCBD5B09, 0x3FB5F6D00A9AA419,
+	0x3FEFF095658E71AD, 0x3FAF656E79F820E0,
+	0xBFAF656E79F820E0, 0x3FEFF095658E71AD,
+	0x3FE5328292A35596, 0x3FE7F8ECE3571771,
+	0xBFE7F8ECE3571771, 0x3FE5328292A35596,
+	0x3FECC1F0F3FCFC5C, 0x3FDC1249D8011EE7,
+	0xBFDC1249D8011EE7, 0x3FECC1F0F3FCFC5C,
+	0x3FD4D1E24278E76A, 0x3FEE426A4B2BC17E,
+	0xBFEE426A4B2BC17E, 0x3FD4D1E24278E76A,
+	0x3FEEF178A3E473C2, 0x3FD04FB80E37FDAE,
+	0xBFD04FB80E37FDAE, 0x3FEEF178A3E473C2,
+	0x3FE01CFC874C3EB7, 0x3FEBA5AA673590D2,
+	0xBFEBA5AA673590D2, 0x3FE01CFC874C3EB7,
+	0x3FE9777EF4C7D742, 0x3FE36058B10659F3,
+	0xBFE36058B10659F3, 0x3FE9777EF4C7D742,
+	0x3FC139F0CEDAF577, 0x3FEFB5797195D741,
+	0xBFEFB5797195D741, 0x3FC139F0CEDAF577,
+	0x3FEF97F924C9099B, 0x3FC45576B1293E5A,
+	0xBFC45576B1293E5A, 0x3FEF97F924C9099B,
+	0x3FE2BEDB25FAF3EA, 0x3FE9EF43EF29AF94,
+	0xBFE9EF43EF29AF94, 0x3FE2BEDB25FAF3EA,
+	0x3FEB3E4D3EF55712, 0x3FE0C9704D5D898F,
+	0xBFE0C9704D5D898F, 0x3FEB3E4D3EF55712,
+	0x3FCD934FE5454311, 0x3FEF2252F7763ADA,
+	0xBFEF2252F7763ADA, 0x3FCD934FE5454311,
+	0x3FEDFEAE622DBE2B, 0x3FD64C7DDD3F27C6,
+	0xBFD64C7DDD3F27C6, 0x3FEDFEAE622DBE2B,
+	0x3FDAA6C82B6D3FCA, 0x3FED17E7743E35DC,
+	0xBFED17E7743E35DC, 0x3FDAA6C82B6D3FCA,
+	0x3FE771E75F037261, 0x3FE5C77BBE65018C,
+	0xBFE5C77BBE65018C, 0x3FE771E75F037261,
+	0x3FA2D865759455CD, 0x3FEFFA72EFFEF75D,
+	0xBFEFFA72EFFEF75D, 0x3FA2D865759455CD,
+	0x3FEFFA72EFFEF75D, 0x3FA2D865759455CD,
+	0xBFA2D865759455CD, 0x3FEFFA72EFFEF75D,
+	0x3FE5C77BBE65018C, 0x3FE771E75F037261,
+	0xBFE771E75F037261, 0x3FE5C77BBE65018C,
+	0x3FED17E7743E35DC, 0x3FDAA6C82B6D3FCA,
+	0xBFDAA6C82B6D3FCA, 0x3FED17E7743E35DC,
+	0x3FD64C7DDD3F27C6, 0x3FEDFEAE622DBE2B,
+	0xBFEDFEAE622DBE2B, 0x3FD64C7DDD3F27C6,
+	0x3FEF2252F7763ADA, 0x3FCD934FE5454311,
+	0xBFCD934FE5454311, 0x3FEF2252F7763ADA,
+	0x3FE0C9704D5D898F, 0x3FEB3E4D3EF55712,
+	0xBFEB3E4D3EF55712, 0x3FE0C9704D5D898F,
+	0x3FE9EF43EF29AF94, 0x3FE2BEDB25FAF3EA,
+	0xBFE2BEDB25FAF3EA, 0x3FE9EF43EF29AF94,
+	0x3FC45576B1293E5A, 0x3FEF97F924C9099B,
+	0xBFEF97F924C9099B, 0x3FC45576B1293E5A,
+	0x3FEFB5797195D741, 0x3FC139F0CEDAF577,
+	0xBFC139F0CEDAF577, 0x3FEFB5797195D741,
+	0x3FE36058B10659F3, 0x3FE9777EF4C7D742,
+	0xBFE9777EF4C7D742, 0x3FE36058B10659F3,
+	0x3FEBA5AA673590D2, 0x3FE01CFC874C3EB7,
+	0xBFE01CFC874C3EB7, 0x3FEBA5AA673590D2,
+	0x3FD04FB80E37FDAE, 0x3FEEF178A3E473C2,
+	0xBFEEF178A3E473C2, 0x3FD04FB80E37FDAE,
+	0x3FEE426A4B2BC17E, 0x3FD4D1E24278E76A,
+	0xBFD4D1E24278E76A, 0x3FEE426A4B2BC17E,
+	0x3FDC1249D8011EE7, 0x3FECC1F0F3FCFC5C,
+	0xBFECC1F0F3FCFC5C, 0x3FDC1249D8011EE7,
+	0x3FE7F8ECE3571771, 0x3FE5328292A35596,
+	0xBFE5328292A35596, 0x3FE7F8ECE3571771,
+	0x3FAF656E79F820E0, 0x3FEFF095658E71AD,
+	0xBFEFF095658E71AD, 0x3FAF656E79F820E0,
+	0x3FEFE1CAFCBD5B09, 0x3FB5F6D00A9AA419,
+	0xBFB5F6D00A9AA419, 0x3FEFE1CAFCBD5B09,
+	0x3FE49A449B9B0939, 0x3FE87C400FBA2EBF,
+	0xBFE87C400FBA2EBF, 0x3FE49A449B9B0939,
+	0x3FEC678B3488739B, 0x3FDD79775B86E389,
+	0xBFDD79775B86E389, 0x3FEC678B3488739B,
+	0x3FD35410C2E18152, 0x3FEE817BAB4CD10D,
+	0xBFEE817BAB4CD10D, 0x3FD35410C2E18152,
+	0x3FEEBBD8C8DF0B74, 0x3FD1D3443F4CDB3E,
+	0xBFD1D3443F4CDB3E, 0x3FEEBBD8C8DF0B74,
+	0x3FDEDC1952EF78D6, 0x3FEC08C426725549,
+	0xBFEC08C426725549, 0x3FDEDC1952EF78D6,
+	0x3FE8FBCCA3EF940D, 0x3FE3FED9534556D4,
+	0xBFE3FED9534556D4, 0x3FE8FBCCA3EF940D,
+	0x3FBC3785C79EC2D5, 0x3FEFCE15FD6DA67B,
+	0xBFEFCE15FD6DA67B, 0x3FBC3785C79EC2D5,
+	0x3FEF7599A3A12077, 0x3FC76DD9DE50BF31,
+	0xBFC76DD9DE50BF31, 0x3FEF7599A3A12077,
+	0x3FE21A799933EB59, 0x3FEA63091B02FAE2,
+	0xBFEA63091B02FAE2, 0x3FE21A799933EB59,
+	0x3FEAD2BC9E21D511, 0x3FE1734D63DEDB49,
+	0xBFE1734D63DEDB49, 0x3FEAD2BC9E21D511,
+	0x3FCA82A025B00451, 0x3FEF4E603B0B2F2D,
+	0xBFEF4E603B0B2F2D, 0x3FCA82A025B00451,
+	0x3FEDB6526238A09B, 0x3FD7C3A9311DCCE7,
+	0xBFD7C3A9311DCCE7, 0x3FEDB6526238A09B,
+	0x3FD9372A63BC93D7, 0x3FED696173C9E68B,
+	0xBFED696173C9E68B, 0x3FD9372A63BC93D7,
+	0x3FE6E74454EAA8AF, 0x3FE6591925F0783D,
+	0xBFE6591925F0783D, 0x3FE6E74454EAA8AF,
+	0x3F8921D1FCDEC784, 0x3FEFFF62169B92DB,
+	0xBFEFFF62169B92DB, 0x3F8921D1FCDEC784,
+	0x3FEFFFD8858E8A92, 0x3F7921F0FE670071,
+	0xBF7921F0FE670071, 0x3FEFFFD8858E8A92,
+	0x3FE67CF78491AF10, 0x3FE6C40D73C18275,
+	0xBFE6C40D73C18275, 0x3FE67CF78491AF10,
+	0x3FED7D0B02B8ECF9, 0x3FD8DAA52EC8A4B0,
+	0xBFD8DAA52EC8A4B0, 0x3FED7D0B02B8ECF9,
+	0x3FD820E3B04EAAC4, 0x3FEDA383A9668988,
+	0xBFEDA383A9668988, 0x3FD820E3B04EAAC4,
+	0x3FEF58A2B1789E84, 0x3FC9BDCBF2DC4366,
+	0xBFC9BDCBF2DC4366, 0x3FEF58A2B1789E84,
+	0x3FE19D5A09F2B9B8, 0x3FEAB7325916C0D4,
+	0xBFEAB7325916C0D4, 0x3FE19D5A09F2B9B8,
+	0x3FEA7F58529FE69D, 0x3FE1F0F08BBC861B,
+	0xBFE1F0F08BBC861B, 0x3FEA7F58529FE69D,
+	0x3FC83366E89C64C6, 0x3FEF6C3F7DF5BBB7,
+	0xBFEF6C3F7DF5BBB7, 0x3FC83366E89C64C6,
+	0x3FEFD37914220B84, 0x3FBAA7B724495C03,
+	0xBFBAA7B724495C03, 0x3FEFD37914220B84,
+	0x3FE425FF178E6BB1, 0x3FE8DC45331698CC,
+	0xBFE8DC45331698CC, 0x3FE425FF178E6BB1,
+	0x3FEC20DE3FA971B0, 0x3FDE83E0EAF85114,
+	0xBFDE83E0EAF85114, 0x3FEC20DE3FA971B0,
+	0x3FD233BBABC3BB71, 0x3FEEADB2E8E7A88E,
+	0xBFEEADB2E8E7A88E, 0x3FD233BBABC3BB71,
+	0x3FEE9084361DF7F2, 0x3FD2F422DAEC0387,
+	0xBFD2F422DAEC0387, 0x3FEE9084361DF7F2,
+	0x3FDDD28F1481CC58, 0x3FEC5042012B6907,
+	0xBFEC5042012B6907, 0x3FDDD28F1481CC58,
+	0x3FE89C7E9A4DD4AA, 0x3FE473B51B987347,
+	0xBFE473B51B987347, 0x3FE89C7E9A4DD4AA,
+	0x3FB787586A5D5B21, 0x3FEFDD539FF1F456,
+	0xBFEFDD539FF1F456, 0x3FB787586A5D5B21,
+	0x3FEFF3830F8D575C, 0x3FAC428D12C0D7E3,
+	0xBFAC428D12C0D7E3, 0x3FEFF3830F8D575C,
+	0x3FE5581038975137, 0x3FE7D7836CC33DB2,
+	0xBFE7D7836CC33DB2, 0x3FE5581038975137,
+	0x3FECD7D9898B32F6, 0x3FDBB7CF2304BD01,
+	0xBFDBB7CF2304BD01, 0x3FECD7D9898B32F6,
+	0x3FD530D880AF3C24, 0x3FEE31EAE870CE25,
+	0xBFEE31EAE870CE25, 0x3FD530D880AF3C24,
+	0x3FEEFE220C0B95EC, 0x3FCFDCDC1ADFEDF9,
+	0xBFCFDCDC1ADFEDF9, 0x3FEEFE220C0B95EC,
+	0x3FE0485626AE221A, 0x3FEB8C38D27504E9,
+	0xBFEB8C38D27504E9, 0x3FE0485626AE221A,
+	0x3FE995CF2ED80D22, 0x3FE338400D0C8E57,
+	0xBFE338400D0C8E57, 0x3FE995CF2ED80D22,
+	0x3FC20116D4EC7BCF, 0x3FEFAE8E8E46CFBB,
+	0xBFEFAE8E8E46CFBB, 0x3FC20116D4EC7BCF,
+	0x3FEF9FCE55ADB2C8, 0x3FC38EDBB0CD8D14,
+	0xBFC38EDBB0CD8D14, 0x3FEF9FCE55ADB2C8,
+	0x3FE2E780E3E8EA17, 0x3FE9D1B1F5EA80D5,
+	0xBFE9D1B1F5EA80D5, 0x3FE2E780E3E8EA17,
+	0x3FEB5889FE921405, 0x3FE09E907417C5E1,
+	0xBFE09E907417C5E1, 0x3FEB5889FE921405,
+	0x3FCE56CA1E101A1B, 0x3FEF168F53F7205D,
+	0xBFEF168F53F7205D, 0x3FCE56CA1E101A1B,
+	0x3FEE100CCA2980AC, 0x3FD5EE27379EA693,
+	0xBFD5EE27379EA693, 0x3FEE100CCA2980AC,
+	0x3FDB020D6C7F4009, 0x3FED02D4FEB2BD92,
+	0xBFED02D4FEB2BD92, 0x3FDB020D6C7F4009,
+	0x3FE79400574F55E5, 0x3FE5A28D2A5D7250,
+	0xBFE5A28D2A5D7250, 0x3FE79400574F55E5,
+	0x3FA5FC00D290CD43, 0x3FEFF871DADB81DF,
+	0xBFEFF871DADB81DF, 0x3FA5FC00D290CD43,
+	0x3FEFFC251DF1D3F8, 0x3F9F693731D1CF01,
+	0xBF9F693731D1CF01, 0x3FEFFC251DF1D3F8,
+	0x3FE5EC3495837074, 0x3FE74F948DA8D28D,
+	0xBFE74F948DA8D28D, 0x3FE5EC3495837074,
+	0x3FED2CB220E0EF9F, 0x3FDA4B4127DEA1E5,
+	0xBFDA4B4127DEA1E5, 0x3FED2CB220E0EF9F,
+	0x3FD6AA9D7DC77E17, 0x3FEDED05F7DE47DA,
+	0xBFEDED05F7DE47DA, 0x3FD6AA9D7DC77E17,
+	0x3FEF2DC9C9089A9D, 0x3FCCCF8CB312B286,
+	0xBFCCCF8CB312B286, 0x3FEF2DC9C9089A9D,
+	0x3FE0F426BB2A8E7E, 0x3FEB23CD470013B4,
+	0xBFEB23CD470013B4, 0x3FE0F426BB2A8E7E,
+	0x3FEA0C95EABAF937, 0x3FE2960727629CA8,
+	0xBFE2960727629CA8, 0x3FEA0C95EABAF937,
+	0x3FC51BDF8597C5F2, 0x3FEF8FD5FFAE41DB,
+	0xBFEF8FD5FFAE41DB, 0x3FC51BDF8597C5F2,
+	0x3FEFBC1617E44186, 0x3FC072A047BA831D,
+	0xBFC072A047BA831D, 0x3FEFBC1617E44186,
+	0x3FE3884185DFEB22, 0x3FE958EFE48E6DD7,
+	0xBFE958EFE48E6DD7, 0x3FE3884185DFEB22,
+	0x3FEBBED7C49380EA, 0x3FDFE2F64BE71210,
+	0xBFDFE2F64BE71210, 0x3FEBBED7C49380EA,
+	0x3FD0B0D9CFDBDB90, 0x3FEEE482E25A9DBC,
+	0xBFEEE482E25A9DBC, 0x3FD0B0D9CFDBDB90,
+	0x3FEE529F04729FFC, 0x3FD472B8A5571054,
+	0xBFD472B8A5571054, 0x3FEE529F04729FFC,
+	0x3FDC6C7F4997000B, 0x3FECABC169A0B900,
+	0xBFECABC169A0B900, 0x3FDC6C7F4997000B,
+	0x3FE81A1B33B57ACC, 0x3FE50CC09F59A09B,
+	0xBFE50CC09F59A09B, 0x3FE81A1B33B57ACC,
+	0x3FB1440134D709B3, 0x3FEFED58ECB673C4,
+	0xBFEFED58ECB673C4, 0x3FB1440134D709B3,
+	0x3FEFE5F3AF2E3940, 0x3FB4661179272096,
+	0xBFB4661179272096, 0x3FEFE5F3AF2E3940,
+	0x3FE4C0A145EC0004, 0x3FE85BC51AE958CC,
+	0xBFE85BC51AE958CC, 0x3FE4C0A145EC0004,
+	0x3FEC7E8E52233CF3, 0x3FDD2016E8E9DB5B,
+	0xBFDD2016E8E9DB5B, 0x3FEC7E8E52233CF3,
+	0x3FD3B3CEFA0414B7, 0x3FEE7227DB6A9744,
+	0xBFEE7227DB6A9744, 0x3FD3B3CEFA0414B7,
+	0x3FEEC9B2D3C3BF84, 0x3FD172A0D7765177,
+	0xBFD172A0D7765177, 0x3FEEC9B2D3C3BF84,
+	0x3FDF3405963FD067, 0x3FEBF064E15377DD,
+	0xBFEBF064E15377DD, 0x3FDF3405963FD067,
+	0x3FE91B166FD49DA2, 0x3FE3D78238C58344,
+	0xBFE3D78238C58344, 0x3FE91B166FD49DA2,
+	0x3FBDC70ECBAE9FC9, 0x3FEFC8646CFEB721,
+	0xBFEFC8646CFEB721, 0x3FBDC70ECBAE9FC9,
+	0x3FEF7EA629E63D6E, 0x3FC6A81304F64AB2,
+	0xBFC6A81304F64AB2, 0x3FEF7EA629E63D6E,
+	0x3FE243D5FB98AC1F, 0x3FEA4678C8119AC8,
+	0xBFEA4678C8119AC8, 0x3FE243D5FB98AC1F,
+	0x3FEAEE04B43C1474, 0x3FE14915AF336CEB,
+	0xBFE14915AF336CEB, 0x3FEAEE04B43C1474,
+	0x3FCB4732EF3D6722, 0x3FEF43D085FF92DD,
+	0xBFEF43D085FF92DD, 0x3FCB4732EF3D6722,
+	0x3FEDC8D7CB410260, 0x3FD766340F2418F6,
+	0xBFD766340F2418F6, 0x3FEDC8D7CB410260,
+	0x3FD993716141BDFF, 0x3FED556F52E93EB1,
+	0xBFED556F52E93EB1, 0x3FD993716141BDFF,
+	0x3FE70A42B3176D7A, 0x3FE63503A31C1BE9,
+	0xBFE63503A31C1BE9, 0x3FE70A42B3176D7A,
+	0x3F92D936BBE30EFD, 0x3FEFFE9CB44B51A1,
+	0xBFEFFE9CB44B51A1, 0x3F92D936BBE30EFD,
+	0x3FEFFE9CB44B51A1, 0x3F92D936BBE30EFD,
+	0xBF92D936BBE30EFD, 0x3FEFFE9CB44B51A1,
+	0x3FE63503A31C1BE9, 0x3FE70A42B3176D7A,
+	0xBFE70A42B3176D7A, 0x3FE63503A31C1BE9,
+	0x3FED556F52E93EB1, 0x3FD993716141BDFF,
+	0xBFD993716141BDFF, 0x3FED556F52E93EB1,
+	0x3FD766340F2418F6, 0x3FEDC8D7CB410260,
+	0xBFEDC8D7CB410260, 0x3FD766340F2418F6,
+	0x3FEF43D085FF92DD, 0x3FCB4732EF3D6722,
+	0xBFCB4732EF3D6722, 0x3FEF43D085FF92DD,
+	0x3FE14915AF336CEB, 0x3FEAEE04B43C1474,
+	0xBFEAEE04B43C1474, 0x3FE14915AF336CEB,
+	0x3FEA4678C8119AC8, 0x3FE243D5FB98AC1F,
+	0xBFE243D5FB98AC1F, 0x3FEA4678C8119AC8,
+	0x3FC6A81304F64AB2, 0x3FEF7EA629E63D6E,
+	0xBFEF7EA629E63D6E, 0x3FC6A81304F64AB2,
+	0x3FEFC8646CFEB721, 0x3FBDC70ECBAE9FC9,
+	0xBFBDC70ECBAE9FC9, 0x3FEFC8646CFEB721,
+	0x3FE3D78238C58344, 0x3FE91B166FD49DA2,
+	0xBFE91B166FD49DA2, 0x3FE3D78238C58344,
+	0x3FEBF064E15377DD, 0x3FDF3405963FD067,
+	0xBFDF3405963FD067, 0x3FEBF064E15377DD,
+	0x3FD172A0D7765177, 0x3FEEC9B2D3C3BF84,
+	0xBFEEC9B2D3C3BF84, 0x3FD172A0D7765177,
+	0x3FEE7227DB6A9744, 0x3FD3B3CEFA0414B7,
+	0xBFD3B3CEFA0414B7, 0x3FEE7227DB6A9744,
+	0x3FDD2016E8E9DB5B, 0x3FEC7E8E52233CF3,
+	0xBFEC7E8E52233CF3, 0x3FDD2016E8E9DB5B,
+	0x3FE85BC51AE958CC, 0x3FE4C0A145EC0004,
+	0xBFE4C0A145EC0004, 0x3FE85BC51AE958CC,
+	0x3FB4661179272096, 0x3FEFE5F3AF2E3940,
+	0xBFEFE5F3AF2E3940, 0x3FB4661179272096,
+	0x3FEFED58ECB673C4, 0x3FB1440134D709B3,
+	0xBFB1440134D709B3, 0x3FEFED58ECB673C4,
+	0x3FE50CC09F59A09B, 0x3FE81A1B33B57ACC,
+	0xBFE81A1B33B57ACC, 0x3FE50CC09F59A09B,
+	0x3FECABC169A0B900, 0x3FDC6C7F4997000B,
+	0xBFDC6C7F4997000B, 0x3FECABC169A0B900,
+	0x3FD472B8A5571054, 0x3FEE529F04729FFC,
+	0xBFEE529F04729FFC, 0x3FD472B8A5571054,
+	0x3FEEE482E25A9DBC, 0x3FD0B0D9CFDBDB90,
+	0xBFD0B0D9CFDBDB90, 0x3FEEE482E25A9DBC,
+	0x3FDFE2F64BE71210, 0x3FEBBED7C49380EA,
+	0xBFEBBED7C49380EA, 0x3FDFE2F64BE71210,
+	0x3FE958EFE48E6DD7, 0x3FE3884185DFEB22,
+	0xBFE3884185DFEB22, 0x3FE958EFE48E6DD7,
+	0x3FC072A047BA831D, 0x3FEFBC1617E44186,
+	0xBFEFBC1617E44186, 0x3FC072A047BA831D,
+	0x3FEF8FD5FFAE41DB, 0x3FC51BDF8597C5F2,
+	0xBFC51BDF8597C5F2, 0x3FEF8FD5FFAE41DB,
+	0x3FE2960727629CA8, 0x3FEA0C95EABAF937,
+	0xBFEA0C95EABAF937, 0x3FE2960727629CA8,
+	0x3FEB23CD470013B4, 0x3FE0F426BB2A8E7E,
+	0xBFE0F426BB2A8E7E, 0x3FEB23CD470013B4,
+	0x3FCCCF8CB312B286, 0x3FEF2DC9C9089A9D,
+	0xBFEF2DC9C9089A9D, 0x3FCCCF8CB312B286,
+	0x3FEDED05F7DE47DA, 0x3FD6AA9D7DC77E17,
+	0xBFD6AA9D7DC77E17, 0x3FEDED05F7DE47DA,
+	0x3FDA4B4127DEA1E5, 0x3FED2CB220E0EF9F,
+	0xBFED2CB220E0EF9F, 0x3FDA4B4127DEA1E5,
+	0x3FE74F948DA8D28D, 0x3FE5EC3495837074,
+	0xBFE5EC3495837074, 0x3FE74F948DA8D28D,
+	0x3F9F693731D1CF01, 0x3FEFFC251DF1D3F8,
+	0xBFEFFC251DF1D3F8, 0x3F9F693731D1CF01,
+	0x3FEFF871DADB81DF, 0x3FA5FC00D290CD43,
+	0xBFA5FC00D290CD43, 0x3FEFF871DADB81DF,
+	0x3FE5A28D2A5D7250, 0x3FE79400574F55E5,
+	0xBFE79400574F55E5, 0x3FE5A28D2A5D7250,
+	0x3FED02D4FEB2BD92, 0x3FDB020D6C7F4009,
+	0xBFDB020D6C7F4009, 0x3FED02D4FEB2BD92,
+	0x3FD5EE27379EA693, 0x3FEE100CCA2980AC,
+	0xBFEE100CCA2980AC, 0x3FD5EE27379EA693,
+	0x3FEF168F53F7205D, 0x3FCE56CA1E101A1B,
+	0xBFCE56CA1E101A1B, 0x3FEF168F53F7205D,
+	0x3FE09E907417C5E1, 0x3FEB5889FE921405,
+	0xBFEB5889FE921405, 0x3FE09E907417C5E1,
+	0x3FE9D1B1F5EA80D5, 0x3FE2E780E3E8EA17,
+	0xBFE2E780E3E8EA17, 0x3FE9D1B1F5EA80D5,
+	0x3FC38EDBB0CD8D14, 0x3FEF9FCE55ADB2C8,
+	0xBFEF9FCE55ADB2C8, 0x3FC38EDBB0CD8D14,
+	0x3FEFAE8E8E46CFBB, 0x3FC20116D4EC7BCF,
+	0xBFC20116D4EC7BCF, 0x3FEFAE8E8E46CFBB,
+	0x3FE338400D0C8E57, 0x3FE995CF2ED80D22,
+	0xBFE995CF2ED80D22, 0x3FE338400D0C8E57,
+	0x3FEB8C38D27504E9, 0x3FE0485626AE221A,
+	0xBFE0485626AE221A, 0x3FEB8C38D27504E9,
+	0x3FCFDCDC1ADFEDF9, 0x3FEEFE220C0B95EC,
+	0xBFEEFE220C0B95EC, 0x3FCFDCDC1ADFEDF9,
+	0x3FEE31EAE870CE25, 0x3FD530D880AF3C24,
+	0xBFD530D880AF3C24, 0x3FEE31EAE870CE25,
+	0x3FDBB7CF2304BD01, 0x3FECD7D9898B32F6,
+	0xBFECD7D9898B32F6, 0x3FDBB7CF2304BD01,
+	0x3FE7D7836CC33DB2, 0x3FE5581038975137,
+	0xBFE5581038975137, 0x3FE7D7836CC33DB2,
+	0x3FAC428D12C0D7E3, 0x3FEFF3830F8D575C,
+	0xBFEFF3830F8D575C, 0x3FAC428D12C0D7E3,
+	0x3FEFDD539FF1F456, 0x3FB787586A5D5B21,
+	0xBFB787586A5D5B21, 0x3FEFDD539FF1F456,
+	0x3FE473B51B987347, 0x3FE89C7E9A4DD4AA,
+	0xBFE89C7E9A4DD4AA, 0x3FE473B51B987347,
+	0x3FEC5042012B6907, 0x3FDDD28F1481CC58,
+	0xBFDDD28F1481CC58, 0x3FEC5042012B6907,
+	0x3FD2F422DAEC0387, 0x3FEE9084361DF7F2,
+	0xBFEE9084361DF7F2, 0x3FD2F422DAEC0387,
+	0x3FEEADB2E8E7A88E, 0x3FD233BBABC3BB71,
+	0xBFD233BBABC3BB71, 0x3FEEADB2E8E7A88E,
+	0x3FDE83E0EAF85114, 0x3FEC20DE3FA971B0,
+	0xBFEC20DE3FA971B0, 0x3FDE83E0EAF85114,
+	0x3FE8DC45331698CC, 0x3FE425FF178E6BB1,
+	0xBFE425FF178E6BB1, 0x3FE8DC45331698CC,
+	0x3FBAA7B724495C03, 0x3FEFD37914220B84,
+	0xBFEFD37914220B84, 0x3FBAA7B724495C03,
+	0x3FEF6C3F7DF5BBB7, 0x3FC83366E89C64C6,
+	0xBFC83366E89C64C6, 0x3FEF6C3F7DF5BBB7,
+	0x3FE1F0F08BBC861B, 0x3FEA7F58529FE69D,
+	0xBFEA7F58529FE69D, 0x3FE1F0F08BBC861B,
+	0x3FEAB7325916C0D4, 0x3FE19D5A09F2B9B8,
+	0xBFE19D5A09F2B9B8, 0x3FEAB7325916C0D4,
+	0x3FC9BDCBF2DC4366, 0x3FEF58A2B1789E84,
+	0xBFEF58A2B1789E84, 0x3FC9BDCBF2DC4366,
+	0x3FEDA383A9668988, 0x3FD820E3B04EAAC4,
+	0xBFD820E3B04EAAC4, 0x3FEDA383A9668988,
+	0x3FD8DAA52EC8A4B0, 0x3FED7D0B02B8ECF9,
+	0xBFED7D0B02B8ECF9, 0x3FD8DAA52EC8A4B0,
+	0x3FE6C40D73C18275, 0x3FE67CF78491AF10,
+	0xBFE67CF78491AF10, 0x3FE6C40D73C18275,
+	0x3F7921F0FE670071, 0x3FEFFFD8858E8A92,
+	0xBFEFFFD8858E8A92, 0x3F7921F0FE670071,
+	0x3FEFFFF621621D02, 0x3F6921F8BECCA4BA,
+	0xBF6921F8BECCA4BA, 0x3FEFFFF621621D02,
+	0x3FE68ED1EAA19C71, 0x3FE6B25CED2FE29C,
+	0xBFE6B25CED2FE29C, 0x3FE68ED1EAA19C71,
+	0x3FED86C48445A44F, 0x3FD8AC4B86D5ED44,
+	0xBFD8AC4B86D5ED44, 0x3FED86C48445A44F,
+	0x3FD84F6AAAF3903F, 0x3FED9A00DD8B3D46,
+	0xBFED9A00DD8B3D46, 0x3FD84F6AAAF3903F,
+	0x3FEF5DA6ED43685D, 0x3FC95B49E9B62AFA,
+	0xBFC95B49E9B62AFA, 0x3FEF5DA6ED43685D,
+	0x3FE1B250171373BF, 0x3FEAA9547A2CB98E,
+	0xBFEAA9547A2CB98E, 0x3FE1B250171373BF,
+	0x3FEA8D676E545AD2, 0x3FE1DC1B64DC4872,
+	0xBFE1DC1B64DC4872, 0x3FEA8D676E545AD2,
+	0x3FC8961727C41804, 0x3FEF677556883CEE,
+	0xBFEF677556883CEE, 0x3FC8961727C41804,
+	0x3FEFD60D2DA75C9E, 0x3FB9DFB6EB24A85C,
+	0xBFB9DFB6EB24A85C, 0x3FEFD60D2DA75C9E,
+	0x3FE4397F5B2A4380, 0x3FE8CC6A75184655,
+	0xBFE8CC6A75184655, 0x3FE4397F5B2A4380,
+	0x3FEC2CD14931E3F1, 0x3FDE57A86D3CD825,
+	0xBFDE57A86D3CD825, 0x3FEC2CD14931E3F1,
+	0x3FD263E6995554BA, 0x3FEEA68393E65800,
+	0xBFEEA68393E65800, 0x3FD263E6995554BA,
+	0x3FEE97EC36016B30, 0x3FD2C41A4E954520,
+	0xBFD2C41A4E954520, 0x3FEE97EC36016B30,
+	0x3FDDFEFF66A941DE, 0x3FEC44833141C004,
+	0xBFEC44833141C004, 0x3FDDFEFF66A941DE,
+	0x3FE8AC871EDE1D88, 0x3FE4605A692B32A2,
+	0xBFE4605A692B32A2, 0x3FE8AC871EDE1D88,
+	0x3FB84F8712C130A1, 0x3FEFDAFA7514538C,
+	0xBFEFDAFA7514538C, 0x3FB84F8712C130A1,
+	0x3FEFF4DC54B1BED3, 0x3FAAB101BD5F8317,
+	0xBFAAB101BD5F8317, 0x3FEFF4DC54B1BED3,
+	0x3FE56AC35197649F, 0x3FE7C6B89CE2D333,
+	0xBFE7C6B89CE2D333, 0x3FE56AC35197649F,
+	0x3FECE2B32799A060, 0x3FDB8A7814FD5693,
+	0xBFDB8A7814FD5693, 0x3FECE2B32799A060,
+	0x3FD5604012F467B4, 0x3FEE298F4439197A,
+	0xBFEE298F4439197A, 0x3FD5604012F467B4,
+	0x3FEF045A14CF738C, 0x3FCF7B7480BD3802,
+	0xBFCF7B7480BD3802, 0x3FEF045A14CF738C,
+	0x3FE05DF3EC31B8B7, 0x3FEB7F6686E792E9,
+	0xBFEB7F6686E792E9, 0x3FE05DF3EC31B8B7,
+	0x3FE9A4DFA42B06B2, 0x3FE32421EC49A61F,
+	0xBFE32421EC49A61F, 0x3FE9A4DFA42B06B2,
+	0x3FC264994DFD3409, 0x3FEFAAFBCB0CFDDC,
+	0xBFEFAAFBCB0CFDDC, 0x3FC264994DFD3409,
+	0x3FEFA39BAC7A1791, 0x3FC32B7BF94516A7,
+	0xBFC32B7BF94516A7, 0x3FEFA39BAC7A1791,
+	0x3FE2FBC24B441015, 0x3FE9C2D110F075C2,
+	0xBFE9C2D110F075C2, 0x3FE2FBC24B441015,
+	0x3FEB658F14FDBC47, 0x3FE089112032B08C,
+	0xBFE089112032B08C, 0x3FEB658F14FDBC47,
+	0x3FCEB86B462DE348, 0x3FEF1090BC898F5F,
+	0xBFEF1090BC898F5F, 0x3FCEB86B462DE348,
+	0x3FEE18A02FDC66D9, 0x3FD5BEE78B9DB3B6,
+	0xBFD5BEE78B9DB3B6, 0x3FEE18A02FDC66D9,
+	0x3FDB2F971DB31972, 0x3FECF830E8CE467B,
+	0xBFECF830E8CE467B, 0x3FDB2F971DB31972,
+	0x3FE7A4F707BF97D2, 0x3FE59001D5F723DF,
+	0xBFE59001D5F723DF, 0x3FE7A4F707BF97D2,
+	0x3FA78DBAA5874686, 0x3FEFF753BB1B9164,
+	0xBFEFF753BB1B9164, 0x3FA78DBAA5874686,
+	0x3FEFFCE09CE2A679, 0x3F9C454F4CE53B1D,
+	0xBF9C454F4CE53B1D, 0x3FEFFCE09CE2A679,
+	0x3FE5FE7CBDE56A10, 0x3FE73E558E079942,
+	0xBFE73E558E079942, 0x3FE5FE7CBDE56A10,
+	0x3FED36FC7BCBFBDC, 0x3FDA1D6543B50AC0,
+	0xBFDA1D6543B50AC0, 0x3FED36FC7BCBFBDC,
+	0x3FD6D998638A0CB6, 0x3FEDE4160F6D8D81,
+	0xBFEDE4160F6D8D81, 0x3FD6D998638A0CB6,
+	0x3FEF33685A3AAEF0, 0x3FCC6D90535D74DD,
+	0xBFCC6D90535D74DD, 0x3FEF33685A3AAEF0,
+	0x3FE1097248D0A957, 0x3FEB16742A4CA2F5,
+	0xBFEB16742A4CA2F5, 0x3FE1097248D0A957,
+	0x3FEA1B26D2C0A75E, 0x3FE2818BEF4D3CBA,
+	0xBFE2818BEF4D3CBA, 0x3FEA1B26D2C0A75E,
+	0x3FC57F008654CBDE, 0x3FEF8BA737CB4B78,
+	0xBFEF8BA737CB4B78, 0x3FC57F008654CBDE,
+	0x3FEFBF470F0A8D88, 0x3FC00EE8AD6FB85B,
+	0xBFC00EE8AD6FB85B, 0x3FEFBF470F0A8D88,
+	0x3FE39C23E3D63029, 0x3FE94990E3AC4A6C,
+	0xBFE94990E3AC4A6C, 0x3FE39C23E3D63029,
+	0x3FEBCB54CB0D2327, 0x3FDFB7575C24D2DE,
+	0xBFDFB7575C24D2DE, 0x3FEBCB54CB0D2327,
+	0x3FD0E15B4E1749CE, 0x3FEEDDEB6A078651,
+	0xBFEEDDEB6A078651, 0x3FD0E15B4E1749CE,
+	0x3FEE5A9D550467D3, 0x3FD44310DC8936F0,
+	0xBFD44310DC8936F0, 0x3FEE5A9D550467D3,
+	0x3FDC997FC3865389, 0x3FECA08F19B9C449,
+	0xBFECA08F19B9C449, 0x3FDC997FC3865389,
+	0x3FE82A9C13F545FF, 0x3FE4F9CC25CCA486,
+	0xBFE4F9CC25CCA486, 0x3FE82A9C13F545FF,
+	0x3FB20C9674ED444D, 0x3FEFEB9D2530410F,
+	0xBFEFEB9D2530410F, 0x3FB20C9674ED444D,
+	0x3FEFE7EA85482D60, 0x3FB39D9F12C5A299,
+	0xBFB39D9F12C5A299, 0x3FEFE7EA85482D60,
+	0x3FE4D3BC6D589F7F, 0x3FE84B7111AF83FA,
+	0xBFE84B7111AF83FA, 0x3FE4D3BC6D589F7F,
+	0x3FEC89F587029C13, 0x3FDCF34BAEE1CD21,
+	0xBFDCF34BAEE1CD21, 0x3FEC89F587029C13,
+	0x3FD3E39BE96EC271, 0x3FEE6A61C55D53A7,
+	0xBFEE6A61C55D53A7, 0x3FD3E39BE96EC271,
+	0x3FEED0835E999009, 0x3FD1423EEFC69378,
+	0xBFD1423EEFC69378, 0x3FEED0835E999009,
+	0x3FDF5FDEE656CDA3, 0x3FEBE41B611154C1,
+	0xBFEBE41B611154C1, 0x3FDF5FDEE656CDA3,
+	0x3FE92AA41FC5A815, 0x3FE3C3C44981C518,
+	0xBFE3C3C44981C518, 0x3FE92AA41FC5A815,
+	0x3FBE8EB7FDE4AA3F, 0x3FEFC56E3B7D9AF6,
+	0xBFEFC56E3B7D9AF6, 0x3FBE8EB7FDE4AA3F,
+	0x3FEF830F4A40C60C, 0x3FC6451A831D830D,
+	0xBFC6451A831D830D, 0x3FEF830F4A40C60C,
+	0x3FE258734CBB7110, 0x3FEA38184A593BC6,
+	0xBFEA38184A593BC6, 0x3FE258734CBB7110,
+	0x3FEAFB8FD89F57B6, 0x3FE133E9CFEE254F,
+	0xBFE133E9CFEE254F, 0x3FEAFB8FD89F57B6,
+	0x3FCBA96334F15DAD, 0x3FEF3E6BBC1BBC65,
+	0xBFEF3E6BBC1BBC65, 0x3FCBA96334F15DAD,
+	0x3FEDD1FEF38A915A, 0x3FD73763C9261092,
+	0xBFD73763C9261092, 0x3FEDD1FEF38A915A,
+	0x3FD9C17D440DF9F2, 0x3FED4B5B1B187524,
+	0xBFED4B5B1B187524, 0x3FD9C17D440DF9F2,
+	0x3FE71BAC960E41BF, 0x3FE622E44FEC22FF,
+	0xBFE622E44FEC22FF, 0x3FE71BAC960E41BF,
+	0x3F95FD4D21FAB226, 0x3FEFFE1C6870CB77,
+	0xBFEFFE1C6870CB77, 0x3F95FD4D21FAB226,
+	0x3FEFFF0943C53BD1, 0x3F8F6A296AB997CB,
+	0xBF8F6A296AB997CB, 0x3FEFFF0943C53BD1,
+	0x3FE64715437F535B, 0x3FE6F8CA99C95B75,
+	0xBFE6F8CA99C95B75, 0x3FE64715437F535B,
+	0x3FED5F7172888A7F, 0x3FD96555B7AB948F,
+	0xBFD96555B7AB948F, 0x3FED5F7172888A7F,
+	0x3FD794F5E613DFAE, 0x3FEDBF9E4395759A,
+	0xBFEDBF9E4395759A, 0x3FD794F5E613DFAE,
+	0x3FEF492206BCABB4, 0x3FCAE4F1D5F3B9AB,
+	0xBFCAE4F1D5F3B9AB, 0x3FEF492206BCABB4,
+	0x3FE15E36E4DBE2BC, 0x3FEAE068F345ECEF,
+	0xBFEAE068F345ECEF, 0x3FE15E36E4DBE2BC,
+	0x3FEA54C91090F523, 0x3FE22F2D662C13E2,
+	0xBFE22F2D662C13E2, 0x3FEA54C91090F523,
+	0x3FC70AFD8D08C4FF, 0x3FEF7A299C1A322A,
+	0xBFEF7A299C1A322A, 0x3FC70AFD8D08C4FF,
+	0x3FEFCB4703914354, 0x3FBCFF533B307DC1,
+	0xBFBCFF533B307DC1, 0x3FEFCB4703914354,
+	0x3FE3EB33EABE0680, 0x3FE90B7943575EFE,
+	0xBFE90B7943575EFE, 0x3FE3EB33EABE0680,
+	0x3FEBFC9D25A1B147, 0x3FDF081906BFF7FE,
+	0xBFDF081906BFF7FE, 0x3FEBFC9D25A1B147,
+	0x3FD1A2F7FBE8F243, 0x3FEEC2CF4B1AF6B2,
+	0xBFEEC2CF4B1AF6B2, 0x3FD1A2F7FBE8F243,
+	0x3FEE79DB29A5165A, 0x3FD383F5E353B6AB,
+	0xBFD383F5E353B6AB, 0x3FEE79DB29A5165A,
+	0x3FDD4CD02BA8609D, 0x3FEC7315899EAAD7,
+	0xBFEC7315899EAAD7, 0x3FDD4CD02BA8609D,
+	0x3FE86C0A1D9AA195, 0x3FE4AD79516722F1,
+	0xBFE4AD79516722F1, 0x3FE86C0A1D9AA195,
+	0x3FB52E774A4D4D0A, 0x3FEFE3E92BE9D886,
+	0xBFEFE3E92BE9D886, 0x3FB52E774A4D4D0A,
+	0x3FEFEF0102826191, 0x3FB07B614E463064,
+	0xBFB07B614E463064, 0x3FEFEF0102826191,
+	0x3FE51FA81CD99AA6, 0x3FE8098B756E52FA,
+	0xBFE8098B756E52FA, 0x3FE51FA81CD99AA6,
+	0x3FECB6E20A00DA99, 0x3FDC3F6D47263129,
+	0xBFDC3F6D47263129, 0x3FECB6E20A00DA99,
+	0x3FD4A253D11B82F3, 0x3FEE4A8DFF81CE5E,
+	0xBFEE4A8DFF81CE5E, 0x3FD4A253D11B82F3,
+	0x3FEEEB074C50A544, 0x3FD0804E05EB661E,
+	0xBFD0804E05EB661E, 0x3FEEEB074C50A544,
+	0x3FE00740C82B82E1, 0x3FEBB249A0B6C40D,
+	0xBFEBB249A0B6C40D, 0x3FE00740C82B82E1,
+	0x3FE9683F42BD7FE1, 0x3FE374531B817F8D,
+	0xBFE374531B817F8D, 0x3FE9683F42BD7FE1,
+	0x3FC0D64DBCB26786, 0x3FEFB8D18D66ADB7,
+	0xBFEFB8D18D66ADB7, 0x3FC0D64DBCB26786,
+	0x3FEF93F14F85AC08, 0x3FC4B8B17F79FA88,
+	0xBFC4B8B17F79FA88, 0x3FEF93F14F85AC08,
+	0x3FE2AA76E87AEB58, 0x3FE9FDF4F13149DE,
+	0xBFE9FDF4F13149DE, 0x3FE2AA76E87AEB58,
+	0x3FEB3115A5F37BF3, 0x3FE0DED0B84BC4B6,
+	0xBFE0DED0B84BC4B6, 0x3FEB3115A5F37BF3,
+	0x3FCD31774D2CBDEE, 0x3FEF2817FC4609CE,
+	0xBFEF2817FC4609CE, 0x3FCD31774D2CBDEE,
+	0x3FEDF5E36A9BA59C, 0x3FD67B949CAD63CB,
+	0xBFD67B949CAD63CB, 0x3FEDF5E36A9BA59C,
+	0x3FDA790CD3DBF31B, 0x3FED2255C6E5A4E1,
+	0xBFED2255C6E5A4E1, 0x3FDA790CD3DBF31B,
+	0x3FE760C52C304764, 0x3FE5D9DEE73E345C,
+	0xBFE5D9DEE73E345C, 0x3FE760C52C304764,
+	0x3FA14685DB42C17F, 0x3FEFFB55E425FDAE,
+	0xBFEFFB55E425FDAE, 0x3FA14685DB42C17F,
+	0x3FEFF97C4208C014, 0x3FA46A396FF86179,
+	0xBFA46A396FF86179, 0x3FEFF97C4208C014,
+	0x3FE5B50B264F7448, 0x3FE782FB1B90B35B,
+	0xBFE782FB1B90B35B, 0x3FE5B50B264F7448,
+	0x3FED0D672F59D2B9, 0x3FDAD473125CDC09,
+	0xBFDAD473125CDC09, 0x3FED0D672F59D2B9,
+	0x3FD61D595C88C202, 0x3FEE0766D9280F54,
+	0xBFEE0766D9280F54, 0x3FD61D595C88C202,
+	0x3FEF1C7ABE284708, 0x3FCDF5163F01099A,
+	0xBFCDF5163F01099A, 0x3FEF1C7ABE284708,
+	0x3FE0B405878F85EC, 0x3FEB4B7409DE7925,
+	0xBFEB4B7409DE7925, 0x3FE0B405878F85EC,
+	0x3FE9E082EDB42472, 0x3FE2D333D34E9BB8,
+	0xBFE2D333D34E9BB8, 0x3FE9E082EDB42472,
+	0x3FC3F22F57DB4893, 0x3FEF9BED7CFBDE29,
+	0xBFEF9BED7CFBDE29, 0x3FC3F22F57DB4893,
+	0x3FEFB20DC681D54D, 0x3FC19D8940BE24E7,
+	0xBFC19D8940BE24E7, 0x3FEFB20DC681D54D,
+	0x3FE34C5252C14DE1, 0x3FE986AEF1457594,
+	0xBFE986AEF1457594, 0x3FE34C5252C14DE1,
+	0x3FEB98FA1FD9155E, 0x3FE032AE55EDBD96,
+	0xBFE032AE55EDBD96, 0x3FEB98FA1FD9155E,
+	0x3FD01F1806B9FDD2, 0x3FEEF7D6E51CA3C0,
+	0xBFEEF7D6E51CA3C0, 0x3FD01F1806B9FDD2,
+	0x3FEE3A33EC75CE85, 0x3FD50163DC197048,
+	0xBFD50163DC197048, 0x3FEE3A33EC75CE85,
+	0x3FDBE51517FFC0D9, 0x3FECCCEE20C2DEA0,
+	0xBFECCCEE20C2DEA0, 0x3FDBE51517FFC0D9,
+	0x3FE7E83F87B03686, 0x3FE5454FF5159DFC,
+	0xBFE5454FF5159DFC, 0x3FE7E83F87B03686,
+	0x3FADD406F9808EC9, 0x3FEFF21614E131ED,
+	0xBFEFF21614E131ED, 0x3FADD406F9808EC9,
+	0x3FEFDF9922F73307, 0x3FB6BF1B3E79B129,
+	0xBFB6BF1B3E79B129, 0x3FEFDF9922F73307,
+	0x3FE48703306091FF, 0x3FE88C66E7481BA1,
+	0xBFE88C66E7481BA1, 0x3FE48703306091FF,
+	0x3FEC5BEF59FEF85A, 0x3FDDA60C5CFA10D9,
+	0xBFDDA60C5CFA10D9, 0x3FEC5BEF59FEF85A,
+	0x3FD3241FB638BAAF, 0x3FEE89095BAD6025,
+	0xBFEE89095BAD6025, 0x3FD3241FB638BAAF,
+	0x3FEEB4CF515B8811, 0x3FD2038583D727BE,
+	0xBFD2038583D727BE, 0x3FEEB4CF515B8811,
+	0x3FDEB00695F25620, 0x3FEC14D9DC465E57,
+	0xBFEC14D9DC465E57, 0x3FDEB00695F25620,
+	0x3FE8EC109B486C49, 0x3FE41272663D108C,
+	0xBFE41272663D108C, 0x3FE8EC109B486C49,
+	0x3FBB6FA6EC38F64C, 0x3FEFD0D158D86087,
+	0xBFEFD0D158D86087, 0x3FBB6FA6EC38F64C,
+	0x3FEF70F6434B7EB7, 0x3FC7D0A7BBD2CB1C,
+	0xBFC7D0A7BBD2CB1C, 0x3FEF70F6434B7EB7,
+	0x3FE205BAA17560D6, 0x3FEA7138DE9D60F5,
+	0xBFEA7138DE9D60F5, 0x3FE205BAA17560D6,
+	0x3FEAC4FFBD3EFAC8, 0x3FE188591F3A46E5,
+	0xBFE188591F3A46E5, 0x3FEAC4FFBD3EFAC8,
+	0x3FCA203E1B1831DA, 0x3FEF538B1FAF2D07,
+	0xBFEF538B1FAF2D07, 0x3FCA203E1B1831DA,
+	0x3FEDACF42CE68AB9, 0x3FD7F24DD37341E4,
+	0xBFD7F24DD37341E4, 0x3FEDACF42CE68AB9,
+	0x3FD908EF81EF7BD1, 0x3FED733F508C0DFF,
+	0xBFED733F508C0DFF, 0x3FD908EF81EF7BD1,
+	0x3FE6D5AFEF4AAFCD, 0x3FE66B0F3F52B386,
+	0xBFE66B0F3F52B386, 0x3FE6D5AFEF4AAFCD,
+	0x3F82D96B0E509703, 0x3FEFFFA72C978C4F,
+	0xBFEFFFA72C978C4F, 0x3F82D96B0E509703,
+	0x3FEFFFA72C978C4F, 0x3F82D96B0E509703,
+	0xBF82D96B0E509703, 0x3FEFFFA72C978C4F,
+	0x3FE66B0F3F52B386, 0x3FE6D5AFEF4AAFCD,
+	0xBFE6D5AFEF4AAFCD, 0x3FE66B0F3F52B386,
+	0x3FED733F508C0DFF, 0x3FD908EF81EF7BD1,
+	0xBFD908EF81EF7BD1, 0x3FED733F508C0DFF,
+	0x3FD7F24DD37341E4, 0x3FEDACF42CE68AB9,
+	0xBFEDACF42CE68AB9, 0x3FD7F24DD37341E4,
+	0x3FEF538B1FAF2D07, 0x3FCA203E1B1831DA,
+	0xBFCA203E1B1831DA, 0x3FEF538B1FAF2D07,
+	0x3FE188591F3A46E5, 0x3FEAC4FFBD3EFAC8,
+	0xBFEAC4FFBD3EFAC8, 0x3FE188591F3A46E5,
+	0x3FEA7138DE9D60F5, 0x3FE205BAA17560D6,
+	0xBFE205BAA17560D6, 0x3FEA7138DE9D60F5,
+	0x3FC7D0A7BBD2CB1C, 0x3FEF70F6434B7EB7,
+	0xBFEF70F6434B7EB7, 0x3FC7D0A7BBD2CB1C,
+	0x3FEFD0D158D86087, 0x3FBB6FA6EC38F64C,
+	0xBFBB6FA6EC38F64C, 0x3FEFD0D158D86087,
+	0x3FE41272663D108C, 0x3FE8EC109B486C49,
+	0xBFE8EC109B486C49, 0x3FE41272663D108C,
+	0x3FEC14D9DC465E57, 0x3FDEB00695F25620,
+	0xBFDEB00695F25620, 0x3FEC14D9DC465E57,
+	0x3FD2038583D727BE, 0x3FEEB4CF515B8811,
+	0xBFEEB4CF515B8811, 0x3FD2038583D727BE,
+	0x3FEE89095BAD6025, 0x3FD3241FB638BAAF,
+	0xBFD3241FB638BAAF, 0x3FEE89095BAD6025,
+	0x3FDDA60C5CFA10D9, 0x3FEC5BEF59FEF85A,
+	0xBFEC5BEF59FEF85A, 0x3FDDA60C5CFA10D9,
+	0x3FE88C66E7481BA1, 0x3FE48703306091FF,
+	0xBFE48703306091FF, 0x3FE88C66E7481BA1,
+	0x3FB6BF1B3E79B129, 0x3FEFDF9922F73307,
+	0xBFEFDF9922F73307, 0x3FB6BF1B3E79B129,
+	0x3FEFF21614E131ED, 0x3FADD406F9808EC9,
+	0xBFADD406F9808EC9, 0x3FEFF21614E131ED,
+	0x3FE5454FF5159DFC, 0x3FE7E83F87B03686,
+	0xBFE7E83F87B03686, 0x3FE5454FF5159DFC,
+	0x3FECCCEE20C2DEA0, 0x3FDBE51517FFC0D9,
+	0xBFDBE51517FFC0D9, 0x3FECCCEE20C2DEA0,
+	0x3FD50163DC197048, 0x3FEE3A33EC75CE85,
+	0xBFEE3A33EC75CE85, 0x3FD50163DC197048,
+	0x3FEEF7D6E51CA3C0, 0x3FD01F1806B9FDD2,
+	0xBFD01F1806B9FDD2, 0x3FEEF7D6E51CA3C0,
+	0x3FE032AE55EDBD96, 0x3FEB98FA1FD9155E,
+	0xBFEB98FA1FD9155E, 0x3FE032AE55EDBD96,
+	0x3FE986AEF1457594, 0x3FE34C5252C14DE1,
+	0xBFE34C5252C14DE1, 0x3FE986AEF1457594,
+	0x3FC19D8940BE24E7, 0x3FEFB20DC681D54D,
+	0xBFEFB20DC681D54D, 0x3FC19D8940BE24E7,
+	0x3FEF9BED7CFBDE29, 0x3FC3F22F57DB4893,
+	0xBFC3F22F57DB4893, 0x3FEF9BED7CFBDE29,
+	0x3FE2D333D34E9BB8, 0x3FE9E082EDB42472,
+	0xBFE9E082EDB42472, 0x3FE2D333D34E9BB8,
+	0x3FEB4B7409DE7925, 0x3FE0B405878F85EC,
+	0xBFE0B405878F85EC, 0x3FEB4B7409DE7925,
+	0x3FCDF5163F01099A, 0x3FEF1C7ABE284708,
+	0xBFEF1C7ABE284708, 0x3FCDF5163F01099A,
+	0x3FEE0766D9280F54, 0x3FD61D595C88C202,
+	0xBFD61D595C88C202, 0x3FEE0766D9280F54,
+	0x3FDAD473125CDC09, 0x3FED0D672F59D2B9,
+	0xBFED0D672F59D2B9, 0x3FDAD473125CDC09,
+	0x3FE782FB1B90B35B, 0x3FE5B50B264F7448,
+	0xBFE5B50B264F7448, 0x3FE782FB1B90B35B,
+	0x3FA46A396FF86179, 0x3FEFF97C4208C014,
+	0xBFEFF97C4208C014, 0x3FA46A396FF86179,
+	0x3FEFFB55E425FDAE, 0x3FA14685DB42C17F,
+	0xBFA14685DB42C17F, 0x3FEFFB55E425FDAE,
+	0x3FE5D9DEE73E345C, 0x3FE760C52C304764,
+	0xBFE760C52C304764, 0x3FE5D9DEE73E345C,
+	0x3FED2255C6E5A4E1, 0x3FDA790CD3DBF31B,
+	0xBFDA790CD3DBF31B, 0x3FED2255C6E5A4E1,
+	0x3FD67B949CAD63CB, 0x3FEDF5E36A9BA59C,
+	0xBFEDF5E36A9BA59C, 0x3FD67B949CAD63CB,
+	0x3FEF2817FC4609CE, 0x3FCD31774D2CBDEE,
+	0xBFCD31774D2CBDEE, 0x3FEF2817FC4609CE,
+	0x3FE0DED0B84BC4B6, 0x3FEB3115A5F37BF3,
+	0xBFEB3115A5F37BF3, 0x3FE0DED0B84BC4B6,
+	0x3FE9FDF4F13149DE, 0x3FE2AA76E87AEB58,
+	0xBFE2AA76E87AEB58, 0x3FE9FDF4F13149DE,
+	0x3FC4B8B17F79FA88, 0x3FEF93F14F85AC08,
+	0xBFEF93F14F85AC08, 0x3FC4B8B17F79FA88,
+	0x3FEFB8D18D66ADB7, 0x3FC0D64DBCB26786,
+	0xBFC0D64DBCB26786, 0x3FEFB8D18D66ADB7,
+	0x3FE374531B817F8D, 0x3FE9683F42BD7FE1,
+	0xBFE9683F42BD7FE1, 0x3FE374531B817F8D,
+	0x3FEBB249A0B6C40D, 0x3FE00740C82B82E1,
+	0xBFE00740C82B82E1, 0x3FEBB249A0B6C40D,
+	0x3FD0804E05EB661E, 0x3FEEEB074C50A544,
+	0xBFEEEB074C50A544, 0x3FD0804E05EB661E,
+	0x3FEE4A8DFF81CE5E, 0x3FD4A253D11B82F3,
+	0xBFD4A253D11B82F3, 0x3FEE4A8DFF81CE5E,
+	0x3FDC3F6D47263129, 0x3FECB6E20A00DA99,
+	0xBFECB6E20A00DA99, 0x3FDC3F6D47263129,
+	0x3FE8098B756E52FA, 0x3FE51FA81CD99AA6,
+	0xBFE51FA81CD99AA6, 0x3FE8098B756E52FA,
+	0x3FB07B614E463064, 0x3FEFEF0102826191,
+	0xBFEFEF0102826191, 0x3FB07B614E463064,
+	0x3FEFE3E92BE9D886, 0x3FB52E774A4D4D0A,
+	0xBFB52E774A4D4D0A, 0x3FEFE3E92BE9D886,
+	0x3FE4AD79516722F1, 0x3FE86C0A1D9AA195,
+	0xBFE86C0A1D9AA195, 0x3FE4AD79516722F1,
+	0x3FEC7315899EAAD7, 0x3FDD4CD02BA8609D,
+	0xBFDD4CD02BA8609D, 0x3FEC7315899EAAD7,
+	0x3FD383F5E353B6AB, 0x3FEE79DB29A5165A,
+	0xBFEE79DB29A5165A, 0x3FD383F5E353B6AB,
+	0x3FEEC2CF4B1AF6B2, 0x3FD1A2F7FBE8F243,
+	0xBFD1A2F7FBE8F243, 0x3FEEC2CF4B1AF6B2,
+	0x3FDF081906BFF7FE, 0x3FEBFC9D25A1B147,
+	0xBFEBFC9D25A1B147, 0x3FDF081906BFF7FE,
+	0x3FE90B7943575EFE, 0x3FE3EB33EABE0680,
+	0xBFE3EB33EABE0680, 0x3FE90B7943575EFE,
+	0x3FBCFF533B307DC1, 0x3FEFCB4703914354,
+	0xBFEFCB4703914354, 0x3FBCFF533B307DC1,
+	0x3FEF7A299C1A322A, 0x3FC70AFD8D08C4FF,
+	0xBFC70AFD8D08C4FF, 0x3FEF7A299C1A322A,
+	0x3FE22F2D662C13E2, 0x3FEA54C91090F523,
+	0xBFEA54C91090F523, 0x3FE22F2D662C13E2,
+	0x3FEAE068F345ECEF, 0x3FE15E36E4DBE2BC,
+	0xBFE15E36E4DBE2BC, 0x3FEAE068F345ECEF,
+	0x3FCAE4F1D5F3B9AB, 0x3FEF492206BCABB4,
+	0xBFEF492206BCABB4, 0x3FCAE4F1D5F3B9AB,
+	0x3FEDBF9E4395759A, 0x3FD794F5E613DFAE,
+	0xBFD794F5E613DFAE, 0x3FEDBF9E4395759A,
+	0x3FD96555B7AB948F, 0x3FED5F7172888A7F,
+	0xBFED5F7172888A7F, 0x3FD96555B7AB948F,
+	0x3FE6F8CA99C95B75, 0x3FE64715437F535B,
+	0xBFE64715437F535B, 0x3FE6F8CA99C95B75,
+	0x3F8F6A296AB997CB, 0x3FEFFF0943C53BD1,
+	0xBFEFFF0943C53BD1, 0x3F8F6A296AB997CB,
+	0x3FEFFE1C6870CB77, 0x3F95FD4D21FAB226,
+	0xBF95FD4D21FAB226, 0x3FEFFE1C6870CB77,
+	0x3FE622E44FEC22FF, 0x3FE71BAC960E41BF,
+	0xBFE71BAC960E41BF, 0x3FE622E44FEC22FF,
+	0x3FED4B5B1B187524, 0x3FD9C17D440DF9F2,
+	0xBFD9C17D440DF9F2, 0x3FED4B5B1B187524,
+	0x3FD73763C9261092, 0x3FEDD1FEF38A915A,
+	0xBFEDD1FEF38A915A, 0x3FD73763C9261092,
+	0x3FEF3E6BBC1BBC65, 0x3FCBA96334F15DAD,
+	0xBFCBA96334F15DAD, 0x3FEF3E6BBC1BBC65,
+	0x3FE133E9CFEE254F, 0x3FEAFB8FD89F57B6,
+	0xBFEAFB8FD89F57B6, 0x3FE133E9CFEE254F,
+	0x3FEA38184A593BC6, 0x3FE258734CBB7110,
+	0xBFE258734CBB7110, 0x3FEA38184A593BC6,
+	0x3FC6451A831D830D, 0x3FEF830F4A40C60C,
+	0xBFEF830F4A40C60C, 0x3FC6451A831D830D,
+	0x3FEFC56E3B7D9AF6, 0x3FBE8EB7FDE4AA3F,
+	0xBFBE8EB7FDE4AA3F, 0x3FEFC56E3B7D9AF6,
+	0x3FE3C3C44981C518, 0x3FE92AA41FC5A815,
+	0xBFE92AA41FC5A815, 0x3FE3C3C44981C518,
+	0x3FEBE41B611154C1, 0x3FDF5FDEE656CDA3,
+	0xBFDF5FDEE656CDA3, 0x3FEBE41B611154C1,
+	0x3FD1423EEFC69378, 0x3FEED0835E999009,
+	0xBFEED0835E999009, 0x3FD1423EEFC69378,
+	0x3FEE6A61C55D53A7, 0x3FD3E39BE96EC271,
+	0xBFD3E39BE96EC271, 0x3FEE6A61C55D53A7,
+	0x3FDCF34BAEE1CD21, 0x3FEC89F587029C13,
+	0xBFEC89F587029C13, 0x3FDCF34BAEE1CD21,
+	0x3FE84B7111AF83FA, 0x3FE4D3BC6D589F7F,
+	0xBFE4D3BC6D589F7F, 0x3FE84B7111AF83FA,
+	0x3FB39D9F12C5A299, 0x3FEFE7EA85482D60,
+	0xBFEFE7EA85482D60, 0x3FB39D9F12C5A299,
+	0x3FEFEB9D2530410F, 0x3FB20C9674ED444D,
+	0xBFB20C9674ED444D, 0x3FEFEB9D2530410F,
+	0x3FE4F9CC25CCA486, 0x3FE82A9C13F545FF,
+	0xBFE82A9C13F545FF, 0x3FE4F9CC25CCA486,
+	0x3FECA08F19B9C449, 0x3FDC997FC3865389,
+	0xBFDC997FC3865389, 0x3FECA08F19B9C449,
+	0x3FD44310DC8936F0, 0x3FEE5A9D550467D3,
+	0xBFEE5A9D550467D3, 0x3FD44310DC8936F0,
+	0x3FEEDDEB6A078651, 0x3FD0E15B4E1749CE,
+	0xBFD0E15B4E1749CE, 0x3FEEDDEB6A078651,
+	0x3FDFB7575C24D2DE, 0x3FEBCB54CB0D2327,
+	0xBFEBCB54CB0D2327, 0x3FDFB7575C24D2DE,
+	0x3FE94990E3AC4A6C, 0x3FE39C23E3D63029,
+	0xBFE39C23E3D63029, 0x3FE94990E3AC4A6C,
+	0x3FC00EE8AD6FB85B, 0x3FEFBF470F0A8D88,
+	0xBFEFBF470F0A8D88, 0x3FC00EE8AD6FB85B,
+	0x3FEF8BA737CB4B78, 0x3FC57F008654CBDE,
+	0xBFC57F008654CBDE, 0x3FEF8BA737CB4B78,
+	0x3FE2818BEF4D3CBA, 0x3FEA1B26D2C0A75E,
+	0xBFEA1B26D2C0A75E, 0x3FE2818BEF4D3CBA,
+	0x3FEB16742A4CA2F5, 0x3FE1097248D0A957,
+	0xBFE1097248D0A957, 0x3FEB16742A4CA2F5,
+	0x3FCC6D90535D74DD, 0x3FEF33685A3AAEF0,
+	0xBFEF33685A3AAEF0, 0x3FCC6D90535D74DD,
+	0x3FEDE4160F6D8D81, 0x3FD6D998638A0CB6,
+	0xBFD6D998638A0CB6, 0x3FEDE4160F6D8D81,
+	0x3FDA1D6543B50AC0, 0x3FED36FC7BCBFBDC,
+	0xBFED36FC7BCBFBDC, 0x3FDA1D6543B50AC0,
+	0x3FE73E558E079942, 0x3FE5FE7CBDE56A10,
+	0xBFE5FE7CBDE56A10, 0x3FE73E558E079942,
+	0x3F9C454F4CE53B1D, 0x3FEFFCE09CE2A679,
+	0xBFEFFCE09CE2A679, 0x3F9C454F4CE53B1D,
+	0x3FEFF753BB1B9164, 0x3FA78DBAA5874686,
+	0xBFA78DBAA5874686, 0x3FEFF753BB1B9164,
+	0x3FE59001D5F723DF, 0x3FE7A4F707BF97D2,
+	0xBFE7A4F707BF97D2, 0x3FE59001D5F723DF,
+	0x3FECF830E8CE467B, 0x3FDB2F971DB31972,
+	0xBFDB2F971DB31972, 0x3FECF830E8CE467B,
+	0x3FD5BEE78B9DB3B6, 0x3FEE18A02FDC66D9,
+	0xBFEE18A02FDC66D9, 0x3FD5BEE78B9DB3B6,
+	0x3FEF1090BC898F5F, 0x3FCEB86B462DE348,
+	0xBFCEB86B462DE348, 0x3FEF1090BC898F5F,
+	0x3FE089112032B08C, 0x3FEB658F14FDBC47,
+	0xBFEB658F14FDBC47, 0x3FE089112032B08C,
+	0x3FE9C2D110F075C2, 0x3FE2FBC24B441015,
+	0xBFE2FBC24B441015, 0x3FE9C2D110F075C2,
+	0x3FC32B7BF94516A7, 0x3FEFA39BAC7A1791,
+	0xBFEFA39BAC7A1791, 0x3FC32B7BF94516A7,
+	0x3FEFAAFBCB0CFDDC, 0x3FC264994DFD3409,
+	0xBFC264994DFD3409, 0x3FEFAAFBCB0CFDDC,
+	0x3FE32421EC49A61F, 0x3FE9A4DFA42B06B2,
+	0xBFE9A4DFA42B06B2, 0x3FE32421EC49A61F,
+	0x3FEB7F6686E792E9, 0x3FE05DF3EC31B8B7,
+	0xBFE05DF3EC31B8B7, 0x3FEB7F6686E792E9,
+	0x3FCF7B7480BD3802, 0x3FEF045A14CF738C,
+	0xBFEF045A14CF738C, 0x3FCF7B7480BD3802,
+	0x3FEE298F4439197A, 0x3FD5604012F467B4,
+	0xBFD5604012F467B4, 0x3FEE298F4439197A,
+	0x3FDB8A7814FD5693, 0x3FECE2B32799A060,
+	0xBFECE2B32799A060, 0x3FDB8A7814FD5693,
+	0x3FE7C6B89CE2D333, 0x3FE56AC35197649F,
+	0xBFE56AC35197649F, 0x3FE7C6B89CE2D333,
+	0x3FAAB101BD5F8317, 0x3FEFF4DC54B1BED3,
+	0xBFEFF4DC54B1BED3, 0x3FAAB101BD5F8317,
+	0x3FEFDAFA7514538C, 0x3FB84F8712C130A1,
+	0xBFB84F8712C130A1, 0x3FEFDAFA7514538C,
+	0x3FE4605A692B32A2, 0x3FE8AC871EDE1D88,
+	0xBFE8AC871EDE1D88, 0x3FE4605A692B32A2,
+	0x3FEC44833141C004, 0x3FDDFEFF66A941DE,
+	0xBFDDFEFF66A941DE, 0x3FEC44833141C004,
+	0x3FD2C41A4E954520, 0x3FEE97EC36016B30,
+	0xBFEE97EC36016B30, 0x3FD2C41A4E954520,
+	0x3FEEA68393E65800, 0x3FD263E6995554BA,
+	0xBFD263E6995554BA, 0x3FEEA68393E65800,
+	0x3FDE57A86D3CD825, 0x3FEC2CD14931E3F1,
+	0xBFEC2CD14931E3F1, 0x3FDE57A86D3CD825,
+	0x3FE8CC6A75184655, 0x3FE4397F5B2A4380,
+	0xBFE4397F5B2A4380, 0x3FE8CC6A75184655,
+	0x3FB9DFB6EB24A85C, 0x3FEFD60D2DA75C9E,
+	0xBFEFD60D2DA75C9E, 0x3FB9DFB6EB24A85C,
+	0x3FEF677556883CEE, 0x3FC8961727C41804,
+	0xBFC8961727C41804, 0x3FEF677556883CEE,
+	0x3FE1DC1B64DC4872, 0x3FEA8D676E545AD2,
+	0xBFEA8D676E545AD2, 0x3FE1DC1B64DC4872,
+	0x3FEAA9547A2CB98E, 0x3FE1B250171373BF,
+	0xBFE1B250171373BF, 0x3FEAA9547A2CB98E,
+	0x3FC95B49E9B62AFA, 0x3FEF5DA6ED43685D,
+	0xBFEF5DA6ED43685D, 0x3FC95B49E9B62AFA,
+	0x3FED9A00DD8B3D46, 0x3FD84F6AAAF3903F,
+	0xBFD84F6AAAF3903F, 0x3FED9A00DD8B3D46,
+	0x3FD8AC4B86D5ED44, 0x3FED86C48445A44F,
+	0xBFED86C48445A44F, 0x3FD8AC4B86D5ED44,
+	0x3FE6B25CED2FE29C, 0x3FE68ED1EAA19C71,
+	0xBFE68ED1EAA19C71, 0x3FE6B25CED2FE29C,
+	0x3F6921F8BECCA4BA, 0x3FEFFFF621621D02,
+	0xBFEFFFF621621D02, 0x3F6921F8BECCA4BA,
+}
+
+// primes are the largest primes p < 2^31 with p = 1 mod 2048, in
+// decreasing order, each with a primitive 2048-th root of unity g modulo p,
+// and s = 2^31/(p_0·…·p_(k-1)) mod p, for the k previous primes p_i.
+var primes = [...]smallPrime{
+	{2147473409, 383167813, 10239},
+	{2147389441, 211808905, 471403745},
+	{2147387393, 37672282, 1329335065},
+	{2147377153, 1977035326, 968223422},
+	{2147358721, 1067163706, 132460015},
+	{2147352577, 1606082042, 598693809},
+	{2147346433, 2033915641, 1056257184},
+	{2147338241, 1653770625, 421286710},
+	{2147309569, 631200819, 1111201074},
+	{2147297281, 2038364663, 1042003613},
+	{2147295233, 1962540515, 19440033},
+	{2147239937, 2100082663, 353296760},
+	{2147235841, 1991153006, 1703918027},
+	{2147217409, 516405114, 1258919613},
+	{2147205121, 409347988, 1089726929},
+	{2147196929, 927788991, 1946238668},
+	{2147178497, 1136922411, 1347028164},
+	{2147100673, 868626236, 701164723},
+	{2147082241, 1897279176, 617820870},
+	{2147074049, 1888819123, 158382189},
+	{2147051521, 25006327, 522758543},
+	{2147043329, 327546255, 37227845},
+	{2147039233, 766324424, 1133356428},
+	{2146988033, 1862817362, 73861329},
+	{2146963457, 404622040, 653019435},
+	{2146959361, 1936581214, 995143093},
+	{2146938881, 1559770096, 634921513},
+	{2146908161, 422623708, 1985060172},
+	{2146885633, 1751189170, 298238186},
+	{2146871297, 578919515, 291810829},
+	{2146846721, 1114060353, 915902322},
+	{2146834433, 2069565474, 47859524},
+	{2146818049, 1552824584, 646281055},
+	{2146775041, 1906267847, 1597832891},
+	{2146756609, 1847414714, 1228090888},
+	{2146744321, 1818792070, 1176377637},
+	{2146738177, 1118066398, 1054971214},
+	{2146736129, 52057278, 933422153},
+	{2146713601, 592259376, 1406621510},
+	{2146695169, 263161877, 1514178701},
+	{2146656257, 685363115, 384505091},
+	{2146650113, 927727032, 537575289},
+	{2146646017, 52575506, 1799464037},
+	{2146643969, 1276803876, 1348954416},
+	{2146603009, 814028633, 1521547704},
+	{2146572289, 1846678872, 1310832121},
+	{2146547713, 919368090, 1019041349},
+	{2146508801, 671847612, 38582496},
+	{2146492417, 283911680, 532424562},
+	{2146490369, 1780044827, 896447978},
+	{2146459649, 327980850, 1327906900},
+	{2146447361, 1310561493, 958645253},
+	{2146441217, 412148926, 287271128},
+	{2146437121, 293186449, 2009822534},
+	{2146430977, 179034356, 1359155584},
+	{2146418689, 1517345488, 1790248672},
+	{2146406401, 1615820390, 1584833571},
+	{2146404353, 826651445, 607120498},
+	{2146379777, 3816988, 1897049071},
+	{2146363393, 1221409784, 1986921567},
+	{2146355201, 1388081168, 849968120},
+	{2146336769, 1803473237, 1655544036},
+	{2146312193, 1023484977, 273671831},
+	{2146293761, 1074591448, 467406983},
+	{2146283521, 831604668, 1523950494},
+	{2146203649, 712865423, 1170834574},
+	{2146154497, 1764991362, 1064856763},
+	{2146142209, 627386213, 1406840151},
+	{2146127873, 1638674429, 2088393537},
+	{2146099201, 1516001018, 690673370},
+	{2146093057, 1294931393, 315136610},
+	{2146091009, 1942399533, 973539425},
+	{2146078721, 1843461814, 2132275436},
+	{2146060289, 1098740778, 360423481},
+	{2146048001, 1617213232, 1951981294},
+	{2146041857, 1805783169, 2075683489},
+	{2146019329, 272027909, 1753219918},
+	{2145986561, 1206530344, 2034028118},
+	{2145976321, 1243769360, 1173377644},
+	{2145964033, 887200839, 1281344586},
+	{2145906689, 1651026455, 906178216},
+	{2145875969, 1673238256, 1043521212},
+	{2145871873, 1226591210, 1399796492},
+	{2145841153, 1465353397, 1324527802},
+	{2145832961, 1150638905, 554084759},
+	{2145816577, 221601706, 427340863},
+	{2145785857, 608896761, 316590738},
+	{2145755137, 1712054942, 1684294304},
+	{2145742849, 1302302867, 724873116},
+	{2145728513, 516717693, 431671476},
+	{2145699841, 524575579, 1619722537},
+	{2145691649, 1925625239, 982974435},
+	{2145687553, 463795662, 1293154300},
+	{2145673217, 771716636, 881778029},
+	{2145630209, 1509556977, 837364988},
+	{2145595393, 229091856, 851648427},
+	{2145587201, 1796903241, 635342424},
+	{2145525761, 715310882, 1677228081},
+	{2145495041, 1040930522, 200685896},
+	{2145466369, 949804237, 1809146322},
+	{2145445889, 1673903706, 95316881},
+	{2145390593, 806941852, 1428671135},
+	{2145372161, 1402525292, 159350694},
+	{2145361921, 2124760298, 1589134749},
+	{2145359873, 1217503067, 1561543010},
+	{2145355777, 338341402, 83865711},
+	{2145343489, 1381532164, 641430002},
+	{2145325057, 1883895478, 1528469895},
+	{2145318913, 1335370424, 65809740},
+	{2145312769, 2000008042, 1919775760},
+	{2145300481, 961450962, 1229540578},
+	{2145282049, 910466767, 1964062701},
+	{2145232897, 816527501, 450152063},
+	{2145218561, 1435128058, 1794509700},
+	{2145187841, 33505311, 1272467582},
+	{2145181697, 269767433, 1380363849},
+	{2145175553, 56386299, 1316870546},
+	{2145079297, 2106880293, 1391797340},
+	{2145021953, 1347906152, 720510798},
+	{2145015809, 206769262, 1651459955},
+	{2145003521, 1885513236, 1393381284},
+	{2144960513, 1810381315, 31937275},
+	{2144944129, 1306487838, 2019419520},
+	{2144935937, 37304730, 1841489054},
+	{2144894977, 1601434616, 157985831},
+	{2144888833, 98749330, 2128592228},
+	{2144880641, 1772327002, 2076128344},
+	{2144864257, 1404514762, 2029969964},
+	{2144827393, 801236594, 406627220},
+	{2144806913, 349217443, 1501080290},
+	{2144796673, 1542656776, 2084736519},
+	{2144778241, 1210734884, 1746416203},
+	{2144759809, 1146598851, 716464489},
+	{2144757761, 286328400, 1823728177},
+	{2144729089, 1347555695, 1836644881},
+	{2144727041, 1795703790, 520296412},
+	{2144696321, 1302475157, 852964281},
+	{2144667649, 1075877614, 504992927},
+	{2144573441, 198765808, 1617144982},
+	{2144555009, 321528767, 155821259},
+	{2144550913, 814139516, 1819937644},
+	{2144536577, 571143206, 962942255},
+	{2144524289, 1746733766, 2471321},
+	{2144512001, 1821415077, 124190939},
+	{2144468993, 917871546, 1260072806},
+	{2144458753, 378417981, 1569240563},
+	{2144421889, 175229668, 1825620763},
+	{2144409601, 1699216963, 351648117},
+	{2144370689, 1071885991, 958186029},
+	{2144348161, 1763151227, 540353574},
+	{2144335873, 1060214804, 919598847},
+	{2144329729, 663515846, 1448552668},
+	{2144327681, 1057776305, 590222840},
+	{2144309249, 1705149168, 1459294624},
+	{2144296961, 325823721, 1649016934},
+	{2144290817, 738775789, 447427206},
+	{2144243713, 962347618, 893050215},
+	{2144237569, 1655257077, 900860862},
+	{2144161793, 242206694, 1567868672},
+	{2144155649, 769415308, 1247993134},
+	{2144137217, 320492023, 515841070},
+	{2144120833, 1639388522, 770877302},
+	{2144071681, 1761785233, 964296120},
+	{2144065537, 419817825, 204564472},
+	{2144028673, 666050597, 2091019760},
+	{2144010241, 1413657615, 1518702610},
+	{2143952897, 1238327946, 475672271},
+	{2143940609, 307063413, 1176750846},
+	{2143918081, 2062905559, 786785803},
+	{2143899649, 1338112849, 1562292083},
+	{2143891457, 68149545, 87166451},
+	{2143885313, 921750778, 394460854},
+	{2143854593, 719766593, 133877196},
+	{2143836161, 1149399850, 1861591875},
+	{2143762433, 1848739366, 1335934145},
+	{2143756289, 1326674710, 102999236},
+	{2143713281, 808061791, 1156900308},
+	{2143690753, 388399459, 1926468019},
+	{2143670273, 1427891374, 1756689401},
+	{2143666177, 1912173949, 986629565},
+	{2143645697, 2041160111, 371842865},
+	{2143641601, 1279906897, 2023974350},
+	{2143635457, 720473174, 1389027526},
+	{2143621121, 1298309455, 1732632006},
+	{2143598593, 1548762216, 1825417506},
+	{2143567873, 620475784, 1073787233},
+	{2143561729, 1932954575, 949167309},
+	{2143553537, 354315656, 1652037534},
+	{2143541249, 577424288, 1097027618},
+	{2143531009, 357862822, 478640055},
+	{2143522817, 2017706025, 1550531668},
+	{2143506433, 2078127419, 1824320165},
+	{2143488001, 613475285, 1604011510},
+	{2143469569, 1466594987, 502095196},
+	{2143426561, 1115430331, 1044637111},
+	{2143383553, 9778045, 1902463734},
+	{2143377409, 1557401276, 2056861771},
+	{2143363073, 652036455, 1965915971},
+	{2143260673, 1464581171, 1523257541},
+	{2143246337, 1876119649, 764541916},
+	{2143209473, 1614992673, 1920672844},
+	{2143203329, 981052047, 2049774209},
+	{2143160321, 1847355533, 728535665},
+	{2143129601, 965558457, 603052992},
+	{2143123457, 2140817191, 8348679},
+	{2143100929, 1547263683, 694209023},
+	{2143092737, 643459066, 1979934533},
+	{2143082497, 188603778, 2026175670},
+	{2143062017, 1657329695, 377451099},
+	{2143051777, 114967950, 979255473},
+	{2143025153, 1698431342, 1449196896},
+	{2143006721, 1862741675, 1739650365},
+	{2142996481, 756660457, 996160050},
+	{2142976001, 927864010, 1166847574},
+	{2142965761, 905070557, 661974566},
+	{2142916609, 40932754, 1787161127},
+	{2142892033, 1987985648, 675335382},
+	{2142885889, 797497211, 1323096997},
+	{2142871553, 2068025830, 1411877159},
+	{2142861313, 1217177090, 1438410687},
+	{2142830593, 409906375, 1767860634},
+	{2142803969, 1197788993, 359782919},
+	{2142785537, 643817365, 513932862},
+	{2142779393, 1717046338, 218943121},
+	{2142724097, 89336830, 416687049},
+	{2142707713, 5944581, 1356813523},
+	{2142658561, 887942135, 2074011722},
+	{2142638081, 151851972, 1647339939},
+	{2142564353, 1691505537, 1483107336},
+	{2142533633, 1989920200, 1135938817},
+	{2142529537, 959263126, 1531961857},
+	{2142527489, 453251129, 1725566162},
+	{2142502913, 1536028102, 182053257},
+	{2142498817, 570138730, 701443447},
+	{2142416897, 326965800, 411931819},
+	{2142363649, 1675665410, 1517191733},
+	{2142351361, 968529566, 1575712703},
+	{2142330881, 1384953238, 1769087884},
+	{2142314497, 1977173242, 1833745524},
+	{2142289921, 95082313, 1714775493},
+	{2142283777, 109377615, 1070584533},
+	{2142277633, 16960510, 702157145},
+	{2142263297, 553850819, 431364395},
+	{2142208001, 241466367, 2053967982},
+	{2142164993, 1795661326, 1031836848},
+	{2142097409, 1212530046, 712772031},
+	{2142087169, 1763869720, 822276067},
+	{2142078977, 644065713, 1765268066},
+	{2142074881, 112671944, 643204925},
+	{2142044161, 1387785471, 1297890174},
+	{2142025729, 783885537, 1000425730},
+	{2142011393, 905662232, 1679401033},
+	{2141974529, 799788433, 468119557},
+	{2141943809, 1932544124, 449305555},
+	{2141933569, 1527403256, 841867925},
+	{2141931521, 1247076451, 743823916},
+	{2141902849, 1199660531, 401687910},
+	{2141890561, 150132350, 1720336972},
+	{2141857793, 1287438162, 663880489},
+	{2141833217, 618017731, 1819208266},
+	{2141820929, 999578638, 1403090096},
+	{2141786113, 81834325, 1523542501},
+	{2141771777, 120001928, 463556492},
+	{2141759489, 122455485, 2124928282},
+	{2141749249, 141986041, 940339153},
+	{2141685761, 889088734, 477141499},
+	{2141673473, 324212681, 1122558298},
+	{2141669377, 1175806187, 1373818177},
+	{2141655041, 1113654822, 296887082},
+	{2141587457, 991103258, 1585913875},
+	{2141583361, 1401451409, 1802457360},
+	{2141575169, 1571977166, 712760980},
+	{2141546497, 1107849376, 1250270109},
+	{2141515777, 196544219, 356001130},
+	{2141495297, 1733571506, 1060744866},
+	{2141483009, 321552363, 1168297026},
+	{2141458433, 505818251, 733225819},
+	{2141360129, 1026840098, 948342276},
+	{2141325313, 945133744, 2129965998},
+	{2141317121, 1871100260, 1843844634},
+	{2141286401, 1790639498, 1750465696},
+	{2141267969, 1376858592, 186160720},
+	{2141255681, 2129698296, 1876677959},
+	{2141243393, 2138900688, 1340009628},
+	{2141214721, 1933049835, 1087819477},
+	{2141212673, 1898664939, 1786328049},
+	{2141202433, 990234828, 940682169},
+	{2141175809, 1406392421, 993089586},
+	{2141165569, 1263518371, 289019479},
+	{2141073409, 1485624211, 507864514},
+	{2141052929, 1885134788, 311252465},
+	{2141040641, 1285021247, 280941862},
+	{2141028353, 1527610374, 375035110},
+	{2141011969, 1400626168, 164696620},
+	{2140999681, 632959608, 966175067},
+	{2140997633, 2045628978, 1290889438},
+	{2140993537, 1412755491, 375366253},
+	{2140942337, 719477232, 785367828},
+	{2140925953, 45224252, 836552317},
+	{2140917761, 1157376588, 1001839569},
+	{2140887041, 278480752, 2098732796},
+	{2140837889, 1663139953, 924094810},
+	{2140788737, 802501511, 2045368990},
+	{2140766209, 1820083885, 1800295504},
+	{2140764161, 1169561905, 2106792035},
+	{2140696577, 127781498, 1885987531},
+	{2140684289, 16014477, 1098116827},
+	{2140653569, 665960598, 1796728247},
+}
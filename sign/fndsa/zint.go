@@ -0,0 +1,528 @@
+package fndsa
+
+import "math/bits"
+
+// Arithmetic modulo the small primes of the residue number system (RNS),
+// and on big integers, used to solve the NTRU equation in constant time.
+// This follows the reference implementation.
+//
+// Big integers are slices of 31-bit words, in little-endian order. Signed
+// integers use two's complement, the sign being bit 30 of the last word.
+
+// smallPrime is an entry of the primes table.
+type smallPrime struct{ p, g, s uint32 }
+
+// modp holds the constants for computations modulo a small prime p. Values
+// are in [0, p), and multiplications use Montgomery representation with
+// R = 2^31.
+type modp struct {
+	p   uint32
+	p0i uint32 // -1/p mod 2^31
+	r2  uint32 // R² mod p
+}
+
+func newModp(p uint32) modp {
+	y := 2 - p
+	for range 4 {
+		y *= 2 - p*y
+	}
+	m := modp{p: p, p0i: 0x7FFFFFFF & -y}
+
+	// 2^32, squared five times, gives 2^63; halve it for 2^62 = R².
+	z := m.add(m.r(), m.r())
+	for range 5 {
+		z = m.mul(z, z)
+	}
+	m.r2 = (z + (p & -(z & 1))) >> 1
+	return m
+}
+
+// r returns R mod p.
+func (m modp) r() uint32 { return 1<<31 - m.p }
+
+// set returns x mod p, for -p < x < p.
+func (m modp) set(x int32) uint32 {
+	w := uint32(x)
+	return w + m.p&-(w>>31)
+}
+
+// norm returns x as a signed value in (-p/2, p/2].
+func (m modp) norm(x uint32) int32 {
+	return int32(x - (m.p & (((x - ((m.p + 1) >> 1)) >> 31) - 1)))
+}
+
+func (m modp) add(a, b uint32) uint32 {
+	d := a + b - m.p
+	return d + m.p&-(d>>31)
+}
+
+func (m modp) sub(a, b uint32) uint32 {
+	d := a - b
+	return d + m.p&-(d>>31)
+}
+
+// mul returns the Montgomery product a·b/R mod p.
+func (m modp) mul(a, b uint32) uint32 {
+	z := uint64(a) * uint64(b)
+	w := ((z * uint64(m.p0i)) & 0x7FFFFFFF) * uint64(m.p)
+	d := uint32((z+w)>>31) - m.p
+	return d + m.p&-(d>>31)
+}
+
+// rx returns 2^(31·x) mod p, for x > 0.
+func (m modp) rx(x int) uint32 {
+	x--
+	r := m.r2
+	z := m.r()
+	for i := 0; 1<<i <= x; i++ {
+		if x&(1<<i) != 0 {
+			z = m.mul(z, r)
+		}
+		r = m.mul(r, r)
+	}
+	return z
+}
+
+// div returns a/b mod p, or zero if b is zero. If a is in Montgomery
+// representation, so is the result.
+func (m modp) div(a, b uint32) uint32 {
+	e := m.p - 2
+	z := m.r()
+	for i := 30; i >= 0; i-- {
+		z = m.mul(z, z)
+		z2 := m.mul(z, b)
+		z ^= (z ^ z2) & -((e >> i) & 1)
+	}
+	z = m.mul(z, 1)
+	return m.mul(a, z)
+}
+
+// mkgm2 fills the tables of powers of g and 1/g, in Montgomery
+// representation and bit-reversed order, for an NTT of size 2^logn. The
+// root g is a primitive 2048-th root of unity.
+func (m modp) mkgm2(gm, igm []uint32, logn uint, g uint32) {
+	g = m.mul(g, m.r2)
+	for k := logn; k < 10; k++ {
+		g = m.mul(g, g)
+	}
+	ig := m.div(m.r2, g)
+	k := 10 - logn
+	x1, x2 := m.r(), m.r()
+	for u := range 1 << logn {
+		v := bits.Reverse16(uint16(u<<k)) >> 6
+		gm[v] = x1
+		igm[v] = x2
+		x1 = m.mul(x1, g)
+		x2 = m.mul(x2, ig)
+	}
+}
+
+// ntt converts a to NTT form in place. The coefficients are a[0],
+// a[stride], a[2·stride], ...
+func (m modp) ntt(a []uint32, stride int, gm []uint32, logn uint) {
+	n := 1 << logn
+	t := n
+	for k := 1; k < n; k <<= 1 {
+		ht := t >> 1
+		for u, v1 := 0, 0; u < k; u, v1 = u+1, v1+t {
+			s := gm[k+u]
+			r1 := v1 * stride
+			r2 := r1 + ht*stride
+			for range ht {
+				x := a[r1]
+				y := m.mul(a[r2], s)
+				a[r1] = m.add(x, y)
+				a[r2] = m.sub(x, y)
+				r1 += stride
+				r2 += stride
+			}
+		}
+		t = ht
+	}
+}
+
+// intt is the inverse of ntt.
+func (m modp) intt(a []uint32, stride int, igm []uint32, logn uint) {
+	if logn == 0 {
+		return
+	}
+	n := 1 << logn
+	t := 1
+	for k := n; k > 1; k >>= 1 {
+		hk := k >> 1
+		dt := t << 1
+		for u, v1 := 0, 0; u < hk; u, v1 = u+1, v1+dt {
+			s := igm[hk+u]
+			r1 := v1 * stride
+			r2 := r1 + t*stride
+			for range t {
+				x, y := a[r1], a[r2]
+				a[r1] = m.add(x, y)
+				a[r2] = m.mul(m.sub(x, y), s)
+				r1 += stride
+				r2 += stride
+			}
+		}
+		t = dt
+	}
+	ni := uint32(1) << (31 - logn)
+	for k := 0; k < n*stride; k += stride {
+		a[k] = m.mul(a[k], ni)
+	}
+}
+
+// polyRecRes replaces f, in NTT form of size 2^logn, with its field norm
+// N(f) = f(x)·f(-x), in NTT form of size 2^(logn-1).
+func (m modp) polyRecRes(f []uint32, logn uint) {
+	for u := range 1 << (logn - 1) {
+		w0, w1 := f[2*u], f[2*u+1]
+		f[u] = m.mul(m.mul(w0, w1), m.r2)
+	}
+}
+
+// zintSub sets a to a - b if ctl is 1, and leaves it unchanged if ctl is 0.
+// The returned value is the final borrow. a and b have the same length.
+func zintSub(a, b []uint32, ctl uint32) uint32 {
+	cc := uint32(0)
+	m := -ctl
+	for u := range a {
+		aw := a[u]
+		w := aw - b[u] - cc
+		cc = w >> 31
+		aw ^= ((w & 0x7FFFFFFF) ^ aw) & m
+		a[u] = aw
+	}
+	return cc
+}
+
+// zintMulSmall sets a to a·x, and returns the carry.
+func zintMulSmall(a []uint32, x uint32) uint32 {
+	cc := uint32(0)
+	for u := range a {
+		z := uint64(a[u])*uint64(x) + uint64(cc)
+		a[u] = uint32(z) & 0x7FFFFFFF
+		cc = uint32(z >> 31)
+	}
+	return cc
+}
+
+// zintModSmallUnsigned returns d mod p, for an unsigned d.
+func zintModSmallUnsigned(d []uint32, m modp) uint32 {
+	x := uint32(0)
+	for u := len(d) - 1; u >= 0; u-- {
+		x = m.mul(x, m.r2)
+		w := d[u] - m.p
+		w += m.p & -(w >> 31)
+		x = m.add(x, w)
+	}
+	return x
+}
+
+// zintModSmallSigned returns d mod p, for a signed d; rx must be
+// 2^(31·len(d)) mod p.
+func zintModSmallSigned(d []uint32, m modp, rx uint32) uint32 {
+	if len(d) == 0 {
+		return 0
+	}
+	z := zintModSmallUnsigned(d, m)
+	return m.sub(z, rx&-(d[len(d)-1]>>30))
+}
+
+// zintAddMulSmall sets x to x + y·s. x must have one more word than y,
+// which receives the carry.
+func zintAddMulSmall(x, y []uint32, s uint32) {
+	cc := uint32(0)
+	for u := range y {
+		z := uint64(y[u])*uint64(s) + uint64(x[u]) + uint64(cc)
+		x[u] = uint32(z) & 0x7FFFFFFF
+		cc = uint32(z >> 31)
+	}
+	x[len(y)] = cc
+}
+
+// zintNormZero normalizes x, an unsigned value modulo p, to a signed value
+// in (-p/2, p/2].
+func zintNormZero(x, p []uint32) {
+	// Compare x with (p-1)/2, from the top word down; r is -1, 0 or 1,
+	// and set by the first differing word.
+	r, bb := uint32(0), uint32(0)
+	for u := len(x) - 1; u >= 0; u-- {
+		wx := x[u]
+		wp := (p[u] >> 1) | (bb << 30)
+		bb = p[u] & 1
+		cc := wp - wx
+		cc = ((-cc) >> 31) | -(cc >> 31)
+		r |= cc & ((r & 1) - 1)
+	}
+	zintSub(x, p, r>>31)
+}
+
+// zintRebuildCRT rebuilds num integers of xlen words from their RNS
+// representation, in place. The k-th integer starts at xx[k·xstride], and
+// its i-th word is initially its value modulo the i-th prime. If signed is
+// true, the results are normalized to signed values.
+func zintRebuildCRT(xx []uint32, xlen, xstride, num int, signed bool) {
+	// tmp holds the product of the primes used so far.
+	tmp := make([]uint32, xlen)
+	tmp[0] = primes[0].p
+	for u := 1; u < xlen; u++ {
+		m := newModp(primes[u].p)
+		s := primes[u].s
+		for v := range num {
+			x := xx[v*xstride : v*xstride+xlen]
+			xp := x[u]
+			xq := zintModSmallUnsigned(x[:u], m)
+			xr := m.mul(s, m.sub(xp, xq))
+			zintAddMulSmall(x[:u+1], tmp[:u], xr)
+		}
+		tmp[u] = zintMulSmall(tmp[:u], primes[u].p)
+	}
+	if signed {
+		for v := range num {
+			zintNormZero(xx[v*xstride:v*xstride+xlen], tmp)
+		}
+	}
+}
+
+// zintNegate negates a if ctl is 1, and leaves it unchanged if ctl is 0.
+func zintNegate(a []uint32, ctl uint32) {
+	cc := ctl
+	m := -ctl >> 1
+	for u := range a {
+		aw := (a[u] ^ m) + cc
+		a[u] = aw & 0x7FFFFFFF
+		cc = aw >> 31
+	}
+}
+
+// zintCoReduce sets a to |(a·xa + b·xb)/2^31| and b to
+// |(a·ya + b·yb)/2^31|, where the divisions are exact. Bit 0 (resp. 1) of
+// the returned value is set if the first (resp. second) value was negated.
+func zintCoReduce(a, b []uint32, xa, xb, ya, yb int64) uint32 {
+	cca, ccb := int64(0), int64(0)
+	for u := range a {
+		wa, wb := uint64(a[u]), uint64(b[u])
+		za := wa*uint64(xa) + wb*uint64(xb) + uint64(cca)
+		zb := wa*uint64(ya) + wb*uint64(yb) + uint64(ccb)
+		if u > 0 {
+			a[u-1] = uint32(za) & 0x7FFFFFFF
+			b[u-1] = uint32(zb) & 0x7FFFFFFF
+		}
+		cca = int64(za) >> 31
+		ccb = int64(zb) >> 31
+	}
+	a[len(a)-1] = uint32(cca)
+	b[len(b)-1] = uint32(ccb)
+	nega := uint32(uint64(cca) >> 63)
+	negb := uint32(uint64(ccb) >> 63)
+	zintNegate(a, nega)
+	zintNegate(b, negb)
+	return nega | negb<<1
+}
+
+// zintFinishMod reduces a, in (-m, 2m), modulo m. The value is negative if
+// neg is 1.
+func zintFinishMod(a, m []uint32, neg uint32) {
+	// Compute a - m; the borrow tells whether a < m.
+	cc := uint32(0)
+	for u := range a {
+		cc = (a[u] - m[u] - cc) >> 31
+	}
+
+	// If neg is 1, add m; if neg is 0 and a >= m, subtract m.
+	xm := -neg >> 1
+	ym := -(neg | (1 - cc))
+	cc = neg
+	for u := range a {
+		mw := (m[u] ^ xm) & ym
+		aw := a[u] - mw - cc
+		a[u] = aw & 0x7FFFFFFF
+		cc = aw >> 31
+	}
+}
+
+// zintCoReduceMod sets a to (a·xa + b·xb)/2^31 mod m and b to
+// (a·ya + b·yb)/2^31 mod m, where m0i is -1/m mod 2^31.
+func zintCoReduceMod(a, b, m []uint32, m0i uint32, xa, xb, ya, yb int64) {
+	// fa and fb are chosen so that adding fa·m and fb·m makes the low
+	// words zero, hence the division by 2^31 exact.
+	cca, ccb := int64(0), int64(0)
+	fa := ((a[0]*uint32(xa) + b[0]*uint32(xb)) * m0i) & 0x7FFFFFFF
+	fb := ((a[0]*uint32(ya) + b[0]*uint32(yb)) * m0i) & 0x7FFFFFFF
+	for u := range a {
+		wa, wb := uint64(a[u]), uint64(b[u])
+		za := wa*uint64(xa) + wb*uint64(xb) + uint64(m[u])*uint64(fa) + uint64(cca)
+		zb := wa*uint64(ya) + wb*uint64(yb) + uint64(m[u])*uint64(fb) + uint64(ccb)
+		if u > 0 {
+			a[u-1] = uint32(za) & 0x7FFFFFFF
+			b[u-1] = uint32(zb) & 0x7FFFFFFF
+		}
+		cca = int64(za) >> 31
+		ccb = int64(zb) >> 31
+	}
+	a[len(a)-1] = uint32(cca)
+	b[len(b)-1] = uint32(ccb)
+	zintFinishMod(a, m, uint32(uint64(cca)>>63))
+	zintFinishMod(b, m, uint32(uint64(ccb)>>63))
+}
+
+// zintBezout computes u and v such that x·u - y·v = 1, with 0 <= u <= y
+// and 0 <= v <= x, using a binary GCD in constant time. x and y must be
+// odd; it returns false if they are not, or if they are not coprime.
+func zintBezout(u, v, x, y []uint32) bool {
+	n := len(x)
+	if n == 0 {
+		return false
+	}
+
+	// Invariants: a = x·u0 - y·v0 and b = x·u1 - y·v1, with a and b
+	// odd, starting from a = x and b = y.
+	u0, v0 := u, v
+	u1 := make([]uint32, n)
+	v1 := make([]uint32, n)
+	a := make([]uint32, n)
+	b := make([]uint32, n)
+	x0i := newModp(x[0]).p0i
+	y0i := newModp(y[0]).p0i
+	copy(a, x)
+	copy(b, y)
+	u0[0] = 1
+	clear(u0[1:])
+	clear(v0)
+	copy(u1, y)
+	copy(v1, x)
+	v1[0]--
+
+	// Each outer iteration performs 31 inner iterations on approximations
+	// of a and b, and reduces their total size by at least 30 bits.
+	for num := 62*n + 30; num >= 30; num -= 30 {
+		// Extract the top 62 bits of a and b, aligned on the largest of
+		// the two: a0 and a1 are the two top non-zero words, or zero.
+		c0, c1 := ^uint32(0), ^uint32(0)
+		var a0, a1, b0, b1 uint32
+		for j := n - 1; j >= 0; j-- {
+			aw, bw := a[j], b[j]
+			a0 ^= (a0 ^ aw) & c0
+			a1 ^= (a1 ^ aw) & c1
+			b0 ^= (b0 ^ bw) & c0
+			b1 ^= (b1 ^ bw) & c1
+			c1 = c0
+			c0 &= (((aw | bw) + 0x7FFFFFFF) >> 31) - 1
+		}
+
+		// If the top word was the lowest one, a1 and b1 are not set.
+		a1 |= a0 & c1
+		a0 &^= c1
+		b1 |= b0 & c1
+		b0 &^= c1
+		aHi := uint64(a0)<<31 + uint64(a1)
+		bHi := uint64(b0)<<31 + uint64(b1)
+		aLo, bLo := a[0], b[0]
+
+		// The binary GCD on the approximations yields the update
+		// factors: a' = (a·pa + b·pb)/2^31 and b' = (a·qa + b·qb)/2^31.
+		pa, pb, qa, qb := int64(1), int64(0), int64(0), int64(1)
+		for i := range 31 {
+			// rt is 1 if aHi > bHi.
+			rz := bHi - aHi
+			rt := uint32((rz ^ ((aHi ^ bHi) & (aHi ^ rz))) >> 63)
+
+			// If a and b are odd, subtract the smaller from the larger;
+			// then halve a if it is even, and b otherwise.
+			oa := (aLo >> i) & 1
+			ob := (bLo >> i) & 1
+			cAB := oa & ob & rt
+			cBA := oa & ob &^ rt
+			cA := cAB | (oa ^ 1)
+
+			aLo -= bLo & -cAB
+			aHi -= bHi & -uint64(cAB)
+			pa -= qa & -int64(cAB)
+			pb -= qb & -int64(cAB)
+			bLo -= aLo & -cBA
+			bHi -= aHi & -uint64(cBA)
+			qa -= pa & -int64(cBA)
+			qb -= pb & -int64(cBA)
+
+			aLo += aLo & (cA - 1)
+			pa += pa & (int64(cA) - 1)
+			pb += pb & (int64(cA) - 1)
+			aHi ^= (aHi ^ (aHi >> 1)) & -uint64(cA)
+			bLo += bLo & -cA
+			qa += qa & -int64(cA)
+			qb += qb & -int64(cA)
+			bHi ^= (bHi ^ (bHi >> 1)) & (uint64(cA) - 1)
+		}
+
+		// Apply the factors to a and b, and to the coefficients u and
+		// v, adjusting for the signs of the results.
+		r := zintCoReduce(a, b, pa, pb, qa, qb)
+		pa -= (pa + pa) & -int64(r&1)
+		pb -= (pb + pb) & -int64(r&1)
+		qa -= (qa + qa) & -int64(r>>1)
+		qb -= (qb + qb) & -int64(r>>1)
+		zintCoReduceMod(u0, u1, y, y0i, pa, pb, qa, qb)
+		zintCoReduceMod(v0, v1, x, x0i, pa, pb, qa, qb)
+	}
+
+	// a is now the GCD of x and y.
+	rc := a[0] ^ 1
+	for _, w := range a[1:] {
+		rc |= w
+	}
+	return (1-((rc|-rc)>>31))&x[0]&y[0]&1 == 1
+}
+
+// zintAddScaledMulSmall sets x to x + k·y·2^(31·sch+scl), truncated to the
+// length of x. y is signed, and sign-extended as needed.
+func zintAddScaledMulSmall(x, y []uint32, k int32, sch, scl uint32) {
+	if len(y) == 0 {
+		return
+	}
+	ysign := -(y[len(y)-1] >> 30) >> 1
+	tw := uint32(0)
+	cc := int32(0)
+	for u := int(sch); u < len(x); u++ {
+		// Get the next word of y·2^scl.
+		v := u - int(sch)
+		wy := ysign
+		if v < len(y) {
+			wy = y[v]
+		}
+		wys := ((wy << scl) & 0x7FFFFFFF) | tw
+		tw = wy >> (31 - scl)
+
+		z := uint64(int64(wys)*int64(k) + int64(x[u]) + int64(cc))
+		x[u] = uint32(z) & 0x7FFFFFFF
+		cc = int32(uint32(z >> 31))
+	}
+}
+
+// zintSubScaled sets x to x - y·2^(31·sch+scl), truncated to the length of
+// x. y is signed, and sign-extended as needed.
+func zintSubScaled(x, y []uint32, sch, scl uint32) {
+	if len(y) == 0 {
+		return
+	}
+	ysign := -(y[len(y)-1] >> 30) >> 1
+	tw := uint32(0)
+	cc := uint32(0)
+	for u := int(sch); u < len(x); u++ {
+		v := u - int(sch)
+		wy := ysign
+		if v < len(y) {
+			wy = y[v]
+		}
+		wys := ((wy << scl) & 0x7FFFFFFF) | tw
+		tw = wy >> (31 - scl)
+
+		w := x[u] - wys - cc
+		x[u] = w & 0x7FFFFFFF
+		cc = w >> 31
+	}
+}
+
+// zintOneToPlain returns the signed one-word integer x as an int32.
+func zintOneToPlain(x uint32) int32 {
+	return int32(x | (x&0x40000000)<<1)
+}
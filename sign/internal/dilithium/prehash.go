@@ -0,0 +1,36 @@
+package dilithium
+
+import (
+	"crypto"
+	"errors"
+
+	"github.com/cloudflare/circl/sign/internal/prehash"
+	"github.com/cloudflare/circl/xof"
+)
+
+// ErrPreHash is returned when the pre-hash function is not supported.
+var ErrPreHash = errors.New("sign/mldsa: invalid prehash function")
+
+// PreHash is a helper for hashing a message before signing with HashML-DSA,
+// see FIPS 204 -- Section 5.4.
+type PreHash = prehash.PreHash
+
+// NewPreHashWithHash returns a PreHash using either the SHA2 or SHA3 hash
+// functions. Returns ErrPreHash if the function is not supported.
+func NewPreHashWithHash(h crypto.Hash) (*PreHash, error) {
+	ph := prehash.NewWithHash(h)
+	if ph == nil {
+		return nil, ErrPreHash
+	}
+	return ph, nil
+}
+
+// NewPreHashWithXof returns a PreHash using either SHAKE-128 or SHAKE-256.
+// Returns ErrPreHash if the function is not supported.
+func NewPreHashWithXof(x xof.ID) (*PreHash, error) {
+	ph := prehash.NewWithXof(x)
+	if ph == nil {
+		return nil, ErrPreHash
+	}
+	return ph, nil
+}
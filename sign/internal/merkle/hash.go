@@ -0,0 +1,44 @@
+package merkle
+
+import (
+	"crypto/sha256"
+	"hash"
+
+	"github.com/cloudflare/circl/internal/sha3"
+)
+
+// Hash is SHA-256 or SHAKE256, with n-byte outputs. See Section 3 of
+// NIST SP 800-208.
+type Hash struct {
+	sha   hash.Hash
+	shake sha3.State
+	n     int
+	buf   [sha256.Size]byte
+}
+
+// NewSHA256 returns SHA-256 truncated to n bytes.
+func NewSHA256(n int) *Hash { return &Hash{sha: sha256.New(), n: n} }
+
+// NewSHAKE256 returns SHAKE256 with n-byte outputs.
+func NewSHAKE256(n int) *Hash { return &Hash{shake: sha3.NewShake256(), n: n} }
+
+// Size returns n.
+func (h *Hash) Size() int { return h.n }
+
+func (h *Hash) Write(p []byte) (int, error) {
+	if h.sha != nil {
+		return h.sha.Write(p)
+	}
+	return h.shake.Write(p)
+}
+
+// Final writes the n-byte hash of the input to out, and resets h.
+func (h *Hash) Final(out []byte) {
+	if h.sha != nil {
+		copy(out[:h.n], h.sha.Sum(h.buf[:0]))
+		h.sha.Reset()
+	} else {
+		_, _ = h.shake.Read(out[:h.n])
+		h.shake.Reset()
+	}
+}
@@ -0,0 +1,93 @@
+// Package merkle provides the hash functions and the Merkle trees of the
+// stateful hash-based signature schemes of NIST SP 800-208.
+//
+// The trees of LMS and XMSS have up to 2^25 and 2^20 leaves, so only their
+// top part is cached. SLH-DSA does not use this package: its trees have at
+// most 2^9 leaves and are recomputed on each signature, and its hashes take
+// compressed addresses.
+package merkle
+
+import "math/bits"
+
+// maxCachedHeight is the largest height of the part of a tree that is kept
+// in memory. The lower subtrees are recomputed when needed.
+const maxCachedHeight = 16
+
+// LeafFunc writes the leaf of the given index to out.
+type LeafFunc func(out []byte, index uint32)
+
+// NodeFunc writes to out the node of the given height and index, which has
+// children left and right. The height is at least one.
+type NodeFunc func(out []byte, height uint, index uint32, left, right []byte)
+
+// Tree is a Merkle tree of n-byte nodes. Its nodes of height at least cut are
+// stored, and those below are recomputed from the leaves when needed.
+type Tree struct {
+	n      int
+	height uint
+	cut    uint
+	leaf   LeafFunc
+	node   NodeFunc
+	// nodes holds the stored nodes, numbered from the root as 1, and with
+	// the children of node r numbered as 2r and 2r+1.
+	nodes []byte
+}
+
+// New computes the tree of the given height with n-byte nodes.
+func New(n int, height uint, leaf LeafFunc, node NodeFunc) *Tree {
+	t := &Tree{n: n, height: height, leaf: leaf, node: node}
+	t.cut = height - min(height, maxCachedHeight)
+	top := height - t.cut
+	t.nodes = make([]byte, (2<<top)*n)
+	for i := range uint32(1) << top {
+		sub := t.subtree(i)
+		copy(t.at(t.nodes, 1<<top+i), t.at(sub, 1))
+	}
+	t.fill(t.nodes, top, t.cut, 0)
+	return t
+}
+
+func (t *Tree) at(nodes []byte, r uint32) []byte {
+	return nodes[int(r)*t.n : int(r+1)*t.n]
+}
+
+// fill computes the internal nodes of a tree, whose leaves at height base
+// are set. The tree has the given height, and its root is the node of
+// index offset at height base+height.
+func (t *Tree) fill(nodes []byte, height, base uint, offset uint32) {
+	for r := uint32(1)<<height - 1; r > 0; r-- {
+		depth := uint(bits.Len32(r)) - 1
+		h := height - depth
+		index := offset<<depth + r - 1<<depth
+		t.node(t.at(nodes, r), base+h, index, t.at(nodes, 2*r), t.at(nodes, 2*r+1))
+	}
+}
+
+// subtree returns all the nodes of the subtree of height cut whose root has
+// index i, numbered as in Tree.nodes.
+func (t *Tree) subtree(i uint32) []byte {
+	sub := make([]byte, (2<<t.cut)*t.n)
+	for j := range uint32(1) << t.cut {
+		t.leaf(t.at(sub, 1<<t.cut+j), i<<t.cut+j)
+	}
+	t.fill(sub, t.cut, 0, i)
+	return sub
+}
+
+// Root returns the root of the tree.
+func (t *Tree) Root() []byte { return t.at(t.nodes, 1) }
+
+// AuthPath writes to out the authentication path of the leaf of the given
+// index, which is the sibling of each node on the path from the leaf to the
+// root, from the bottom.
+func (t *Tree) AuthPath(out []byte, leaf uint32) {
+	sub := t.subtree(leaf >> t.cut)
+	r := 1<<t.cut + leaf&(1<<t.cut-1)
+	for k := range t.cut {
+		copy(out[int(k)*t.n:], t.at(sub, r>>k^1))
+	}
+	r = 1<<(t.height-t.cut) + leaf>>t.cut
+	for k := t.cut; k < t.height; k++ {
+		copy(out[int(k)*t.n:], t.at(t.nodes, r>>(k-t.cut)^1))
+	}
+}
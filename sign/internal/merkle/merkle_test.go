@@ -0,0 +1,46 @@
+package merkle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"testing"
+)
+
+func testLeaf(out []byte, index uint32) {
+	s := sha256.Sum256(binary.BigEndian.AppendUint32(nil, index))
+	copy(out, s[:])
+}
+
+func testNode(out []byte, height uint, index uint32, left, right []byte) {
+	b := binary.BigEndian.AppendUint32(nil, uint32(height))
+	b = binary.BigEndian.AppendUint32(b, index)
+	s := sha256.Sum256(append(append(b, left...), right...))
+	copy(out, s[:])
+}
+
+func TestTree(t *testing.T) {
+	for _, height := range []uint{0, 1, 5, maxCachedHeight + 2} {
+		tree := New(32, height, testLeaf, testNode)
+		path := make([]byte, 32*height)
+		for _, leaf := range []uint32{0, 1, 1<<height/3 + 1, 1<<height - 1} {
+			leaf &= 1<<height - 1
+			tree.AuthPath(path, leaf)
+
+			// Recompute the root from the leaf and its path.
+			node := make([]byte, 32)
+			testLeaf(node, leaf)
+			for k := range height {
+				sibling := path[32*k : 32*k+32]
+				if leaf>>k&1 == 0 {
+					testNode(node, k+1, leaf>>(k+1), node, sibling)
+				} else {
+					testNode(node, k+1, leaf>>(k+1), sibling, node)
+				}
+			}
+			if !bytes.Equal(node, tree.Root()) {
+				t.Fatalf("height %v, leaf %v: root mismatch", height, leaf)
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Package prehash hashes messages before signing, as done by HashML-DSA
+// and HashSLH-DSA. See FIPS 204 -- Section 5.4 and FIPS 205 -- Section 10.2.
+package prehash
+
+import (
+	"crypto"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"hash"
+	"io"
+
+	"github.com/cloudflare/circl/xof"
+	_ "golang.org/x/crypto/sha3"
+)
+
+// PreHash hashes a message, which can be written in chunks, and returns its
+// digest prefixed with the OID of the hash function.
+type PreHash struct {
+	writer interface {
+		io.Writer
+		Reset()
+	}
+	size int
+	oid  byte
+}
+
+// NewWithHash returns a PreHash using either the SHA2 or SHA3 hash
+// functions, or nil if the function is not supported.
+func NewWithHash(h crypto.Hash) *PreHash {
+	hash2oid := [...]byte{
+		crypto.SHA256:     1,
+		crypto.SHA384:     2,
+		crypto.SHA512:     3,
+		crypto.SHA224:     4,
+		crypto.SHA512_224: 5,
+		crypto.SHA512_256: 6,
+		crypto.SHA3_224:   7,
+		crypto.SHA3_256:   8,
+		crypto.SHA3_384:   9,
+		crypto.SHA3_512:   10,
+	}
+
+	if int(h) >= len(hash2oid) || !h.Available() {
+		return nil
+	}
+
+	oid := hash2oid[h]
+	if oid == 0 {
+		return nil
+	}
+
+	return &PreHash{h.New(), h.Size(), oid}
+}
+
+// NewWithXof returns a PreHash using either SHAKE-128 or SHAKE-256, or nil
+// if the function is not supported.
+func NewWithXof(x xof.ID) *PreHash {
+	switch x {
+	case xof.SHAKE128:
+		return &PreHash{x.New(), 32, 11}
+	case xof.SHAKE256:
+		return &PreHash{x.New(), 64, 12}
+	default:
+		return nil
+	}
+}
+
+func (ph *PreHash) Reset()                      { ph.writer.Reset() }
+func (ph *PreHash) Write(b []byte) (int, error) { return ph.writer.Write(b) }
+
+// Digest returns the DER encoding of the OID of the pre-hash function
+// followed by the hash of the message written so far, and resets the
+// writer.
+func (ph *PreHash) Digest() ([]byte, error) {
+	// Source https://csrc.nist.gov/Projects/computer-security-objects-register/algorithm-registration
+	const oidLen = 11
+	oid := [oidLen]byte{
+		0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, ph.oid,
+	}
+
+	msg := make([]byte, oidLen+ph.size)
+	copy(msg, oid[:])
+	switch f := ph.writer.(type) {
+	case hash.Hash:
+		msg = f.Sum(msg[:oidLen])
+	case xof.XOF:
+		_, err := f.Read(msg[oidLen:])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ph.Reset()
+	return msg, nil
+}
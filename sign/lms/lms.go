@@ -0,0 +1,317 @@
+// Package lms implements the Leighton-Micali hash-based signatures (LMS)
+// and their multi-level variant (HSS), as specified in RFC 8554 and NIST
+// SP 800-208.
+//
+//   - https://www.rfc-editor.org/rfc/rfc8554
+//   - https://doi.org/10.6028/NIST.SP.800-208
+//
+// LMS and HSS are stateful: each signature uses a one-time key, which must
+// never be used again. Private keys are immutable, and the index of the next
+// unused one-time key is persisted in a [stateful.Store] by a [Signer].
+//
+// Child trees of HSS and the randomizers of signatures are derived from the
+// private key, as the private keys of LM-OTS in Appendix A of RFC 8554. So
+// the signatures of the public keys of child trees can be recomputed
+// instead of being stored.
+package lms
+
+import (
+	"bytes"
+	"crypto"
+	cryptoRand "crypto/rand"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/cloudflare/circl/sign/stateful"
+)
+
+// MaxLevels is the maximum number of levels of HSS.
+const MaxLevels = 8
+
+// ErrParam is returned on invalid or unsupported parameter sets.
+var ErrParam = errors.New("lms: invalid parameter set")
+
+// PublicKey is an HSS public key. An LMS public key is an HSS public key
+// with one level.
+type PublicKey struct {
+	levels uint32
+	pk     []byte // LMS public key of the top tree
+}
+
+// PrivateKey is an HSS private key. It does not hold the index of the next
+// unused one-time key, which is kept by a [Signer].
+type PrivateKey struct {
+	levels []Level
+	id     []byte // I of the top tree
+	seed   []byte // SEED of the top tree
+	pk     PublicKey
+}
+
+// GenerateKey generates an HSS key pair with the given levels, from the top
+// one, using entropy from rand. If rand is nil, crypto/rand.Reader is used.
+// This computes the whole top tree, which takes time for large heights.
+func GenerateKey(rand io.Reader, levels []Level) (*PublicKey, *PrivateKey, error) {
+	if len(levels) == 0 || len(levels) > MaxLevels {
+		return nil, nil, ErrParam
+	}
+	for _, l := range levels {
+		if _, _, ok := l.params(); !ok {
+			return nil, nil, ErrParam
+		}
+	}
+	lp, op, _ := levels[0].params()
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+	b := make([]byte, idSize+lp.m)
+	if _, err := io.ReadFull(rand, b); err != nil {
+		return nil, nil, err
+	}
+
+	sk := &PrivateKey{levels: slices.Clone(levels), id: b[:idSize], seed: b[idSize:]}
+	sk.pk = PublicKey{
+		levels: uint32(len(levels)),
+		pk:     newLMSKey(lp, op, sk.id, sk.seed).publicKey(),
+	}
+	pk := sk.pk
+	return &pk, sk, nil
+}
+
+// MarshalBinary returns u32str(L) || pub[0]. See Section 6.1 of RFC 8554.
+func (pk *PublicKey) MarshalBinary() ([]byte, error) {
+	return append(binary.BigEndian.AppendUint32(nil, pk.levels), pk.pk...), nil
+}
+
+// UnmarshalBinary decodes an HSS public key.
+func (pk *PublicKey) UnmarshalBinary(b []byte) error {
+	if len(b) < 4 {
+		return ErrParam
+	}
+	levels := binary.BigEndian.Uint32(b)
+	if levels == 0 || levels > MaxLevels {
+		return ErrParam
+	}
+	if _, _, ok := parsePublicKey(b[4:]); !ok {
+		return ErrParam
+	}
+	*pk = PublicKey{levels: levels, pk: bytes.Clone(b[4:])}
+	return nil
+}
+
+// Equal returns whether the two public keys are equal.
+func (pk *PublicKey) Equal(other crypto.PublicKey) bool {
+	o, ok := other.(*PublicKey)
+	return ok && pk.levels == o.levels && bytes.Equal(pk.pk, o.pk)
+}
+
+// Public returns the public key.
+func (sk *PrivateKey) Public() crypto.PublicKey { pk := sk.pk; return &pk }
+
+// Equal returns whether the two private keys are equal.
+func (sk *PrivateKey) Equal(other crypto.PrivateKey) bool {
+	o, ok := other.(*PrivateKey)
+	if !ok {
+		return false
+	}
+	a, _ := sk.MarshalBinary()
+	b, _ := o.MarshalBinary()
+	return subtle.ConstantTimeCompare(a, b) == 1
+}
+
+// MarshalBinary returns u32str(L) || (u32str(type) || u32str(otstype))^L ||
+// I || SEED || T[1], where I, SEED and T[1] are those of the top tree.
+func (sk *PrivateKey) MarshalBinary() ([]byte, error) {
+	b := binary.BigEndian.AppendUint32(nil, uint32(len(sk.levels)))
+	for _, l := range sk.levels {
+		b = binary.BigEndian.AppendUint32(b, uint32(l.LMS))
+		b = binary.BigEndian.AppendUint32(b, uint32(l.OTS))
+	}
+	b = append(b, sk.id...)
+	b = append(b, sk.seed...)
+	return append(b, sk.pk.pk[8+idSize:]...), nil
+}
+
+// UnmarshalBinary decodes a private key encoded by MarshalBinary.
+func (sk *PrivateKey) UnmarshalBinary(b []byte) error {
+	if len(b) < 4 {
+		return ErrParam
+	}
+	n := binary.BigEndian.Uint32(b)
+	if n == 0 || n > MaxLevels || len(b) < 4+8*int(n) {
+		return ErrParam
+	}
+	levels := make([]Level, n)
+	for i := range levels {
+		levels[i].LMS = LMSType(binary.BigEndian.Uint32(b[4+8*i:]))
+		levels[i].OTS = OTSType(binary.BigEndian.Uint32(b[8+8*i:]))
+		if _, _, ok := levels[i].params(); !ok {
+			return ErrParam
+		}
+	}
+	lp, _, _ := levels[0].params()
+	b = b[4+8*n:]
+	if len(b) != idSize+2*lp.m {
+		return ErrParam
+	}
+
+	pk := binary.BigEndian.AppendUint32(nil, uint32(levels[0].LMS))
+	pk = binary.BigEndian.AppendUint32(pk, uint32(levels[0].OTS))
+	pk = append(pk, b[:idSize]...)
+	pk = append(pk, b[idSize+lp.m:]...)
+	*sk = PrivateKey{
+		levels: levels,
+		id:     bytes.Clone(b[:idSize]),
+		seed:   bytes.Clone(b[idSize : idSize+lp.m]),
+		pk:     PublicKey{levels: n, pk: pk},
+	}
+	return nil
+}
+
+// keyID identifies the key in its state.
+func (sk *PrivateKey) keyID() []byte { b, _ := sk.pk.MarshalBinary(); return b }
+
+// InitState saves the initial state of a new private key in store. It fails
+// if store already has a state, so that a key in use cannot be reset.
+func (sk *PrivateKey) InitState(store stateful.Store) error {
+	return stateful.Init(store, sk.keyID())
+}
+
+// Signer signs messages with an HSS private key, using each one-time key at
+// most once. It is safe for concurrent use.
+type Signer struct {
+	mu      sync.Mutex
+	sk      *PrivateKey
+	counter *stateful.Counter
+	shifts  []uint    // Number of index bits of the levels below each level
+	trees   []*lmsKey // Current tree of each level
+	indices []uint64  // Index of the current tree of each level
+	signed  [][]byte  // Signed public key of each level but the top one
+}
+
+// NewSigner returns a signer for sk, whose state is kept in store. The state
+// must have been created with [PrivateKey.InitState].
+//
+// The signer reserves batch one-time keys at once in store. A larger batch
+// saves the state less often, but loses the reserved one-time keys that are
+// not used if the process stops.
+//
+// The signer keeps the tree of each level in memory, and computes it when
+// the first signature with this tree is made. A tree of height h takes
+// about 2^min(h, 16) nodes of memory.
+func NewSigner(sk *PrivateKey, store stateful.Store, batch uint64) (*Signer, error) {
+	height := uint(0)
+	shifts := make([]uint, len(sk.levels))
+	for i := len(sk.levels) - 1; i >= 0; i-- {
+		shifts[i] = height
+		height += sk.levels[i].LMS.params().h
+	}
+	limit := uint64(math.MaxUint64)
+	if height < 64 {
+		limit = 1 << height
+	}
+	c, err := stateful.NewCounter(store, sk.keyID(), limit, batch)
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{
+		sk: sk, counter: c, shifts: shifts,
+		trees:   make([]*lmsKey, len(sk.levels)),
+		indices: make([]uint64, len(sk.levels)),
+		signed:  make([][]byte, len(sk.levels)),
+	}, nil
+}
+
+// Public returns the public key.
+func (s *Signer) Public() crypto.PublicKey { return s.sk.Public() }
+
+// Remaining returns the number of signatures that can still be made.
+func (s *Signer) Remaining() uint64 { return s.counter.Remaining() }
+
+// Sign signs msg with an unused one-time key, which is saved as used before
+// signing. It implements [crypto.Signer], and rand is not used since
+// signing is deterministic. Pre-hashing is not supported, so
+// opts.HashFunc() must be zero.
+func (s *Signer) Sign(_ io.Reader, msg []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if opts != nil && opts.HashFunc() != crypto.Hash(0) {
+		return nil, errors.New("lms: cannot sign hashed message")
+	}
+	index, err := s.counter.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// See Algorithm 8 of RFC 8554.
+	last := len(s.trees) - 1
+	sig := binary.BigEndian.AppendUint32(nil, uint32(last))
+	for i := range s.trees {
+		if err := s.loadTree(i, index); err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			sig = append(sig, s.signed[i]...)
+		}
+	}
+	q := uint32(index>>s.shifts[last]) & (1<<s.trees[last].lms.h - 1)
+	return append(sig, s.trees[last].sign(q, msg)...), nil
+}
+
+// loadTree makes the tree of level i the one used by the one-time key of
+// the given index. The trees of the levels above must have been loaded.
+func (s *Signer) loadTree(i int, index uint64) error {
+	h := s.sk.levels[i].LMS.params().h
+	tree := index >> (s.shifts[i] + h)
+	if s.trees[i] != nil && s.indices[i] == tree {
+		return nil
+	}
+
+	lp, op, _ := s.sk.levels[i].params()
+	if i == 0 {
+		top := newLMSKey(lp, op, s.sk.id, s.sk.seed)
+		if !bytes.Equal(top.publicKey(), s.sk.pk.pk) {
+			return errors.New("lms: private key does not match its public key")
+		}
+		s.trees[0] = top
+		return nil
+	}
+	parent := s.trees[i-1]
+	q := uint32(tree) & (1<<parent.lms.h - 1)
+	id, seed := parent.child(q)
+	s.trees[i] = newLMSKey(lp, op, id, seed)
+	s.indices[i] = tree
+	pk := s.trees[i].publicKey()
+	s.signed[i] = append(parent.sign(q, pk), pk...)
+	return nil
+}
+
+// Verify returns whether sig is a valid HSS signature of msg under pk. See
+// Algorithm 7 of RFC 8554.
+func Verify(pk *PublicKey, msg, sig []byte) bool {
+	if len(sig) < 4 || binary.BigEndian.Uint32(sig)+1 != pk.levels {
+		return false
+	}
+	key, sig := pk.pk, sig[4:]
+	for range pk.levels - 1 {
+		n, ok := sigSize(sig)
+		if !ok || len(sig) < n+8 {
+			return false
+		}
+		lp := LMSType(binary.BigEndian.Uint32(sig[n:])).params()
+		if lp == nil || len(sig) < n+lp.publicKeySize() {
+			return false
+		}
+		next := sig[n : n+lp.publicKeySize()]
+		if !verifyLMS(key, next, sig[:n]) {
+			return false
+		}
+		key, sig = next, sig[n+lp.publicKeySize():]
+	}
+	return verifyLMS(key, msg, sig)
+}
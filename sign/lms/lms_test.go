@@ -0,0 +1,249 @@
+package lms
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/sha3"
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/stateful"
+)
+
+func TestParams(t *testing.T) {
+	// See Table 1 of RFC 8554, and Section 4.1 of NIST SP 800-208.
+	for _, tc := range []struct {
+		typ   OTSType
+		p     int
+		ls    uint
+		sigSz int
+	}{
+		{LMOTS_SHA256_N32_W1, 265, 7, 8516},
+		{LMOTS_SHA256_N32_W2, 133, 6, 4292},
+		{LMOTS_SHA256_N32_W4, 67, 4, 2180},
+		{LMOTS_SHA256_N32_W8, 34, 0, 1124},
+		{LMOTS_SHAKE_N24_W1, 200, 8, 4828},
+		{LMOTS_SHAKE_N24_W2, 101, 6, 2452},
+		{LMOTS_SHAKE_N24_W4, 51, 4, 1252},
+		{LMOTS_SHAKE_N24_W8, 26, 0, 652},
+	} {
+		p := tc.typ.params()
+		if p.p != tc.p || p.ls != tc.ls || p.sigSize() != tc.sigSz {
+			t.Fatalf("%v: got p=%v ls=%v size=%v", tc.typ, p.p, p.ls, p.sigSize())
+		}
+	}
+	test.CheckOk(LMS_SHAKE_M24_H20.String() == "LMS_SHAKE_M24_H20", "bad name", t)
+	test.CheckOk(LMOTS_SHA256_N24_W4.String() == "LMOTS_SHA256_N24_W4", "bad name", t)
+	test.CheckOk(LMSType(0).params() == nil && OTSType(17).params() == nil, "bad type", t)
+
+	_, _, err := GenerateKey(nil, []Level{{LMS_SHA256_M32_H5, LMOTS_SHAKE_N32_W4}})
+	test.CheckIsErr(t, err, "GenerateKey accepted different hash functions")
+	_, _, err = GenerateKey(nil, nil)
+	test.CheckIsErr(t, err, "GenerateKey accepted no levels")
+}
+
+// newSigner returns a signer for a new key, whose state is in a new file.
+func newSigner(t *testing.T, levels []Level, batch uint64) (*PublicKey, *PrivateKey, *Signer, stateful.Store) {
+	t.Helper()
+	pk, sk, err := GenerateKey(nil, levels)
+	test.CheckNoErr(t, err, "GenerateKey failed")
+	store := stateful.NewFileStore(filepath.Join(t.TempDir(), "state"))
+	test.CheckNoErr(t, sk.InitState(store), "InitState failed")
+	s, err := NewSigner(sk, store, batch)
+	test.CheckNoErr(t, err, "NewSigner failed")
+	return pk, sk, s, store
+}
+
+func TestSignVerify(t *testing.T) {
+	for _, levels := range [][]Level{
+		{{LMS_SHA256_M32_H5, LMOTS_SHA256_N32_W4}},
+		{{LMS_SHA256_M24_H5, LMOTS_SHA256_N24_W8}},
+		{{LMS_SHAKE_M32_H5, LMOTS_SHAKE_N32_W2}},
+		{{LMS_SHAKE_M24_H5, LMOTS_SHAKE_N24_W1}},
+		{{LMS_SHA256_M32_H5, LMOTS_SHA256_N32_W4}, {LMS_SHA256_M32_H5, LMOTS_SHA256_N32_W2}},
+		{
+			{LMS_SHAKE_M24_H5, LMOTS_SHAKE_N24_W4},
+			{LMS_SHAKE_M24_H5, LMOTS_SHAKE_N24_W4},
+			{LMS_SHAKE_M24_H5, LMOTS_SHAKE_N24_W4},
+		},
+	} {
+		t.Run(fmt.Sprint(levels), func(t *testing.T) {
+			pk, _, s, _ := newSigner(t, levels, 3)
+			count := 5
+			if len(levels) > 1 {
+				// Use several trees of the bottom level.
+				count = 40
+			}
+			for i := range count {
+				msg := []byte(fmt.Sprintf("message %v", i))
+				sig, err := s.Sign(nil, msg, crypto.Hash(0))
+				test.CheckNoErr(t, err, "Sign failed")
+				test.CheckOk(Verify(pk, msg, sig), "Verify failed", t)
+				test.CheckOk(!Verify(pk, msg[1:], sig), "Verify accepted other message", t)
+				test.CheckOk(!Verify(pk, msg, sig[:len(sig)-1]), "Verify accepted short signature", t)
+				test.CheckOk(!Verify(pk, msg, append(sig, 0)), "Verify accepted long signature", t)
+				for _, j := range []int{0, 3, 4, 8, 20, len(sig) / 2, len(sig) - 1} {
+					sig[j] ^= 1
+					test.CheckOk(!Verify(pk, msg, sig), "Verify accepted altered signature", t)
+					sig[j] ^= 1
+				}
+			}
+			_, err := s.Sign(nil, []byte("msg"), crypto.SHA256)
+			test.CheckIsErr(t, err, "Sign should reject hashed messages")
+		})
+	}
+}
+
+func TestKeys(t *testing.T) {
+	levels := []Level{{LMS_SHA256_M32_H5, LMOTS_SHA256_N32_W8}, {LMS_SHA256_M32_H5, LMOTS_SHA256_N32_W8}}
+	pk, sk, err := GenerateKey(nil, levels)
+	test.CheckNoErr(t, err, "GenerateKey failed")
+	test.CheckOk(pk.Equal(sk.Public()), "public key not equal", t)
+
+	ppk, err := pk.MarshalBinary()
+	test.CheckNoErr(t, err, "MarshalBinary failed")
+	test.CheckOk(len(ppk) == 4+8+16+32, "bad public key size", t)
+	psk, err := sk.MarshalBinary()
+	test.CheckNoErr(t, err, "MarshalBinary failed")
+
+	var pk2 PublicKey
+	var sk2 PrivateKey
+	test.CheckNoErr(t, pk2.UnmarshalBinary(ppk), "UnmarshalBinary failed")
+	test.CheckNoErr(t, sk2.UnmarshalBinary(psk), "UnmarshalBinary failed")
+	test.CheckOk(pk.Equal(&pk2), "public key not equal", t)
+	test.CheckOk(sk.Equal(&sk2), "private key not equal", t)
+	test.CheckOk(pk.Equal(sk2.Public()), "public key not equal", t)
+
+	for _, n := range []int{0, 4, len(ppk) - 1} {
+		test.CheckIsErr(t, pk2.UnmarshalBinary(ppk[:n]), "UnmarshalBinary accepted short key")
+		test.CheckIsErr(t, sk2.UnmarshalBinary(psk[:n]), "UnmarshalBinary accepted short key")
+	}
+	bad := append([]byte(nil), ppk...)
+	bad[3] = 9
+	test.CheckIsErr(t, pk2.UnmarshalBinary(bad), "UnmarshalBinary accepted 9 levels")
+	bad = append([]byte(nil), psk...)
+	bad[11] = 0x0F
+	test.CheckIsErr(t, sk2.UnmarshalBinary(bad), "UnmarshalBinary accepted different hash functions")
+
+	// A private key whose root is altered cannot sign.
+	bad = append([]byte(nil), psk...)
+	bad[len(bad)-1] ^= 1
+	test.CheckNoErr(t, sk2.UnmarshalBinary(bad), "UnmarshalBinary failed")
+	store := stateful.NewFileStore(filepath.Join(t.TempDir(), "state"))
+	test.CheckNoErr(t, sk2.InitState(store), "InitState failed")
+	s, err := NewSigner(&sk2, store, 1)
+	test.CheckNoErr(t, err, "NewSigner failed")
+	_, err = s.Sign(nil, []byte("msg"), nil)
+	test.CheckIsErr(t, err, "Sign should fail")
+}
+
+// leafIndex returns the index of the one-time key of an LMS signature.
+func leafIndex(sig []byte) uint32 { return binary.BigEndian.Uint32(sig[4:]) }
+
+func TestState(t *testing.T) {
+	levels := []Level{{LMS_SHA256_M32_H5, LMOTS_SHA256_N32_W8}}
+	pk, sk, s, store := newSigner(t, levels, 4)
+	used := make(map[uint32]bool)
+	sign := func(s *Signer) error {
+		sig, err := s.Sign(nil, []byte("msg"), nil)
+		if err != nil {
+			return err
+		}
+		test.CheckOk(Verify(pk, []byte("msg"), sig), "Verify failed", t)
+		q := leafIndex(sig)
+		test.CheckOk(!used[q], "one-time key reused", t)
+		used[q] = true
+		return nil
+	}
+
+	// The state cannot be reset.
+	err := sk.InitState(store)
+	test.CheckOk(errors.Is(err, stateful.ErrStateExists), "InitState reset the state", t)
+
+	// Simulate crashes by dropping signers.
+	for range 3 {
+		test.CheckNoErr(t, sign(s), "Sign failed")
+		s, err = NewSigner(sk, store, 4)
+		test.CheckNoErr(t, err, "NewSigner failed")
+	}
+	test.CheckOk(s.Remaining() == 32-12, "unexpected remaining signatures", t)
+
+	// Another key cannot use the state.
+	_, sk2, err := GenerateKey(nil, levels)
+	test.CheckNoErr(t, err, "GenerateKey failed")
+	_, err = NewSigner(sk2, store, 1)
+	test.CheckOk(errors.Is(err, stateful.ErrKeyMismatch), "NewSigner accepted another key", t)
+
+	// All one-time keys are used exactly once.
+	for s.Remaining() > 0 {
+		test.CheckNoErr(t, sign(s), "Sign failed")
+	}
+	err = sign(s)
+	test.CheckOk(errors.Is(err, stateful.ErrExhausted), "Sign should fail", t)
+}
+
+// Digests of the public key and 33 signatures of two-level keys, made by
+// this implementation. They only detect regressions.
+//
+// TODO crossreference with the test cases of Appendix F of RFC 8554 and the
+// ACVP vectors of NIST SP 800-208, which are not vendored.
+var sigDigests = map[LMSType]string{
+	LMS_SHA256_M32_H5: "5ab562819bb377a4f3b241f702e7badb0c423ef1c6fcbbf1425056c410e3fb6e",
+	LMS_SHA256_M24_H5: "56feebdb4ee7be60823578f32d71b99a45bc4b9005c719fd7b472d80f7d0d9d6",
+	LMS_SHAKE_M32_H5:  "d3c69b834d616dbaa5f498987e9cdee75adf8b900e38544f1ff85de7c8d1eb1b",
+	LMS_SHAKE_M24_H5:  "70fe5c142cb9e849094fd07011a2e47603ae09d63941258f4e4dedf3ae0c07e9",
+}
+
+func TestVectors(t *testing.T) {
+	for typ, want := range sigDigests {
+		lp := typ.params()
+		ots := OTSType(uint32(lp.hash)*4) + LMOTS_SHA256_N32_W4
+		levels := []Level{{typ, ots}, {typ, ots}}
+
+		rng := sha3.NewShake128()
+		pk, sk, err := GenerateKey(&rng, levels)
+		test.CheckNoErr(t, err, "GenerateKey failed")
+		store := stateful.NewFileStore(filepath.Join(t.TempDir(), "state"))
+		test.CheckNoErr(t, sk.InitState(store), "InitState failed")
+		s, err := NewSigner(sk, store, 10)
+		test.CheckNoErr(t, err, "NewSigner failed")
+
+		h := sha256.New()
+		ppk, _ := pk.MarshalBinary()
+		h.Write(ppk)
+		for i := range 33 {
+			sig, err := s.Sign(nil, []byte{byte(i)}, nil)
+			test.CheckNoErr(t, err, "Sign failed")
+			h.Write(sig)
+		}
+		if got := hex.EncodeToString(h.Sum(nil)); got != want {
+			t.Errorf("%v: got %v, want %v", typ, got, want)
+		}
+	}
+}
+
+func BenchmarkLMS(b *testing.B) {
+	levels := []Level{{LMS_SHA256_M32_H10, LMOTS_SHA256_N32_W8}}
+	pk, sk, _ := GenerateKey(nil, levels)
+	store := stateful.NewFileStore(filepath.Join(b.TempDir(), "state"))
+	_ = sk.InitState(store)
+	s, _ := NewSigner(sk, store, 1<<10)
+	msg := []byte("Alice and Bob")
+	sig, _ := s.Sign(nil, msg, nil)
+
+	b.Run("Sign", func(b *testing.B) {
+		for range min(b.N, 1000) {
+			_, _ = s.Sign(nil, msg, nil)
+		}
+	})
+	b.Run("Verify", func(b *testing.B) {
+		for range b.N {
+			_ = Verify(pk, msg, sig)
+		}
+	})
+}
@@ -0,0 +1,150 @@
+package lms
+
+import (
+	"encoding/binary"
+
+	"github.com/cloudflare/circl/sign/internal/merkle"
+)
+
+// LM-OTS one-time signatures. See Section 4 of RFC 8554.
+
+// Domain separation constants of Section 7.1 of RFC 8554.
+const (
+	dPBLC = 0x8080
+	dMESG = 0x8181
+	dLEAF = 0x8282
+	dINTR = 0x8383
+)
+
+// Indices used to derive values from SEED, which do not collide with those
+// of the private keys of LM-OTS.
+const (
+	seedIndexRandomizer = 0xFFFD
+	seedIndexChildSeed  = 0xFFFE
+	seedIndexChildI     = 0xFFFF
+)
+
+// idSize is the size of the identifier I of LMS trees.
+const idSize = 16
+
+func (p *otsParams) sigSize() int { return 4 + p.n*(p.p+1) }
+
+// fromSeed writes H(I || u32str(q) || u16str(i) || u8str(0xFF) || SEED) to
+// out. With i < p, this is the private key x_q[i], as in Appendix A of
+// RFC 8554.
+func fromSeed(h *merkle.Hash, out, id []byte, q uint32, i uint16, seed []byte) {
+	var b [idSize + 7]byte
+	copy(b[:], id)
+	binary.BigEndian.PutUint32(b[idSize:], q)
+	binary.BigEndian.PutUint16(b[idSize+4:], i)
+	b[idSize+6] = 0xFF
+	_, _ = h.Write(b[:])
+	_, _ = h.Write(seed)
+	h.Final(out)
+}
+
+// chain applies the chain function to tmp, from step start to step end-1,
+// for the chain i of the one-time key q.
+func (p *otsParams) chain(h *merkle.Hash, tmp, id []byte, q uint32, i int, start, end uint) {
+	var b [idSize + 7]byte
+	copy(b[:], id)
+	binary.BigEndian.PutUint32(b[idSize:], q)
+	binary.BigEndian.PutUint16(b[idSize+4:], uint16(i))
+	for j := start; j < end; j++ {
+		b[idSize+6] = byte(j)
+		_, _ = h.Write(b[:])
+		_, _ = h.Write(tmp)
+		h.Final(tmp)
+	}
+}
+
+// digits returns the Winternitz coefficients of Q || Cksm(Q). See
+// Section 4.4 of RFC 8554.
+func (p *otsParams) digits(q []byte) []uint {
+	mask := uint(1)<<p.w - 1
+	coef := func(s []byte, i int) uint {
+		bit := uint(i) * p.w
+		return uint(s[bit/8]>>(8-bit%8-p.w)) & mask
+	}
+
+	sum := uint(0)
+	for i := range 8 * p.n / int(p.w) {
+		sum += mask - coef(q, i)
+	}
+	s := binary.BigEndian.AppendUint16(q[:p.n:p.n], uint16(sum<<p.ls))
+	d := make([]uint, p.p)
+	for i := range d {
+		d[i] = coef(s, i)
+	}
+	return d
+}
+
+// msgHash returns Q = H(I || u32str(q) || u16str(D_MESG) || C || message).
+func (p *otsParams) msgHash(h *merkle.Hash, id []byte, q uint32, c, msg []byte) []byte {
+	var b [idSize + 6]byte
+	copy(b[:], id)
+	binary.BigEndian.PutUint32(b[idSize:], q)
+	binary.BigEndian.PutUint16(b[idSize+4:], dMESG)
+	_, _ = h.Write(b[:])
+	_, _ = h.Write(c)
+	_, _ = h.Write(msg)
+	out := make([]byte, p.n)
+	h.Final(out)
+	return out
+}
+
+// publicHash writes K = H(I || u32str(q) || u16str(D_PBLC) || z[0] || ...
+// || z[p-1]) to out, where z holds the ends of the chains.
+func publicHash(h *merkle.Hash, out, id []byte, q uint32, z []byte) {
+	var b [idSize + 6]byte
+	copy(b[:], id)
+	binary.BigEndian.PutUint32(b[idSize:], q)
+	binary.BigEndian.PutUint16(b[idSize+4:], dPBLC)
+	_, _ = h.Write(b[:])
+	_, _ = h.Write(z)
+	h.Final(out)
+}
+
+// publicKey writes the public key K of the one-time key q to out. See
+// Algorithm 1 of RFC 8554.
+func (p *otsParams) publicKey(h *merkle.Hash, out, id []byte, q uint32, seed []byte) {
+	z := make([]byte, p.p*p.n)
+	for i := range p.p {
+		tmp := z[i*p.n : (i+1)*p.n]
+		fromSeed(h, tmp, id, q, uint16(i), seed)
+		p.chain(h, tmp, id, q, i, 0, 1<<p.w-1)
+	}
+	publicHash(h, out, id, q, z)
+}
+
+// sign appends the signature of msg with the one-time key q to sig. The
+// randomizer C is derived from SEED, so signing the same message twice
+// gives the same signature. See Algorithm 3 of RFC 8554.
+func (p *otsParams) sign(h *merkle.Hash, sig, id []byte, q uint32, seed, msg []byte) []byte {
+	sig = binary.BigEndian.AppendUint32(sig, uint32(p.typ))
+	c := make([]byte, p.n)
+	fromSeed(h, c, id, q, seedIndexRandomizer, seed)
+	sig = append(sig, c...)
+
+	d := p.digits(p.msgHash(h, id, q, c, msg))
+	tmp := make([]byte, p.n)
+	for i := range p.p {
+		fromSeed(h, tmp, id, q, uint16(i), seed)
+		p.chain(h, tmp, id, q, i, 0, d[i])
+		sig = append(sig, tmp...)
+	}
+	return sig
+}
+
+// candidate computes the public key candidate Kc from a signature, whose
+// size and type must have been checked. See Algorithm 4b of RFC 8554.
+func (p *otsParams) candidate(h *merkle.Hash, out, id []byte, q uint32, msg, sig []byte) {
+	c, y := sig[4:4+p.n], sig[4+p.n:]
+	d := p.digits(p.msgHash(h, id, q, c, msg))
+	z := make([]byte, p.p*p.n)
+	copy(z, y)
+	for i := range p.p {
+		p.chain(h, z[i*p.n:(i+1)*p.n], id, q, i, d[i], 1<<p.w-1)
+	}
+	publicHash(h, out, id, q, z)
+}
@@ -0,0 +1,168 @@
+package lms
+
+import (
+	"fmt"
+
+	"github.com/cloudflare/circl/sign/internal/merkle"
+)
+
+// LMSType is the typecode of an LMS parameter set.
+type LMSType uint32
+
+// LMS parameter sets of RFC 8554 and NIST SP 800-208, with their typecodes.
+const (
+	LMS_SHA256_M32_H5  LMSType = 0x05
+	LMS_SHA256_M32_H10 LMSType = 0x06
+	LMS_SHA256_M32_H15 LMSType = 0x07
+	LMS_SHA256_M32_H20 LMSType = 0x08
+	LMS_SHA256_M32_H25 LMSType = 0x09
+	LMS_SHA256_M24_H5  LMSType = 0x0A
+	LMS_SHA256_M24_H10 LMSType = 0x0B
+	LMS_SHA256_M24_H15 LMSType = 0x0C
+	LMS_SHA256_M24_H20 LMSType = 0x0D
+	LMS_SHA256_M24_H25 LMSType = 0x0E
+	LMS_SHAKE_M32_H5   LMSType = 0x0F
+	LMS_SHAKE_M32_H10  LMSType = 0x10
+	LMS_SHAKE_M32_H15  LMSType = 0x11
+	LMS_SHAKE_M32_H20  LMSType = 0x12
+	LMS_SHAKE_M32_H25  LMSType = 0x13
+	LMS_SHAKE_M24_H5   LMSType = 0x14
+	LMS_SHAKE_M24_H10  LMSType = 0x15
+	LMS_SHAKE_M24_H15  LMSType = 0x16
+	LMS_SHAKE_M24_H20  LMSType = 0x17
+	LMS_SHAKE_M24_H25  LMSType = 0x18
+)
+
+// OTSType is the typecode of an LM-OTS parameter set.
+type OTSType uint32
+
+// LM-OTS parameter sets of RFC 8554 and NIST SP 800-208, with their
+// typecodes.
+const (
+	LMOTS_SHA256_N32_W1 OTSType = 0x01
+	LMOTS_SHA256_N32_W2 OTSType = 0x02
+	LMOTS_SHA256_N32_W4 OTSType = 0x03
+	LMOTS_SHA256_N32_W8 OTSType = 0x04
+	LMOTS_SHA256_N24_W1 OTSType = 0x05
+	LMOTS_SHA256_N24_W2 OTSType = 0x06
+	LMOTS_SHA256_N24_W4 OTSType = 0x07
+	LMOTS_SHA256_N24_W8 OTSType = 0x08
+	LMOTS_SHAKE_N32_W1  OTSType = 0x09
+	LMOTS_SHAKE_N32_W2  OTSType = 0x0A
+	LMOTS_SHAKE_N32_W4  OTSType = 0x0B
+	LMOTS_SHAKE_N32_W8  OTSType = 0x0C
+	LMOTS_SHAKE_N24_W1  OTSType = 0x0D
+	LMOTS_SHAKE_N24_W2  OTSType = 0x0E
+	LMOTS_SHAKE_N24_W4  OTSType = 0x0F
+	LMOTS_SHAKE_N24_W8  OTSType = 0x10
+)
+
+// hashFamily is one of the hash functions of NIST SP 800-208: SHA-256,
+// SHA-256/192, SHAKE256/256 or SHAKE256/192, in the order of typecodes.
+type hashFamily uint8
+
+func (f hashFamily) n() int       { return [...]int{32, 24, 32, 24}[f] }
+func (f hashFamily) name() string { return [...]string{"SHA256", "SHA256", "SHAKE", "SHAKE"}[f] }
+
+func (f hashFamily) new() *merkle.Hash {
+	if f < 2 {
+		return merkle.NewSHA256(f.n())
+	}
+	return merkle.NewSHAKE256(f.n())
+}
+
+// lmsParams are the parameters of an LMS tree. See Section 5.1 of RFC 8554.
+type lmsParams struct {
+	typ  LMSType
+	hash hashFamily
+	m    int  // Size of nodes
+	h    uint // Height of the tree
+}
+
+// otsParams are the parameters of LM-OTS. See Section 4.1 of RFC 8554.
+type otsParams struct {
+	typ  OTSType
+	hash hashFamily
+	n    int  // Size of hashes
+	w    uint // Number of bits of the Winternitz coefficients
+	p    int  // Number of chains
+	ls   uint // Left shift of the checksum
+}
+
+var (
+	allLMS [LMS_SHAKE_M24_H25 - LMS_SHA256_M32_H5 + 1]lmsParams
+	allOTS [LMOTS_SHAKE_N24_W8 - LMOTS_SHA256_N32_W1 + 1]otsParams
+)
+
+func init() {
+	for i := range allLMS {
+		f := hashFamily(i / 5)
+		allLMS[i] = lmsParams{
+			typ: LMS_SHA256_M32_H5 + LMSType(i), hash: f,
+			m: f.n(), h: uint(5 * (i%5 + 1)),
+		}
+	}
+
+	// See Appendix B of RFC 8554.
+	for i := range allOTS {
+		f := hashFamily(i / 4)
+		w := uint(1) << (i % 4)
+		u := (8*f.n() + int(w) - 1) / int(w)
+		bitLen := 0
+		for x := (1<<w - 1) * u; x > 0; x >>= 1 {
+			bitLen++
+		}
+		v := (bitLen + int(w) - 1) / int(w)
+		allOTS[i] = otsParams{
+			typ: LMOTS_SHA256_N32_W1 + OTSType(i), hash: f,
+			n: f.n(), w: w, p: u + v, ls: 16 - uint(v)*w,
+		}
+	}
+}
+
+func (t LMSType) params() *lmsParams {
+	if t < LMS_SHA256_M32_H5 || t > LMS_SHAKE_M24_H25 {
+		return nil
+	}
+	return &allLMS[t-LMS_SHA256_M32_H5]
+}
+
+func (t OTSType) params() *otsParams {
+	if t < LMOTS_SHA256_N32_W1 || t > LMOTS_SHAKE_N24_W8 {
+		return nil
+	}
+	return &allOTS[t-LMOTS_SHA256_N32_W1]
+}
+
+func (t LMSType) String() string {
+	p := t.params()
+	if p == nil {
+		return fmt.Sprintf("LMSType(%d)", uint32(t))
+	}
+	return fmt.Sprintf("LMS_%v_M%v_H%v", p.hash.name(), p.m, p.h)
+}
+
+func (t OTSType) String() string {
+	p := t.params()
+	if p == nil {
+		return fmt.Sprintf("OTSType(%d)", uint32(t))
+	}
+	return fmt.Sprintf("LMOTS_%v_N%v_W%v", p.hash.name(), p.n, p.w)
+}
+
+// Level is the parameter set of a level of HSS.
+type Level struct {
+	LMS LMSType
+	OTS OTSType
+}
+
+// params returns the parameters of the level, or false if they are not
+// supported. NIST SP 800-208 requires LMS and LM-OTS to use the same hash
+// function.
+func (l Level) params() (*lmsParams, *otsParams, bool) {
+	lp, op := l.LMS.params(), l.OTS.params()
+	if lp == nil || op == nil || lp.hash != op.hash {
+		return nil, nil, false
+	}
+	return lp, op, true
+}
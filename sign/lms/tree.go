@@ -0,0 +1,166 @@
+package lms
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/cloudflare/circl/sign/internal/merkle"
+)
+
+// LMS trees of one-time keys. See Section 5 of RFC 8554.
+
+func (p *lmsParams) publicKeySize() int { return 8 + idSize + p.m }
+
+func (p *lmsParams) sigSize(o *otsParams) int {
+	return 4 + o.sigSize() + 4 + int(p.h)*p.m
+}
+
+// lmsKey is an LMS private key, with the nodes of its tree.
+type lmsKey struct {
+	lms  *lmsParams
+	ots  *otsParams
+	id   []byte
+	seed []byte
+	h    *merkle.Hash
+	tree *merkle.Tree
+}
+
+// newLMSKey computes the tree of an LMS private key. See Algorithm 5 of
+// RFC 8554.
+func newLMSKey(lp *lmsParams, op *otsParams, id, seed []byte) *lmsKey {
+	k := &lmsKey{lms: lp, ots: op, id: id, seed: seed, h: lp.hash.new()}
+	k.tree = merkle.New(lp.m, lp.h, k.leaf, k.node)
+	return k
+}
+
+// leaf computes the node T[2^h+q] = H(I || u32str(2^h+q) || u16str(D_LEAF)
+// || K), where K is the public key of the one-time key q.
+func (k *lmsKey) leaf(out []byte, q uint32) {
+	pk := make([]byte, k.ots.n)
+	k.ots.publicKey(k.h, pk, k.id, q, k.seed)
+	leafHash(k.h, out, k.id, 1<<k.lms.h+q, pk)
+}
+
+// node computes the node T[r] = H(I || u32str(r) || u16str(D_INTR) ||
+// T[2r] || T[2r+1]).
+func (k *lmsKey) node(out []byte, height uint, index uint32, left, right []byte) {
+	interiorHash(k.h, out, k.id, 1<<(k.lms.h-height)+index, left, right)
+}
+
+func leafHash(h *merkle.Hash, out, id []byte, r uint32, pk []byte) {
+	var b [idSize + 6]byte
+	copy(b[:], id)
+	binary.BigEndian.PutUint32(b[idSize:], r)
+	binary.BigEndian.PutUint16(b[idSize+4:], dLEAF)
+	_, _ = h.Write(b[:])
+	_, _ = h.Write(pk)
+	h.Final(out)
+}
+
+func interiorHash(h *merkle.Hash, out, id []byte, r uint32, left, right []byte) {
+	var b [idSize + 6]byte
+	copy(b[:], id)
+	binary.BigEndian.PutUint32(b[idSize:], r)
+	binary.BigEndian.PutUint16(b[idSize+4:], dINTR)
+	_, _ = h.Write(b[:])
+	_, _ = h.Write(left)
+	_, _ = h.Write(right)
+	h.Final(out)
+}
+
+// publicKey returns u32str(type) || u32str(otstype) || I || T[1]. See
+// Section 5.3 of RFC 8554.
+func (k *lmsKey) publicKey() []byte {
+	b := make([]byte, 0, k.lms.publicKeySize())
+	b = binary.BigEndian.AppendUint32(b, uint32(k.lms.typ))
+	b = binary.BigEndian.AppendUint32(b, uint32(k.ots.typ))
+	b = append(b, k.id...)
+	return append(b, k.tree.Root()...)
+}
+
+// sign returns the signature of msg with the one-time key q. See
+// Section 5.4.1 of RFC 8554.
+func (k *lmsKey) sign(q uint32, msg []byte) []byte {
+	sig := make([]byte, 0, k.lms.sigSize(k.ots))
+	sig = binary.BigEndian.AppendUint32(sig, q)
+	sig = k.ots.sign(k.h, sig, k.id, q, k.seed, msg)
+	sig = binary.BigEndian.AppendUint32(sig, uint32(k.lms.typ))
+	path := make([]byte, int(k.lms.h)*k.lms.m)
+	k.tree.AuthPath(path, q)
+	return append(sig, path...)
+}
+
+// child derives the identifier and seed of the tree signed by the one-time
+// key q, in the next level of HSS.
+func (k *lmsKey) child(q uint32) (id, seed []byte) {
+	id, seed = make([]byte, k.lms.m), make([]byte, k.lms.m)
+	fromSeed(k.h, id, k.id, q, seedIndexChildI, k.seed)
+	fromSeed(k.h, seed, k.id, q, seedIndexChildSeed, k.seed)
+	return id[:idSize], seed
+}
+
+// parsePublicKey returns the parameters of an LMS public key, or false if
+// it is invalid.
+func parsePublicKey(pk []byte) (*lmsParams, *otsParams, bool) {
+	if len(pk) < 8 {
+		return nil, nil, false
+	}
+	lp, op, ok := Level{
+		LMS: LMSType(binary.BigEndian.Uint32(pk)),
+		OTS: OTSType(binary.BigEndian.Uint32(pk[4:])),
+	}.params()
+	if !ok || len(pk) != lp.publicKeySize() {
+		return nil, nil, false
+	}
+	return lp, op, true
+}
+
+// sigSize returns the size of the LMS signature at the start of sig, or
+// false if it is invalid.
+func sigSize(sig []byte) (int, bool) {
+	if len(sig) < 8 {
+		return 0, false
+	}
+	op := OTSType(binary.BigEndian.Uint32(sig[4:])).params()
+	if op == nil || len(sig) < 4+op.sigSize()+4 {
+		return 0, false
+	}
+	lp := LMSType(binary.BigEndian.Uint32(sig[4+op.sigSize():])).params()
+	if lp == nil || len(sig) < lp.sigSize(op) {
+		return 0, false
+	}
+	return lp.sigSize(op), true
+}
+
+// verifyLMS returns whether sig is a valid LMS signature of msg under pk.
+// See Algorithm 6a of RFC 8554.
+func verifyLMS(pk, msg, sig []byte) bool {
+	lp, op, ok := parsePublicKey(pk)
+	if !ok || len(sig) != lp.sigSize(op) ||
+		!bytes.Equal(sig[4:8], pk[4:8]) ||
+		!bytes.Equal(sig[4+op.sigSize():8+op.sigSize()], pk[:4]) {
+		return false
+	}
+	q := binary.BigEndian.Uint32(sig)
+	if q >= 1<<lp.h {
+		return false
+	}
+	id, root := pk[8:8+idSize], pk[8+idSize:]
+	path := sig[8+op.sigSize():]
+
+	h := lp.hash.new()
+	node := make([]byte, lp.m)
+	op.candidate(h, node, id, q, msg, sig[4:4+op.sigSize()])
+	r := 1<<lp.h + q
+	leafHash(h, node, id, r, node)
+	for i := 0; r > 1; i++ {
+		sibling := path[i*lp.m : (i+1)*lp.m]
+		if r&1 == 1 {
+			interiorHash(h, node, id, r/2, sibling, node)
+		} else {
+			interiorHash(h, node, id, r/2, node, sibling)
+		}
+		r /= 2
+	}
+	return bytes.Equal(node, root)
+}
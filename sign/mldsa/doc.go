@@ -5,6 +5,11 @@
 //
 //	github.com/cloudflare/circl/sign/mldsa/mldsa44
 //
+// Besides pure ML-DSA, each subpackage implements HashML-DSA, where the
+// message is hashed before signing (see NewPreHashWithHash), and signing
+// of an externally computed message representative μ (see ComputeMu and
+// SignMuTo), as defined in FIPS 204.
+//
 // If your choice for mode is fixed compile-time, use the subpackages.
 // To choose a scheme at runtime, use the generic signatures API under
 //
@@ -13,6 +13,7 @@ import (
 	"github.com/cloudflare/circl/sign"
 	common "github.com/cloudflare/circl/sign/internal/dilithium"
 	"github.com/cloudflare/circl/sign/mldsa/mldsa87/internal"
+	"github.com/cloudflare/circl/xof"
 )
 
 const (
@@ -27,6 +28,9 @@ const (
 
 	// Size of a signature
 	SignatureSize = internal.SignatureSize
+
+	// Size of the message representative μ
+	MuSize = 64
 )
 
 // PublicKey is the type of ML-DSA-87 public key
@@ -108,6 +112,203 @@ func unsafeVerifyInternal(pk *PublicKey, msg, sig []byte) bool {
 	)
 }
 
+// PreHash is a helper for hashing a message before signing with HashML-DSA.
+// It implements the io.Writer interface, so the message can be provided in
+// chunks before calling SignPreHashTo, VerifyPreHash, or ComputePreHashMu.
+type PreHash = common.PreHash
+
+// ErrPreHash is returned when the pre-hash function is not supported.
+var ErrPreHash = common.ErrPreHash
+
+// NewPreHashWithHash returns a PreHash using either the SHA2 or SHA3 hash
+// functions. Returns ErrPreHash if the function is not supported.
+func NewPreHashWithHash(h crypto.Hash) (*PreHash, error) {
+	return common.NewPreHashWithHash(h)
+}
+
+// NewPreHashWithXof returns a PreHash using either SHAKE-128 or SHAKE-256.
+// Returns ErrPreHash if the function is not supported.
+func NewPreHashWithXof(x xof.ID) (*PreHash, error) {
+	return common.NewPreHashWithXof(x)
+}
+
+// messagePrime returns the formatted message M' of FIPS 204 -- Algorithm 2
+// and Algorithm 4, where preHash is 0 for pure ML-DSA and 1 for HashML-DSA.
+func messagePrime(preHash byte, ctx, msg []byte) (func(io.Writer), error) {
+	if len(ctx) > 255 {
+		return nil, sign.ErrContextTooLong
+	}
+	return func(w io.Writer) {
+		_, _ = w.Write([]byte{preHash, byte(len(ctx))})
+		_, _ = w.Write(ctx)
+		_, _ = w.Write(msg)
+	}, nil
+}
+
+// preHashMessagePrime returns the formatted message M' of HashML-DSA for
+// the message hashed by ph, and resets ph.
+func preHashMessagePrime(ph *PreHash, ctx []byte) (func(io.Writer), error) {
+	digest, err := ph.Digest()
+	if err != nil {
+		return nil, err
+	}
+	return messagePrime(1, ctx, digest)
+}
+
+func randomness(randomized bool) (rnd [32]byte, err error) {
+	if randomized {
+		_, err = cryptoRand.Read(rnd[:])
+	}
+	return rnd, err
+}
+
+// SignPreHashTo signs the message hashed by ph with HashML-DSA, and writes
+// the signature into sig. It resets ph, and will panic if sig is not of
+// length at least SignatureSize.
+//
+// ctx is the optional context string. Errors if ctx is larger than 255 bytes.
+func SignPreHashTo(sk *PrivateKey, ph *PreHash, ctx []byte, randomized bool, sig []byte) error {
+	rnd, err := randomness(randomized)
+	if err != nil {
+		return err
+	}
+	msg, err := preHashMessagePrime(ph, ctx)
+	if err != nil {
+		return err
+	}
+	internal.SignTo((*internal.PrivateKey)(sk), msg, rnd, sig)
+	return nil
+}
+
+// VerifyPreHash checks whether the given HashML-DSA signature by pk on the
+// message hashed by ph is valid. It resets ph.
+//
+// ctx is the optional context string. Fails if ctx is larger than 255 bytes.
+func VerifyPreHash(pk *PublicKey, ph *PreHash, ctx, sig []byte) bool {
+	msg, err := preHashMessagePrime(ph, ctx)
+	if err != nil {
+		return false
+	}
+	return internal.Verify((*internal.PublicKey)(pk), msg, sig)
+}
+
+// ComputeMu returns the message representative μ of the pure ML-DSA
+// signatures by pk on msg with context ctx, as in FIPS 204 -- Algorithm 7,
+// line 6. It allows μ to be computed apart from the private key, which
+// then signs it with SignMuTo ("external μ").
+//
+// ctx is the optional context string. Errors if ctx is larger than 255 bytes.
+func ComputeMu(pk *PublicKey, msg, ctx []byte) (mu [MuSize]byte, err error) {
+	m, err := messagePrime(0, ctx, msg)
+	if err != nil {
+		return mu, err
+	}
+	internal.ComputeMu((*internal.PublicKey)(pk), m, &mu)
+	return mu, nil
+}
+
+// ComputePreHashMu returns the message representative μ of the HashML-DSA
+// signatures by pk on the message hashed by ph with context ctx. It resets
+// ph.
+//
+// ctx is the optional context string. Errors if ctx is larger than 255 bytes.
+func ComputePreHashMu(pk *PublicKey, ph *PreHash, ctx []byte) (mu [MuSize]byte, err error) {
+	m, err := preHashMessagePrime(ph, ctx)
+	if err != nil {
+		return mu, err
+	}
+	internal.ComputeMu((*internal.PublicKey)(pk), m, &mu)
+	return mu, nil
+}
+
+// SignMuTo signs the message representative μ, as returned by ComputeMu or
+// ComputePreHashMu for the public key of sk, and writes the signature into
+// sig. It will panic if sig is not of length at least SignatureSize.
+func SignMuTo(sk *PrivateKey, mu *[MuSize]byte, randomized bool, sig []byte) error {
+	rnd, err := randomness(randomized)
+	if err != nil {
+		return err
+	}
+	internal.SignMuTo((*internal.PrivateKey)(sk), mu, rnd, sig)
+	return nil
+}
+
+// VerifyMu checks whether the given signature by pk on the message
+// representative μ is valid.
+func VerifyMu(pk *PublicKey, mu *[MuSize]byte, sig []byte) bool {
+	return internal.VerifyMu((*internal.PublicKey)(pk), mu, sig)
+}
+
+// Signer signs a message written to it in chunks, such that the message
+// does not need to be held in memory.
+type Signer struct {
+	sk         *PrivateKey
+	h          *internal.MuHash
+	randomized bool
+}
+
+// NewSigner returns a Signer for messages signed by sk with context ctx.
+// The signatures are the same as those of SignTo.
+//
+// ctx is the optional context string. Errors if ctx is larger than 255 bytes.
+func NewSigner(sk *PrivateKey, ctx []byte, randomized bool) (*Signer, error) {
+	if len(ctx) > 255 {
+		return nil, sign.ErrContextTooLong
+	}
+	h := (*internal.PrivateKey)(sk).MuHash()
+	_, _ = h.Write([]byte{0, byte(len(ctx))})
+	_, _ = h.Write(ctx)
+	return &Signer{sk, h, randomized}, nil
+}
+
+// Write appends p to the message. It never returns an error.
+func (s *Signer) Write(p []byte) (int, error) { return s.h.Write(p) }
+
+// SignTo signs the message written so far, and writes the signature into
+// sig. It will panic if sig is not of length at least SignatureSize.
+func (s *Signer) SignTo(sig []byte) error {
+	var mu [MuSize]byte
+	rnd, err := randomness(s.randomized)
+	if err != nil {
+		return err
+	}
+	s.h.Sum(&mu)
+	internal.SignMuTo((*internal.PrivateKey)(s.sk), &mu, rnd, sig)
+	return nil
+}
+
+// Verifier verifies signatures of a message written to it in chunks, such
+// that the message does not need to be held in memory.
+type Verifier struct {
+	pk *PublicKey
+	h  *internal.MuHash
+}
+
+// NewVerifier returns a Verifier for messages signed by pk with context
+// ctx.
+//
+// ctx is the optional context string. Errors if ctx is larger than 255 bytes.
+func NewVerifier(pk *PublicKey, ctx []byte) (*Verifier, error) {
+	if len(ctx) > 255 {
+		return nil, sign.ErrContextTooLong
+	}
+	h := (*internal.PublicKey)(pk).MuHash()
+	_, _ = h.Write([]byte{0, byte(len(ctx))})
+	_, _ = h.Write(ctx)
+	return &Verifier{pk, h}, nil
+}
+
+// Write appends p to the message. It never returns an error.
+func (v *Verifier) Write(p []byte) (int, error) { return v.h.Write(p) }
+
+// Verify checks whether the given signature of the message written so far
+// is valid.
+func (v *Verifier) Verify(sig []byte) bool {
+	var mu [64]byte
+	v.h.Sum(&mu)
+	return internal.VerifyMu((*internal.PublicKey)(v.pk), &mu, sig)
+}
+
 // Verify checks whether the given signature by pk on msg is valid.
 //
 // ctx is the optional context string. Fails if ctx is larger than 255 bytes.
@@ -9,12 +9,15 @@
 //	Dilithium
 //	ML-DSA
 //	SLH-DSA
+//	FN-DSA (Falcon)
+//	Composite ML-DSA
 package schemes
 
 import (
 	"strings"
 
 	"github.com/cloudflare/circl/sign"
+	"github.com/cloudflare/circl/sign/composite"
 	dilithium2 "github.com/cloudflare/circl/sign/dilithium/mode2"
 	dilithium3 "github.com/cloudflare/circl/sign/dilithium/mode3"
 	dilithium5 "github.com/cloudflare/circl/sign/dilithium/mode5"
@@ -22,6 +25,7 @@ import (
 	"github.com/cloudflare/circl/sign/ed448"
 	"github.com/cloudflare/circl/sign/eddilithium2"
 	"github.com/cloudflare/circl/sign/eddilithium3"
+	"github.com/cloudflare/circl/sign/fndsa"
 	"github.com/cloudflare/circl/sign/mldsa/mldsa44"
 	"github.com/cloudflare/circl/sign/mldsa/mldsa65"
 	"github.com/cloudflare/circl/sign/mldsa/mldsa87"
@@ -51,6 +55,24 @@ var allSchemes = [...]sign.Scheme{
 	slhdsa.SHAKE_256s.Scheme(),
 	slhdsa.SHA2_256f.Scheme(),
 	slhdsa.SHAKE_256f.Scheme(),
+	fndsa.Falcon512.Scheme(),
+	fndsa.Falcon1024.Scheme(),
+	composite.MLDSA44RSA2048PSS(),
+	composite.MLDSA44RSA2048PKCS15(),
+	composite.MLDSA44Ed25519(),
+	composite.MLDSA44P256(),
+	composite.MLDSA65RSA3072PSS(),
+	composite.MLDSA65RSA3072PKCS15(),
+	composite.MLDSA65RSA4096PSS(),
+	composite.MLDSA65RSA4096PKCS15(),
+	composite.MLDSA65P256(),
+	composite.MLDSA65P384(),
+	composite.MLDSA65Ed25519(),
+	composite.MLDSA87P384(),
+	composite.MLDSA87Ed448(),
+	composite.MLDSA87RSA3072PSS(),
+	composite.MLDSA87RSA4096PSS(),
+	composite.MLDSA87P521(),
 }
 
 var allSchemeNames map[string]sign.Scheme
@@ -2,9 +2,11 @@ package schemes_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/cloudflare/circl/sign"
+	"github.com/cloudflare/circl/sign/composite"
 	"github.com/cloudflare/circl/sign/schemes"
 )
 
@@ -41,7 +43,16 @@ func TestApi(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			if len(packedSk) != scheme.PrivateKeySize() {
+			// Composite schemes with RSA or ECDSA embed DER encoded RSA
+			// private keys and ECDSA signatures, which have variable length,
+			// so their sizes are upper bounds. The sizes of the composites
+			// with Ed25519 or Ed448 are fixed.
+			_, isComposite := pk.(*composite.PublicKey)
+			isVariable := isComposite && (strings.Contains(scheme.Name(), "-RSA") ||
+				strings.Contains(scheme.Name(), "-ECDSA-"))
+
+			if len(packedSk) > scheme.PrivateKeySize() ||
+				(!isVariable && len(packedSk) != scheme.PrivateKeySize()) {
 				t.Fatal()
 			}
 
@@ -70,7 +81,8 @@ func TestApi(t *testing.T) {
 			}
 			sig := scheme.Sign(sk, msg, opts)
 
-			if scheme.SignatureSize() != len(sig) {
+			if len(sig) > scheme.SignatureSize() ||
+				(!isVariable && len(sig) != scheme.SignatureSize()) {
 				t.Fatal()
 			}
 
@@ -134,6 +146,24 @@ func Example() {
 	// SLH-DSA-SHAKE-256s
 	// SLH-DSA-SHA2-256f
 	// SLH-DSA-SHAKE-256f
+	// Falcon-512
+	// Falcon-1024
+	// MLDSA44-RSA2048-PSS-SHA256
+	// MLDSA44-RSA2048-PKCS15-SHA256
+	// MLDSA44-Ed25519-SHA512
+	// MLDSA44-ECDSA-P256-SHA256
+	// MLDSA65-RSA3072-PSS-SHA512
+	// MLDSA65-RSA3072-PKCS15-SHA512
+	// MLDSA65-RSA4096-PSS-SHA512
+	// MLDSA65-RSA4096-PKCS15-SHA512
+	// MLDSA65-ECDSA-P256-SHA512
+	// MLDSA65-ECDSA-P384-SHA512
+	// MLDSA65-Ed25519-SHA512
+	// MLDSA87-ECDSA-P384-SHA512
+	// MLDSA87-Ed448-SHAKE256
+	// MLDSA87-RSA3072-PSS-SHA512
+	// MLDSA87-RSA4096-PSS-SHA512
+	// MLDSA87-ECDSA-P521-SHA512
 }
 
 func BenchmarkGenerateKeyPair(b *testing.B) {
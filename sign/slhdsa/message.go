@@ -2,11 +2,9 @@ package slhdsa
 
 import (
 	"crypto"
-	"hash"
-	"io"
 
+	"github.com/cloudflare/circl/sign/internal/prehash"
 	"github.com/cloudflare/circl/xof"
-	_ "golang.org/x/crypto/sha3"
 )
 
 // [PreHash] is a helper for hashing a message before signing.
@@ -14,84 +12,39 @@ import (
 // in chunks before calling the [SignDeterministic], [SignRandomized], or
 // [Verify] functions.
 // Pre-hash must not be used for generating pure signatures.
-type PreHash struct {
-	writer interface {
-		io.Writer
-		Reset()
-	}
-	size int
-	oid  byte
-}
+type PreHash struct{ ph *prehash.PreHash }
 
 // [NewPreHashWithHash] is used to prehash messages using either the SHA2 or
 // SHA3 hash functions.
 // Returns [ErrPreHash] if the function is not supported.
 func NewPreHashWithHash(h crypto.Hash) (*PreHash, error) {
-	hash2oid := [...]byte{
-		crypto.SHA256:     1,
-		crypto.SHA384:     2,
-		crypto.SHA512:     3,
-		crypto.SHA224:     4,
-		crypto.SHA512_224: 5,
-		crypto.SHA512_256: 6,
-		crypto.SHA3_224:   7,
-		crypto.SHA3_256:   8,
-		crypto.SHA3_384:   9,
-		crypto.SHA3_512:   10,
-	}
-
-	if int(h) >= len(hash2oid) {
-		return nil, ErrPreHash
-	}
-
-	oid := hash2oid[h]
-	if oid == 0 {
+	ph := prehash.NewWithHash(h)
+	if ph == nil {
 		return nil, ErrPreHash
 	}
-
-	return &PreHash{h.New(), h.Size(), oid}, nil
+	return &PreHash{ph}, nil
 }
 
 // [NewPreHashWithXof] is used to prehash messages using either SHAKE-128
 // or SHAKE-256.
 // Returns [ErrPreHash] if the function is not supported.
 func NewPreHashWithXof(x xof.ID) (*PreHash, error) {
-	switch x {
-	case xof.SHAKE128:
-		return &PreHash{x.New(), 32, 11}, nil
-	case xof.SHAKE256:
-		return &PreHash{x.New(), 64, 12}, nil
-	default:
+	ph := prehash.NewWithXof(x)
+	if ph == nil {
 		return nil, ErrPreHash
 	}
+	return &PreHash{ph}, nil
 }
 
-func (ph *PreHash) Reset()                      { ph.writer.Reset() }
-func (ph *PreHash) Write(b []byte) (int, error) { return ph.writer.Write(b) }
+func (ph *PreHash) Reset()                      { ph.ph.Reset() }
+func (ph *PreHash) Write(b []byte) (int, error) { return ph.ph.Write(b) }
 
 // BuildMessage returns a [Message] for signing, and resets the writer.
 func (ph *PreHash) BuildMessage() (*Message, error) {
-	// Source https://csrc.nist.gov/Projects/computer-security-objects-register/algorithm-registration
-	const oidLen = 11
-	oid := [oidLen]byte{
-		0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, ph.oid,
-	}
-
-	msg := make([]byte, oidLen+ph.size)
-	copy(msg, oid[:])
-	switch f := ph.writer.(type) {
-	case hash.Hash:
-		msg = f.Sum(msg[:oidLen])
-	case xof.XOF:
-		_, err := f.Read(msg[oidLen:])
-		if err != nil {
-			return nil, err
-		}
-	default:
-		return nil, ErrPreHash
+	msg, err := ph.ph.Digest()
+	if err != nil {
+		return nil, err
 	}
-
-	ph.Reset()
 	return &Message{msg, 1}, nil
 }
 
@@ -0,0 +1,81 @@
+package stateful
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// FileStore is a [Store] that keeps the state in a file.
+//
+// The state is written to a temporary file, which is synced and renamed
+// over the state file; then the directory is synced. Hence, a crash leaves
+// either the previous state or the new one.
+type FileStore struct{ path string }
+
+// NewFileStore returns a store that keeps the state in the file at path.
+// The directory of path must exist.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: filepath.Clean(path)}
+}
+
+// Load reads the state from the file.
+func (f *FileStore) Load() (*State, error) {
+	b, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNoState
+	} else if err != nil {
+		return nil, err
+	}
+	st := new(State)
+	if err := st.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+// Save writes the state to the file, and returns once it is durable.
+func (f *FileStore) Save(st *State) error {
+	b, err := st.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	tmp := f.path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err = file.Write(b); err == nil {
+		err = file.Sync()
+	}
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, f.path); err != nil {
+		return err
+	}
+	return syncDir(filepath.Dir(f.path))
+}
+
+// syncDir makes a rename in dir durable. Directories cannot be synced on
+// Windows, so this is skipped there.
+func syncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	err = d.Sync()
+	if errClose := d.Close(); err == nil {
+		err = errClose
+	}
+	return err
+}
@@ -0,0 +1,195 @@
+// Package stateful provides the state of private keys of stateful
+// hash-based signature schemes, such as LMS/HSS and XMSS/XMSS^MT.
+//
+// The private keys of these schemes contain many one-time keys, each of
+// which must sign at most one message: signing two messages with the same
+// one-time key allows forgeries. See Section 8 of NIST SP 800-208.
+//
+// https://doi.org/10.6028/NIST.SP.800-208
+//
+// The index of the next unused one-time key is kept in a [State], which is
+// persisted in a [Store]. A [Counter] reserves indices in the store before
+// handing them out, so that an index is never used twice, even if the
+// process crashes right after signing. Reserved indices that were not used
+// before a crash are lost.
+//
+// A store must be used by a single signer at a time, and must not be
+// restored from a backup: an older state gives out indices already used.
+package stateful
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math"
+	"sync"
+)
+
+var (
+	// ErrNoState is returned by [Store.Load] when no state was saved.
+	ErrNoState = errors.New("stateful: no state")
+
+	// ErrStateExists is returned by [Init] when a state was already saved.
+	ErrStateExists = errors.New("stateful: state already exists")
+
+	// ErrKeyMismatch is returned when a state belongs to another key.
+	ErrKeyMismatch = errors.New("stateful: state belongs to another key")
+
+	// ErrRollback is returned when a saved state is older than the last
+	// state saved by a counter.
+	ErrRollback = errors.New("stateful: state was rolled back")
+
+	// ErrExhausted is returned when all one-time keys were used.
+	ErrExhausted = errors.New("stateful: all one-time keys are used")
+
+	// ErrInvalidState is returned when decoding a corrupted state.
+	ErrInvalidState = errors.New("stateful: invalid state")
+)
+
+// State is the mutable part of a stateful private key.
+type State struct {
+	// KeyID identifies the private key, and is its packed public key.
+	KeyID []byte
+	// Next is the index of the next unused one-time key.
+	Next uint64
+}
+
+const (
+	stateVersion  = 1
+	checksumSize  = 8
+	maxKeyIDSize  = math.MaxUint16
+	stateOverhead = 1 + 2 + 8 + checksumSize
+)
+
+// MarshalBinary encodes the state, followed by a checksum.
+func (st *State) MarshalBinary() ([]byte, error) {
+	if len(st.KeyID) > maxKeyIDSize {
+		return nil, ErrInvalidState
+	}
+	b := make([]byte, 0, stateOverhead+len(st.KeyID))
+	b = append(b, stateVersion)
+	b = binary.BigEndian.AppendUint16(b, uint16(len(st.KeyID)))
+	b = append(b, st.KeyID...)
+	b = binary.BigEndian.AppendUint64(b, st.Next)
+	sum := sha256.Sum256(b)
+	return append(b, sum[:checksumSize]...), nil
+}
+
+// UnmarshalBinary decodes a state, and checks its checksum.
+func (st *State) UnmarshalBinary(b []byte) error {
+	if len(b) < stateOverhead || b[0] != stateVersion {
+		return ErrInvalidState
+	}
+	n := int(binary.BigEndian.Uint16(b[1:]))
+	if len(b) != stateOverhead+n {
+		return ErrInvalidState
+	}
+	body, sum := b[:len(b)-checksumSize], b[len(b)-checksumSize:]
+	want := sha256.Sum256(body)
+	if !bytes.Equal(sum, want[:checksumSize]) {
+		return ErrInvalidState
+	}
+	st.KeyID = bytes.Clone(b[3 : 3+n])
+	st.Next = binary.BigEndian.Uint64(b[3+n:])
+	return nil
+}
+
+// Store persists the state of a private key.
+type Store interface {
+	// Load returns the last saved state, or ErrNoState if there is none.
+	Load() (*State, error)
+
+	// Save replaces the saved state with st. It must only return once st
+	// is durable: a later Load must return st, even after a crash. If Save
+	// fails, the saved state must be either st or the previous one.
+	Save(st *State) error
+}
+
+// Init saves the initial state of a new private key in s. It fails with
+// ErrStateExists if s already has a state, so that the state of a key in
+// use cannot be reset.
+func Init(s Store, keyID []byte) error {
+	_, err := s.Load()
+	switch {
+	case err == nil:
+		return ErrStateExists
+	case !errors.Is(err, ErrNoState):
+		return err
+	}
+	return s.Save(&State{KeyID: keyID})
+}
+
+// Counter hands out the indices of one-time keys, reserving them in a
+// [Store] before they are used. It is safe for concurrent use.
+type Counter struct {
+	mu    sync.Mutex
+	store Store
+	keyID []byte
+	limit uint64 // Number of one-time keys
+	batch uint64 // Number of indices reserved by each Save
+	next  uint64 // Next index to hand out
+	end   uint64 // End of the reserved indices, as last saved
+}
+
+// NewCounter loads the state of the key identified by keyID from s. The key
+// has limit one-time keys, and batch indices are reserved at once: a larger
+// batch saves the state less often, but loses more indices on a crash.
+func NewCounter(s Store, keyID []byte, limit, batch uint64) (*Counter, error) {
+	st, err := s.Load()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(st.KeyID, keyID) {
+		return nil, ErrKeyMismatch
+	}
+	return &Counter{
+		store: s, keyID: bytes.Clone(keyID),
+		limit: limit, batch: max(batch, 1),
+		next: st.Next, end: st.Next,
+	}, nil
+}
+
+// Next returns the index of an unused one-time key. The index is saved as
+// used before Next returns.
+func (c *Counter) Next() (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.next == c.end {
+		// The saved state can be ahead of this counter if a Save failed
+		// after writing, or if another signer used the store; the indices
+		// up to it are skipped. It must never be behind.
+		st, err := c.store.Load()
+		if err != nil {
+			return 0, err
+		}
+		if !bytes.Equal(st.KeyID, c.keyID) {
+			return 0, ErrKeyMismatch
+		}
+		if st.Next < c.end {
+			return 0, ErrRollback
+		}
+		c.next, c.end = st.Next, st.Next
+		if c.next >= c.limit {
+			return 0, ErrExhausted
+		}
+
+		end := c.next + min(c.batch, c.limit-c.next)
+		if err := c.store.Save(&State{KeyID: c.keyID, Next: end}); err != nil {
+			return 0, err
+		}
+		c.end = end
+	}
+
+	i := c.next
+	c.next++
+	return i, nil
+}
+
+// Remaining returns the number of one-time keys not handed out yet.
+func (c *Counter) Remaining() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.limit - min(c.next, c.limit)
+}
@@ -0,0 +1,144 @@
+package stateful_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/stateful"
+)
+
+// failStore fails to save the state after a number of saves. If written is
+// true, the failing save still writes the state, as when syncing fails.
+type failStore struct {
+	stateful.Store
+	saves   int
+	written bool
+}
+
+var errSave = errors.New("save failed")
+
+func (f *failStore) Save(st *stateful.State) error {
+	if f.saves == 0 {
+		if f.written {
+			_ = f.Store.Save(st)
+		}
+		return errSave
+	}
+	f.saves--
+	return f.Store.Save(st)
+}
+
+func TestState(t *testing.T) {
+	st := stateful.State{KeyID: []byte("key"), Next: 1234}
+	b, err := st.MarshalBinary()
+	test.CheckNoErr(t, err, "MarshalBinary failed")
+
+	var st2 stateful.State
+	test.CheckNoErr(t, st2.UnmarshalBinary(b), "UnmarshalBinary failed")
+	test.CheckOk(string(st2.KeyID) == "key" && st2.Next == 1234, "state mismatch", t)
+
+	for i := range b {
+		b[i] ^= 1
+		err := st2.UnmarshalBinary(b)
+		test.CheckIsErr(t, err, "UnmarshalBinary accepted corrupted state")
+		b[i] ^= 1
+	}
+	test.CheckIsErr(t, st2.UnmarshalBinary(b[:len(b)-1]), "short state")
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key.state")
+	s := stateful.NewFileStore(path)
+	_, err := s.Load()
+	test.CheckOk(errors.Is(err, stateful.ErrNoState), "Load should fail with ErrNoState", t)
+
+	keyID := []byte("key")
+	test.CheckNoErr(t, stateful.Init(s, keyID), "Init failed")
+	err = stateful.Init(s, keyID)
+	test.CheckOk(errors.Is(err, stateful.ErrStateExists), "Init should not reset a state", t)
+
+	test.CheckNoErr(t, s.Save(&stateful.State{KeyID: keyID, Next: 5}), "Save failed")
+	st, err := stateful.NewFileStore(path).Load()
+	test.CheckNoErr(t, err, "Load failed")
+	test.CheckOk(st.Next == 5, "state not saved", t)
+
+	test.CheckNoErr(t, os.WriteFile(path, []byte("garbage"), 0o600), "write failed")
+	_, err = s.Load()
+	test.CheckOk(errors.Is(err, stateful.ErrInvalidState), "Load accepted an invalid state", t)
+}
+
+func TestCounter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key.state")
+	s := stateful.NewFileStore(path)
+	keyID := []byte("key")
+	test.CheckNoErr(t, stateful.Init(s, keyID), "Init failed")
+
+	_, err := stateful.NewCounter(s, []byte("other key"), 100, 1)
+	test.CheckOk(errors.Is(err, stateful.ErrKeyMismatch), "NewCounter accepted another key", t)
+
+	used := make(map[uint64]bool)
+	next := func(c *stateful.Counter) {
+		t.Helper()
+		i, err := c.Next()
+		test.CheckNoErr(t, err, "Next failed")
+		test.CheckOk(!used[i], "index reused", t)
+		used[i] = true
+	}
+
+	// Simulate crashes by dropping counters after a few indices.
+	for _, batch := range []uint64{1, 7, 3} {
+		c, err := stateful.NewCounter(s, keyID, 100, batch)
+		test.CheckNoErr(t, err, "NewCounter failed")
+		for range 5 {
+			next(c)
+		}
+	}
+	st, _ := s.Load()
+	test.CheckOk(st.Next == 5+7+6, "unexpected state", t)
+
+	// Failing saves do not hand out indices, and partial saves are skipped.
+	c, err := stateful.NewCounter(&failStore{Store: s, written: true}, keyID, 100, 1)
+	test.CheckNoErr(t, err, "NewCounter failed")
+	_, err = c.Next()
+	test.CheckOk(errors.Is(err, errSave), "Next should fail", t)
+	c, err = stateful.NewCounter(&failStore{Store: s, saves: 1}, keyID, 100, 1)
+	test.CheckNoErr(t, err, "NewCounter failed")
+	next(c)
+	_, err = c.Next()
+	test.CheckOk(errors.Is(err, errSave), "Next should fail", t)
+
+	// The indices saved by another signer are skipped.
+	c, err = stateful.NewCounter(s, keyID, 100, 2)
+	test.CheckNoErr(t, err, "NewCounter failed")
+	next(c)
+	next(c)
+	c2, err := stateful.NewCounter(s, keyID, 100, 2)
+	test.CheckNoErr(t, err, "NewCounter failed")
+	next(c2)
+	next(c2)
+	next(c)
+
+	// A rollback is detected.
+	st, _ = s.Load()
+	test.CheckNoErr(t, s.Save(&stateful.State{KeyID: keyID, Next: st.Next - 1}), "Save failed")
+	next(c) // The last reserved index.
+	_, err = c.Next()
+	test.CheckOk(errors.Is(err, stateful.ErrRollback), "rollback not detected", t)
+	test.CheckNoErr(t, s.Save(st), "Save failed")
+
+	// The counter stops at the limit.
+	c, err = stateful.NewCounter(s, keyID, 40, 100)
+	test.CheckNoErr(t, err, "NewCounter failed")
+	for c.Remaining() > 0 {
+		next(c)
+	}
+	_, err = c.Next()
+	test.CheckOk(errors.Is(err, stateful.ErrExhausted), "Next should fail", t)
+	c, err = stateful.NewCounter(s, keyID, 40, 100)
+	test.CheckNoErr(t, err, "NewCounter failed")
+	_, err = c.Next()
+	test.CheckOk(errors.Is(err, stateful.ErrExhausted), "Next should fail", t)
+}
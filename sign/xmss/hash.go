@@ -0,0 +1,155 @@
+package xmss
+
+import (
+	"encoding/binary"
+
+	"github.com/cloudflare/circl/sign/internal/merkle"
+)
+
+// Types of addresses. See Section 2.5 of RFC 8391.
+const (
+	addrOTS      = 0
+	addrLTree    = 1
+	addrHashTree = 2
+)
+
+// address is a hash function address of Section 2.5 of RFC 8391, made of
+// eight 32-bit words: layer, tree (two words), type, then OTS, chain, hash
+// and keyAndMask for OTS addresses, L-tree, tree height, tree index and
+// keyAndMask for L-tree addresses, and padding, tree height, tree index and
+// keyAndMask for hash tree addresses.
+type address [32]byte
+
+func (a *address) setWord(i int, v uint32) { binary.BigEndian.PutUint32(a[4*i:], v) }
+
+func (a *address) setLayer(l uint32) { a.setWord(0, l) }
+func (a *address) setTree(t uint64)  { binary.BigEndian.PutUint64(a[4:], t) }
+
+// setType sets the type of the address, and clears the words that follow.
+func (a *address) setType(t uint32) {
+	a.setWord(3, t)
+	clear(a[16:])
+}
+
+func (a *address) setOTS(i uint32)        { a.setWord(4, i) }
+func (a *address) setLTree(i uint32)      { a.setWord(4, i) }
+func (a *address) setChain(i uint32)      { a.setWord(5, i) }
+func (a *address) setTreeHeight(i uint32) { a.setWord(5, i) }
+func (a *address) setHash(i uint32)       { a.setWord(6, i) }
+func (a *address) setTreeIndex(i uint32)  { a.setWord(6, i) }
+func (a *address) setKeyAndMask(i uint32) { a.setWord(7, i) }
+
+// Prefixes of the hash functions. See Section 5 of NIST SP 800-208.
+const (
+	prefixF         = 0
+	prefixH         = 1
+	prefixHMsg      = 2
+	prefixPRF       = 3
+	prefixPRFKeygen = 4
+)
+
+// hasher computes the hash functions of a key, keyed by its public seed.
+type hasher struct {
+	*params
+	h      *merkle.Hash
+	seed   []byte // SEED
+	skSeed []byte // SK_SEED, which is only needed for signing
+	key    []byte
+	masks  []byte
+}
+
+func newHasher(p *params, seed, skSeed []byte) *hasher {
+	return &hasher{
+		params: p, h: p.newHash(), seed: seed, skSeed: skSeed,
+		key: make([]byte, p.n), masks: make([]byte, 2*p.n),
+	}
+}
+
+// prefix writes toByte(x, 32) for n = 32, and toByte(x, 4) for n = 24.
+func (s *hasher) prefix(x byte) {
+	var b [32]byte
+	pad := 4
+	if s.n == 32 {
+		pad = 32
+	}
+	b[pad-1] = x
+	_, _ = s.h.Write(b[:pad])
+}
+
+// prf writes PRF(SEED, ADRS) to out.
+func (s *hasher) prf(out []byte, a *address) {
+	s.prefix(prefixPRF)
+	_, _ = s.h.Write(s.seed)
+	_, _ = s.h.Write(a[:])
+	s.h.Final(out)
+}
+
+// prfKeygen writes PRF_keygen(SK_SEED, SEED || ADRS) to out.
+func (s *hasher) prfKeygen(out []byte, a *address) {
+	s.prefix(prefixPRFKeygen)
+	_, _ = s.h.Write(s.skSeed)
+	_, _ = s.h.Write(s.seed)
+	_, _ = s.h.Write(a[:])
+	s.h.Final(out)
+}
+
+// f replaces x by F(KEY, x XOR BM), with the key and the bitmask given by
+// the address. See Algorithm 2 of RFC 8391.
+func (s *hasher) f(x []byte, a *address) {
+	a.setKeyAndMask(0)
+	s.prf(s.key, a)
+	a.setKeyAndMask(1)
+	s.prf(s.masks[:s.n], a)
+	for i := range x {
+		x[i] ^= s.masks[i]
+	}
+	s.prefix(prefixF)
+	_, _ = s.h.Write(s.key)
+	_, _ = s.h.Write(x)
+	s.h.Final(x)
+}
+
+// randHash writes RAND_HASH(left, right, SEED, ADRS) to out, which may
+// overlap with left. See Algorithm 7 of RFC 8391.
+func (s *hasher) randHash(out, left, right []byte, a *address) {
+	a.setKeyAndMask(0)
+	s.prf(s.key, a)
+	a.setKeyAndMask(1)
+	s.prf(s.masks[:s.n], a)
+	a.setKeyAndMask(2)
+	s.prf(s.masks[s.n:], a)
+	for i := range s.n {
+		s.masks[i] ^= left[i]
+		s.masks[s.n+i] ^= right[i]
+	}
+	s.prefix(prefixH)
+	_, _ = s.h.Write(s.key)
+	_, _ = s.h.Write(s.masks)
+	s.h.Final(out)
+}
+
+// msgHash returns H_msg(r || root || toByte(index, n), msg).
+func (s *hasher) msgHash(r, root []byte, index uint64, msg []byte) []byte {
+	idx := make([]byte, s.n)
+	binary.BigEndian.PutUint64(idx[s.n-8:], index)
+	s.prefix(prefixHMsg)
+	_, _ = s.h.Write(r)
+	_, _ = s.h.Write(root)
+	_, _ = s.h.Write(idx)
+	_, _ = s.h.Write(msg)
+	out := make([]byte, s.n)
+	s.h.Final(out)
+	return out
+}
+
+// randomizer returns r = PRF(SK_PRF, toByte(index, 32)).
+func (s *hasher) randomizer(skPRF []byte, index uint64) []byte {
+	var idx [32]byte
+	binary.BigEndian.PutUint64(idx[24:], index)
+	s.prefix(prefixPRF)
+	_, _ = s.h.Write(skPRF)
+	_, _ = s.h.Write(idx[:])
+	out := make([]byte, s.n)
+	s.h.Final(out)
+	return out
+}
@@ -0,0 +1,187 @@
+package xmss
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloudflare/circl/sign/internal/merkle"
+)
+
+// [ID] identifies the supported parameter sets of XMSS and XMSS^MT, which
+// are those of NIST SP 800-208. Note that the zero value is not a valid
+// identifier.
+type ID byte
+
+const (
+	XMSS_SHA2_10_256     ID = iota + 1 // XMSS-SHA2_10_256
+	XMSS_SHA2_16_256                   // XMSS-SHA2_16_256
+	XMSS_SHA2_20_256                   // XMSS-SHA2_20_256
+	XMSS_SHA2_10_192                   // XMSS-SHA2_10_192
+	XMSS_SHA2_16_192                   // XMSS-SHA2_16_192
+	XMSS_SHA2_20_192                   // XMSS-SHA2_20_192
+	XMSS_SHAKE256_10_256               // XMSS-SHAKE256_10_256
+	XMSS_SHAKE256_16_256               // XMSS-SHAKE256_16_256
+	XMSS_SHAKE256_20_256               // XMSS-SHAKE256_20_256
+	XMSS_SHAKE256_10_192               // XMSS-SHAKE256_10_192
+	XMSS_SHAKE256_16_192               // XMSS-SHAKE256_16_192
+	XMSS_SHAKE256_20_192               // XMSS-SHAKE256_20_192
+
+	XMSSMT_SHA2_20_2_256      // XMSSMT-SHA2_20/2_256
+	XMSSMT_SHA2_20_4_256      // XMSSMT-SHA2_20/4_256
+	XMSSMT_SHA2_40_2_256      // XMSSMT-SHA2_40/2_256
+	XMSSMT_SHA2_40_4_256      // XMSSMT-SHA2_40/4_256
+	XMSSMT_SHA2_40_8_256      // XMSSMT-SHA2_40/8_256
+	XMSSMT_SHA2_60_3_256      // XMSSMT-SHA2_60/3_256
+	XMSSMT_SHA2_60_6_256      // XMSSMT-SHA2_60/6_256
+	XMSSMT_SHA2_60_12_256     // XMSSMT-SHA2_60/12_256
+	XMSSMT_SHA2_20_2_192      // XMSSMT-SHA2_20/2_192
+	XMSSMT_SHA2_20_4_192      // XMSSMT-SHA2_20/4_192
+	XMSSMT_SHA2_40_2_192      // XMSSMT-SHA2_40/2_192
+	XMSSMT_SHA2_40_4_192      // XMSSMT-SHA2_40/4_192
+	XMSSMT_SHA2_40_8_192      // XMSSMT-SHA2_40/8_192
+	XMSSMT_SHA2_60_3_192      // XMSSMT-SHA2_60/3_192
+	XMSSMT_SHA2_60_6_192      // XMSSMT-SHA2_60/6_192
+	XMSSMT_SHA2_60_12_192     // XMSSMT-SHA2_60/12_192
+	XMSSMT_SHAKE256_20_2_256  // XMSSMT-SHAKE256_20/2_256
+	XMSSMT_SHAKE256_20_4_256  // XMSSMT-SHAKE256_20/4_256
+	XMSSMT_SHAKE256_40_2_256  // XMSSMT-SHAKE256_40/2_256
+	XMSSMT_SHAKE256_40_4_256  // XMSSMT-SHAKE256_40/4_256
+	XMSSMT_SHAKE256_40_8_256  // XMSSMT-SHAKE256_40/8_256
+	XMSSMT_SHAKE256_60_3_256  // XMSSMT-SHAKE256_60/3_256
+	XMSSMT_SHAKE256_60_6_256  // XMSSMT-SHAKE256_60/6_256
+	XMSSMT_SHAKE256_60_12_256 // XMSSMT-SHAKE256_60/12_256
+	XMSSMT_SHAKE256_20_2_192  // XMSSMT-SHAKE256_20/2_192
+	XMSSMT_SHAKE256_20_4_192  // XMSSMT-SHAKE256_20/4_192
+	XMSSMT_SHAKE256_40_2_192  // XMSSMT-SHAKE256_40/2_192
+	XMSSMT_SHAKE256_40_4_192  // XMSSMT-SHAKE256_40/4_192
+	XMSSMT_SHAKE256_40_8_192  // XMSSMT-SHAKE256_40/8_192
+	XMSSMT_SHAKE256_60_3_192  // XMSSMT-SHAKE256_60/3_192
+	XMSSMT_SHAKE256_60_6_192  // XMSSMT-SHAKE256_60/6_192
+	XMSSMT_SHAKE256_60_12_192 // XMSSMT-SHAKE256_60/12_192
+	_MaxParams
+)
+
+// [IDByName] returns the [ID] that corresponds to the given name, such as
+// "XMSS-SHA2_10_256" or "XMSSMT-SHAKE256_40/8_192", or an error if no
+// parameter set was found. Names are case insensitive.
+func IDByName(name string) (ID, error) {
+	for i := range supportedParams {
+		if strings.EqualFold(supportedParams[i].name, name) {
+			return supportedParams[i].ID, nil
+		}
+	}
+	return ID(0), ErrParam
+}
+
+// IsValid returns true if the parameter set is supported.
+func (id ID) IsValid() bool { return 0 < id && id < _MaxParams }
+
+func (id ID) String() string {
+	if !id.IsValid() {
+		return ErrParam.Error()
+	}
+	return supportedParams[id-1].name
+}
+
+func (id ID) params() *params {
+	if !id.IsValid() {
+		panic(ErrParam)
+	}
+	return &supportedParams[id-1]
+}
+
+// params contains the constants of a parameter set. See Section 5 of
+// RFC 8391 and Section 5 of NIST SP 800-208.
+type params struct {
+	ID
+	name   string
+	oid    uint32 // Identifier in the XMSS or the XMSS^MT registry
+	mt     bool   // Whether this is XMSS^MT
+	isSHA2 bool   // SHA-256 or SHAKE256
+	n      int    // Size of hashes
+	h      uint   // Total height
+	d      uint   // Number of layers
+}
+
+var supportedParams [_MaxParams - 1]params
+
+func init() {
+	// Hash functions in the order of the identifiers, with the first OID
+	// of each one for XMSS and XMSS^MT.
+	hashes := [...]struct {
+		name       string
+		n          int
+		oid, oidMT uint32
+		isSHA2     bool
+	}{
+		{"SHA2", 32, 0x01, 0x01, true},
+		{"SHA2", 24, 0x0D, 0x21, true},
+		{"SHAKE256", 32, 0x10, 0x29, false},
+		{"SHAKE256", 24, 0x13, 0x31, false},
+	}
+	trees := [...]struct{ h, d uint }{
+		{20, 2}, {20, 4}, {40, 2}, {40, 4}, {40, 8}, {60, 3}, {60, 6}, {60, 12},
+	}
+
+	id := ID(1)
+	add := func(p params) {
+		p.ID = id
+		supportedParams[id-1] = p
+		id++
+	}
+	for _, f := range hashes {
+		for i, h := range []uint{10, 16, 20} {
+			add(params{
+				name: fmt.Sprintf("XMSS-%v_%v_%v", f.name, h, 8*f.n),
+				oid:  f.oid + uint32(i), isSHA2: f.isSHA2, n: f.n, h: h, d: 1,
+			})
+		}
+	}
+	for _, f := range hashes {
+		for i, t := range trees {
+			add(params{
+				name: fmt.Sprintf("XMSSMT-%v_%v/%v_%v", f.name, t.h, t.d, 8*f.n),
+				oid:  f.oidMT + uint32(i), mt: true, isSHA2: f.isSHA2,
+				n: f.n, h: t.h, d: t.d,
+			})
+		}
+	}
+}
+
+// wotsLen is the number of chains of WOTS+, with w = 16.
+func (p *params) wotsLen() int { return 2*p.n + 3 }
+
+// treeHeight is the height of each tree of the hypertree.
+func (p *params) treeHeight() uint { return p.h / p.d }
+
+// indexSize is the size of the index of the one-time key in signatures.
+func (p *params) indexSize() int {
+	if !p.mt {
+		return 4
+	}
+	return int(p.h+7) / 8
+}
+
+// PublicKeySize returns the size of public keys.
+func (id ID) PublicKeySize() int { return 4 + 2*id.params().n }
+
+// PrivateKeySize returns the size of private keys.
+func (id ID) PrivateKeySize() int { return 4 + 4*id.params().n }
+
+// SignatureSize returns the size of signatures.
+func (id ID) SignatureSize() int {
+	p := id.params()
+	layer := (p.wotsLen() + int(p.treeHeight())) * p.n
+	return p.indexSize() + p.n + int(p.d)*layer
+}
+
+// Height returns the total height of the trees, so that 2^Height signatures
+// can be made with a key.
+func (id ID) Height() uint { return id.params().h }
+
+func (p *params) newHash() *merkle.Hash {
+	if p.isSHA2 {
+		return merkle.NewSHA256(p.n)
+	}
+	return merkle.NewSHAKE256(p.n)
+}
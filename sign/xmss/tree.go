@@ -0,0 +1,105 @@
+package xmss
+
+import "github.com/cloudflare/circl/sign/internal/merkle"
+
+// ltree compresses the WOTS+ public key pk into its first n bytes. The
+// address must have its L-tree address set. See Algorithm 8 of RFC 8391.
+func (s *hasher) ltree(pk []byte, a *address) {
+	n := s.n
+	for height, l := uint32(0), s.wotsLen(); l > 1; height++ {
+		a.setTreeHeight(height)
+		for i := range l / 2 {
+			a.setTreeIndex(uint32(i))
+			s.randHash(pk[i*n:(i+1)*n], pk[2*i*n:(2*i+1)*n], pk[(2*i+1)*n:(2*i+2)*n], a)
+		}
+		if l%2 == 1 {
+			copy(pk[(l/2)*n:], pk[(l-1)*n:l*n])
+		}
+		l = (l + 1) / 2
+	}
+}
+
+// subtree is an XMSS tree of a layer of the hypertree, with its nodes.
+type subtree struct {
+	s     *hasher
+	layer uint32
+	index uint64
+	tree  *merkle.Tree
+}
+
+// newSubtree computes the tree of the given index in the given layer. See
+// Algorithm 9 of RFC 8391.
+func newSubtree(s *hasher, layer uint32, index uint64) *subtree {
+	t := &subtree{s: s, layer: layer, index: index}
+	t.tree = merkle.New(s.n, s.treeHeight(), t.leaf, t.node)
+	return t
+}
+
+func (t *subtree) address(typ uint32) *address {
+	a := new(address)
+	a.setLayer(t.layer)
+	a.setTree(t.index)
+	a.setType(typ)
+	return a
+}
+
+// leaf computes the leaf of the one-time key i, which is the root of the
+// L-tree of its public key.
+func (t *subtree) leaf(out []byte, i uint32) {
+	pk := make([]byte, t.s.wotsLen()*t.s.n)
+	a := t.address(addrOTS)
+	a.setOTS(i)
+	t.s.wotsPublicKey(pk, a)
+	a = t.address(addrLTree)
+	a.setLTree(i)
+	t.s.ltree(pk, a)
+	copy(out, pk)
+}
+
+func (t *subtree) node(out []byte, height uint, index uint32, left, right []byte) {
+	a := t.address(addrHashTree)
+	a.setTreeHeight(uint32(height - 1))
+	a.setTreeIndex(index)
+	t.s.randHash(out, left, right, a)
+}
+
+// sign appends the signature of msg with the one-time key i, and its
+// authentication path, to sig. See Algorithm 11 of RFC 8391.
+func (t *subtree) sign(sig, msg []byte, i uint32) []byte {
+	a := t.address(addrOTS)
+	a.setOTS(i)
+	sig = t.s.wotsSign(sig, msg, a)
+	path := make([]byte, int(t.s.treeHeight())*t.s.n)
+	t.tree.AuthPath(path, i)
+	return append(sig, path...)
+}
+
+// rootFromSig writes to node the root of the tree of the given index in
+// the given layer, computed from the signature of msg with the one-time key
+// i. The signature holds the WOTS+ signature and the authentication path.
+// See Algorithm 13 of RFC 8391.
+func (s *hasher) rootFromSig(node []byte, layer uint32, index uint64, i uint32, msg, sig []byte) {
+	n := s.n
+	sub := subtree{s: s, layer: layer, index: index}
+	pk := make([]byte, s.wotsLen()*n)
+	a := sub.address(addrOTS)
+	a.setOTS(i)
+	s.wotsPublicKeyFromSig(pk, sig, msg, a)
+	a = sub.address(addrLTree)
+	a.setLTree(i)
+	s.ltree(pk, a)
+	copy(node, pk[:n])
+
+	path := sig[s.wotsLen()*n:]
+	a = sub.address(addrHashTree)
+	for k := range s.treeHeight() {
+		a.setTreeHeight(uint32(k))
+		a.setTreeIndex(i >> (k + 1))
+		sibling := path[int(k)*n : int(k+1)*n]
+		if (i>>k)&1 == 0 {
+			s.randHash(node, node, sibling, a)
+		} else {
+			s.randHash(node, sibling, node, a)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package xmss
+
+// WOTS+ one-time signatures with w = 16. See Section 3 of RFC 8391.
+
+const wotsW = 16
+
+// chain applies steps times the chain function to x, from step start. The
+// address must have its OTS address and chain address set. See Algorithm 2
+// of RFC 8391.
+func (s *hasher) chain(x []byte, start, steps uint8, a *address) {
+	for j := start; j < start+steps; j++ {
+		a.setHash(uint32(j))
+		s.f(x, a)
+	}
+}
+
+// digits returns the base-w digits of msg followed by those of its
+// checksum. See Algorithm 5 of RFC 8391.
+func (s *hasher) digits(msg []byte) []uint8 {
+	d := make([]uint8, 0, s.wotsLen())
+	sum := 0
+	for _, b := range msg {
+		d = append(d, b>>4, b&0xF)
+		sum += 2*(wotsW-1) - int(b>>4) - int(b&0xF)
+	}
+	// The checksum has 12 bits, which are shifted left by 4 bits.
+	return append(d, uint8(sum>>8), uint8(sum>>4)&0xF, uint8(sum)&0xF)
+}
+
+// wotsSecret writes the private key of chain i to out, as specified in
+// Section 7.2.1 of NIST SP 800-208. The address must have its OTS address
+// set.
+func (s *hasher) wotsSecret(out []byte, i int, a *address) {
+	a.setChain(uint32(i))
+	a.setHash(0)
+	a.setKeyAndMask(0)
+	s.prfKeygen(out, a)
+}
+
+// wotsPublicKey writes the public key of the one-time key of the address
+// to pk. See Algorithm 4 of RFC 8391.
+func (s *hasher) wotsPublicKey(pk []byte, a *address) {
+	for i := range s.wotsLen() {
+		x := pk[i*s.n : (i+1)*s.n]
+		s.wotsSecret(x, i, a)
+		s.chain(x, 0, wotsW-1, a)
+	}
+}
+
+// wotsSign appends the signature of msg with the one-time key of the
+// address to sig. See Algorithm 5 of RFC 8391.
+func (s *hasher) wotsSign(sig, msg []byte, a *address) []byte {
+	for i, d := range s.digits(msg) {
+		x := make([]byte, s.n)
+		s.wotsSecret(x, i, a)
+		s.chain(x, 0, d, a)
+		sig = append(sig, x...)
+	}
+	return sig
+}
+
+// wotsPublicKeyFromSig writes to pk the public key computed from the
+// signature of msg. See Algorithm 6 of RFC 8391.
+func (s *hasher) wotsPublicKeyFromSig(pk, sig, msg []byte, a *address) {
+	copy(pk, sig)
+	for i, d := range s.digits(msg) {
+		a.setChain(uint32(i))
+		s.chain(pk[i*s.n:(i+1)*s.n], d, wotsW-1-d, a)
+	}
+}
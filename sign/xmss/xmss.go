@@ -0,0 +1,288 @@
+// Package xmss implements the eXtended Merkle Signature Scheme (XMSS) and
+// its multi-tree variant (XMSS^MT), as specified in RFC 8391 and NIST
+// SP 800-208.
+//
+//   - https://www.rfc-editor.org/rfc/rfc8391
+//   - https://doi.org/10.6028/NIST.SP.800-208
+//
+// XMSS and XMSS^MT are stateful: each signature uses a one-time key, which
+// must never be used again. Private keys are immutable, and the index of the
+// next unused one-time key is persisted in a [stateful.Store] by a [Signer].
+//
+// The private keys of WOTS+ are derived from SK_SEED as in NIST SP 800-208,
+// and the keys of this package are those of its parameter sets. Like in
+// RFC 8391, the private key of XMSS is a private key of XMSS^MT with one
+// layer.
+package xmss
+
+import (
+	"bytes"
+	"crypto"
+	cryptoRand "crypto/rand"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/cloudflare/circl/sign/stateful"
+)
+
+// ErrParam is returned on invalid or unsupported parameter sets.
+var ErrParam = errors.New("xmss: invalid parameter set")
+
+// PublicKey is an XMSS or XMSS^MT public key.
+type PublicKey struct {
+	root, seed []byte
+	ID
+}
+
+// PrivateKey is an XMSS or XMSS^MT private key. It does not hold the index
+// of the next unused one-time key, which is kept by a [Signer].
+type PrivateKey struct {
+	skSeed, skPRF []byte
+	publicKey     PublicKey
+	ID
+}
+
+// GenerateKey generates a key pair of the given parameter set, using
+// entropy from rand. If rand is nil, crypto/rand.Reader is used. This
+// computes the whole top tree, which takes time for large heights.
+func GenerateKey(rand io.Reader, id ID) (*PublicKey, *PrivateKey, error) {
+	if !id.IsValid() {
+		return nil, nil, ErrParam
+	}
+	p := id.params()
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+	b := make([]byte, 3*p.n)
+	if _, err := io.ReadFull(rand, b); err != nil {
+		return nil, nil, err
+	}
+
+	sk := &PrivateKey{skSeed: b[:p.n], skPRF: b[p.n : 2*p.n], ID: id}
+	sk.publicKey = PublicKey{seed: b[2*p.n:], ID: id}
+	top := newSubtree(newHasher(p, sk.publicKey.seed, sk.skSeed), uint32(p.d-1), 0)
+	sk.publicKey.root = top.tree.Root()
+	pk := sk.publicKey
+	return &pk, sk, nil
+}
+
+// MarshalBinary returns u32str(OID) || root || SEED. See Section 4.1.7 of
+// RFC 8391.
+func (pk *PublicKey) MarshalBinary() ([]byte, error) {
+	if !pk.ID.IsValid() {
+		return nil, ErrParam
+	}
+	b := binary.BigEndian.AppendUint32(nil, pk.params().oid)
+	b = append(b, pk.root...)
+	return append(b, pk.seed...), nil
+}
+
+// UnmarshalBinary decodes a public key of the parameter set pk.ID, which
+// must be set beforehand since XMSS and XMSS^MT share the values of OIDs.
+func (pk *PublicKey) UnmarshalBinary(b []byte) error {
+	if !pk.ID.IsValid() {
+		return ErrParam
+	}
+	p := pk.params()
+	if len(b) != pk.PublicKeySize() || binary.BigEndian.Uint32(b) != p.oid {
+		return ErrParam
+	}
+	pk.root = bytes.Clone(b[4 : 4+p.n])
+	pk.seed = bytes.Clone(b[4+p.n:])
+	return nil
+}
+
+// Equal returns whether the two public keys are equal.
+func (pk *PublicKey) Equal(other crypto.PublicKey) bool {
+	o, ok := other.(*PublicKey)
+	return ok && pk.ID == o.ID &&
+		bytes.Equal(pk.root, o.root) &&
+		bytes.Equal(pk.seed, o.seed)
+}
+
+// Public returns the public key.
+func (sk *PrivateKey) Public() crypto.PublicKey { pk := sk.publicKey; return &pk }
+
+// Equal returns whether the two private keys are equal.
+func (sk *PrivateKey) Equal(other crypto.PrivateKey) bool {
+	o, ok := other.(*PrivateKey)
+	return ok && sk.ID == o.ID &&
+		subtle.ConstantTimeCompare(sk.skSeed, o.skSeed) == 1 &&
+		subtle.ConstantTimeCompare(sk.skPRF, o.skPRF) == 1 &&
+		sk.publicKey.Equal(&o.publicKey)
+}
+
+// MarshalBinary returns u32str(OID) || SK_SEED || SK_PRF || root || SEED.
+// Unlike in Section 4.1.7 of RFC 8391, the index of the next unused
+// one-time key is not part of private keys.
+func (sk *PrivateKey) MarshalBinary() ([]byte, error) {
+	if !sk.ID.IsValid() {
+		return nil, ErrParam
+	}
+	b := binary.BigEndian.AppendUint32(nil, sk.params().oid)
+	b = append(b, sk.skSeed...)
+	b = append(b, sk.skPRF...)
+	b = append(b, sk.publicKey.root...)
+	return append(b, sk.publicKey.seed...), nil
+}
+
+// UnmarshalBinary decodes a private key of the parameter set sk.ID, which
+// must be set beforehand since XMSS and XMSS^MT share the values of OIDs.
+func (sk *PrivateKey) UnmarshalBinary(b []byte) error {
+	if !sk.ID.IsValid() {
+		return ErrParam
+	}
+	p := sk.params()
+	if len(b) != sk.PrivateKeySize() || binary.BigEndian.Uint32(b) != p.oid {
+		return ErrParam
+	}
+	b = bytes.Clone(b[4:])
+	sk.skSeed, sk.skPRF = b[:p.n], b[p.n:2*p.n]
+	sk.publicKey = PublicKey{root: b[2*p.n : 3*p.n], seed: b[3*p.n:], ID: sk.ID}
+	return nil
+}
+
+// keyID identifies the key in its state.
+func (sk *PrivateKey) keyID() []byte {
+	b, _ := sk.publicKey.MarshalBinary()
+	return append([]byte(sk.ID.String()), b...)
+}
+
+// InitState saves the initial state of a new private key in store. It fails
+// if store already has a state, so that a key in use cannot be reset.
+func (sk *PrivateKey) InitState(store stateful.Store) error {
+	return stateful.Init(store, sk.keyID())
+}
+
+// Signer signs messages with an XMSS or XMSS^MT private key, using each
+// one-time key at most once. It is safe for concurrent use.
+type Signer struct {
+	mu      sync.Mutex
+	sk      *PrivateKey
+	counter *stateful.Counter
+	s       *hasher
+	trees   []*subtree // Current tree of each layer, from the bottom
+	signed  [][]byte   // Signature of the root of the tree below each layer
+}
+
+// NewSigner returns a signer for sk, whose state is kept in store. The state
+// must have been created with [PrivateKey.InitState].
+//
+// The signer reserves batch one-time keys at once in store. A larger batch
+// saves the state less often, but loses the reserved one-time keys that are
+// not used if the process stops.
+//
+// The signer keeps the tree of each layer in memory, and computes it when
+// the first signature with this tree is made. A tree of height h takes
+// about 2^min(h, 16) nodes of memory.
+func NewSigner(sk *PrivateKey, store stateful.Store, batch uint64) (*Signer, error) {
+	if !sk.ID.IsValid() {
+		return nil, ErrParam
+	}
+	p := sk.params()
+	c, err := stateful.NewCounter(store, sk.keyID(), 1<<p.h, batch)
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{
+		sk: sk, counter: c,
+		s:      newHasher(p, sk.publicKey.seed, sk.skSeed),
+		trees:  make([]*subtree, p.d),
+		signed: make([][]byte, p.d),
+	}, nil
+}
+
+// Public returns the public key.
+func (s *Signer) Public() crypto.PublicKey { return s.sk.Public() }
+
+// Remaining returns the number of signatures that can still be made.
+func (s *Signer) Remaining() uint64 { return s.counter.Remaining() }
+
+// Sign signs msg with an unused one-time key, which is saved as used before
+// signing. It implements [crypto.Signer], and rand is not used since
+// signing is deterministic. Pre-hashing is not supported, so
+// opts.HashFunc() must be zero.
+func (s *Signer) Sign(_ io.Reader, msg []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if opts != nil && opts.HashFunc() != crypto.Hash(0) {
+		return nil, errors.New("xmss: cannot sign hashed message")
+	}
+	index, err := s.counter.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// See Algorithm 15 of RFC 8391.
+	p, h := s.s.params, s.s.treeHeight()
+	for j := int(p.d) - 1; j >= 0; j-- {
+		if err := s.loadTree(j, index>>(uint(j+1)*h)); err != nil {
+			return nil, err
+		}
+	}
+
+	sig := make([]byte, p.indexSize(), p.SignatureSize())
+	for i := range sig {
+		sig[i] = byte(index >> (8 * (len(sig) - 1 - i)))
+	}
+	r := s.s.randomizer(s.sk.skPRF, index)
+	sig = append(sig, r...)
+	m := s.s.msgHash(r, s.sk.publicKey.root, index, msg)
+	sig = s.trees[0].sign(sig, m, uint32(index)&(1<<h-1))
+	for _, b := range s.signed[1:] {
+		sig = append(sig, b...)
+	}
+	return sig, nil
+}
+
+// loadTree makes the tree of the given index the current tree of layer j.
+// The trees of the layers above must have been loaded.
+func (s *Signer) loadTree(j int, index uint64) error {
+	if s.trees[j] != nil && s.trees[j].index == index {
+		return nil
+	}
+	t := newSubtree(s.s, uint32(j), index)
+	if j == len(s.trees)-1 {
+		if !bytes.Equal(t.tree.Root(), s.sk.publicKey.root) {
+			return errors.New("xmss: private key does not match its public key")
+		}
+	} else {
+		h := s.s.treeHeight()
+		leaf := uint32(index) & (1<<h - 1)
+		s.signed[j+1] = s.trees[j+1].sign(nil, t.tree.Root(), leaf)
+	}
+	s.trees[j] = t
+	return nil
+}
+
+// Verify returns whether sig is a valid signature of msg under pk. See
+// Algorithms 14 and 17 of RFC 8391.
+func Verify(pk *PublicKey, msg, sig []byte) bool {
+	if !pk.ID.IsValid() || len(sig) != pk.SignatureSize() {
+		return false
+	}
+	p := pk.params()
+	index := uint64(0)
+	for _, b := range sig[:p.indexSize()] {
+		index = index<<8 | uint64(b)
+	}
+	if index>>p.h != 0 {
+		return false
+	}
+	r, sig := sig[p.indexSize():p.indexSize()+p.n], sig[p.indexSize()+p.n:]
+
+	s := newHasher(p, pk.seed, nil)
+	node := s.msgHash(r, pk.root, index, msg)
+	h := p.treeHeight()
+	size := (p.wotsLen() + int(h)) * p.n
+	for j := range p.d {
+		leaf := uint32(index) & (1<<h - 1)
+		index >>= h
+		s.rootFromSig(node, uint32(j), index, leaf, node, sig[int(j)*size:int(j+1)*size])
+	}
+	return bytes.Equal(node, pk.root)
+}
@@ -0,0 +1,244 @@
+package xmss
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/sha3"
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/stateful"
+)
+
+func TestParams(t *testing.T) {
+	// See Section 5 of RFC 8391, and Section 5 of NIST SP 800-208.
+	for _, tc := range []struct {
+		id                ID
+		oid               uint32
+		pkSize, sigSize   int
+		name              string
+		height, treeLayer uint
+	}{
+		{XMSS_SHA2_10_256, 0x01, 68, 2500, "XMSS-SHA2_10_256", 10, 10},
+		{XMSS_SHA2_20_192, 0x0F, 52, 1732, "XMSS-SHA2_20_192", 20, 20},
+		{XMSS_SHAKE256_16_256, 0x11, 68, 2692, "XMSS-SHAKE256_16_256", 16, 16},
+		{XMSS_SHAKE256_10_192, 0x13, 52, 1492, "XMSS-SHAKE256_10_192", 10, 10},
+		{XMSSMT_SHA2_20_2_256, 0x01, 68, 4963, "XMSSMT-SHA2_20/2_256", 20, 10},
+		{XMSSMT_SHA2_60_12_256, 0x08, 68, 27688, "XMSSMT-SHA2_60/12_256", 60, 5},
+		{XMSSMT_SHA2_20_2_192, 0x21, 52, 2955, "XMSSMT-SHA2_20/2_192", 20, 10},
+		{XMSSMT_SHAKE256_40_8_256, 0x2D, 68, 18469, "XMSSMT-SHAKE256_40/8_256", 40, 5},
+		{XMSSMT_SHAKE256_60_12_192, 0x38, 52, 16160, "XMSSMT-SHAKE256_60/12_192", 60, 5},
+	} {
+		p := tc.id.params()
+		if p.oid != tc.oid || tc.id.PublicKeySize() != tc.pkSize ||
+			tc.id.SignatureSize() != tc.sigSize || tc.id.String() != tc.name ||
+			tc.id.Height() != tc.height || p.treeHeight() != tc.treeLayer {
+			t.Fatalf("%v: got oid=%v pk=%v sig=%v name=%v", tc.id, p.oid,
+				tc.id.PublicKeySize(), tc.id.SignatureSize(), tc.id.String())
+		}
+		id, err := IDByName(tc.name)
+		test.CheckNoErr(t, err, "IDByName failed")
+		test.CheckOk(id == tc.id, "IDByName returned another ID", t)
+	}
+	test.CheckOk(!ID(0).IsValid() && !_MaxParams.IsValid(), "invalid ID", t)
+	_, err := IDByName("XMSS-SHA2_10_512")
+	test.CheckIsErr(t, err, "IDByName should fail")
+	_, _, err = GenerateKey(nil, ID(0))
+	test.CheckIsErr(t, err, "GenerateKey should fail")
+}
+
+// newSigner returns a signer for a new key, whose state is in a new file.
+func newSigner(t *testing.T, id ID, batch uint64) (*PublicKey, *PrivateKey, *Signer, stateful.Store) {
+	t.Helper()
+	pk, sk, err := GenerateKey(nil, id)
+	test.CheckNoErr(t, err, "GenerateKey failed")
+	store := stateful.NewFileStore(filepath.Join(t.TempDir(), "state"))
+	test.CheckNoErr(t, sk.InitState(store), "InitState failed")
+	s, err := NewSigner(sk, store, batch)
+	test.CheckNoErr(t, err, "NewSigner failed")
+	return pk, sk, s, store
+}
+
+func TestSignVerify(t *testing.T) {
+	for _, tc := range []struct {
+		id    ID
+		count int
+	}{
+		{XMSS_SHA2_10_256, 3},
+		{XMSS_SHA2_10_192, 3},
+		// Use several trees of the bottom layer.
+		{XMSSMT_SHA2_20_4_256, 40},
+		{XMSSMT_SHA2_20_4_192, 40},
+		{XMSSMT_SHAKE256_20_4_256, 5},
+		{XMSSMT_SHAKE256_40_8_192, 5},
+	} {
+		t.Run(tc.id.String(), func(t *testing.T) {
+			if testing.Short() && !tc.id.params().mt {
+				t.Skip("key generation is slow")
+			}
+			pk, _, s, _ := newSigner(t, tc.id, 3)
+			for i := range tc.count {
+				msg := []byte(fmt.Sprintf("message %v", i))
+				sig, err := s.Sign(nil, msg, crypto.Hash(0))
+				test.CheckNoErr(t, err, "Sign failed")
+				test.CheckOk(len(sig) == tc.id.SignatureSize(), "bad signature size", t)
+				test.CheckOk(Verify(pk, msg, sig), "Verify failed", t)
+				test.CheckOk(!Verify(pk, msg[1:], sig), "Verify accepted other message", t)
+				test.CheckOk(!Verify(pk, msg, sig[:len(sig)-1]), "Verify accepted short signature", t)
+				for _, j := range []int{0, 2, 5, 40, len(sig) / 2, len(sig) - 1} {
+					sig[j] ^= 1
+					test.CheckOk(!Verify(pk, msg, sig), "Verify accepted altered signature", t)
+					sig[j] ^= 1
+				}
+			}
+			_, err := s.Sign(nil, []byte("msg"), crypto.SHA256)
+			test.CheckIsErr(t, err, "Sign should reject hashed messages")
+		})
+	}
+}
+
+func TestKeys(t *testing.T) {
+	id := XMSSMT_SHAKE256_20_4_256
+	pk, sk, err := GenerateKey(nil, id)
+	test.CheckNoErr(t, err, "GenerateKey failed")
+	test.CheckOk(pk.Equal(sk.Public()), "public key not equal", t)
+
+	ppk, err := pk.MarshalBinary()
+	test.CheckNoErr(t, err, "MarshalBinary failed")
+	test.CheckOk(len(ppk) == id.PublicKeySize(), "bad public key size", t)
+	psk, err := sk.MarshalBinary()
+	test.CheckNoErr(t, err, "MarshalBinary failed")
+	test.CheckOk(len(psk) == id.PrivateKeySize(), "bad private key size", t)
+
+	pk2 := PublicKey{ID: id}
+	sk2 := PrivateKey{ID: id}
+	test.CheckNoErr(t, pk2.UnmarshalBinary(ppk), "UnmarshalBinary failed")
+	test.CheckNoErr(t, sk2.UnmarshalBinary(psk), "UnmarshalBinary failed")
+	test.CheckOk(pk.Equal(&pk2), "public key not equal", t)
+	test.CheckOk(sk.Equal(&sk2), "private key not equal", t)
+	test.CheckOk(pk.Equal(sk2.Public()), "public key not equal", t)
+
+	test.CheckIsErr(t, pk2.UnmarshalBinary(ppk[1:]), "UnmarshalBinary accepted short key")
+	test.CheckIsErr(t, sk2.UnmarshalBinary(psk[1:]), "UnmarshalBinary accepted short key")
+	var pk3 PublicKey
+	test.CheckIsErr(t, pk3.UnmarshalBinary(ppk), "UnmarshalBinary accepted key without ID")
+	pk3.ID = XMSSMT_SHAKE256_20_2_256
+	test.CheckIsErr(t, pk3.UnmarshalBinary(ppk), "UnmarshalBinary accepted other OID")
+
+	// A private key whose root is altered cannot sign.
+	psk[len(psk)-1-id.params().n] ^= 1
+	test.CheckNoErr(t, sk2.UnmarshalBinary(psk), "UnmarshalBinary failed")
+	store := stateful.NewFileStore(filepath.Join(t.TempDir(), "state"))
+	test.CheckNoErr(t, sk2.InitState(store), "InitState failed")
+	s, err := NewSigner(&sk2, store, 1)
+	test.CheckNoErr(t, err, "NewSigner failed")
+	_, err = s.Sign(nil, []byte("msg"), nil)
+	test.CheckIsErr(t, err, "Sign should fail")
+}
+
+func TestState(t *testing.T) {
+	id := XMSSMT_SHA2_20_4_192
+	pk, sk, s, store := newSigner(t, id, 4)
+	used := make(map[uint32]bool)
+	sign := func(s *Signer) error {
+		sig, err := s.Sign(nil, []byte("msg"), nil)
+		if err != nil {
+			return err
+		}
+		test.CheckOk(Verify(pk, []byte("msg"), sig), "Verify failed", t)
+		q := uint32(sig[0])<<16 | uint32(sig[1])<<8 | uint32(sig[2])
+		test.CheckOk(!used[q], "one-time key reused", t)
+		used[q] = true
+		return nil
+	}
+
+	// The state cannot be reset.
+	err := sk.InitState(store)
+	test.CheckOk(errors.Is(err, stateful.ErrStateExists), "InitState reset the state", t)
+
+	// Simulate crashes by dropping signers.
+	for range 3 {
+		test.CheckNoErr(t, sign(s), "Sign failed")
+		s, err = NewSigner(sk, store, 4)
+		test.CheckNoErr(t, err, "NewSigner failed")
+	}
+	test.CheckOk(s.Remaining() == 1<<20-12, "unexpected remaining signatures", t)
+
+	// Another key cannot use the state.
+	_, sk2, err := GenerateKey(nil, id)
+	test.CheckNoErr(t, err, "GenerateKey failed")
+	_, err = NewSigner(sk2, store, 1)
+	test.CheckOk(errors.Is(err, stateful.ErrKeyMismatch), "NewSigner accepted another key", t)
+
+	// The last one-time keys can be used once.
+	test.CheckNoErr(t, store.Save(&stateful.State{KeyID: sk.keyID(), Next: 1<<20 - 2}), "Save failed")
+	s, err = NewSigner(sk, store, 4)
+	test.CheckNoErr(t, err, "NewSigner failed")
+	for range 2 {
+		test.CheckNoErr(t, sign(s), "Sign failed")
+	}
+	err = sign(s)
+	test.CheckOk(errors.Is(err, stateful.ErrExhausted), "Sign should fail", t)
+}
+
+// Digests of the public key and 33 signatures, made by this implementation.
+// They only detect regressions.
+//
+// TODO crossreference with the ACVP vectors of NIST SP 800-208, which are
+// not vendored.
+var sigDigests = map[ID]string{
+	XMSSMT_SHA2_20_4_256:     "1d05cb82110d14d7fded5577aecb465a180e80dcf25a2e8403de0f3bd01322b2",
+	XMSSMT_SHA2_20_4_192:     "d4f218ca1771de0bde03094c12aa4d9f268b02c68e636aca46e4c7cf6cbaae73",
+	XMSSMT_SHAKE256_20_4_256: "8d22674132b2ef27ae3fedf264ca08b0cda0d8a9933b2dc5773063418f08a65b",
+	XMSSMT_SHAKE256_20_4_192: "25cafbabcd6e21a81c5a768b9514ad1bac2ea11a566b5596c2e40d5ac69f6a6b",
+}
+
+func TestVectors(t *testing.T) {
+	for id, want := range sigDigests {
+		rng := sha3.NewShake128()
+		pk, sk, err := GenerateKey(&rng, id)
+		test.CheckNoErr(t, err, "GenerateKey failed")
+		store := stateful.NewFileStore(filepath.Join(t.TempDir(), "state"))
+		test.CheckNoErr(t, sk.InitState(store), "InitState failed")
+		s, err := NewSigner(sk, store, 10)
+		test.CheckNoErr(t, err, "NewSigner failed")
+
+		h := sha256.New()
+		ppk, _ := pk.MarshalBinary()
+		h.Write(ppk)
+		for i := range 33 {
+			sig, err := s.Sign(nil, []byte{byte(i)}, nil)
+			test.CheckNoErr(t, err, "Sign failed")
+			h.Write(sig)
+		}
+		if got := hex.EncodeToString(h.Sum(nil)); got != want {
+			t.Errorf("%v: got %v, want %v", id, got, want)
+		}
+	}
+}
+
+func BenchmarkXMSS(b *testing.B) {
+	for _, id := range []ID{XMSS_SHA2_10_256, XMSSMT_SHA2_20_4_256} {
+		pk, sk, _ := GenerateKey(nil, id)
+		store := stateful.NewFileStore(filepath.Join(b.TempDir(), "state"))
+		_ = sk.InitState(store)
+		s, _ := NewSigner(sk, store, 1<<10)
+		msg := []byte("Alice and Bob")
+		sig, _ := s.Sign(nil, msg, nil)
+
+		b.Run(id.String()+"/Sign", func(b *testing.B) {
+			for range min(b.N, 1000) {
+				_, _ = s.Sign(nil, msg, nil)
+			}
+		})
+		b.Run(id.String()+"/Verify", func(b *testing.B) {
+			for range b.N {
+				_ = Verify(pk, msg, sig)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package frost
+
+import (
+	"slices"
+
+	"github.com/cloudflare/circl/group"
+	"github.com/cloudflare/circl/math/polynomial"
+)
+
+// Nonce is the secret pair of nonces generated by a signer in the first
+// round. It must be kept secret and used at most once.
+type Nonce struct {
+	ID      group.Scalar
+	hiding  group.Scalar
+	binding group.Scalar
+}
+
+// Commitment is the public commitment to a Nonce that a signer sends to the
+// Coordinator in the first round.
+type Commitment struct {
+	Suite
+	ID      group.Scalar
+	Hiding  group.Element
+	Binding group.Element
+}
+
+// MarshalBinary returns the serialization of the commitment, which is
+// SerializeScalar(ID) || SerializeElement(Hiding) || SerializeElement(Binding).
+func (c Commitment) MarshalBinary() ([]byte, error) {
+	p := c.getParams()
+	id, err := c.ID.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	hiding, err := p.serializeElement(c.Hiding)
+	if err != nil {
+		return nil, err
+	}
+	binding, err := p.serializeElement(c.Binding)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Concat(id, hiding, binding), nil
+}
+
+// UnmarshalBinary recovers a commitment from its serialization. The Suite of
+// the receiver must be set before calling this function.
+func (c *Commitment) UnmarshalBinary(data []byte) error {
+	p := c.getParams()
+	sclLen := int(p.g.Params().ScalarLength)
+	eltLen := int(p.g.Params().CompressedElementLength)
+	if len(data) != sclLen+2*eltLen {
+		return ErrUnmarshal
+	}
+
+	id, err := p.deserializeScalar(data[:sclLen])
+	if err != nil {
+		return err
+	}
+	if id.IsZero() {
+		return ErrUnmarshal
+	}
+	hiding, err := p.deserializeElement(data[sclLen : sclLen+eltLen])
+	if err != nil {
+		return err
+	}
+	binding, err := p.deserializeElement(data[sclLen+eltLen:])
+	if err != nil {
+		return err
+	}
+
+	c.ID, c.Hiding, c.Binding = id, hiding, binding
+	return nil
+}
+
+// commitmentList is a list of commitments sorted by identifier together
+// with the values derived from it, see RFC 9591, Section 4.
+type commitmentList struct {
+	p              *suiteParams
+	coms           []Commitment
+	bindingFactors []group.Scalar
+	groupCom       group.Element
+	challenge      group.Scalar
+}
+
+// newCommitmentList validates and sorts the commitments, and computes the
+// binding factors, the group commitment, and the challenge for signing msg
+// under groupPublicKey.
+func newCommitmentList(
+	s Suite, threshold, maxSigners uint, groupPublicKey PublicKey,
+	coms []Commitment, msg []byte,
+) (*commitmentList, error) {
+	p := s.getParams()
+	if l := uint(len(coms)); l <= threshold || l > maxSigners {
+		return nil, ErrCommitments
+	}
+
+	sorted := slices.Clone(coms)
+	for i := range sorted {
+		if sorted[i].Suite != s || sorted[i].ID == nil || sorted[i].ID.IsZero() ||
+			sorted[i].Hiding == nil || sorted[i].Binding == nil {
+			return nil, ErrCommitments
+		}
+	}
+	slices.SortFunc(sorted, func(a, b Commitment) int {
+		return p.scalarToInt(a.ID).Cmp(p.scalarToInt(b.ID))
+	})
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i-1].ID.IsEqual(sorted[i].ID) {
+			return nil, ErrCommitments
+		}
+	}
+
+	// encode_group_commitment_list
+	var encComs []byte
+	for i := range sorted {
+		enc, err := sorted[i].MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		encComs = append(encComs, enc...)
+	}
+
+	// compute_binding_factors
+	encPK, err := p.serializeElement(groupPublicKey.key)
+	if err != nil {
+		return nil, err
+	}
+	prefix := slices.Concat(encPK, p.h4(msg), p.h5(encComs))
+	bindingFactors := make([]group.Scalar, len(sorted))
+	for i := range sorted {
+		encID, errID := sorted[i].ID.MarshalBinary()
+		if errID != nil {
+			return nil, errID
+		}
+		bindingFactors[i] = p.h1(prefix, encID)
+	}
+
+	// compute_group_commitment
+	groupCom := p.g.Identity()
+	tmp := p.g.NewElement()
+	for i := range sorted {
+		tmp.Mul(sorted[i].Binding, bindingFactors[i])
+		groupCom.Add(groupCom, tmp)
+		groupCom.Add(groupCom, sorted[i].Hiding)
+	}
+
+	challenge, err := p.challenge(groupCom, groupPublicKey.key, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &commitmentList{p, sorted, bindingFactors, groupCom, challenge}, nil
+}
+
+// index returns the position of the commitment with the given identifier, or
+// -1 if there is none.
+func (l *commitmentList) index(id group.Scalar) int {
+	for i := range l.coms {
+		if l.coms[i].ID.IsEqual(id) {
+			return i
+		}
+	}
+	return -1
+}
+
+// lagrange returns the Lagrange coefficient of the i-th participant at zero,
+// see derive_interpolating_value in RFC 9591, Section 4.2.
+func (l *commitmentList) lagrange(i int) group.Scalar {
+	ids := make([]group.Scalar, len(l.coms))
+	for j := range l.coms {
+		ids[j] = l.coms[j].ID
+	}
+	return polynomial.LagrangeBase(uint(i), ids, l.p.g.NewScalar())
+}
+
+// commitmentShare returns the commitment of the i-th participant weighted
+// by its binding factor.
+func (l *commitmentList) commitmentShare(i int) group.Element {
+	e := l.p.g.NewElement().Mul(l.coms[i].Binding, l.bindingFactors[i])
+	return e.Add(e, l.coms[i].Hiding)
+}
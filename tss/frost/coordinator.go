@@ -0,0 +1,103 @@
+package frost
+
+import (
+	"slices"
+)
+
+// Coordinator collects commitments and signature shares from signers, and
+// aggregates them into a signature under the group public key.
+type Coordinator struct {
+	Suite
+	threshold      uint
+	maxSigners     uint
+	groupPublicKey PublicKey
+}
+
+// NewCoordinator returns a Coordinator for a group of maxSigners signers
+// that requires at least threshold+1 of them to sign.
+func NewCoordinator(s Suite, groupPublicKey PublicKey, threshold, maxSigners uint) (*Coordinator, error) {
+	if threshold == 0 || threshold >= maxSigners || maxSigners >= 1<<16 {
+		return nil, ErrThreshold
+	}
+	if groupPublicKey.Suite != s || groupPublicKey.key == nil || groupPublicKey.key.IsIdentity() {
+		return nil, ErrIdentity
+	}
+	return &Coordinator{s, threshold, maxSigners, groupPublicKey}, nil
+}
+
+// VerifySignShare returns true if signShare is a valid signature share of
+// msg produced by the signer with public key signerPublicKey, given the list
+// of commitments used for signing. See verify_signature_share in RFC 9591,
+// Section 5.4.
+func (c Coordinator) VerifySignShare(
+	msg []byte, coms []Commitment, signShare SignShare, signerPublicKey PublicKey,
+) bool {
+	list, err := newCommitmentList(c.Suite, c.threshold, c.maxSigners, c.groupPublicKey, coms, msg)
+	if err != nil {
+		return false
+	}
+	return c.verifySignShare(list, signShare, signerPublicKey)
+}
+
+func (c Coordinator) verifySignShare(
+	list *commitmentList, signShare SignShare, signerPublicKey PublicKey,
+) bool {
+	if signShare.Suite != c.Suite || signShare.ID == nil || signShare.Share == nil ||
+		signerPublicKey.Suite != c.Suite || signerPublicKey.key == nil {
+		return false
+	}
+
+	i := list.index(signShare.ID)
+	if i < 0 {
+		return false
+	}
+
+	g := c.getParams().group()
+	lc := g.NewScalar().Mul(list.lagrange(i), list.challenge)
+	r := g.NewElement().Mul(signerPublicKey.key, lc)
+	r.Add(r, list.commitmentShare(i))
+	l := g.NewElement().MulGen(signShare.Share)
+	return l.IsEqual(r)
+}
+
+// Aggregate combines the signature shares into a signature of msg under the
+// group public key, given the list of commitments used for signing. There
+// must be one signature share per commitment. See aggregate in RFC 9591,
+// Section 5.3.
+//
+// Aggregate does not check the signature shares. If the resulting signature
+// is not valid, VerifySignShare can be used to find the faulty shares.
+func (c Coordinator) Aggregate(msg []byte, coms []Commitment, signShares []SignShare) ([]byte, error) {
+	list, err := newCommitmentList(c.Suite, c.threshold, c.maxSigners, c.groupPublicKey, coms, msg)
+	if err != nil {
+		return nil, err
+	}
+	if len(signShares) != len(list.coms) {
+		return nil, ErrSignShare
+	}
+
+	g := c.getParams().group()
+	seen := make([]bool, len(list.coms))
+	z := g.NewScalar()
+	for i := range signShares {
+		if signShares[i].Suite != c.Suite || signShares[i].ID == nil || signShares[i].Share == nil {
+			return nil, ErrSignShare
+		}
+		j := list.index(signShares[i].ID)
+		if j < 0 || seen[j] {
+			return nil, ErrSignShare
+		}
+		seen[j] = true
+		z.Add(z, signShares[i].Share)
+	}
+
+	encR, err := c.getParams().serializeElement(list.groupCom)
+	if err != nil {
+		return nil, err
+	}
+	encZ, err := z.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return slices.Concat(encR, encZ), nil
+}
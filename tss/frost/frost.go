@@ -0,0 +1,182 @@
+// Package frost provides the FROST threshold Schnorr signature scheme.
+//
+// This package implements FROST (Flexible Round-Optimized Schnorr Threshold
+// signatures) as specified in RFC 9591 [1], with the following ciphersuites:
+//
+//   - FROST(ristretto255, SHA-512),
+//   - FROST(P-256, SHA-256), and
+//   - FROST(Ed25519, SHA-512), whose signatures are valid Ed25519 signatures.
+//
+// Key generation uses a trusted dealer (RFC 9591, Appendix C): PrivateKey.Split
+// shares a private key using a (t,n) Shamir secret sharing, such that any
+// subset of at least t+1 signers can produce a signature. Shares can be
+// validated against the Feldman commitment returned by Split. Alternatively,
+// shares produced by the distributed key generation of secretsharing/dkg can
+// be used with NewPeerSigner, so the private key is never materialized.
+//
+// Signing is a two-round protocol:
+//
+//  1. Each signer calls PeerSigner.Commit, keeps the Nonce secret, and sends
+//     the Commitment to the Coordinator.
+//  2. The Coordinator chooses a set of commitments and the message, and each
+//     chosen signer calls PeerSigner.Sign, sending the SignShare back.
+//
+// Finally, Coordinator.Aggregate combines the signature shares into a
+// signature that can be checked with Verify. Signature shares can be
+// individually checked with Coordinator.VerifySignShare to identify
+// misbehaving signers.
+//
+// Warning: A Nonce must be used for signing at most once.
+//
+// Warning: With the P256 suite, signing operations are currently not constant
+// time in the secret key share.
+//
+// References
+//
+//	[1] https://www.rfc-editor.org/rfc/rfc9591
+package frost
+
+import (
+	"errors"
+	"io"
+
+	"github.com/cloudflare/circl/group"
+	"github.com/cloudflare/circl/secretsharing"
+)
+
+var (
+	ErrThreshold   = errors.New("frost: invalid threshold")
+	ErrCommitments = errors.New("frost: invalid list of commitments")
+	ErrSignShare   = errors.New("frost: invalid signature share")
+	ErrNonce       = errors.New("frost: nonce does not match commitment")
+	ErrIdentity    = errors.New("frost: unexpected identity element")
+	ErrUnmarshal   = errors.New("frost: error unmarshaling")
+)
+
+// PrivateKey is a FROST group private key. It is only used by a trusted
+// dealer to generate key shares.
+type PrivateKey struct {
+	Suite
+	key       group.Scalar
+	publicKey *PublicKey
+}
+
+// PublicKey is either the group public key, which is used to verify
+// signatures, or the public key of a signer, which is used to verify its
+// signature shares.
+type PublicKey struct {
+	Suite
+	key group.Element
+}
+
+// GenerateKey returns a random private key for the given suite.
+func GenerateKey(s Suite, rnd io.Reader) PrivateKey {
+	g := s.getParams().group()
+	return PrivateKey{Suite: s, key: g.RandomNonZeroScalar(rnd)}
+}
+
+// NewPrivateKey returns a private key from a non-zero scalar of the suite's
+// group.
+func NewPrivateKey(s Suite, key group.Scalar) (PrivateKey, error) {
+	if key.IsZero() {
+		return PrivateKey{}, ErrUnmarshal
+	}
+	return PrivateKey{Suite: s, key: key.Copy()}, nil
+}
+
+// Public returns the group public key corresponding to the private key.
+func (k *PrivateKey) Public() PublicKey {
+	if k.publicKey == nil {
+		g := k.getParams().group()
+		k.publicKey = &PublicKey{k.Suite, g.NewElement().MulGen(k.key)}
+	}
+	return *k.publicKey
+}
+
+// Split generates maxSigners shares of the private key, such that any
+// subset of at least threshold+1 signers can produce a signature. It returns
+// a PeerSigner for each share, and a commitment to the private key that
+// allows signers to check their key share with PeerSigner.CheckKeyShare.
+func (k *PrivateKey) Split(rnd io.Reader, threshold, maxSigners uint) (
+	[]PeerSigner, secretsharing.SecretCommitment, error,
+) {
+	if threshold == 0 || threshold >= maxSigners || maxSigners >= 1<<16 {
+		return nil, nil, ErrThreshold
+	}
+
+	ss := secretsharing.New(rnd, threshold, k.key)
+	shares := ss.Share(maxSigners)
+	groupPublicKey := k.Public()
+	g := k.getParams().group()
+
+	peers := make([]PeerSigner, len(shares))
+	for i := range shares {
+		peers[i] = PeerSigner{
+			Suite:          k.Suite,
+			threshold:      uint16(threshold),
+			maxSigners:     uint16(maxSigners),
+			keyShare:       shares[i],
+			publicKey:      PublicKey{k.Suite, g.NewElement().MulGen(shares[i].Value)},
+			groupPublicKey: groupPublicKey,
+		}
+	}
+
+	return peers, ss.CommitSecret(), nil
+}
+
+// NewPublicKey returns a public key from a non-identity element of the
+// suite's group.
+func NewPublicKey(s Suite, key group.Element) (PublicKey, error) {
+	if key.IsIdentity() {
+		return PublicKey{}, ErrIdentity
+	}
+	return PublicKey{s, key.Copy()}, nil
+}
+
+// Element returns a copy of the group element of the public key.
+func (k PublicKey) Element() group.Element { return k.key.Copy() }
+
+// MarshalBinary returns the serialization of the public key.
+func (k PublicKey) MarshalBinary() ([]byte, error) {
+	return k.getParams().serializeElement(k.key)
+}
+
+// UnmarshalBinary recovers a public key from its serialization. The Suite of
+// the receiver must be set before calling this function.
+func (k *PublicKey) UnmarshalBinary(data []byte) (err error) {
+	k.key, err = k.getParams().deserializeElement(data)
+	return err
+}
+
+// Verify returns true if signature is a valid signature of msg under the
+// group public key pubKey.
+func Verify(pubKey PublicKey, msg, signature []byte) bool {
+	p := pubKey.getParams()
+	g := p.group()
+	eltLen := int(g.Params().CompressedElementLength)
+	sclLen := int(g.Params().ScalarLength)
+	if len(signature) != eltLen+sclLen {
+		return false
+	}
+
+	R, err := p.deserializeElement(signature[:eltLen])
+	if err != nil {
+		return false
+	}
+	z := g.NewScalar()
+	if err = z.UnmarshalBinary(signature[eltLen:]); err != nil {
+		return false
+	}
+
+	c, err := p.challenge(R, pubKey.key, msg)
+	if err != nil {
+		return false
+	}
+
+	// Since elements are always decoded into the prime-order subgroup, there
+	// is no need to clear the cofactor for the Ed25519 suite.
+	l := g.NewElement().MulGen(z)
+	r := g.NewElement().Mul(pubKey.key, c)
+	r.Add(r, R)
+	return l.IsEqual(r)
+}
@@ -0,0 +1,233 @@
+package frost_test
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ed25519"
+	"github.com/cloudflare/circl/tss/frost"
+)
+
+var allSuites = []frost.Suite{frost.Ristretto255, frost.P256, frost.Ed25519}
+
+func TestFrost(t *testing.T) {
+	for _, s := range allSuites {
+		t.Run(fmt.Sprint(s), func(t *testing.T) {
+			for _, tn := range [][2]uint{{1, 2}, {1, 3}, {2, 3}, {3, 5}, {4, 9}} {
+				testSign(t, s, tn[0], tn[1])
+			}
+			testSignShareVerify(t, s)
+			testErrors(t, s)
+			testMarshal(t, s)
+		})
+	}
+}
+
+// runProtocol signs msg with the given signers and returns their commitments
+// and signature shares.
+func runProtocol(
+	t *testing.T, peers []frost.PeerSigner, msg []byte,
+) ([]frost.Commitment, []frost.SignShare) {
+	t.Helper()
+	nonces := make([]*frost.Nonce, len(peers))
+	coms := make([]frost.Commitment, len(peers))
+	for i := range peers {
+		nonce, com, err := peers[i].Commit(rand.Reader)
+		test.CheckNoErr(t, err, "commit failed")
+		nonces[i], coms[i] = nonce, *com
+	}
+
+	shares := make([]frost.SignShare, len(peers))
+	for i := range peers {
+		share, err := peers[i].Sign(msg, nonces[i], coms)
+		test.CheckNoErr(t, err, "sign failed")
+		shares[i] = *share
+	}
+	return coms, shares
+}
+
+func testSign(t *testing.T, s frost.Suite, threshold, maxSigners uint) {
+	privKey := frost.GenerateKey(s, rand.Reader)
+	pubKey := privKey.Public()
+	peers, keyShareCom, err := privKey.Split(rand.Reader, threshold, maxSigners)
+	test.CheckNoErr(t, err, "split failed")
+
+	for i := range peers {
+		test.CheckOk(peers[i].CheckKeyShare(keyShareCom), "invalid key share", t)
+	}
+
+	coordinator, err := frost.NewCoordinator(s, pubKey, threshold, maxSigners)
+	test.CheckNoErr(t, err, "failed to create coordinator")
+
+	msg := []byte("it's cold here")
+	// Any subset of at least threshold+1 signers, in any order.
+	for _, signers := range [][]frost.PeerSigner{
+		peers[:threshold+1],
+		peers[maxSigners-threshold-1:],
+		append(append([]frost.PeerSigner{}, peers[maxSigners-1]), peers[:threshold]...),
+		peers,
+	} {
+		coms, shares := runProtocol(t, signers, msg)
+		for i := range shares {
+			ok := coordinator.VerifySignShare(msg, coms, shares[i], signers[i].Public())
+			test.CheckOk(ok, "signature share must be valid", t)
+		}
+
+		signature, err := coordinator.Aggregate(msg, coms, shares)
+		test.CheckNoErr(t, err, "failed to aggregate")
+		test.CheckOk(frost.Verify(pubKey, msg, signature), "signature must be valid", t)
+		test.CheckOk(!frost.Verify(pubKey, []byte("other"), signature), "signature must be invalid", t)
+
+		if s == frost.Ed25519 {
+			encPubKey, err := pubKey.MarshalBinary()
+			test.CheckNoErr(t, err, "failed to marshal public key")
+			ok := ed25519.Verify(ed25519.PublicKey(encPubKey), msg, signature)
+			test.CheckOk(ok, "signature must be a valid Ed25519 signature", t)
+		}
+	}
+
+	// Not enough signers.
+	coms, shares := runProtocol(t, peers[:threshold+1], msg)
+	_, err = coordinator.Aggregate(msg, coms[:threshold], shares[:threshold])
+	test.CheckIsErr(t, err, "should fail with not enough signers")
+	// Missing signature shares.
+	_, err = coordinator.Aggregate(msg, coms, shares[:threshold])
+	test.CheckIsErr(t, err, "should fail with missing signature shares")
+}
+
+func testSignShareVerify(t *testing.T, s frost.Suite) {
+	const threshold, maxSigners = 2, 4
+	privKey := frost.GenerateKey(s, rand.Reader)
+	pubKey := privKey.Public()
+	peers, _, err := privKey.Split(rand.Reader, threshold, maxSigners)
+	test.CheckNoErr(t, err, "split failed")
+	coordinator, err := frost.NewCoordinator(s, pubKey, threshold, maxSigners)
+	test.CheckNoErr(t, err, "failed to create coordinator")
+
+	msg := []byte("hello")
+	signers := peers[:threshold+1]
+	coms, shares := runProtocol(t, signers, msg)
+
+	// A faulty signer is detected.
+	one := s.Group().NewScalar().SetUint64(1)
+	shares[1].Share.Add(shares[1].Share, one)
+	test.CheckOk(coordinator.VerifySignShare(msg, coms, shares[0], signers[0].Public()), "share must be valid", t)
+	test.CheckOk(!coordinator.VerifySignShare(msg, coms, shares[1], signers[1].Public()), "share must be invalid", t)
+	test.CheckOk(!coordinator.VerifySignShare(msg, coms, shares[0], signers[1].Public()), "share must be invalid", t)
+	test.CheckOk(!coordinator.VerifySignShare([]byte("other"), coms, shares[0], signers[0].Public()), "share must be invalid", t)
+
+	signature, err := coordinator.Aggregate(msg, coms, shares)
+	test.CheckNoErr(t, err, "failed to aggregate")
+	test.CheckOk(!frost.Verify(pubKey, msg, signature), "signature must be invalid", t)
+}
+
+func testErrors(t *testing.T, s frost.Suite) {
+	privKey := frost.GenerateKey(s, rand.Reader)
+	pubKey := privKey.Public()
+
+	for _, tn := range [][2]uint{{0, 3}, {3, 3}, {4, 3}} {
+		_, _, err := privKey.Split(rand.Reader, tn[0], tn[1])
+		test.CheckIsErr(t, err, "split should fail")
+		_, err = frost.NewCoordinator(s, pubKey, tn[0], tn[1])
+		test.CheckIsErr(t, err, "coordinator should fail")
+	}
+
+	peers, _, err := privKey.Split(rand.Reader, 1, 3)
+	test.CheckNoErr(t, err, "split failed")
+	msg := []byte("hello")
+	nonce0, com0, err := peers[0].Commit(rand.Reader)
+	test.CheckNoErr(t, err, "commit failed")
+	nonce1, com1, err := peers[1].Commit(rand.Reader)
+	test.CheckNoErr(t, err, "commit failed")
+	_, com2, err := peers[2].Commit(rand.Reader)
+	test.CheckNoErr(t, err, "commit failed")
+
+	// Signer is not part of the commitment list.
+	_, err = peers[0].Sign(msg, nonce0, []frost.Commitment{*com1, *com2})
+	test.CheckIsErr(t, err, "should fail without own commitment")
+	// Nonce does not belong to the signer.
+	_, err = peers[0].Sign(msg, nonce1, []frost.Commitment{*com0, *com1})
+	test.CheckIsErr(t, err, "should fail with other's nonce")
+	// Duplicated commitments.
+	_, err = peers[0].Sign(msg, nonce0, []frost.Commitment{*com0, *com0})
+	test.CheckIsErr(t, err, "should fail with duplicated commitments")
+	// Not enough commitments.
+	_, err = peers[0].Sign(msg, nonce0, []frost.Commitment{*com0})
+	test.CheckIsErr(t, err, "should fail with not enough commitments")
+	// Commitment does not match the nonce.
+	_, otherCom, err := peers[0].Commit(rand.Reader)
+	test.CheckNoErr(t, err, "commit failed")
+	_, err = peers[0].Sign(msg, nonce0, []frost.Commitment{*otherCom, *com1})
+	test.CheckIsErr(t, err, "should fail with mismatched nonce")
+
+	// Invalid signatures.
+	g := s.Group()
+	sigLen := g.Params().CompressedElementLength + g.Params().ScalarLength
+	test.CheckOk(!frost.Verify(pubKey, msg, nil), "empty signature must be invalid", t)
+	test.CheckOk(!frost.Verify(pubKey, msg, make([]byte, sigLen)), "zero signature must be invalid", t)
+}
+
+func testMarshal(t *testing.T, s frost.Suite) {
+	privKey := frost.GenerateKey(s, rand.Reader)
+	pubKey := privKey.Public()
+	peers, _, err := privKey.Split(rand.Reader, 1, 2)
+	test.CheckNoErr(t, err, "split failed")
+	coms, shares := runProtocol(t, peers, []byte("hello"))
+
+	test.CheckMarshal(t, &pubKey, &frost.PublicKey{Suite: s})
+	test.CheckMarshal(t, &coms[0], &frost.Commitment{Suite: s})
+	test.CheckMarshal(t, &shares[0], &frost.SignShare{Suite: s})
+
+	err = (&frost.PublicKey{Suite: s}).UnmarshalBinary(nil)
+	test.CheckIsErr(t, err, "should fail to unmarshal")
+	err = (&frost.Commitment{Suite: s}).UnmarshalBinary([]byte{0})
+	test.CheckIsErr(t, err, "should fail to unmarshal")
+	err = (&frost.SignShare{Suite: s}).UnmarshalBinary([]byte{0})
+	test.CheckIsErr(t, err, "should fail to unmarshal")
+}
+
+func BenchmarkFrost(b *testing.B) {
+	const threshold, maxSigners = 2, 3
+	msg := []byte("hello")
+	for _, s := range allSuites {
+		privKey := frost.GenerateKey(s, rand.Reader)
+		peers, _, _ := privKey.Split(rand.Reader, threshold, maxSigners)
+		coordinator, _ := frost.NewCoordinator(s, privKey.Public(), threshold, maxSigners)
+
+		nonces := make([]*frost.Nonce, maxSigners)
+		coms := make([]frost.Commitment, maxSigners)
+		shares := make([]frost.SignShare, maxSigners)
+		for i := range peers {
+			nonce, com, _ := peers[i].Commit(rand.Reader)
+			nonces[i], coms[i] = nonce, *com
+		}
+		for i := range peers {
+			share, _ := peers[i].Sign(msg, nonces[i], coms)
+			shares[i] = *share
+		}
+		signature, _ := coordinator.Aggregate(msg, coms, shares)
+
+		b.Run(s.String()+"/Commit", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _, _ = peers[0].Commit(rand.Reader)
+			}
+		})
+		b.Run(s.String()+"/Sign", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = peers[0].Sign(msg, nonces[0], coms)
+			}
+		})
+		b.Run(s.String()+"/Aggregate", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = coordinator.Aggregate(msg, coms, shares)
+			}
+		})
+		b.Run(s.String()+"/Verify", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = frost.Verify(privKey.Public(), msg, signature)
+			}
+		})
+	}
+}
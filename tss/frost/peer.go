@@ -0,0 +1,186 @@
+package frost
+
+import (
+	"io"
+	"slices"
+
+	"github.com/cloudflare/circl/group"
+	"github.com/cloudflare/circl/secretsharing"
+)
+
+// PeerSigner is a signer holding a share of the group private key.
+type PeerSigner struct {
+	Suite
+	threshold      uint16
+	maxSigners     uint16
+	keyShare       secretsharing.Share
+	publicKey      PublicKey
+	groupPublicKey PublicKey
+}
+
+// NewPeerSigner returns a signer from a share of the group private key
+// produced by a (threshold, maxSigners) secret sharing, as done by
+// PrivateKey.Split or by the distributed key generation in
+// secretsharing/dkg.
+func NewPeerSigner(
+	s Suite, threshold, maxSigners uint, keyShare secretsharing.Share, groupPublicKey PublicKey,
+) (PeerSigner, error) {
+	if threshold == 0 || threshold >= maxSigners || maxSigners >= 1<<16 {
+		return PeerSigner{}, ErrThreshold
+	}
+	if keyShare.ID == nil || keyShare.ID.IsZero() || keyShare.Value == nil {
+		return PeerSigner{}, ErrUnmarshal
+	}
+	if groupPublicKey.Suite != s || groupPublicKey.key == nil || groupPublicKey.key.IsIdentity() {
+		return PeerSigner{}, ErrIdentity
+	}
+
+	g := s.getParams().group()
+	return PeerSigner{
+		Suite:      s,
+		threshold:  uint16(threshold),
+		maxSigners: uint16(maxSigners),
+		keyShare: secretsharing.Share{
+			ID:    keyShare.ID.Copy(),
+			Value: keyShare.Value.Copy(),
+		},
+		publicKey:      PublicKey{s, g.NewElement().MulGen(keyShare.Value)},
+		groupPublicKey: groupPublicKey,
+	}, nil
+}
+
+// ID returns the identifier of the signer.
+func (p PeerSigner) ID() group.Scalar { return p.keyShare.ID.Copy() }
+
+// Public returns the public key of the signer, which is used to verify its
+// signature shares.
+func (p PeerSigner) Public() PublicKey { return p.publicKey }
+
+// GroupPublicKey returns the group public key.
+func (p PeerSigner) GroupPublicKey() PublicKey { return p.groupPublicKey }
+
+// CheckKeyShare returns true if the key share of the signer is consistent
+// with the commitment c produced when splitting the private key.
+func (p PeerSigner) CheckKeyShare(c secretsharing.SecretCommitment) bool {
+	return len(c) > 0 && c[0].IsEqual(p.groupPublicKey.key) &&
+		secretsharing.Verify(uint(p.threshold), p.keyShare, c)
+}
+
+// Commit runs the first round of signing. It returns a Nonce that must be
+// kept secret and used at most once, and a Commitment that is sent to the
+// Coordinator. See commit in RFC 9591, Section 5.1.
+func (p PeerSigner) Commit(rnd io.Reader) (*Nonce, *Commitment, error) {
+	hiding, err := p.nonceGenerate(rnd)
+	if err != nil {
+		return nil, nil, err
+	}
+	binding, err := p.nonceGenerate(rnd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	g := p.getParams().group()
+	nonce := &Nonce{ID: p.keyShare.ID.Copy(), hiding: hiding, binding: binding}
+	com := &Commitment{
+		Suite:   p.Suite,
+		ID:      p.keyShare.ID.Copy(),
+		Hiding:  g.NewElement().MulGen(hiding),
+		Binding: g.NewElement().MulGen(binding),
+	}
+	return nonce, com, nil
+}
+
+// nonceGenerate returns H3(random_bytes(32) || SerializeScalar(secret)), see
+// RFC 9591, Section 4.1.
+func (p PeerSigner) nonceGenerate(rnd io.Reader) (group.Scalar, error) {
+	var random [32]byte
+	if _, err := io.ReadFull(rnd, random[:]); err != nil {
+		return nil, err
+	}
+	secret, err := p.keyShare.Value.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return p.getParams().h3(random[:], secret), nil
+}
+
+// Sign runs the second round of signing. Given the message and the list of
+// commitments chosen by the Coordinator, which must contain the commitment
+// of this signer, it returns the signature share of the signer using the
+// nonce generated by Commit. See sign in RFC 9591, Section 5.2.
+func (p PeerSigner) Sign(msg []byte, nonce *Nonce, coms []Commitment) (*SignShare, error) {
+	if nonce == nil || !nonce.ID.IsEqual(p.keyShare.ID) {
+		return nil, ErrNonce
+	}
+
+	list, err := newCommitmentList(
+		p.Suite, uint(p.threshold), uint(p.maxSigners), p.groupPublicKey, coms, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	i := list.index(p.keyShare.ID)
+	if i < 0 {
+		return nil, ErrCommitments
+	}
+
+	g := p.getParams().group()
+	hiding := g.NewElement().MulGen(nonce.hiding)
+	binding := g.NewElement().MulGen(nonce.binding)
+	if !hiding.IsEqual(list.coms[i].Hiding) || !binding.IsEqual(list.coms[i].Binding) {
+		return nil, ErrNonce
+	}
+
+	// z_i = hiding + binding * rho_i + lambda_i * sk_i * c
+	z := g.NewScalar().Mul(list.lagrange(i), p.keyShare.Value)
+	z.Mul(z, list.challenge)
+	tmp := g.NewScalar().Mul(nonce.binding, list.bindingFactors[i])
+	z.Add(z, tmp)
+	z.Add(z, nonce.hiding)
+
+	return &SignShare{Suite: p.Suite, ID: p.keyShare.ID.Copy(), Share: z}, nil
+}
+
+// SignShare is a signature share produced by a signer in the second round.
+type SignShare struct {
+	Suite
+	ID    group.Scalar
+	Share group.Scalar
+}
+
+// MarshalBinary returns the serialization of the signature share, which is
+// SerializeScalar(ID) || SerializeScalar(Share).
+func (s SignShare) MarshalBinary() ([]byte, error) {
+	id, err := s.ID.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	share, err := s.Share.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return slices.Concat(id, share), nil
+}
+
+// UnmarshalBinary recovers a signature share from its serialization. The
+// Suite of the receiver must be set before calling this function.
+func (s *SignShare) UnmarshalBinary(data []byte) error {
+	p := s.getParams()
+	sclLen := int(p.g.Params().ScalarLength)
+	if len(data) != 2*sclLen {
+		return ErrUnmarshal
+	}
+	id, err := p.deserializeScalar(data[:sclLen])
+	if err != nil {
+		return err
+	}
+	if id.IsZero() {
+		return ErrUnmarshal
+	}
+	share, err := p.deserializeScalar(data[sclLen:])
+	if err != nil {
+		return err
+	}
+	s.ID, s.Share = id, share
+	return nil
+}
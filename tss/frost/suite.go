@@ -0,0 +1,204 @@
+package frost
+
+import (
+	"crypto"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"math/big"
+	"slices"
+
+	"github.com/cloudflare/circl/group"
+)
+
+// Suite identifies a FROST ciphersuite.
+type Suite uint8
+
+const (
+	// Ristretto255 is the FROST(ristretto255, SHA-512) ciphersuite.
+	Ristretto255 Suite = iota + 1
+	// P256 is the FROST(P-256, SHA-256) ciphersuite.
+	P256
+	// Ed25519 is the FROST(Ed25519, SHA-512) ciphersuite. It produces
+	// signatures that verify with the Ed25519 verification algorithm.
+	Ed25519
+)
+
+// String returns the context string of the suite.
+func (s Suite) String() string { return s.getParams().context }
+
+// Group returns the prime-order group used by the suite.
+func (s Suite) Group() group.Group { return s.getParams().group() }
+
+func (s Suite) getParams() *suiteParams {
+	switch s {
+	case Ristretto255:
+		return &suiteRistretto255
+	case P256:
+		return &suiteP256
+	case Ed25519:
+		return &suiteEd25519
+	default:
+		panic("frost: unsupported suite")
+	}
+}
+
+// suiteParams holds the group and hash functions H1 to H5 of a ciphersuite,
+// see RFC 9591, Section 6.
+type suiteParams struct {
+	g       group.Group
+	hash    crypto.Hash
+	context string
+	// If set, hashing to scalars uses hash_to_field from RFC 9380 instead of
+	// reducing the digest modulo the group order.
+	hashToField bool
+	// If set, H2 does not prepend the context string, as required for
+	// compatibility with Ed25519.
+	plainChallenge bool
+	// If set, scalars are encoded in little-endian order.
+	littleEndian bool
+}
+
+var (
+	suiteRistretto255 = suiteParams{
+		g:            group.Ristretto255,
+		hash:         crypto.SHA512,
+		context:      "FROST-RISTRETTO255-SHA512-v1",
+		littleEndian: true,
+	}
+	suiteP256 = suiteParams{
+		g:           group.P256,
+		hash:        crypto.SHA256,
+		context:     "FROST-P256-SHA256-v1",
+		hashToField: true,
+	}
+	suiteEd25519 = suiteParams{
+		g:              group.Ed25519,
+		hash:           crypto.SHA512,
+		context:        "FROST-ED25519-SHA512-v1",
+		plainChallenge: true,
+		littleEndian:   true,
+	}
+)
+
+func (p *suiteParams) group() group.Group { return p.g }
+
+func (p *suiteParams) hashToScalar(tag string, msg ...[]byte) group.Scalar {
+	if p.hashToField {
+		return p.g.HashToScalar(slices.Concat(msg...), []byte(p.context+tag))
+	}
+
+	h := p.hash.New()
+	if tag != "" {
+		_, _ = h.Write([]byte(p.context + tag))
+	}
+	for _, m := range msg {
+		_, _ = h.Write(m)
+	}
+	return p.reduceLittleEndian(h.Sum(nil))
+}
+
+// reduceLittleEndian interprets x as a little-endian integer and returns it
+// reduced modulo the group order. To avoid non-constant-time operations on
+// secrets, x is split into chunks smaller than the group order, which are
+// then combined with scalar arithmetic.
+func (p *suiteParams) reduceLittleEndian(x []byte) group.Scalar {
+	scalarLen := int(p.g.Params().ScalarLength)
+	chunkLen := scalarLen - 1
+	shift := p.g.NewScalar().SetBigInt(new(big.Int).Lsh(big.NewInt(1), uint(8*chunkLen)))
+
+	s := p.g.NewScalar()
+	chunk := p.g.NewScalar()
+	buf := make([]byte, scalarLen)
+	for i := (len(x) - 1) / chunkLen; i >= 0; i-- {
+		clear(buf)
+		copy(buf, x[i*chunkLen:min((i+1)*chunkLen, len(x))])
+		if err := chunk.UnmarshalBinary(buf); err != nil {
+			panic(err)
+		}
+		s.Mul(s, shift)
+		s.Add(s, chunk)
+	}
+	return s
+}
+
+func (p *suiteParams) hashToBytes(tag string, msg []byte) []byte {
+	h := p.hash.New()
+	_, _ = h.Write([]byte(p.context + tag))
+	_, _ = h.Write(msg)
+	return h.Sum(nil)
+}
+
+// h1 derives binding factors.
+func (p *suiteParams) h1(m ...[]byte) group.Scalar { return p.hashToScalar("rho", m...) }
+
+// h2 derives the challenge.
+func (p *suiteParams) h2(m ...[]byte) group.Scalar {
+	if p.plainChallenge {
+		return p.hashToScalar("", m...)
+	}
+	return p.hashToScalar("chal", m...)
+}
+
+// h3 derives nonces.
+func (p *suiteParams) h3(m ...[]byte) group.Scalar { return p.hashToScalar("nonce", m...) }
+
+// h4 hashes the message.
+func (p *suiteParams) h4(m []byte) []byte { return p.hashToBytes("msg", m) }
+
+// h5 hashes the encoded list of commitments.
+func (p *suiteParams) h5(m []byte) []byte { return p.hashToBytes("com", m) }
+
+func (p *suiteParams) serializeElement(e group.Element) ([]byte, error) {
+	if e.IsIdentity() {
+		return nil, ErrIdentity
+	}
+	return e.MarshalBinaryCompress()
+}
+
+func (p *suiteParams) deserializeElement(data []byte) (group.Element, error) {
+	e := p.g.NewElement()
+	if err := e.UnmarshalBinary(data); err != nil {
+		return nil, ErrUnmarshal
+	}
+	if e.IsIdentity() {
+		return nil, ErrIdentity
+	}
+	return e, nil
+}
+
+func (p *suiteParams) deserializeScalar(data []byte) (group.Scalar, error) {
+	if len(data) != int(p.g.Params().ScalarLength) {
+		return nil, ErrUnmarshal
+	}
+	s := p.g.NewScalar()
+	if err := s.UnmarshalBinary(data); err != nil {
+		return nil, ErrUnmarshal
+	}
+	return s, nil
+}
+
+// scalarToInt returns the integer represented by a scalar, which is used to
+// sort identifiers.
+func (p *suiteParams) scalarToInt(s group.Scalar) *big.Int {
+	b, err := s.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	if p.littleEndian {
+		slices.Reverse(b)
+	}
+	return new(big.Int).SetBytes(b)
+}
+
+// challenge computes H2(R || PK || msg), see RFC 9591, Section 4.6.
+func (p *suiteParams) challenge(R, pubKey group.Element, msg []byte) (group.Scalar, error) {
+	encR, err := p.serializeElement(R)
+	if err != nil {
+		return nil, err
+	}
+	encPK, err := p.serializeElement(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	return p.h2(encR, encPK, msg), nil
+}
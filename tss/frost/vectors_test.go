@@ -0,0 +1,187 @@
+package frost_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/circl/group"
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/secretsharing"
+	"github.com/cloudflare/circl/tss/frost"
+)
+
+// vectorSigner holds the inputs and outputs of a participant in a test
+// vector.
+type vectorSigner struct {
+	id                uint64
+	share             string
+	hidingRandomness  string
+	bindingRandomness string
+	sigShare          string
+}
+
+// vector is a test vector of Appendix E of RFC 9591, with MAX_PARTICIPANTS
+// = 3 and MIN_PARTICIPANTS = 2.
+type vector struct {
+	suite     frost.Suite
+	secretKey string
+	publicKey string
+	msg       string
+	signers   []vectorSigner
+	signature string
+}
+
+// Test vectors from Appendix E of RFC 9591. The suites FROST(Ed448,
+// SHAKE256) and FROST(secp256k1, SHA-256) are not supported.
+var rfc9591Vectors = []vector{
+	{
+		// Appendix E.1: FROST(Ed25519, SHA-512).
+		suite:     frost.Ed25519,
+		secretKey: "7b1c33d3f5291d85de664833beb1ad469f7fb6025a0ec78b3a790c6e13a98304",
+		publicKey: "15d21ccd7ee42959562fc8aa63224c8851fb3ec85a3faf66040d380fb9738673",
+		msg:       "74657374",
+		signers: []vectorSigner{
+			{
+				id:                1,
+				share:             "929dcc590407aae7d388761cddb0c0db6f5627aea8e217f4a033f2ec83d93509",
+				hidingRandomness:  "0fd2e39e111cdc266f6c0f4d0fd45c947761f1f5d3cb583dfcb9bbaf8d4c9fec",
+				bindingRandomness: "69cd85f631d5f7f2721ed5e40519b1366f340a87c2f6856363dbdcda348a7501",
+				sigShare:          "001719ab5a53ee1a12095cd088fd149702c0720ce5fd2f29dbecf24b7281b603",
+			},
+			{
+				id:                3,
+				share:             "d3cb090a075eb154e82fdb4b3cb507f110040905468bb9c46da8bdea643a9a02",
+				hidingRandomness:  "86d64a260059e495d0fb4fcc17ea3da7452391baa494d4b00321098ed2a0062f",
+				bindingRandomness: "13e6b25afb2eba51716a9a7d44130c0dbae0004a9ef8d7b5550c8a0e07c61775",
+				sigShare:          "bd86125de990acc5e1f13781d8e32c03a9bbd4c53539bbc106058bfd14326007",
+			},
+		},
+		signature: "36282629c383bb820a88b71cae937d41f2f2adfcc3d02e55507e2fb9e2dd3cbe" +
+			"bd9d2b0844e49ae0f3fa935161e1419aab7b47d21a37ebeae1f17d4987b3160b",
+	},
+	{
+		// Appendix E.3: FROST(ristretto255, SHA-512).
+		suite:     frost.Ristretto255,
+		secretKey: "1b25a55e463cfd15cf14a5d3acc3d15053f08da49c8afcf3ab265f2ebc4f970b",
+		publicKey: "e2a62f39eede11269e3bd5a7d97554f5ca384f9f6d3dd9c3c0d05083c7254f57",
+		msg:       "74657374",
+		signers: []vectorSigner{
+			{
+				id:                1,
+				share:             "5c3430d391552f6e60ecdc093ff9f6f4488756aa6cebdbad75a768010b8f830e",
+				hidingRandomness:  "f595a133b4d95c6e1f79887220c8b275ce6277e7f68a6640e1e7140f9be2fb5c",
+				bindingRandomness: "34dd1001360e3513cb37bebfabe7be4a32c5bb91ba19fbd4360d039111f0fbdc",
+				sigShare:          "9285f875923ce7e0c491a592e9ea1865ec1b823ead4854b48c8a46287749ee09",
+			},
+			{
+				id:                3,
+				share:             "f17e505f0e2581c6acfe54d3846a622834b5e7b50cad9a2109a97ba7a80d5c04",
+				hidingRandomness:  "daa0cf42a32617786d390e0c7edfbf2efbd428037069357b5173ae61d6dd5d5e",
+				bindingRandomness: "b4387e72b2e4108ce4168931cc2c7fcce5f345a5297368952c18b5fc8473f050",
+				sigShare:          "7cb211fe0e3d59d25db6e36b3fb32344794139602a7b24f1ae0dc4e26ad7b908",
+			},
+		},
+		signature: "fc45655fbc66bbffad654ea4ce5fdae253a49a64ace25d9adb62010dd9fb2555" +
+			"2164141787162e5b4cab915b4aa45d94655dbb9ed7c378a53b980a0be220a802",
+	},
+	{
+		// Appendix E.4: FROST(P-256, SHA-256).
+		suite:     frost.P256,
+		secretKey: "8ba9bba2e0fd8c4767154d35a0b7562244a4aaf6f36c8fb8735fa48b301bd8de",
+		publicKey: "023a309ad94e9fe8a7ba45dfc58f38bf091959d3c99cfbd02b4dc00585ec45ab70",
+		msg:       "74657374",
+		signers: []vectorSigner{
+			{
+				id:                1,
+				share:             "0c9c1a0fe806c184add50bbdcac913dda73e482daf95dcb9f35dbb0d8a9f7731",
+				hidingRandomness:  "ec4c891c85fee802a9d757a67d1252e7f4e5efb8a538991ac18fbd0e06fb6fd3",
+				bindingRandomness: "9334e29d09061223f69a09421715a347e4e6deba77444c8f42b0c833f80f4ef9",
+				sigShare:          "400308eaed7a2ddee02a265abe6a1cfe04d946ee8720768899619cfabe7a3aeb",
+			},
+			{
+				id:                3,
+				share:             "0e80d6e8f6192c003b5488ce1eec8f5429587d48cf001541e713b2d53c09d928",
+				hidingRandomness:  "c0451c5a0a5480d6c1f860e5db7d655233dca2669fd90ff048454b8ce983367b",
+				bindingRandomness: "2ba5f7793ae700e40e78937a82f407dd35e847e33d1e607b5c7eb6ed2a8ed799",
+				sigShare:          "561da3c179edbb0502d941bb3e3ace3c37d122aaa46fb54499f15f3a3331de44",
+			},
+		},
+		signature: "026d8d434874f87bdb7bc0dfd239b2c00639044f9dcb195e9a04426f70bfa4b70d" +
+			"9620acac6767e8e3e3036815fca4eb3a3caa69992b902bcd3352fc34f1ac192f",
+	},
+}
+
+func TestVectors(t *testing.T) {
+	for _, v := range rfc9591Vectors {
+		t.Run(fmt.Sprint(v.suite), func(t *testing.T) { v.test(t) })
+	}
+}
+
+func (v *vector) test(t *testing.T) {
+	const threshold, maxSigners = 1, 3
+	g := v.suite.Group()
+	scalar := func(s string) group.Scalar {
+		x := g.NewScalar()
+		test.CheckNoErr(t, x.UnmarshalBinary(mustDecode(t, s)), "bad scalar")
+		return x
+	}
+
+	sk, err := frost.NewPrivateKey(v.suite, scalar(v.secretKey))
+	test.CheckNoErr(t, err, "NewPrivateKey failed")
+	pk := sk.Public()
+	got, err := pk.MarshalBinary()
+	test.CheckNoErr(t, err, "MarshalBinary failed")
+	checkHex(t, got, v.publicKey, "group public key")
+
+	msg := mustDecode(t, v.msg)
+	peers := make([]frost.PeerSigner, len(v.signers))
+	nonces := make([]*frost.Nonce, len(v.signers))
+	coms := make([]frost.Commitment, len(v.signers))
+	for i, s := range v.signers {
+		share := secretsharing.Share{
+			ID:    g.NewScalar().SetUint64(s.id),
+			Value: scalar(s.share),
+		}
+		peers[i], err = frost.NewPeerSigner(v.suite, threshold, maxSigners, share, pk)
+		test.CheckNoErr(t, err, "NewPeerSigner failed")
+
+		// Commit reads the hiding randomness, then the binding randomness.
+		rnd := bytes.NewReader(mustDecode(t, s.hidingRandomness+s.bindingRandomness))
+		nonce, com, err := peers[i].Commit(rnd)
+		test.CheckNoErr(t, err, "Commit failed")
+		nonces[i], coms[i] = nonce, *com
+	}
+
+	shares := make([]frost.SignShare, len(v.signers))
+	for i, s := range v.signers {
+		share, err := peers[i].Sign(msg, nonces[i], coms)
+		test.CheckNoErr(t, err, "Sign failed")
+		got, err := share.Share.MarshalBinary()
+		test.CheckNoErr(t, err, "MarshalBinary failed")
+		checkHex(t, got, s.sigShare, "signature share")
+		shares[i] = *share
+	}
+
+	coordinator, err := frost.NewCoordinator(v.suite, pk, threshold, maxSigners)
+	test.CheckNoErr(t, err, "NewCoordinator failed")
+	sig, err := coordinator.Aggregate(msg, coms, shares)
+	test.CheckNoErr(t, err, "Aggregate failed")
+	checkHex(t, sig, v.signature, "signature")
+	test.CheckOk(frost.Verify(pk, msg, sig), "Verify failed", t)
+}
+
+func mustDecode(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	test.CheckNoErr(t, err, "bad hex string")
+	return b
+}
+
+func checkHex(t *testing.T, got []byte, want, name string) {
+	t.Helper()
+	if g := hex.EncodeToString(got); g != want {
+		test.ReportError(t, g, want, name)
+	}
+}
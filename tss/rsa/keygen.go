@@ -0,0 +1,695 @@
+package rsa
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sync"
+
+	"github.com/cloudflare/circl/internal/sha3"
+)
+
+// Distributed key generation.
+//
+// This implements the protocol of "Efficient Generation of Shared RSA Keys"
+// by Boneh and Franklin [2], which generates an RSA modulus N = pq such that
+// no player learns the factorization of N, and then shares the private
+// exponent among the players in the format expected by KeyShare.Sign and
+// CombineSignShares, so a trusted dealer is not needed.
+//
+//  1. Each player picks additive shares p_i, q_i of the candidate primes, and
+//     the players compute N = (Σ p_i)(Σ q_i) using the BGW protocol [3].
+//  2. N is rejected if it has small factors, or if it fails the distributed
+//     biprimality test of Boneh and Franklin, which also checks that
+//     gcd(N, p+q-1) = 1. The players start over until a valid N is found.
+//  3. The players compute additive shares of d = e^{-1} mod ϕ(N), revealing
+//     ϕ(N) mod e, and reshare d with a polynomial over the integers.
+//
+// The protocol is secure against honest-but-curious players, as long as
+// fewer than half of them collude. It does not detect players deviating from
+// the protocol.
+//
+// [2] https://doi.org/10.1145/357830.357849
+// [3] https://doi.org/10.1145/62212.62213
+
+const (
+	// keyGenExponent is the public exponent of the generated keys.
+	keyGenExponent = 65537
+	// biprimalityRounds is the number of iterations of the biprimality test,
+	// each of which rejects a non-biprime modulus with probability at least 1/2.
+	biprimalityRounds = 64
+	// sieveBound is the bound of the primes used to filter candidate moduli.
+	sieveBound = 1 << 16
+)
+
+var (
+	ErrKeyGenRound   = errors.New("rsa_threshold: key generation function called out of order")
+	ErrKeyGenMessage = errors.New("rsa_threshold: invalid key generation message")
+)
+
+// KeyGenMessage is a message sent by a player in the distributed key
+// generation. It is sent privately to the player with index To, or
+// broadcast to all players if To is zero.
+type KeyGenMessage struct {
+	From, To uint
+	Values   []*big.Int
+}
+
+type keyGenState int
+
+const (
+	stateStart keyGenState = iota
+	stateModulus
+	stateSieve
+	stateBiprimality
+	stateGCD
+	stateGCDCheck
+	stateInverse
+	stateCorrection
+	stateDeal
+	stateDone
+)
+
+// KeyGenParty is a player in the distributed key generation. The protocol
+// runs in rounds: in each round, every player passes to Next all the
+// messages addressed to it in the previous round, including the ones it sent
+// to itself and the broadcast ones, and sends the messages returned. The
+// protocol ends when Done returns true.
+type KeyGenParty struct {
+	players, threshold, index uint
+	bits                      int
+
+	state  keyGenState
+	prime  *big.Int // a prime larger than N for BGW multiplication.
+	degree uint     // degree of BGW sharings, less than players/2.
+	// Lagrange coefficients for interpolating products modulo prime.
+	lambdas []*big.Int
+
+	// Additive shares of p and q.
+	p, q *big.Int
+	n    *big.Int
+	// Number of biprimality tests passed.
+	tests int
+	// Additive share of d.
+	d *big.Int
+
+	share *KeyShare
+}
+
+// NewKeyGenParty returns the player with the given index, between 1 and
+// players, in the distributed generation of an RSA key of the given bit
+// length. The resulting key is shared so that threshold signature shares
+// are needed to form a signature, as in Deal.
+//
+// At least three players are needed, as BGW multiplication requires an
+// honest majority.
+func NewKeyGenParty(players, threshold, index uint, bits int) (*KeyGenParty, error) {
+	if err := validateParams(players, threshold); err != nil {
+		return nil, err
+	}
+	if players < 3 || players >= sieveBound {
+		return nil, errors.New("rsa_threshold: Players (l) invalid: key generation needs 3 <= l < 2^16")
+	}
+	if index < 1 || index > players {
+		return nil, errors.New("rsa_threshold: invalid player index")
+	}
+
+	prime := keyGenPrime(bits)
+	if prime == nil || bits < 64 {
+		return nil, fmt.Errorf("rsa_threshold: unsupported key size for key generation: %v", bits)
+	}
+
+	degree := (players - 1) / 2
+	return &KeyGenParty{
+		players:   players,
+		threshold: threshold,
+		index:     index,
+		bits:      bits,
+		prime:     prime,
+		degree:    degree,
+		lambdas:   lagrangeAtZero(2*degree+1, prime),
+	}, nil
+}
+
+// Done returns true if the protocol has finished.
+func (kp *KeyGenParty) Done() bool { return kp.state == stateDone }
+
+// KeyShare returns the key share of the player and the public key, once the
+// protocol has finished.
+func (kp *KeyGenParty) KeyShare() (KeyShare, *rsa.PublicKey, error) {
+	if kp.state != stateDone {
+		return KeyShare{}, nil, ErrKeyGenRound
+	}
+	return *kp.share, &rsa.PublicKey{N: new(big.Int).Set(kp.n), E: keyGenExponent}, nil
+}
+
+// Next processes the messages received in the previous round, and returns
+// the messages to send in this round. In the first round, msgs is ignored.
+func (kp *KeyGenParty) Next(randSource io.Reader, msgs []KeyGenMessage) ([]KeyGenMessage, error) {
+	switch kp.state {
+	case stateStart:
+		return kp.sampleCandidate(randSource)
+	case stateModulus:
+		return kp.modulusShare(msgs)
+	case stateSieve:
+		return kp.sieve(randSource, msgs)
+	case stateBiprimality:
+		return kp.checkBiprimality(randSource, msgs)
+	case stateGCD:
+		return kp.gcdShare(msgs)
+	case stateGCDCheck:
+		return kp.checkGCD(randSource, msgs)
+	case stateInverse:
+		return kp.inverse(randSource, msgs)
+	case stateCorrection:
+		return kp.correct(randSource, msgs)
+	case stateDeal:
+		return nil, kp.finalize(msgs)
+	default:
+		return nil, ErrKeyGenRound
+	}
+}
+
+// sampleCandidate picks the additive shares of a candidate modulus, and
+// shares them with BGW. Player 1 picks p_1 = q_1 = 3 mod 4, and the others
+// pick p_i = q_i = 0 mod 4, so that p = q = 3 mod 4.
+func (kp *KeyGenParty) sampleCandidate(randSource io.Reader) ([]KeyGenMessage, error) {
+	var err error
+	kp.p, err = kp.sampleAdditiveShare(randSource, (kp.bits+1)/2)
+	if err != nil {
+		return nil, err
+	}
+	kp.q, err = kp.sampleAdditiveShare(randSource, kp.bits/2)
+	if err != nil {
+		return nil, err
+	}
+	kp.n, kp.tests = nil, 0
+
+	msgs, err := kp.shareProduct(randSource, kp.p, kp.q, kp.prime)
+	if err != nil {
+		return nil, err
+	}
+	kp.state = stateModulus
+	return msgs, nil
+}
+
+// sampleAdditiveShare returns a share of a prime candidate of the given bit
+// length. The shares are taken from [0, 2^{bits-2}/players), and player 1
+// adds 3*2^{bits-2}, so the candidate has its two most significant bits set,
+// and the product of two candidates has the expected bit length.
+func (kp *KeyGenParty) sampleAdditiveShare(randSource io.Reader, bits int) (*big.Int, error) {
+	bound := new(big.Int).Lsh(big.NewInt(1), uint(bits-2))
+	bound.Div(bound, big.NewInt(int64(kp.players)))
+	x, err := rand.Int(randSource, bound)
+	if err != nil {
+		return nil, err
+	}
+
+	// Clear the two least significant bits.
+	x.Rsh(x, 2).Lsh(x, 2)
+	if kp.index == 1 {
+		offset := big.NewInt(3)
+		offset.Lsh(offset, uint(bits-2))
+		x.Add(x, offset).Or(x, big.NewInt(3))
+	}
+	return x, nil
+}
+
+// shareProduct returns the messages for computing a*b mod m with BGW, where
+// a and b are the sums of the values of all players. It shares a and b with
+// polynomials of degree kp.degree, and zero with a polynomial of degree
+// 2*kp.degree, which randomizes the product.
+func (kp *KeyGenParty) shareProduct(randSource io.Reader, a, b, m *big.Int) ([]KeyGenMessage, error) {
+	fa, err := randomPoly(randSource, a, kp.degree, m)
+	if err != nil {
+		return nil, err
+	}
+	fb, err := randomPoly(randSource, b, kp.degree, m)
+	if err != nil {
+		return nil, err
+	}
+	fh, err := randomPoly(randSource, new(big.Int), 2*kp.degree, m)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := make([]KeyGenMessage, kp.players)
+	for j := uint(1); j <= kp.players; j++ {
+		msgs[j-1] = KeyGenMessage{
+			From: kp.index,
+			To:   j,
+			Values: []*big.Int{
+				computePolynomial(fa, j, m),
+				computePolynomial(fb, j, m),
+				computePolynomial(fh, j, m),
+			},
+		}
+	}
+	return msgs, nil
+}
+
+// productShare returns the share of the product a*b for this player, from
+// the messages produced by shareProduct.
+func (kp *KeyGenParty) productShare(msgs []KeyGenMessage, m *big.Int) ([]KeyGenMessage, error) {
+	values, err := kp.collect(msgs, kp.index, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	a, b, h := new(big.Int), new(big.Int), new(big.Int)
+	for _, v := range values {
+		a.Add(a, v[0])
+		b.Add(b, v[1])
+		h.Add(h, v[2])
+	}
+	share := a.Mul(a, b)
+	share.Add(share, h).Mod(share, m)
+
+	return kp.broadcast(share), nil
+}
+
+// interpolateProduct recovers the product computed with BGW from the shares
+// broadcast by all players.
+// The product has degree 2*kp.degree < players, so the Lagrange
+// coefficients for the first 2*kp.degree+1 points are given.
+func (kp *KeyGenParty) interpolateProduct(msgs []KeyGenMessage, m *big.Int, lambdas []*big.Int) (*big.Int, error) {
+	values, err := kp.collect(msgs, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := new(big.Int)
+	tmp := new(big.Int)
+	for j := range lambdas {
+		sum.Add(sum, tmp.Mul(lambdas[j], values[j][0]))
+	}
+	return sum.Mod(sum, m), nil
+}
+
+func (kp *KeyGenParty) modulusShare(msgs []KeyGenMessage) ([]KeyGenMessage, error) {
+	out, err := kp.productShare(msgs, kp.prime)
+	if err != nil {
+		return nil, err
+	}
+	kp.state = stateSieve
+	return out, nil
+}
+
+// sieve recovers the candidate modulus, and starts the biprimality test if it
+// has the expected size and no small factors.
+func (kp *KeyGenParty) sieve(randSource io.Reader, msgs []KeyGenMessage) ([]KeyGenMessage, error) {
+	n, err := kp.interpolateProduct(msgs, kp.prime, kp.lambdas)
+	if err != nil {
+		return nil, err
+	}
+	if n.BitLen() != kp.bits || hasSmallFactor(n) {
+		return kp.sampleCandidate(randSource)
+	}
+
+	kp.n = n
+	kp.state = stateBiprimality
+	// A first test discards most candidates, the remaining tests are run at
+	// once.
+	return kp.biprimalityShares(1), nil
+}
+
+// biprimalityShares returns the values for the next count tests of the
+// distributed biprimality test. For a public g with Jacobi symbol 1, player 1
+// sends v_1 = g^{(N-p_1-q_1+1)/4}, and the others send v_i = g^{(p_i+q_i)/4}.
+// N is biprime with high probability if v_1 = ±Π v_i (mod N) for all g.
+func (kp *KeyGenParty) biprimalityShares(count int) []KeyGenMessage {
+	exp := new(big.Int).Add(kp.p, kp.q)
+	if kp.index == 1 {
+		exp.Sub(kp.n, exp).Add(exp, big.NewInt(1))
+	}
+	exp.Rsh(exp, 2)
+
+	values := make([]*big.Int, count)
+	for k := range values {
+		g := biprimalityBase(kp.n, kp.tests+k)
+		values[k] = g.Exp(g, exp, kp.n)
+	}
+	return []KeyGenMessage{{From: kp.index, Values: values}}
+}
+
+func (kp *KeyGenParty) checkBiprimality(randSource io.Reader, msgs []KeyGenMessage) ([]KeyGenMessage, error) {
+	count := 1
+	if kp.tests != 0 {
+		count = biprimalityRounds - 1
+	}
+	values, err := kp.collect(msgs, 0, count)
+	if err != nil {
+		return nil, err
+	}
+
+	one := big.NewInt(1)
+	minusOne := new(big.Int).Sub(kp.n, one)
+	prod := new(big.Int)
+	for k := 0; k < count; k++ {
+		prod.SetInt64(1)
+		for i := 1; i < len(values); i++ {
+			prod.Mul(prod, values[i][k]).Mod(prod, kp.n)
+		}
+		if prod.ModInverse(prod, kp.n) == nil {
+			return kp.sampleCandidate(randSource)
+		}
+		prod.Mul(prod, values[0][k]).Mod(prod, kp.n)
+		if prod.Cmp(one) != 0 && prod.Cmp(minusOne) != 0 {
+			return kp.sampleCandidate(randSource)
+		}
+	}
+
+	kp.tests += count
+	if kp.tests < biprimalityRounds {
+		return kp.biprimalityShares(biprimalityRounds - kp.tests), nil
+	}
+
+	// Computes z = (p+q-1)*r mod N for a random r, and checks gcd(z, N) = 1.
+	a := new(big.Int).Add(kp.p, kp.q)
+	if kp.index == 1 {
+		a.Sub(a, one)
+	}
+	r, err := rand.Int(randSource, kp.n)
+	if err != nil {
+		return nil, err
+	}
+	out, err := kp.shareProduct(randSource, a.Mod(a, kp.n), r, kp.n)
+	if err != nil {
+		return nil, err
+	}
+	kp.state = stateGCD
+	return out, nil
+}
+
+func (kp *KeyGenParty) gcdShare(msgs []KeyGenMessage) ([]KeyGenMessage, error) {
+	out, err := kp.productShare(msgs, kp.n)
+	if err != nil {
+		return nil, err
+	}
+	kp.state = stateGCDCheck
+	return out, nil
+}
+
+// checkGCD completes the biprimality test, and then reveals the share of
+// ϕ(N) mod e, where ϕ(N) = Σ ϕ_i with ϕ_1 = N-p_1-q_1+1 and ϕ_i = -(p_i+q_i).
+func (kp *KeyGenParty) checkGCD(randSource io.Reader, msgs []KeyGenMessage) ([]KeyGenMessage, error) {
+	lambdas := lagrangeAtZero(2*kp.degree+1, kp.n)
+	if lambdas == nil {
+		return nil, ErrKeyGenMessage
+	}
+	z, err := kp.interpolateProduct(msgs, kp.n, lambdas)
+	if err != nil {
+		return nil, err
+	}
+	if z.GCD(nil, nil, z, kp.n).Cmp(big.NewInt(1)) != 0 {
+		return kp.sampleCandidate(randSource)
+	}
+
+	phi := kp.phiShare()
+	kp.state = stateInverse
+	return kp.broadcast(phi.Mod(phi, big.NewInt(keyGenExponent))), nil
+}
+
+func (kp *KeyGenParty) phiShare() *big.Int {
+	phi := new(big.Int).Add(kp.p, kp.q)
+	phi.Neg(phi)
+	if kp.index == 1 {
+		phi.Add(phi, kp.n).Add(phi, big.NewInt(1))
+	}
+	return phi
+}
+
+// inverse computes the additive share of d as in Section 4.2 of [2]. Let
+// ψ = -ϕ(N)^{-1} mod e, so d = (1+ψϕ(N))/e. Player 1 takes
+// d_1 = ⌊(1+ψϕ_1)/e⌋, and the others d_i = ⌊ψϕ_i/e⌋, so that
+// Σ d_i = d - r for some 0 <= r < players. Then, each player reveals
+// x^{d_i} for a public x to find r.
+func (kp *KeyGenParty) inverse(randSource io.Reader, msgs []KeyGenMessage) ([]KeyGenMessage, error) {
+	values, err := kp.collect(msgs, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	e := big.NewInt(keyGenExponent)
+	psi := new(big.Int)
+	for _, v := range values {
+		psi.Add(psi, v[0])
+	}
+	psi.Neg(psi).Mod(psi, e)
+	if psi.ModInverse(psi, e) == nil {
+		// e divides ϕ(N), so it is not invertible; start over.
+		return kp.sampleCandidate(randSource)
+	}
+
+	d := kp.phiShare()
+	d.Mul(d, psi)
+	if kp.index == 1 {
+		d.Add(d, big.NewInt(1))
+	}
+	kp.d = d.Div(d, e)
+
+	kp.state = stateCorrection
+	return kp.broadcast(new(big.Int).Exp(big.NewInt(2), kp.d, kp.n)), nil
+}
+
+// correct finds the r such that d = Σ d_i + r, which is added by player 1.
+// Then, every player shares its d_i with a polynomial over the integers of
+// degree threshold-1, so that the key share of player j is s_j = Σ f_i(j).
+// As all the coefficients of the polynomials except d_i are positive, s_j is
+// positive.
+func (kp *KeyGenParty) correct(randSource io.Reader, msgs []KeyGenMessage) ([]KeyGenMessage, error) {
+	values, err := kp.collect(msgs, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	x := big.NewInt(2)
+	e := big.NewInt(keyGenExponent)
+	y := big.NewInt(1)
+	for _, v := range values {
+		y.Mul(y, v[0]).Mod(y, kp.n)
+	}
+
+	found := false
+	tmp := new(big.Int)
+	for r := uint(0); r < kp.players && !found; r++ {
+		if tmp.Exp(y, e, kp.n).Cmp(x) == 0 {
+			found = true
+			if kp.index == 1 {
+				kp.d.Add(kp.d, big.NewInt(int64(r)))
+			}
+		}
+		y.Mul(y, x).Mod(y, kp.n)
+	}
+	if !found {
+		return nil, ErrKeyGenMessage
+	}
+
+	// The coefficients are taken from [0, ∆^2 2^{bits+128}), so the shares
+	// statistically hide d_i.
+	bound := calculateDelta(int64(kp.players))
+	bound.Mul(bound, bound).Lsh(bound, uint(kp.bits+proofSecParam))
+	f := make([]*big.Int, kp.threshold)
+	f[0] = kp.d
+	for k := 1; k < len(f); k++ {
+		f[k], err = rand.Int(randSource, bound)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	out := make([]KeyGenMessage, kp.players)
+	for j := uint(1); j <= kp.players; j++ {
+		out[j-1] = KeyGenMessage{
+			From:   kp.index,
+			To:     j,
+			Values: []*big.Int{evalIntPoly(f, j)},
+		}
+	}
+	kp.state = stateDeal
+	return out, nil
+}
+
+func (kp *KeyGenParty) finalize(msgs []KeyGenMessage) error {
+	values, err := kp.collect(msgs, kp.index, 1)
+	if err != nil {
+		return err
+	}
+
+	si := new(big.Int)
+	for _, v := range values {
+		si.Add(si, v[0])
+	}
+	if si.Sign() <= 0 {
+		return ErrKeyGenMessage
+	}
+
+	kp.share = &KeyShare{
+		si:        si,
+		Index:     kp.index,
+		Players:   kp.players,
+		Threshold: kp.threshold,
+	}
+	kp.p, kp.q, kp.d = nil, nil, nil
+	kp.state = stateDone
+	return nil
+}
+
+func (kp *KeyGenParty) broadcast(v *big.Int) []KeyGenMessage {
+	return []KeyGenMessage{{From: kp.index, Values: []*big.Int{v}}}
+}
+
+// collect returns the values of the messages sent to the player with index
+// to (or broadcast if zero), ordered by sender. It checks that there is
+// exactly one message from each player, with count values.
+func (kp *KeyGenParty) collect(msgs []KeyGenMessage, to uint, count int) ([][]*big.Int, error) {
+	values := make([][]*big.Int, kp.players)
+	for i := range msgs {
+		m := &msgs[i]
+		if m.To != to {
+			continue
+		}
+		if m.From < 1 || m.From > kp.players || values[m.From-1] != nil || len(m.Values) != count {
+			return nil, ErrKeyGenMessage
+		}
+		for _, v := range m.Values {
+			if v == nil {
+				return nil, ErrKeyGenMessage
+			}
+		}
+		values[m.From-1] = m.Values
+	}
+	for i := range values {
+		if values[i] == nil {
+			return nil, ErrKeyGenMessage
+		}
+	}
+	return values, nil
+}
+
+// randomPoly returns the coefficients of a random polynomial of the given
+// degree over Z/mZ, such that f(0) = secret.
+func randomPoly(randSource io.Reader, secret *big.Int, degree uint, m *big.Int) ([]*big.Int, error) {
+	f := make([]*big.Int, degree+1)
+	f[0] = new(big.Int).Mod(secret, m)
+	for k := uint(1); k <= degree; k++ {
+		c, err := rand.Int(randSource, m)
+		if err != nil {
+			return nil, err
+		}
+		f[k] = c
+	}
+	return f, nil
+}
+
+// evalIntPoly returns f(x) over the integers.
+func evalIntPoly(f []*big.Int, x uint) *big.Int {
+	xBig := new(big.Int).SetUint64(uint64(x))
+	sum := new(big.Int).Set(f[len(f)-1])
+	for i := len(f) - 2; i >= 0; i-- {
+		sum.Mul(sum, xBig).Add(sum, f[i])
+	}
+	return sum
+}
+
+// lagrangeAtZero returns the Lagrange coefficients at zero of the points
+// with indices 1 to k, modulo m. It returns nil if the coefficients are not
+// defined modulo m.
+func lagrangeAtZero(k uint, m *big.Int) []*big.Int {
+	lambdas := make([]*big.Int, k)
+	tmp := new(big.Int)
+	for j := uint(1); j <= k; j++ {
+		num := big.NewInt(1)
+		den := big.NewInt(1)
+		for i := uint(1); i <= k; i++ {
+			if i != j {
+				num.Mul(num, tmp.SetInt64(int64(i)))
+				den.Mul(den, tmp.SetInt64(int64(i)-int64(j)))
+			}
+		}
+		if den.Mod(den, m).ModInverse(den, m) == nil {
+			return nil
+		}
+		lambdas[j-1] = num.Mul(num, den).Mod(num, m)
+	}
+	return lambdas
+}
+
+// biprimalityBase returns the k-th public base for the biprimality test of
+// N, which is derived from N and has Jacobi symbol 1.
+func biprimalityBase(n *big.Int, k int) *big.Int {
+	size := (n.BitLen() + 7) / 8
+	buf := make([]byte, size+8)
+	h := sha3.NewShake256()
+	_, _ = h.Write([]byte("tss/rsa biprimality"))
+	_, _ = h.Write(n.FillBytes(make([]byte, size)))
+	_, _ = h.Write([]byte{byte(k >> 8), byte(k)})
+
+	g := new(big.Int)
+	for {
+		_, _ = h.Read(buf)
+		g.SetBytes(buf).Mod(g, n)
+		if g.Sign() != 0 && big.Jacobi(g, n) == 1 {
+			return g
+		}
+	}
+}
+
+// keyGenPrime returns the smallest Mersenne prime larger than 2^bits, which
+// is used for computing the modulus with BGW. It returns nil if bits is too
+// large.
+func keyGenPrime(bits int) *big.Int {
+	for _, k := range []uint{521, 607, 1279, 2203, 2281, 3217, 4253, 4423, 9689} {
+		if uint(bits) < k {
+			p := new(big.Int).Lsh(big.NewInt(1), k)
+			return p.Sub(p, big.NewInt(1))
+		}
+	}
+	return nil
+}
+
+var (
+	sieveOnce     sync.Once
+	sieveProducts []uint64
+)
+
+// hasSmallFactor returns true if n is divisible by a prime smaller than
+// sieveBound. Primes are grouped into products that fit in 64 bits to speed
+// up the reductions.
+func hasSmallFactor(n *big.Int) bool {
+	sieveOnce.Do(func() {
+		composite := make([]bool, sieveBound)
+		prod := uint64(1)
+		for i := uint64(2); i < sieveBound; i++ {
+			if composite[i] {
+				continue
+			}
+			for j := i * i; j < sieveBound; j += i {
+				composite[j] = true
+			}
+			if prod > (1<<64-1)/i {
+				sieveProducts = append(sieveProducts, prod)
+				prod = 1
+			}
+			prod *= i
+		}
+		sieveProducts = append(sieveProducts, prod)
+	})
+
+	var r, m big.Int
+	for _, prod := range sieveProducts {
+		m.SetUint64(prod)
+		g := r.Mod(n, &m).Uint64()
+		a, b := prod, g
+		for b != 0 {
+			a, b = b, a%b
+		}
+		if a != 1 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package rsa
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"math/big"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+// runKeyGen runs the distributed key generation delivering all the messages
+// between the players.
+func runKeyGen(t testing.TB, players, threshold uint, bits int) ([]KeyShare, *rsa.PublicKey) {
+	parties := make([]*KeyGenParty, players)
+	for i := range parties {
+		var err error
+		parties[i], err = NewKeyGenParty(players, threshold, uint(i+1), bits)
+		test.CheckNoErr(t, err, "failed to create player")
+	}
+
+	var msgs []KeyGenMessage
+	for !parties[0].Done() {
+		var next []KeyGenMessage
+		for _, p := range parties {
+			var in []KeyGenMessage
+			for _, m := range msgs {
+				if m.To == 0 || m.To == p.index {
+					in = append(in, m)
+				}
+			}
+			out, err := p.Next(rand.Reader, in)
+			test.CheckNoErr(t, err, "failed to run round")
+			next = append(next, out...)
+		}
+		msgs = next
+	}
+
+	keys := make([]KeyShare, players)
+	var pub *rsa.PublicKey
+	for i, p := range parties {
+		test.CheckOk(p.Done(), "all players must finish at the same round", t)
+		var err error
+		var pubi *rsa.PublicKey
+		keys[i], pubi, err = p.KeyShare()
+		test.CheckNoErr(t, err, "failed to get key share")
+		if pub == nil {
+			pub = pubi
+		}
+		test.CheckOk(pub.Equal(pubi), "public keys must match", t)
+	}
+	return keys, pub
+}
+
+func TestKeyGen(t *testing.T) {
+	// [Warning]: this is only for tests, use a secure bitlen above 2048 bits.
+	const bits = 512
+	for _, params := range [][2]uint{{3, 2}, {3, 3}, {5, 3}} {
+		players, threshold := params[0], params[1]
+		keys, pub := runKeyGen(t, players, threshold, bits)
+		test.CheckOk(pub.N.BitLen() == bits, "bad modulus size", t)
+
+		msg := []byte("hello")
+		msgPH, err := PadHash(&PKCS1v15Padder{}, crypto.SHA256, pub, msg)
+		test.CheckNoErr(t, err, "failed to pad")
+
+		// Any subset of threshold players can sign.
+		for _, signers := range [][]KeyShare{keys[:threshold], keys[players-threshold:]} {
+			shares := make([]SignShare, len(signers))
+			for i := range signers {
+				shares[i], err = signers[i].Sign(rand.Reader, pub, msgPH, true)
+				test.CheckNoErr(t, err, "failed to sign")
+			}
+			sig, err := CombineSignShares(pub, players, threshold, shares, msgPH)
+			test.CheckNoErr(t, err, "failed to combine")
+
+			got := new(big.Int).SetBytes(sig)
+			got.Exp(got, big.NewInt(int64(pub.E)), pub.N)
+			test.CheckOk(got.Cmp(new(big.Int).SetBytes(msgPH)) == 0, "invalid signature", t)
+		}
+	}
+}
+
+func TestKeyGenErrors(t *testing.T) {
+	for _, params := range [][4]int{
+		{2, 2, 1, 512},     // too few players
+		{3, 4, 1, 512},     // threshold above players
+		{3, 2, 0, 512},     // invalid index
+		{3, 2, 4, 512},     // invalid index
+		{3, 2, 1, 32},      // key too small
+		{3, 2, 1, 1 << 14}, // key too large
+	} {
+		_, err := NewKeyGenParty(uint(params[0]), uint(params[1]), uint(params[2]), params[3])
+		test.CheckIsErr(t, err, "should fail with invalid parameters")
+	}
+
+	p, err := NewKeyGenParty(3, 2, 1, 512)
+	test.CheckNoErr(t, err, "failed to create player")
+	_, _, err = p.KeyShare()
+	test.CheckIsErr(t, err, "should fail before finishing")
+	_, err = p.Next(rand.Reader, nil)
+	test.CheckNoErr(t, err, "failed to run round")
+	_, err = p.Next(rand.Reader, nil)
+	test.CheckIsErr(t, err, "should fail with missing messages")
+}
+
+func TestHasSmallFactor(t *testing.T) {
+	p, err := rand.Prime(rand.Reader, 256)
+	test.CheckNoErr(t, err, "failed to generate prime")
+	test.CheckOk(!hasSmallFactor(p), "prime has no small factors", t)
+	for _, f := range []int64{2, 3, 65521} {
+		n := new(big.Int).Mul(p, big.NewInt(f))
+		test.CheckOk(hasSmallFactor(n), "should find small factor", t)
+	}
+}
+
+func BenchmarkKeyGen(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = runKeyGen(b, 3, 2, 1024)
+	}
+}
@@ -3,6 +3,15 @@
 // This package implements the Protocol 1 of "Practical Threshold Signatures"
 // by Victor Shoup [1].
 //
+// Signature shares can be made verifiable: a VerifyKey, generated by the
+// dealer, allows anyone to check the proof of correctness attached to shares
+// by KeyShare.SignAndProve, so CombineVerifiedSignShares can exclude the
+// shares of faulty players.
+//
+// Instead of trusting a dealer, players can generate the key and their key
+// shares with a distributed protocol using KeyGenParty, so that no player
+// learns the private key.
+//
 // Warning: Sign operations rely on math/big and are not constant time in the
 // secret key share. Pass a non-nil random source to Sign to enable blinding.
 // The public key passed to KeyShare.Sign must correspond to the private key
@@ -5,12 +5,16 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+
+	"github.com/cloudflare/circl/zk/qndleq"
 )
 
 // SignShare represents a portion of a signature. It is generated when a message is signed by a KeyShare. t SignShare's are then combined by calling CombineSignShares, where t is the Threshold.
 type SignShare struct {
 	xi *big.Int
 
+	proof *qndleq.Proof // optional proof of correctness, set by KeyShare.SignAndProve.
+
 	Index uint
 
 	Players   uint
@@ -25,7 +29,8 @@ func (s SignShare) String() string {
 // MarshalBinary encodes SignShare into a byte array in a format readable by UnmarshalBinary.
 // Note: Only Index's up to math.MaxUint16 are supported
 func (s *SignShare) MarshalBinary() ([]byte, error) {
-	// | Players: uint16 | Threshold: uint16 | Index: uint16 | xiLen: uint16 | xi: []byte |
+	// | Players: uint16 | Threshold: uint16 | Index: uint16 | xiLen: uint16 | xi: []byte | proofLen: uint16 | proof: []byte |
+	// where proofLen and proof are only present if the share has a proof.
 
 	if s.Players > math.MaxUint16 {
 		return nil, fmt.Errorf("rsa_threshold: signshare marshall: Players is too big to fit in a uint16")
@@ -55,8 +60,20 @@ func (s *SignShare) MarshalBinary() ([]byte, error) {
 		xiBytes = []byte{0}
 	}
 
+	var proofBytes []byte
+	if s.proof != nil {
+		var err error
+		proofBytes, err = s.proof.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		if len(proofBytes) > math.MaxUint16 {
+			return nil, fmt.Errorf("rsa_threshold: signshare marshall: proof is too big to fit its length in a uint16")
+		}
+	}
+
 	blen := 2 + 2 + 2 + 2 + xiLen
-	out := make([]byte, blen)
+	out := make([]byte, blen, blen+2+len(proofBytes))
 
 	binary.BigEndian.PutUint16(out[0:2], players)
 	binary.BigEndian.PutUint16(out[2:4], threshold)
@@ -66,12 +83,17 @@ func (s *SignShare) MarshalBinary() ([]byte, error) {
 
 	copy(out[8:8+xiLen], xiBytes)
 
+	if proofBytes != nil {
+		out = binary.BigEndian.AppendUint16(out, uint16(len(proofBytes)))
+		out = append(out, proofBytes...)
+	}
+
 	return out, nil
 }
 
 // UnmarshalBinary converts a byte array outputted from Marshall into a SignShare or returns an error if the value is invalid
 func (s *SignShare) UnmarshalBinary(data []byte) error {
-	// | Players: uint16 | Threshold: uint16 | Index: uint16 | xiLen: uint16 | xi: []byte |
+	// | Players: uint16 | Threshold: uint16 | Index: uint16 | xiLen: uint16 | xi: []byte | proofLen: uint16 | proof: []byte |
 	if len(data) < 8 {
 		return fmt.Errorf("rsa_threshold: signshare unmarshalKeyShareTest failed: data length was too short for reading Players, Threshold, Index, and xiLen")
 	}
@@ -94,10 +116,26 @@ func (s *SignShare) UnmarshalBinary(data []byte) error {
 	copy(bytes, data[8:8+xiLen])
 	xi.SetBytes(bytes)
 
+	var proof *qndleq.Proof
+	if rest := data[8+xiLen:]; len(rest) != 0 {
+		if len(rest) < 2 {
+			return fmt.Errorf("rsa_threshold: signshare unmarshalKeyShareTest failed: data length was too short for reading proofLen")
+		}
+		proofLen := int(binary.BigEndian.Uint16(rest[0:2]))
+		if len(rest[2:]) != proofLen {
+			return fmt.Errorf("rsa_threshold: signshare unmarshalKeyShareTest failed: proof length mismatch, needed: %d found: %d", proofLen, len(rest[2:]))
+		}
+		proof = new(qndleq.Proof)
+		if err := proof.UnmarshalBinary(rest[2:]); err != nil {
+			return err
+		}
+	}
+
 	s.Players = uint(players)
 	s.Threshold = uint(threshold)
 	s.Index = uint(index)
 	s.xi = &xi
+	s.proof = proof
 
 	return nil
 }
@@ -0,0 +1,129 @@
+package rsa
+
+import (
+	"crypto/rsa"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/cloudflare/circl/zk/qndleq"
+)
+
+// proofSecParam is the security parameter of the proofs of correctness of
+// signature shares, L1 in Section 3 of [1].
+const proofSecParam = 128
+
+var (
+	ErrVerifyKey      = errors.New("rsa_threshold: invalid verification key")
+	ErrSignShareProof = errors.New("rsa_threshold: invalid signature share proof")
+)
+
+// VerifyKey allows anyone to check that signature shares are correct. It is
+// generated by the dealer along with the key shares, and made public.
+//
+// V is a random element of Q_N (the subgroup of squares modulo N), and
+// Vi[i-1] = V^{s_i} mod N is the verification key of the player with Index i,
+// where s_i is its secret key share.
+type VerifyKey struct {
+	V  *big.Int
+	Vi []*big.Int
+}
+
+// NewVerifyKey returns the verification key for the key shares generated by
+// Deal. shares must contain the key shares of all the players.
+func NewVerifyKey(randSource io.Reader, pub *rsa.PublicKey, shares []KeyShare) (*VerifyKey, error) {
+	v, err := qndleq.SampleQn(randSource, pub.N)
+	if err != nil {
+		return nil, err
+	}
+
+	vk := &VerifyKey{V: v, Vi: make([]*big.Int, len(shares))}
+	for _, share := range shares {
+		if share.Index < 1 || share.Index > uint(len(shares)) || vk.Vi[share.Index-1] != nil {
+			return nil, ErrVerifyKey
+		}
+		vk.Vi[share.Index-1] = new(big.Int).Exp(v, share.si, pub.N)
+	}
+
+	return vk, nil
+}
+
+// SignAndProve signs digest as KeyShare.Sign does, and attaches to the
+// SignShare a proof that it was correctly computed with respect to the
+// verification key vk. randSource must not be nil.
+func (kshare *KeyShare) SignAndProve(randSource io.Reader, pub *rsa.PublicKey, vk *VerifyKey, digest []byte, parallel bool) (SignShare, error) {
+	if randSource == nil {
+		return SignShare{}, errors.New("rsa_threshold: random source is required for proofs")
+	}
+	if kshare.Index < 1 || kshare.Index > uint(len(vk.Vi)) {
+		return SignShare{}, ErrVerifyKey
+	}
+
+	share, err := kshare.Sign(randSource, pub, digest, parallel)
+	if err != nil {
+		return SignShare{}, err
+	}
+
+	// Proves that log_v(v_i) = log_x~(x_i^2) = s_i, where x~ = x^{4∆}.
+	xTilde, xi2 := proofBases(pub, digest, &share)
+	share.proof, err = qndleq.Prove(randSource, kshare.si,
+		vk.V, vk.Vi[kshare.Index-1], xTilde, xi2, pub.N, proofSecParam)
+	if err != nil {
+		return SignShare{}, err
+	}
+
+	return share, nil
+}
+
+// VerifySignShare checks the proof attached to the SignShare by
+// KeyShare.SignAndProve. It returns an error if the share is not correct for
+// digest, in which case it must be excluded from CombineSignShares.
+func (vk *VerifyKey) VerifySignShare(pub *rsa.PublicKey, digest []byte, share SignShare) error {
+	if share.Players != uint(len(vk.Vi)) || share.Index < 1 || share.Index > share.Players {
+		return ErrVerifyKey
+	}
+	if share.proof == nil || share.xi == nil {
+		return ErrSignShareProof
+	}
+
+	xTilde, xi2 := proofBases(pub, digest, &share)
+	if !share.proof.Verify(vk.V, vk.Vi[share.Index-1], xTilde, xi2, pub.N) {
+		return ErrSignShareProof
+	}
+
+	return nil
+}
+
+// proofBases returns x~ = x^{4∆} and x_i^2, where x is the digest and x_i is
+// the signature share.
+func proofBases(pub *rsa.PublicKey, digest []byte, share *SignShare) (xTilde, xi2 *big.Int) {
+	// 4∆
+	exp := calculateDelta(int64(share.Players))
+	exp.Lsh(exp, 2)
+
+	x := new(big.Int).SetBytes(digest)
+	xTilde = x.Exp(x, exp, pub.N)
+	xi2 = new(big.Int).Mul(share.xi, share.xi)
+	xi2.Mod(xi2, pub.N)
+	return xTilde, xi2
+}
+
+// CombineVerifiedSignShares verifies the shares with vk, excludes the faulty
+// and repeated ones, and combines the remaining ones with CombineSignShares.
+// It returns an error if there are fewer than threshold correct shares.
+func CombineVerifiedSignShares(pub *rsa.PublicKey, vk *VerifyKey, players, threshold uint, shares []SignShare, msg []byte) (Signature, error) {
+	seen := make(map[uint]bool)
+	valid := make([]SignShare, 0, len(shares))
+	for i := range shares {
+		if !seen[shares[i].Index] && vk.VerifySignShare(pub, msg, shares[i]) == nil {
+			seen[shares[i].Index] = true
+			valid = append(valid, shares[i])
+		}
+	}
+
+	if uint(len(valid)) < threshold {
+		return nil, errors.New("rsa_threshold: insufficient valid shares for the threshold")
+	}
+
+	return CombineSignShares(pub, players, threshold, valid, msg)
+}
@@ -0,0 +1,66 @@
+package rsa
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"math/big"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+func TestVerifySignShares(t *testing.T) {
+	const players = 5
+	const threshold = 3
+	// [Warning]: this is only for tests, use a secure bitlen above 2048 bits.
+	// The proofs are only sound for safe primes, so the key is generated
+	// with GenerateKey of this package.
+	const bits = 1024
+
+	key, err := GenerateKey(rand.Reader, bits)
+	test.CheckNoErr(t, err, "failed to create key")
+	pub := &key.PublicKey
+	keys, err := Deal(rand.Reader, players, threshold, key, false)
+	test.CheckNoErr(t, err, "failed to deal")
+	vk, err := NewVerifyKey(rand.Reader, pub, keys)
+	test.CheckNoErr(t, err, "failed to create verification key")
+
+	msg := []byte("hello")
+	msgPH, err := PadHash(&PKCS1v15Padder{}, crypto.SHA256, pub, msg)
+	test.CheckNoErr(t, err, "failed to pad")
+
+	shares := make([]SignShare, players)
+	for i := range keys {
+		shares[i], err = keys[i].SignAndProve(rand.Reader, pub, vk, msgPH, true)
+		test.CheckNoErr(t, err, "failed to sign")
+		test.CheckNoErr(t, vk.VerifySignShare(pub, msgPH, shares[i]), "share must be valid")
+
+		var got SignShare
+		test.CheckMarshal(t, &shares[i], &got)
+		test.CheckNoErr(t, vk.VerifySignShare(pub, msgPH, got), "unmarshaled share must be valid")
+	}
+
+	// A faulty player is detected, and excluded before combining.
+	shares[1].xi = new(big.Int).Add(shares[1].xi, big.NewInt(1))
+	test.CheckIsErr(t, vk.VerifySignShare(pub, msgPH, shares[1]), "share must be invalid")
+	test.CheckIsErr(t, vk.VerifySignShare(pub, []byte("other"), shares[0]), "share must be invalid")
+	unproven, err := keys[2].Sign(rand.Reader, pub, msgPH, true)
+	test.CheckNoErr(t, err, "failed to sign")
+	test.CheckIsErr(t, vk.VerifySignShare(pub, msgPH, unproven), "share without proof must be invalid")
+
+	_, err = CombineSignShares(pub, players, threshold, shares[:threshold], msgPH)
+	test.CheckIsErr(t, err, "combining a faulty share must fail")
+
+	sig, err := CombineVerifiedSignShares(pub, vk, players, threshold, shares, msgPH)
+	test.CheckNoErr(t, err, "failed to combine verified shares")
+	hashed := crypto.SHA256.New()
+	hashed.Write(msg)
+	err = rsa.VerifyPKCS1v15(pub, crypto.SHA256, hashed.Sum(nil), sig)
+	test.CheckNoErr(t, err, "invalid signature")
+
+	// Not enough valid shares, even if repeated.
+	repeated := []SignShare{shares[0], shares[0], shares[1], shares[2]}
+	_, err = CombineVerifiedSignShares(pub, vk, players, threshold, repeated, msgPH)
+	test.CheckIsErr(t, err, "should fail with insufficient valid shares")
+}
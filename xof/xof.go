@@ -3,6 +3,8 @@
 // # Available Functions
 //
 // SHAKE functions are defined in FIPS-202, see https://nvlpubs.nist.gov/nistpubs/FIPS/NIST.FIPS.202.pdf.
+// TurboSHAKE functions are defined in RFC 9861, and are used with the
+// default domain separation byte 0x1F.
 // BLAKE2Xb and BLAKE2Xs are defined in https://www.blake2.net/blake2x.pdf.
 package xof
 
@@ -41,8 +43,13 @@ const (
 	BLAKE2XB
 	BLAKE2XS
 	K12D10
+	TURBOSHAKE128
+	TURBOSHAKE256
 )
 
+// Default domain separation byte of TurboSHAKE.
+const turboShakeDS = 0x1F
+
 func (x ID) New() XOF {
 	switch x {
 	case SHAKE128:
@@ -60,6 +67,12 @@ func (x ID) New() XOF {
 	case K12D10:
 		x := k12.NewDraft10([]byte{})
 		return k12d10{&x}
+	case TURBOSHAKE128:
+		s := sha3.NewTurboShake128(turboShakeDS)
+		return shakeBody{&s}
+	case TURBOSHAKE256:
+		s := sha3.NewTurboShake256(turboShakeDS)
+		return shakeBody{&s}
 	default:
 		panic("crypto: requested unavailable XOF function")
 	}
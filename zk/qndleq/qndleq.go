@@ -24,8 +24,10 @@ package qndleq
 
 import (
 	"crypto/rand"
+	"encoding/binary"
 	"errors"
 	"io"
+	"math"
 	"math/big"
 
 	"github.com/cloudflare/circl/internal/sha3"
@@ -212,6 +214,54 @@ func doChallenge(g, gx, h, hx, gP, hP, N *big.Int, secParam uint) (*big.Int, err
 	return new(big.Int).SetBytes(cBytes), nil
 }
 
+// MarshalBinary encodes a Proof into a byte array in a format readable by
+// UnmarshalBinary.
+func (p *Proof) MarshalBinary() ([]byte, error) {
+	// | secParam: uint16 | cLen: uint16 | c: []byte | zLen: uint16 | z: []byte |
+	// with all values in big-endian.
+	if p.secParam > math.MaxUint16 {
+		return nil, ErrEncoding
+	}
+
+	c := p.c.Bytes()
+	z := p.z.Bytes()
+	if len(c) > math.MaxUint16 || len(z) > math.MaxUint16 {
+		return nil, ErrEncoding
+	}
+
+	out := make([]byte, 0, 6+len(c)+len(z))
+	out = binary.BigEndian.AppendUint16(out, uint16(p.secParam))
+	out = binary.BigEndian.AppendUint16(out, uint16(len(c)))
+	out = append(out, c...)
+	out = binary.BigEndian.AppendUint16(out, uint16(len(z)))
+	out = append(out, z...)
+	return out, nil
+}
+
+// UnmarshalBinary recovers a Proof from a slice of bytes, or returns an error
+// if the encoding is invalid.
+func (p *Proof) UnmarshalBinary(data []byte) error {
+	if len(data) < 4 {
+		return ErrEncoding
+	}
+	secParam := uint(binary.BigEndian.Uint16(data[0:2]))
+	cLen := int(binary.BigEndian.Uint16(data[2:4]))
+	data = data[4:]
+	if len(data) < cLen+2 {
+		return ErrEncoding
+	}
+	c := new(big.Int).SetBytes(data[:cLen])
+	zLen := int(binary.BigEndian.Uint16(data[cLen : cLen+2]))
+	data = data[cLen+2:]
+	if len(data) != zLen {
+		return ErrEncoding
+	}
+	z := new(big.Int).SetBytes(data)
+
+	p.z, p.c, p.secParam = z, c, secParam
+	return nil
+}
+
 // checkBounds returns nil if 0 < x[i] < N for all 0 <= i < len(x);
 // otherwise, returns ErrBounds.
 func checkBounds(N *big.Int, x ...*big.Int) error {
@@ -235,4 +285,6 @@ var (
 	ErrBounds = errors.New("zk/qndleq: input must be greater than 0 and less than N")
 	// ErrProve is returned when Prove exhausted the number of proof tries.
 	ErrProve = errors.New("zk/qndleq: exhausted the number of proof tries")
+	// ErrEncoding is returned when a Proof cannot be encoded or decoded.
+	ErrEncoding = errors.New("zk/qndleq: invalid proof encoding")
 )
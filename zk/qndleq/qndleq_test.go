@@ -52,6 +52,30 @@ func TestInvalidStatement(t *testing.T) {
 	test.CheckOk(isValid == false, "proof verification must fail", t)
 }
 
+func TestMarshal(t *testing.T) {
+	p, q := big.NewInt(1019), big.NewInt(1187)
+	N := new(big.Int).Mul(p, q)
+	x := big.NewInt(5)
+	g, h := big.NewInt(4), big.NewInt(9)
+	gx := new(big.Int).Exp(g, x, N)
+	hx := new(big.Int).Exp(h, x, N)
+
+	proof, err := qndleq.Prove(rand.Reader, x, g, gx, h, hx, N, 128)
+	test.CheckNoErr(t, err, "failed to generate proof")
+	test.CheckMarshal(t, proof, new(qndleq.Proof))
+
+	data, err := proof.MarshalBinary()
+	test.CheckNoErr(t, err, "failed to marshal")
+	var got qndleq.Proof
+	test.CheckNoErr(t, got.UnmarshalBinary(data), "failed to unmarshal")
+	test.CheckOk(got.Verify(g, gx, h, hx, N), "failed to verify", t)
+
+	for _, n := range []int{0, 3, 5, len(data) - 1} {
+		test.CheckIsErr(t, got.UnmarshalBinary(data[:n]), "should fail to unmarshal")
+	}
+	test.CheckIsErr(t, got.UnmarshalBinary(append(data, 0)), "should fail to unmarshal")
+}
+
 func TestSampleQn(t *testing.T) {
 	const testTimes = 1 << 7
 	one := big.NewInt(1)